@@ -0,0 +1,22 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/lazharichir/poker/domain/escrow"
+)
+
+// Generates a shuffle seed escrow key pair. The public key is given to the server
+// (POKER_SEED_ESCROW_PUBLIC_KEY), each share to a different operator.
+func main() {
+	publicKey, shareA, shareB, err := escrow.GenerateKeyShares()
+	if err != nil {
+		log.Fatalf("Could not generate escrow keys: %v", err)
+	}
+
+	fmt.Println("POKER_SEED_ESCROW_PUBLIC_KEY=" + base64.StdEncoding.EncodeToString(publicKey))
+	fmt.Println("Share A:", base64.StdEncoding.EncodeToString(shareA))
+	fmt.Println("Share B:", base64.StdEncoding.EncodeToString(shareB))
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// Prints the hand state machine, e.g. `go run ./cmd/handgraph -format dot | dot -Tsvg > hand.svg`
+func main() {
+	format := flag.String("format", "mermaid", "output format, mermaid or dot")
+	current := flag.String("highlight", "", "phase to highlight, e.g. continuation")
+	flag.Parse()
+
+	switch *format {
+	case "mermaid":
+		fmt.Print(domain.HandStateMachineMermaid(domain.HandPhase(*current)))
+	case "dot":
+		fmt.Print(domain.HandStateMachineDOT(domain.HandPhase(*current)))
+	default:
+		log.Fatalf("Unknown format %q, use mermaid or dot", *format)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lazharichir/poker/server/store"
+)
+
+// Brings the schema of the database in POKER_EVENTS_POSTGRES_URL up to date, as the server does on startup.
+// With -status, lists the pending migrations without applying them.
+func main() {
+	status := flag.Bool("status", false, "list the pending migrations without applying them")
+	flag.Parse()
+
+	databaseURL := os.Getenv("POKER_EVENTS_POSTGRES_URL")
+	if databaseURL == "" {
+		log.Fatal("POKER_EVENTS_POSTGRES_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("Invalid POKER_EVENTS_POSTGRES_URL: %v", err)
+	}
+	defer pool.Close()
+
+	migrator := store.NewMigrator(pool)
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		log.Fatalf("Could not read the applied migrations: %v", err)
+	}
+
+	for _, migration := range pending {
+		fmt.Println("Pending:", migration)
+	}
+	if *status || len(pending) == 0 {
+		fmt.Println(len(pending), "pending migrations")
+		return
+	}
+
+	if err := migrator.Migrate(ctx); err != nil {
+		log.Fatalf("Could not migrate: %v", err)
+	}
+	fmt.Println("Applied", len(pending), "migrations")
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Catalog is the protocol reference: every command clients may send, every event they may receive,
+// and the structured types their fields are made of
+type Catalog struct {
+	Commands []Message `json:"commands"`
+	Events   []Message `json:"events"`
+	Types    []Type    `json:"types"`
+}
+
+// Message is a command or an event
+type Message struct {
+	Name        string  `json:"name"` // On the wire, e.g. "PLAYER_FOLDS"
+	Type        string  `json:"type"` // In the code, e.g. "commands.PlayerFolds"
+	Description string  `json:"description,omitempty"`
+	Category    string  `json:"category,omitempty"`   // Events only, see events.CategoryOf
+	Visibility  string  `json:"visibility,omitempty"` // Events only, see events.Classify
+	Fields      []Field `json:"fields"`
+}
+
+// Type is a struct used by a message field
+type Type struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description,omitempty"`
+	Fields      []Field `json:"fields"`
+}
+
+// Field is an exported field of a message or type
+type Field struct {
+	Name        string `json:"name"` // As encoded in JSON
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// Writes the protocol catalog as JSON, e.g. `go run ./cmd/protocoldoc -out server/protocol.json`
+func main() {
+	out := flag.String("out", "", "file to write, standard output when empty")
+	flag.Parse()
+
+	g := &generator{docs: map[string]*packageDocs{}, types: map[reflect.Type]bool{}}
+	catalog := Catalog{}
+
+	for _, command := range commands.Registered() {
+		catalog.Commands = append(catalog.Commands, g.message(command.Name(), reflect.TypeOf(command)))
+	}
+	for _, event := range events.Registered() {
+		message := g.message(event.Name(), reflect.TypeOf(event))
+		visibility, _ := events.Classify(event)
+		message.Category = string(events.CategoryOf(event.Name()))
+		message.Visibility = string(visibility)
+		catalog.Events = append(catalog.Events, message)
+	}
+	catalog.Types = g.nestedTypes()
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		log.Fatalf("Could not encode the catalog: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("Could not write %s: %v", *out, err)
+	}
+}
+
+// generator describes types from reflection, and reads their doc comments from the source of their package
+type generator struct {
+	docs  map[string]*packageDocs // By import path
+	types map[reflect.Type]bool   // Structs met in message fields
+}
+
+// packageDocs holds the comments of a package's structs and of their fields
+type packageDocs struct {
+	types  map[string]string
+	fields map[string]map[string]string // By type, then field
+}
+
+func (g *generator) message(name string, t reflect.Type) Message {
+	docs := g.packageDocs(t.PkgPath())
+	return Message{
+		Name:        name,
+		Type:        t.String(),
+		Description: docs.types[t.Name()],
+		Fields:      g.fields(t),
+	}
+}
+
+// fields describes a struct's exported fields, in declaration order, remembering the structs they use
+func (g *generator) fields(t reflect.Type) []Field {
+	docs := g.packageDocs(t.PkgPath())
+	fields := []Field{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		g.collect(field.Type)
+		fields = append(fields, Field{
+			Name:        name,
+			Type:        field.Type.String(),
+			Description: docs.fields[t.Name()][field.Name],
+		})
+	}
+
+	return fields
+}
+
+// collect remembers the named structs a field type is made of
+func (g *generator) collect(t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		g.collect(t.Elem())
+	case reflect.Map:
+		g.collect(t.Key())
+		g.collect(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" || t == reflect.TypeOf(time.Time{}) || g.types[t] {
+			return
+		}
+		g.types[t] = true
+		g.fields(t)
+	}
+}
+
+// nestedTypes describes the structs met in message fields, sorted by name
+func (g *generator) nestedTypes() []Type {
+	types := []Type{}
+	for t := range g.types {
+		types = append(types, Type{
+			Type:        t.String(),
+			Description: g.packageDocs(t.PkgPath()).types[t.Name()],
+			Fields:      g.fields(t),
+		})
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Type < types[j].Type })
+	return types
+}
+
+// packageDocs parses a package's source once for its comments, a package that can't be found has none
+func (g *generator) packageDocs(path string) *packageDocs {
+	if docs, ok := g.docs[path]; ok {
+		return docs
+	}
+
+	docs := &packageDocs{types: map[string]string{}, fields: map[string]map[string]string{}}
+	g.docs[path] = docs
+
+	pkg, err := build.Import(path, ".", build.FindOnly)
+	if err != nil {
+		log.Printf("Could not find package %s, its types are left undocumented: %v", path, err)
+		return docs
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, pkg.Dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("Could not parse package %s: %v", path, err)
+	}
+
+	for _, parsed := range pkgs {
+		for _, file := range parsed.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+
+					// A lone type declaration keeps its comment on the declaration rather than on the spec
+					comment := typeSpec.Doc
+					if comment == nil && len(gen.Specs) == 1 {
+						comment = gen.Doc
+					}
+					docs.types[typeSpec.Name.Name] = text(comment)
+
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						docs.fields[typeSpec.Name.Name] = fieldDocs(structType)
+					}
+				}
+			}
+		}
+	}
+
+	return docs
+}
+
+// fieldDocs reads the comment of each field, above it or at the end of its line
+func fieldDocs(structType *ast.StructType) map[string]string {
+	docs := map[string]string{}
+	for _, field := range structType.Fields.List {
+		comment := text(field.Doc)
+		if comment == "" {
+			comment = text(field.Comment)
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			// Embedded, named after its type
+			if ident, ok := ast.Unparen(field.Type).(*ast.Ident); ok {
+				names = []*ast.Ident{ident}
+			}
+		}
+		for _, name := range names {
+			docs[name.Name] = comment
+		}
+	}
+	return docs
+}
+
+// text returns a comment on one line, without its markers
+func text(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
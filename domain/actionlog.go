@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ActionLogEntryKind tells what an action log entry records
+type ActionLogEntryKind string
+
+const (
+	ActionLogPrompt  ActionLogEntryKind = "prompt"  // The player was asked to act
+	ActionLogAction  ActionLogEntryKind = "action"  // The player acted
+	ActionLogTimeout ActionLogEntryKind = "timeout" // The player did not act in time and the table acted for them
+)
+
+// ActionLogEntry is one step of a player's part in a hand, as seen by the server.
+// It is redacted: cards never appear, only what the player was asked and what they did.
+type ActionLogEntry struct {
+	EventID   string
+	Kind      ActionLogEntryKind
+	Event     string // Name of the event the entry comes from
+	Phase     string
+	Amount    int           // Chips put in by an ante or a bet
+	Deadline  time.Time     // When a prompt expires, zero if it does not
+	Latency   time.Duration // For actions and timeouts, time since the prompt they answer
+	Automatic bool          // The action was taken by auto-play, not by the player
+	At        time.Time
+}
+
+// PlayerActionLog returns the chronological list of a player's prompts, actions and timeouts in a hand.
+// Support uses it to settle "I did click call!" complaints, so it only relies on the table's event log.
+func (t *Table) PlayerActionLog(handID string, playerID string) ([]ActionLogEntry, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	found := false
+	for _, hand := range t.Hands {
+		if hand.ID == handID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.New("hand not found")
+	}
+
+	entries := []ActionLogEntry{}
+	phase := ""
+	autoPlay := false
+	out := false // The player folded, timed out or sat the hand out
+	var promptedAt time.Time
+
+	for _, event := range t.Events {
+		switch e := event.(type) {
+		case events.PlayerAutoPlayToggled:
+			// Auto-play is toggled at the table level and spans hands
+			if e.PlayerID == playerID {
+				autoPlay = e.Enabled
+			}
+		case events.PhaseChanged:
+			if e.HandID == handID {
+				phase = e.NewPhase
+			}
+		case events.ReadyCheckCompleted:
+			if e.HandID == handID && slices.Contains(e.SatOut, playerID) {
+				out = true
+			}
+		}
+
+		entry, ok := actionLogEntry(event, handID, playerID)
+		if !ok || (out && entry.Kind == ActionLogPrompt) {
+			continue
+		}
+
+		if entry.Phase == "" {
+			entry.Phase = phase
+		}
+
+		switch entry.Kind {
+		case ActionLogPrompt:
+			promptedAt = entry.At
+		case ActionLogAction, ActionLogTimeout:
+			if !promptedAt.IsZero() {
+				entry.Latency = entry.At.Sub(promptedAt)
+			}
+			entry.Automatic = entry.Kind == ActionLogAction && autoPlay
+			out = out || entry.Event == events.PlayerFolded{}.Name() || entry.Kind == ActionLogTimeout
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// actionLogEntry maps an event to an action log entry, if the event concerns the player in the hand
+func actionLogEntry(event events.Event, handID string, playerID string) (ActionLogEntry, bool) {
+	entry := ActionLogEntry{
+		EventID: events.ExtractEventID(event),
+		Event:   event.Name(),
+		At:      event.Timestamp(),
+	}
+
+	switch e := event.(type) {
+	case events.ReadyCheckStarted:
+		entry.Kind = ActionLogPrompt
+		entry.Deadline = e.Deadline
+		return entry, e.HandID == handID && slices.Contains(e.Players, playerID)
+	case events.PlayerReady:
+		entry.Kind = ActionLogAction
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.PlayerTurnStarted:
+		entry.Kind = ActionLogPrompt
+		entry.Phase = e.Phase
+		entry.Deadline = e.TimeoutAt
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.CommunitySelectionStarted:
+		// Every player still in the hand is asked to select
+		entry.Kind = ActionLogPrompt
+		entry.Deadline = e.At.Add(e.TimeLimit)
+		return entry, e.HandID == handID
+	case events.AntePlaced:
+		entry.Kind = ActionLogAction
+		entry.Amount = e.Amount
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.ContinuationBetPlaced:
+		entry.Kind = ActionLogAction
+		entry.Amount = e.Amount
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.PlayerFolded:
+		entry.Kind = ActionLogAction
+		entry.Phase = e.Phase
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.CommunityCardSelected:
+		// The selected card stays out of the log
+		entry.Kind = ActionLogAction
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	case events.PlayerTimedOut:
+		entry.Kind = ActionLogTimeout
+		entry.Phase = e.Phase
+		return entry, e.HandID == handID && e.PlayerID == playerID
+	}
+
+	return entry, false
+}
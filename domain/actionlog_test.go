@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerActionLog(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	setup := func() *Table {
+		table := NewTable("Test Table", TableRules{})
+		table.Hands = []*Hand{{ID: "hand-1"}}
+		table.Events = []events.Event{
+			events.PhaseChanged{ID: "1", HandID: "hand-1", NewPhase: string(HandPhase_Antes), At: at(0)},
+			events.PlayerTurnStarted{ID: "2", HandID: "hand-1", PlayerID: "player-1", Phase: string(HandPhase_Antes), TimeoutAt: at(30), At: at(0)},
+			events.AntePlaced{ID: "3", HandID: "hand-1", PlayerID: "player-1", Amount: 10, At: at(4)},
+			events.PlayerTurnStarted{ID: "4", HandID: "hand-1", PlayerID: "player-2", Phase: string(HandPhase_Antes), TimeoutAt: at(34), At: at(4)},
+			events.AntePlaced{ID: "5", HandID: "hand-1", PlayerID: "player-2", Amount: 10, At: at(6)},
+			events.PhaseChanged{ID: "6", HandID: "hand-1", NewPhase: string(HandPhase_Continuation), At: at(7)},
+			events.PlayerTurnStarted{ID: "7", HandID: "hand-1", PlayerID: "player-1", Phase: string(HandPhase_Continuation), TimeoutAt: at(37), At: at(7)},
+			events.PlayerTimedOut{ID: "8", HandID: "hand-1", PlayerID: "player-1", Phase: string(HandPhase_Continuation), DefaultAction: "fold", At: at(37)},
+			events.PhaseChanged{ID: "9", HandID: "hand-1", NewPhase: string(HandPhase_CommunitySelection), At: at(40)},
+			events.CommunitySelectionStarted{ID: "10", HandID: "hand-1", TimeLimit: 5 * time.Second, At: at(40)},
+			events.CommunityCardSelected{ID: "11", HandID: "hand-1", PlayerID: "player-2", Card: "AS", At: at(42)},
+			events.PlayerTurnStarted{ID: "12", HandID: "hand-2", PlayerID: "player-1", At: at(60)},
+		}
+		return table
+	}
+
+	t.Run("Lists the player's prompts, actions and timeouts with latencies", func(t *testing.T) {
+		// Setup
+		table := setup()
+
+		// Act
+		entries, err := table.PlayerActionLog("hand-1", "player-1")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		assert.Equal(t, ActionLogPrompt, entries[0].Kind)
+		assert.Equal(t, at(30), entries[0].Deadline)
+
+		assert.Equal(t, ActionLogAction, entries[1].Kind)
+		assert.Equal(t, 10, entries[1].Amount)
+		assert.Equal(t, 4*time.Second, entries[1].Latency)
+		assert.Equal(t, string(HandPhase_Antes), entries[1].Phase)
+
+		assert.Equal(t, ActionLogTimeout, entries[3].Kind)
+		assert.Equal(t, 30*time.Second, entries[3].Latency)
+		assert.Equal(t, string(HandPhase_Continuation), entries[3].Phase)
+	})
+
+	t.Run("Records selections without the selected card", func(t *testing.T) {
+		// Setup
+		table := setup()
+
+		// Act
+		entries, err := table.PlayerActionLog("hand-1", "player-2")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+		selection := entries[3]
+		assert.Equal(t, events.CommunityCardSelected{}.Name(), selection.Event)
+		assert.Equal(t, string(HandPhase_CommunitySelection), selection.Phase)
+		assert.Equal(t, 2*time.Second, selection.Latency)
+	})
+
+	t.Run("Fails for an unknown hand", func(t *testing.T) {
+		// Setup
+		table := setup()
+
+		// Act
+		_, err := table.PlayerActionLog("hand-9", "player-1")
+
+		// Assert
+		assert.EqualError(t, err, "hand not found")
+	})
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// AnteScaling raises the ante of tables where pots stay small, to keep casual tables lively.
+// A zero Step disables it.
+type AnteScaling struct {
+	Hands         int // Number of hands whose final pots are averaged
+	MinAveragePot int // The ante goes up when the average pot is below this
+	Step          int // Added to the ante on each raise
+	MaxAnte       int // The ante never goes above this, zero for no cap
+	CooldownHands int // Hands to wait after a raise before the ante may go up again
+}
+
+// enabled checks if the scaling has what it needs to run
+func (s AnteScaling) enabled() bool {
+	return s.Step > 0 && s.Hands > 0
+}
+
+// scaleAnte records the final pot of a hand and raises the table's ante when pots have been too small.
+// The next hand picks the new ante up, as hands copy the table rules when they start.
+func (t *Table) scaleAnte(finalPot int) {
+	scaling := t.Rules.AnteScaling
+	if !scaling.enabled() {
+		return
+	}
+
+	t.recentPots = append(t.recentPots, finalPot)
+	if len(t.recentPots) > scaling.Hands {
+		t.recentPots = t.recentPots[1:]
+	}
+
+	if t.anteCooldown > 0 {
+		t.anteCooldown--
+		return
+	}
+
+	if len(t.recentPots) < scaling.Hands {
+		return
+	}
+
+	if scaling.MaxAnte > 0 && t.Rules.AnteValue >= scaling.MaxAnte {
+		return
+	}
+
+	total := 0
+	for _, pot := range t.recentPots {
+		total += pot
+	}
+	average := total / len(t.recentPots)
+	if average >= scaling.MinAveragePot {
+		return
+	}
+
+	previous := t.Rules.AnteValue
+	t.Rules.AnteValue += scaling.Step
+	if scaling.MaxAnte > 0 {
+		t.Rules.AnteValue = min(t.Rules.AnteValue, scaling.MaxAnte)
+	}
+
+	// Pots played at the old ante say nothing about the new one
+	t.recentPots = nil
+	t.anteCooldown = scaling.CooldownHands
+
+	t.emitEvent(events.AnteScaled{
+		TableID:      t.ID,
+		PreviousAnte: previous,
+		NewAnte:      t.Rules.AnteValue,
+		AveragePot:   average,
+		Hands:        scaling.Hands,
+		At:           t.clock().Now(),
+	})
+}
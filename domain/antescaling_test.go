@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnteScaling(t *testing.T) {
+	setup := func(scaling AnteScaling) *Table {
+		return NewTable("Test Table", TableRules{AnteValue: 10, AnteScaling: scaling})
+	}
+
+	t.Run("Raises the ante when pots stay small", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 3, MinAveragePot: 100, Step: 5})
+
+		// Act
+		for _, pot := range []int{40, 60, 50} {
+			table.scaleAnte(pot)
+		}
+
+		// Assert
+		assert.Equal(t, 15, table.Rules.AnteValue)
+		event, found := findEventOfType(table.Events, events.AnteScaled{}.Name())
+		require.True(t, found)
+		scaled := event.(events.AnteScaled)
+		assert.Equal(t, 10, scaled.PreviousAnte)
+		assert.Equal(t, 15, scaled.NewAnte)
+		assert.Equal(t, 50, scaled.AveragePot)
+	})
+
+	t.Run("Keeps the ante when pots are big enough", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 3, MinAveragePot: 100, Step: 5})
+
+		// Act
+		for _, pot := range []int{40, 200, 90} {
+			table.scaleAnte(pot)
+		}
+
+		// Assert
+		assert.Equal(t, 10, table.Rules.AnteValue)
+	})
+
+	t.Run("Waits for a full window of hands", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 3, MinAveragePot: 100, Step: 5})
+
+		// Act
+		table.scaleAnte(0)
+		table.scaleAnte(0)
+
+		// Assert
+		assert.Equal(t, 10, table.Rules.AnteValue)
+	})
+
+	t.Run("Never goes above the cap", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 1, MinAveragePot: 100, Step: 5, MaxAnte: 12})
+
+		// Act
+		for i := 0; i < 5; i++ {
+			table.scaleAnte(0)
+		}
+
+		// Assert
+		assert.Equal(t, 12, table.Rules.AnteValue)
+	})
+
+	t.Run("Cools down after a raise", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 1, MinAveragePot: 100, Step: 5, CooldownHands: 2})
+
+		// Act
+		raises := []int{}
+		for i := 0; i < 6; i++ {
+			table.scaleAnte(0)
+			raises = append(raises, table.Rules.AnteValue)
+		}
+
+		// Assert
+		assert.Equal(t, []int{15, 15, 15, 20, 20, 20}, raises)
+	})
+
+	t.Run("Is disabled without a step", func(t *testing.T) {
+		// Setup
+		table := setup(AnteScaling{Hands: 1, MinAveragePot: 100})
+
+		// Act
+		table.scaleAnte(0)
+
+		// Assert
+		assert.Equal(t, 10, table.Rules.AnteValue)
+	})
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+)
+
+// autoPlayDelay is how long the table waits before acting on behalf of an away player
+var autoPlayDelay = 500 * time.Millisecond
+
+// MarkPlayerAway flags a seated player as disconnected so the table can act for them
+func (t *Table) MarkPlayerAway(playerID string) error {
+	if !t.IsSeated(playerID) {
+		return errors.New("player not found")
+	}
+
+	if t.Away == nil {
+		t.Away = make(map[string]bool)
+	}
+
+	if t.Away[playerID] {
+		return nil
+	}
+	t.Away[playerID] = true
+
+	if !t.autoPlaysAwayPlayers() {
+		return nil
+	}
+
+	t.emitEvent(events.PlayerAutoPlayToggled{
+		TableID:  t.ID,
+		PlayerID: playerID,
+		Enabled:  true,
+		At:       t.clock().Now(),
+	})
+
+	// The player may be disconnecting while it is their turn
+	if hand := t.ActiveHand; hand != nil {
+		if hand.IsPlayerTheCurrentBettor(playerID) || hand.IsInPhase(HandPhase_CommunitySelection) {
+			t.scheduleAutoPlay(hand, playerID)
+		}
+	}
+
+	return nil
+}
+
+// MarkPlayerBack clears the away flag once the player is connected again
+func (t *Table) MarkPlayerBack(playerID string) {
+	// Players who left a tournament table stay away until their stack is blinded off
+	if !t.Away[playerID] || t.Left[playerID] {
+		return
+	}
+
+	delete(t.Away, playerID)
+
+	if !t.autoPlaysAwayPlayers() {
+		return
+	}
+
+	t.emitEvent(events.PlayerAutoPlayToggled{
+		TableID:  t.ID,
+		PlayerID: playerID,
+		Enabled:  false,
+		At:       t.clock().Now(),
+	})
+}
+
+// autoPlaysAwayPlayers checks if the table acts for away players, blinding off their stacks is a form of auto-play
+func (t *Table) autoPlaysAwayPlayers() bool {
+	return t.Rules.AutoPlayWhenAway || t.Rules.BlindOff
+}
+
+// IsPlayerAway checks if a player is flagged as disconnected
+func (t *Table) IsPlayerAway(playerID string) bool {
+	return t.Away[playerID]
+}
+
+// IsSeated checks if the player has a seat at the table
+func (t *Table) IsSeated(playerID string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, p := range t.Players {
+		if p.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// handleAutoPlayEvent schedules actions for away players when the hand is waiting on them
+func (t *Table) handleAutoPlayEvent(event events.Event) {
+	if !t.autoPlaysAwayPlayers() || t.ActiveHand == nil {
+		return
+	}
+
+	switch ev := event.(type) {
+	case events.PlayerTurnStarted:
+		if t.IsPlayerAway(ev.PlayerID) {
+			t.scheduleAutoPlay(t.ActiveHand, ev.PlayerID)
+		}
+	case events.CommunitySelectionStarted:
+		for playerID := range t.Away {
+			if t.ActiveHand.IsPlayerActive(playerID) {
+				t.scheduleAutoPlay(t.ActiveHand, playerID)
+			}
+		}
+	}
+}
+
+// scheduleAutoPlay acts for the player after a short delay, so that the
+// action is not applied while the hand is still emitting the current event
+func (t *Table) scheduleAutoPlay(hand *Hand, playerID string) {
+	t.clock().AfterFunc(autoPlayDelay, func() {
+		err := t.guard("auto-play", func() error {
+			if t.ActiveHand != hand || !t.IsPlayerAway(playerID) {
+				return nil
+			}
+			return hand.autoPlay(playerID)
+		})
+		if err != nil {
+			fmt.Println("Auto-play failed for player", playerID, ":", err)
+		}
+	})
+}
+
+// autoPlay applies the conservative policy used for away players:
+// post antes, fold to continuation bets, pass on discards and pick the best community cards
+func (h *Hand) autoPlay(playerID string) error {
+	if !h.IsPlayerActive(playerID) {
+		return nil
+	}
+
+	switch h.Phase {
+	case HandPhase_Antes:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasAlreadyPlacedAnte(playerID) {
+			// A short stack goes all in rather than below zero
+			amount := h.TableRules.AnteValue
+			if h.Table != nil {
+				amount = max(min(amount, h.Table.BuyIns[playerID]), 0)
+			}
+			return h.PlayerPlacesAnte(playerID, amount)
+		}
+
+	case HandPhase_Continuation:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasAlreadyPlacedContinuationBet(playerID) {
+			return h.PlayerFolds(playerID)
+		}
+
+	case HandPhase_Discard:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasPaidDiscardCost(playerID) {
+			return h.PlayerSkipsDiscard(playerID)
+		}
+
+	case HandPhase_CommunitySelection:
+		for _, card := range h.bestCommunitySelection(playerID) {
+			if err := h.PlayerSelectsCommunityCard(playerID, card); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bestCommunitySelection returns the community cards the player should still pick
+// to complete the strongest hand made of both hole cards and three community cards.
+// Tables selecting more than three get the first community cards left for the rest.
+func (h *Hand) bestCommunitySelection(playerID string) cards.Stack {
+	holeCards := h.HoleCards[playerID]
+	selected := h.CommunitySelections[playerID]
+
+	available := append(cards.Stack{}, holeCards...)
+	available = append(available, h.CommunityCards...)
+
+	picks := cards.Stack{}
+	for _, candidate := range hands.ListAllPossibleHands(available) {
+		if !stackContainsAll(candidate.Cards, holeCards) || !stackContainsAll(candidate.Cards, selected) {
+			continue
+		}
+
+		for _, card := range candidate.Cards {
+			if !stackContainsAll(holeCards, cards.Stack{card}) && !stackContainsAll(selected, cards.Stack{card}) {
+				picks = append(picks, card)
+			}
+		}
+		break
+	}
+
+	for _, card := range h.CommunityCards {
+		if len(selected)+len(picks) >= h.TableRules.selectionCount() {
+			break
+		}
+		if !stackContainsAll(selected, cards.Stack{card}) && !stackContainsAll(picks, cards.Stack{card}) {
+			picks = append(picks, card)
+		}
+	}
+	return picks
+}
+
+// stackContainsAll checks that every card of subset is in stack
+func stackContainsAll(stack cards.Stack, subset cards.Stack) bool {
+	for _, wanted := range subset {
+		found := false
+		for _, card := range stack {
+			if card.Equals(wanted) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
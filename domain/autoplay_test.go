@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// attachHandToTable wires a test hand to its table the same way StartNewHand does
+func attachHandToTable(hand *Hand, table *Table) {
+	table.Players = hand.Players
+	table.Rules.AutoPlayWhenAway = true
+	table.ActiveHand = hand
+	hand.RegisterEventHandler(table.handleHandEvent)
+}
+
+func TestAutoPlay(t *testing.T) {
+	autoPlayDelay = time.Millisecond
+
+	t.Run("Away player posts the ante", func(t *testing.T) {
+		hand, table := setupAntesPhaseHand(3)
+		attachHandToTable(hand, table)
+		awayPlayerID := hand.CurrentBettor
+
+		err := table.MarkPlayerAway(awayPlayerID)
+		assert.NoError(t, err)
+
+		_, found := findEventOfType(table.GetEvents(), events.PlayerAutoPlayToggled{}.Name())
+		assert.True(t, found)
+
+		assert.Eventually(t, func() bool {
+			return inLoop(table, func() bool { return hand.AntesPaid[awayPlayerID] == hand.TableRules.AnteValue })
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Away player folds to the continuation bet", func(t *testing.T) {
+		hand, table := setupContinuationPhaseHand(3)
+		attachHandToTable(hand, table)
+		awayPlayerID := hand.CurrentBettor
+
+		err := table.MarkPlayerAway(awayPlayerID)
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return inLoop(table, func() bool { return !hand.IsPlayerActive(awayPlayerID) })
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Player coming back is no longer auto-played", func(t *testing.T) {
+		hand, table := setupContinuationPhaseHand(3)
+		attachHandToTable(hand, table)
+		awayPlayerID := hand.CurrentBettor
+
+		// The player comes back from the game loop, as the auto-play timer may already be running
+		table.MarkPlayerAway(awayPlayerID)
+		table.Do("test", func() error {
+			table.MarkPlayerBack(awayPlayerID)
+			return nil
+		})
+
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, inLoop(table, func() bool { return hand.IsPlayerActive(awayPlayerID) }))
+		assert.False(t, inLoop(table, func() bool { return table.IsPlayerAway(awayPlayerID) }))
+	})
+
+	t.Run("Unknown player cannot be marked away", func(t *testing.T) {
+		table := NewTestTable()
+		err := table.MarkPlayerAway("nobody")
+		assert.Error(t, err)
+	})
+}
+
+func TestBestCommunitySelection(t *testing.T) {
+	hand, _ := setupContinuationPhaseHand(2)
+	playerID := hand.Players[0].ID
+
+	hand.HoleCards[playerID] = cards.Stack{
+		{Suit: cards.Hearts, Value: cards.Ace},
+		{Suit: cards.Spades, Value: cards.Ace},
+	}
+	hand.CommunityCards = cards.Stack{
+		{Suit: cards.Clubs, Value: cards.Two},
+		{Suit: cards.Clubs, Value: cards.Ace},
+		{Suit: cards.Diamonds, Value: cards.Seven},
+		{Suit: cards.Diamonds, Value: cards.Ace},
+		{Suit: cards.Hearts, Value: cards.Four},
+		{Suit: cards.Spades, Value: cards.Nine},
+		{Suit: cards.Clubs, Value: cards.King},
+		{Suit: cards.Hearts, Value: cards.Three},
+	}
+
+	t.Run("Picks the strongest three cards", func(t *testing.T) {
+		picks := hand.bestCommunitySelection(playerID)
+
+		assert.Len(t, picks, 3)
+		assert.Contains(t, picks, cards.Card{Suit: cards.Clubs, Value: cards.Ace})
+		assert.Contains(t, picks, cards.Card{Suit: cards.Diamonds, Value: cards.Ace})
+		assert.Contains(t, picks, cards.Card{Suit: cards.Clubs, Value: cards.King})
+	})
+
+	t.Run("Keeps cards already selected", func(t *testing.T) {
+		hand.CommunitySelections[playerID] = cards.Stack{{Suit: cards.Clubs, Value: cards.Two}}
+
+		picks := hand.bestCommunitySelection(playerID)
+
+		assert.Len(t, picks, 2)
+		assert.Contains(t, picks, cards.Card{Suit: cards.Clubs, Value: cards.Ace})
+		assert.Contains(t, picks, cards.Card{Suit: cards.Diamonds, Value: cards.Ace})
+	})
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "fmt"
+
+// SetBackPressure tells the table whether its events are stored slower than it emits them. The hand in progress
+// plays on, but the next one waits until the pressure is released, so a lagging store doesn't fall further behind.
+func (t *Table) SetBackPressure(lagging bool) {
+	t.mu.Lock()
+	t.backPressure = lagging
+	resume := !lagging && t.handHeld
+	if resume {
+		t.handHeld = false
+	}
+	t.mu.Unlock()
+
+	// The release comes from the store's writer, which the game loop may be waiting on: the hand starts on its own
+	if resume {
+		go func() {
+			if err := t.guard("back-pressure release", func() error {
+				_, err := t.StartNewHand()
+				return err
+			}); err != nil {
+				fmt.Println("Could not start the held hand at table", t.ID, ":", err)
+			}
+		}()
+	}
+}
+
+// startNextHand deals the next hand once the last one has ended, or holds it while there is back-pressure
+func (t *Table) startNextHand() {
+	t.mu.Lock()
+	held := t.backPressure
+	t.handHeld = held
+	t.mu.Unlock()
+
+	if held {
+		fmt.Println("Holding the next hand at table", t.ID, "until its events are stored")
+		return
+	}
+	t.StartNewHand()
+}
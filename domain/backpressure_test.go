@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackPressure(t *testing.T) {
+	t.Run("Next hand waits while there is back-pressure", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}}
+		table.Status = TableStatusPlaying
+		table.SetBackPressure(true)
+
+		// Act
+		table.startNextHand()
+
+		// Assert
+		assert.Nil(t, table.ActiveHand)
+		assert.Empty(t, table.Hands)
+	})
+
+	t.Run("Held hand starts once the pressure is released", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}}
+		table.Status = TableStatusPlaying
+		table.SetBackPressure(true)
+		table.startNextHand()
+
+		// Act
+		table.SetBackPressure(false)
+
+		// Assert
+		assert.Eventually(t, func() bool { return table.GetCurrentHandID() != "" }, time.Second, time.Millisecond)
+		assert.Len(t, table.Hands, 1)
+	})
+
+	t.Run("Releasing without a held hand starts nothing", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}}
+		table.Status = TableStatusPlaying
+		table.SetBackPressure(true)
+
+		// Act
+		table.SetBackPressure(false)
+
+		// Assert
+		assert.Nil(t, table.ActiveHand)
+	})
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// BanReason is the reason code recorded with a ban or a table block
+type BanReason string
+
+const (
+	BanReasonCheating    BanReason = "cheating"
+	BanReasonCollusion   BanReason = "collusion"
+	BanReasonAbuse       BanReason = "abuse"
+	BanReasonChipDumping BanReason = "chip_dumping"
+	BanReasonOther       BanReason = "other"
+)
+
+// IsValid checks if the reason is one of the known codes
+func (r BanReason) IsValid() bool {
+	switch r {
+	case BanReasonCheating, BanReasonCollusion, BanReasonAbuse, BanReasonChipDumping, BanReasonOther:
+		return true
+	}
+	return false
+}
+
+// Ban keeps a player out of the lobby (operator ban) or off a table (host blocklist)
+type Ban struct {
+	PlayerID  string
+	Reason    BanReason
+	Note      string
+	By        string // Operator or host who issued it
+	CreatedAt time.Time
+	ExpiresAt time.Time // Zero for a permanent ban
+}
+
+// IsActive checks if the ban still applies at the given time
+func (b Ban) IsActive(now time.Time) bool {
+	return b.ExpiresAt.IsZero() || now.Before(b.ExpiresAt)
+}
+
+func (b Ban) validate() error {
+	if b.PlayerID == "" {
+		return errors.New("player ID is required")
+	}
+	if !b.Reason.IsValid() {
+		return errors.New("invalid ban reason")
+	}
+	return nil
+}
+
+// BanPlayer bans a player from the whole site. A player already in the lobby is removed from it.
+func (l *Lobby) BanPlayer(ban Ban) error {
+	if err := ban.validate(); err != nil {
+		return err
+	}
+
+	if ban.CreatedAt.IsZero() {
+		ban.CreatedAt = l.clock().Now()
+	}
+
+	l.mu.Lock()
+	if l.bans == nil {
+		l.bans = make(map[string]Ban)
+	}
+	l.bans[ban.PlayerID] = ban
+	l.mu.Unlock()
+
+	l.emitEvent(events.PlayerBanned{
+		PlayerID:  ban.PlayerID,
+		Reason:    string(ban.Reason),
+		Note:      ban.Note,
+		BannedBy:  ban.By,
+		ExpiresAt: ban.ExpiresAt,
+		At:        ban.CreatedAt,
+	})
+
+	if l.IsInLobby(ban.PlayerID) {
+		return l.LeavesLobby(ban.PlayerID)
+	}
+
+	return nil
+}
+
+// UnbanPlayer lifts a player's ban
+func (l *Lobby) UnbanPlayer(playerID string, by string) error {
+	l.mu.Lock()
+	if _, exists := l.bans[playerID]; !exists {
+		l.mu.Unlock()
+		return errors.New("player is not banned")
+	}
+
+	delete(l.bans, playerID)
+	l.mu.Unlock()
+
+	l.emitEvent(events.PlayerUnbanned{
+		PlayerID:   playerID,
+		UnbannedBy: by,
+		At:         l.clock().Now(),
+	})
+
+	return nil
+}
+
+// IsBanned checks if a player has an active ban
+func (l *Lobby) IsBanned(playerID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	ban, exists := l.bans[playerID]
+	return exists && ban.IsActive(l.clock().Now())
+}
+
+// GetBans returns the active bans
+func (l *Lobby) GetBans() []Ban {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return activeBans(l.bans, l.clock().Now())
+}
+
+// BlockPlayer adds a player to the table's blocklist. Only the host can block players,
+// and a blocked player who is seated stays until they leave but cannot sit again.
+func (t *Table) BlockPlayer(hostID string, ban Ban) error {
+	if err := ban.validate(); err != nil {
+		return err
+	}
+
+	if hostID != t.HostID {
+		return errors.New("only the table host can manage the blocklist")
+	}
+
+	if ban.PlayerID == t.HostID {
+		return errors.New("the host cannot block themselves")
+	}
+
+	if t.Blocklist == nil {
+		t.Blocklist = make(map[string]Ban)
+	}
+
+	ban.By = hostID
+	if ban.CreatedAt.IsZero() {
+		ban.CreatedAt = t.clock().Now()
+	}
+	t.Blocklist[ban.PlayerID] = ban
+
+	t.emitEvent(events.PlayerBlockedFromTable{
+		TableID:   t.ID,
+		PlayerID:  ban.PlayerID,
+		Reason:    string(ban.Reason),
+		Note:      ban.Note,
+		BlockedBy: hostID,
+		ExpiresAt: ban.ExpiresAt,
+		At:        ban.CreatedAt,
+	})
+
+	return nil
+}
+
+// UnblockPlayer removes a player from the table's blocklist
+func (t *Table) UnblockPlayer(hostID string, playerID string) error {
+	if hostID != t.HostID {
+		return errors.New("only the table host can manage the blocklist")
+	}
+
+	if _, exists := t.Blocklist[playerID]; !exists {
+		return errors.New("player is not blocked")
+	}
+
+	delete(t.Blocklist, playerID)
+
+	t.emitEvent(events.PlayerUnblockedFromTable{
+		TableID:     t.ID,
+		PlayerID:    playerID,
+		UnblockedBy: hostID,
+		At:          t.clock().Now(),
+	})
+
+	return nil
+}
+
+// IsBlocked checks if a player is on the table's blocklist
+func (t *Table) IsBlocked(playerID string) bool {
+	ban, exists := t.Blocklist[playerID]
+	return exists && ban.IsActive(t.clock().Now())
+}
+
+// GetBlocklist returns the table's active blocks
+func (t *Table) GetBlocklist() []Ban {
+	return activeBans(t.Blocklist, t.clock().Now())
+}
+
+func activeBans(bans map[string]Ban, now time.Time) []Ban {
+	active := make([]Ban, 0, len(bans))
+	for _, ban := range bans {
+		if ban.IsActive(now) {
+			active = append(active, ban)
+		}
+	}
+	return active
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLobbyBans(t *testing.T) {
+	t.Run("Banned player cannot enter the lobby", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		player := &Player{ID: "player-1"}
+		lobby.EntersLobby(player)
+
+		// Act
+		err := lobby.BanPlayer(Ban{PlayerID: player.ID, Reason: BanReasonCheating, By: "operator"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, lobby.IsInLobby(player.ID), "banned player is removed from the lobby")
+		_, found := findEventOfType(lobby.Events, events.PlayerBanned{}.Name())
+		assert.True(t, found)
+
+		err = lobby.EntersLobby(player)
+		assert.EqualError(t, err, "player is banned")
+
+		// Lifting the ban lets them back in
+		assert.NoError(t, lobby.UnbanPlayer(player.ID, "operator"))
+		assert.NoError(t, lobby.EntersLobby(player))
+	})
+
+	t.Run("Expired ban no longer applies", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		lobby.BanPlayer(Ban{PlayerID: "player-1", Reason: BanReasonAbuse, ExpiresAt: time.Now().Add(-time.Minute)})
+
+		// Assert
+		assert.False(t, lobby.IsBanned("player-1"))
+		assert.Empty(t, lobby.GetBans())
+		assert.NoError(t, lobby.EntersLobby(&Player{ID: "player-1"}))
+	})
+
+	t.Run("Unknown reason code is rejected", func(t *testing.T) {
+		lobby := &Lobby{}
+		err := lobby.BanPlayer(Ban{PlayerID: "player-1", Reason: "bad vibes"})
+		assert.EqualError(t, err, "invalid ban reason")
+	})
+}
+
+func TestTableBlocklist(t *testing.T) {
+	t.Run("Host blocks a player from sitting", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.SeatPlayer(&Player{ID: "host"})
+
+		// Act
+		err := table.BlockPlayer("host", Ban{PlayerID: "player-2", Reason: BanReasonAbuse})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.EqualError(t, table.SeatPlayer(&Player{ID: "player-2"}), "player is blocked from this table")
+		_, found := findEventOfType(table.Events, events.PlayerBlockedFromTable{}.Name())
+		assert.True(t, found)
+
+		assert.NoError(t, table.UnblockPlayer("host", "player-2"))
+		assert.NoError(t, table.SeatPlayer(&Player{ID: "player-2"}))
+	})
+
+	t.Run("Only the host manages the blocklist", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.SeatPlayer(&Player{ID: "host"})
+		table.SeatPlayer(&Player{ID: "player-2"})
+
+		// Act
+		err := table.BlockPlayer("player-2", Ban{PlayerID: "player-3", Reason: BanReasonOther})
+
+		// Assert
+		assert.EqualError(t, err, "only the table host can manage the blocklist")
+
+		// The next player in seat order takes over when the host leaves
+		table.PlayerLeaves("host")
+		assert.Equal(t, "player-2", table.HostID)
+	})
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// startBlindOff keeps the stack of a player who left in play, the table posts their antes and folds for them
+func (t *Table) startBlindOff(playerID string) error {
+	if t.Left == nil {
+		t.Left = make(map[string]bool)
+	}
+	t.Left[playerID] = true
+
+	t.emitEvent(events.PlayerBlindingOff{
+		TableID:  t.ID,
+		PlayerID: playerID,
+		Stack:    t.BuyIns[playerID],
+		Reason:   "left the table",
+		At:       t.clock().Now(),
+	})
+
+	return t.MarkPlayerAway(playerID)
+}
+
+// handleBlindOffEvent lets the other players follow an absent stack shrinking
+func (t *Table) handleBlindOffEvent(event events.Event) {
+	if !t.Rules.BlindOff {
+		return
+	}
+
+	switch ev := event.(type) {
+	case events.AntePlaced:
+		if t.IsPlayerAway(ev.PlayerID) {
+			t.emitEvent(events.AbsentStackBlindedOff{
+				TableID:  t.ID,
+				HandID:   ev.HandID,
+				PlayerID: ev.PlayerID,
+				Amount:   ev.Amount,
+				Stack:    t.BuyIns[ev.PlayerID],
+				At:       t.clock().Now(),
+			})
+		}
+	}
+}
+
+// eliminateBlindedOffPlayers removes the absent players whose stack is gone, between two hands
+func (t *Table) eliminateBlindedOffPlayers() {
+	if !t.Rules.BlindOff {
+		return
+	}
+
+	for _, player := range t.GetPlayers() {
+		if !t.IsPlayerAway(player.ID) || t.BuyIns[player.ID] > 0 {
+			continue
+		}
+
+		t.emitEvent(events.PlayerEliminated{
+			TableID:  t.ID,
+			PlayerID: player.ID,
+			Reason:   "blinded off",
+			At:       t.clock().Now(),
+		})
+		// Out of the hand that just ended or not, the player has nothing left to play
+		t.removePlayer(t.seatIndex(player.ID))
+	}
+}
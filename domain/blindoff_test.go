@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlindOff(t *testing.T) {
+	autoPlayDelay = time.Millisecond
+
+	setup := func() (*Hand, *Table) {
+		hand, table := setupAntesPhaseHand(3)
+		attachHandToTable(hand, table)
+		table.Rules.AutoPlayWhenAway = false
+		table.Rules.BlindOff = true
+		table.Status = TableStatusPlaying
+		return hand, table
+	}
+
+	t.Run("A player leaving keeps their stack in play", func(t *testing.T) {
+		// Setup
+		_, table := setup()
+
+		// Act
+		err := table.PlayerLeaves("player-3")
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, table.IsSeated("player-3"))
+		assert.True(t, table.IsPlayerAway("player-3"))
+		assert.Equal(t, 1000, table.BuyIns["player-3"])
+
+		event, found := findEventOfType(table.GetEvents(), events.PlayerBlindingOff{}.Name())
+		require.True(t, found)
+		assert.Equal(t, 1000, event.(events.PlayerBlindingOff).Stack)
+	})
+
+	t.Run("The absent stack posts its ante and the table sees it shrink", func(t *testing.T) {
+		// Setup
+		hand, table := setup()
+		absentID := hand.CurrentBettor
+
+		// Act
+		require.NoError(t, table.PlayerLeaves(absentID))
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			_, found := findEventOfType(table.GetEvents(), events.AbsentStackBlindedOff{}.Name())
+			return found
+		}, time.Second, time.Millisecond)
+
+		event, _ := findEventOfType(table.GetEvents(), events.AbsentStackBlindedOff{}.Name())
+		blindedOff := event.(events.AbsentStackBlindedOff)
+		assert.Equal(t, absentID, blindedOff.PlayerID)
+		assert.Equal(t, hand.TableRules.AnteValue, blindedOff.Amount)
+		assert.Equal(t, 1000-hand.TableRules.AnteValue, blindedOff.Stack)
+	})
+
+	t.Run("A short absent stack goes all in for the ante", func(t *testing.T) {
+		// Setup
+		hand, table := setup()
+		absentID := hand.CurrentBettor
+		table.BuyIns[absentID] = 4
+
+		// Act
+		require.NoError(t, table.PlayerLeaves(absentID))
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			return inLoop(table, func() bool { return hand.AntesPaid[absentID] == 4 })
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Players who left cannot come back to their stack", func(t *testing.T) {
+		// Setup
+		_, table := setup()
+		require.NoError(t, table.PlayerLeaves("player-3"))
+
+		// Act
+		table.MarkPlayerBack("player-3")
+
+		// Assert
+		assert.True(t, table.IsPlayerAway("player-3"))
+	})
+
+	t.Run("An absent player is eliminated once the stack is gone", func(t *testing.T) {
+		// Setup
+		_, table := setup()
+		require.NoError(t, table.MarkPlayerAway("player-3"))
+		table.BuyIns["player-3"] = 0
+
+		// Act
+		table.eliminateBlindedOffPlayers()
+
+		// Assert
+		assert.False(t, table.IsSeated("player-3"))
+		event, found := findEventOfType(table.GetEvents(), events.PlayerEliminated{}.Name())
+		require.True(t, found)
+		assert.Equal(t, "player-3", event.(events.PlayerEliminated).PlayerID)
+	})
+}
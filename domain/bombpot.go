@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// defaultBombPotMultiplier is how many antes each player posts in a bomb pot when the table doesn't say
+const defaultBombPotMultiplier = 2
+
+// BombPots makes every Nth hand of a table a bomb pot: every player posts a multiple of the ante up front,
+// there is no continuation betting and the community cards are dealt right after the hole cards.
+// A zero Every disables them.
+type BombPots struct {
+	Every      int // Every Nth hand is a bomb pot, counting the table's hands from 1
+	Multiplier int // Antes each player posts, 2 when zero
+}
+
+// isBombPot checks if the hand with the given number is a bomb pot
+func (b BombPots) isBombPot(handNumber int) bool {
+	return b.Every > 0 && handNumber > 0 && handNumber%b.Every == 0
+}
+
+// multiplier returns the number of antes each player posts
+func (b BombPots) multiplier() int {
+	if b.Multiplier <= 0 {
+		return defaultBombPotMultiplier
+	}
+	return b.Multiplier
+}
+
+// postBombPot posts every player's share of the bomb pot and moves on to the hole cards, nobody has to act.
+// Players short of the full amount post what they have, those without chips sit the hand out.
+func (h *Hand) postBombPot() {
+	multiplier := h.TableRules.BombPots.multiplier()
+	amount := h.TableRules.AnteValue * multiplier
+
+	h.emitEvent(events.BombPotStarted{
+		TableID:    h.TableID,
+		HandID:     h.ID,
+		HandNumber: h.Number,
+		Multiplier: multiplier,
+		Amount:     amount,
+		At:         h.clock().Now(),
+	})
+
+	for i := range h.Players {
+		// Posted in the order antes are, starting left of the button
+		player := h.getPlayerByIndex((h.ButtonPosition + 1 + i) % len(h.Players))
+		if !h.IsPlayerActive(player.ID) {
+			continue
+		}
+
+		posted := min(amount, h.Table.GetPlayerBuyIn(player.ID))
+		if posted <= 0 {
+			h.setPlayerAsInactive(player.ID)
+			continue
+		}
+
+		h.fundEscrow(player.ID, posted, "bomb pot")
+		h.addToPlayerAntesPaid(player.ID, posted)
+		h.increasePot(posted)
+
+		h.emitEvent(events.AntePlaced{
+			TableID:  h.TableID,
+			HandID:   h.ID,
+			PlayerID: player.ID,
+			Amount:   posted,
+			At:       h.clock().Now(),
+		})
+	}
+
+	h.sweepBetsIntoPot()
+
+	h.emitEvent(events.BettingRoundEnded{
+		TableID:   h.TableID,
+		HandID:    h.ID,
+		Phase:     string(h.Phase),
+		TotalBets: h.Pot,
+		At:        h.clock().Now(),
+	})
+
+	if h.countActivePlayers() > 0 {
+		h.TransitionToHolePhase()
+		return
+	}
+	h.TransitionToEndedPhase()
+}
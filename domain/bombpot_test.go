@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBombPots(t *testing.T) {
+	setup := func(numPlayers int) (*Hand, *Table) {
+		hand, table := setupAntesPhaseHand(numPlayers)
+		hand.Phase = HandPhase_Start
+		hand.BombPot = true
+		hand.Number = 5
+		hand.TableRules.BombPots = BombPots{Every: 5, Multiplier: 3}
+		return hand, table
+	}
+
+	t.Run("Every Nth hand is a bomb pot", func(t *testing.T) {
+		bombPots := BombPots{Every: 3}
+
+		assert.False(t, bombPots.isBombPot(1))
+		assert.False(t, bombPots.isBombPot(2))
+		assert.True(t, bombPots.isBombPot(3))
+		assert.True(t, bombPots.isBombPot(6))
+		assert.False(t, BombPots{}.isBombPot(3), "disabled without a cadence")
+	})
+
+	t.Run("Every player posts a multiple of the ante without acting", func(t *testing.T) {
+		// Setup
+		hand, table := setup(3)
+
+		// Act
+		hand.TransitionToAntesPhase()
+
+		// Assert
+		assert.Equal(t, HandPhase_Hole, hand.Phase)
+		assert.Equal(t, 90, hand.Pot)
+		for _, player := range hand.Players {
+			assert.Equal(t, 30, hand.AntesPaid[player.ID])
+			assert.Equal(t, 970, table.GetPlayerBuyIn(player.ID))
+		}
+		_, turnStarted := findEventOfType(hand.Events, events.PlayerTurnStarted{}.Name())
+		assert.False(t, turnStarted, "nobody has to act")
+
+		event, found := findEventOfType(hand.Events, events.BombPotStarted{}.Name())
+		require.True(t, found)
+		started := event.(events.BombPotStarted)
+		assert.Equal(t, 5, started.HandNumber)
+		assert.Equal(t, 3, started.Multiplier)
+		assert.Equal(t, 30, started.Amount)
+	})
+
+	t.Run("Short stacks post what they have", func(t *testing.T) {
+		// Setup
+		hand, table := setup(3)
+		table.BuyIns["player-2"] = 20
+		table.BuyIns["player-3"] = 0
+
+		// Act
+		hand.TransitionToAntesPhase()
+
+		// Assert
+		assert.Equal(t, 20, hand.AntesPaid["player-2"])
+		assert.Equal(t, 0, table.GetPlayerBuyIn("player-2"))
+		assert.False(t, hand.IsPlayerActive("player-3"))
+		assert.Equal(t, 50, hand.Pot)
+	})
+
+	t.Run("The community cards follow the hole cards", func(t *testing.T) {
+		// Setup
+		hand, _ := setup(3)
+		hand.TransitionToAntesPhase()
+
+		// Act
+		err := hand.DealHoleCards()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, hand.CommunityCards, defaultCommunityCardCount)
+		assert.Empty(t, hand.ContinuationBets)
+		for _, event := range hand.Events {
+			if changed, ok := event.(events.PhaseChanged); ok {
+				assert.NotEqual(t, string(HandPhase_Continuation), changed.NewPhase)
+			}
+		}
+	})
+
+	t.Run("Regular hands are not affected", func(t *testing.T) {
+		// Setup
+		hand, _ := setup(3)
+		hand.BombPot = false
+
+		// Act
+		hand.TransitionToAntesPhase()
+
+		// Assert
+		assert.Equal(t, HandPhase_Antes, hand.Phase)
+		assert.Equal(t, 0, hand.Pot)
+		_, found := findEventOfType(hand.Events, events.BombPotStarted{}.Name())
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ErrInvalidBotFill is returned for bot-fill rules the table can't apply
+var ErrInvalidBotFill = errors.New("invalid bot fill")
+
+// maxFillBots is the most bots seated for a waiting player, enough to start playing without taking over the table
+const maxFillBots = 2
+
+// defaultFillStackAntes is the stack of fill bots, in antes, when the waiting player hasn't bought in yet
+const defaultFillStackAntes = 100
+
+// fillBotName labels fill bots, so players never mistake them for people
+const fillBotName = "House bot %d"
+
+// BotFill seats bots at a table where a player has been waiting alone, so play can start.
+// The bots leave one by one as other players join, after the hand they are playing if any.
+type BotFill struct {
+	After    time.Duration // How long a player waits alone before bots join, zero disables bot fill
+	Bots     int           // Bots seated, from 1 (when zero) to 2
+	Strategy string        // How the bots play, "ev" when empty
+	Stack    int           // Chips each bot brings, as many as the waiting player has when zero
+}
+
+// Validate checks the bot-fill rules
+func (f BotFill) Validate() error {
+	if f.After < 0 || f.Bots < 0 || f.Bots > maxFillBots || f.Stack < 0 {
+		return ErrInvalidBotFill
+	}
+	if f.Strategy != "" {
+		if _, err := BotStrategyByName(f.Strategy); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBotFill, err)
+		}
+	}
+	return nil
+}
+
+// humanCount is the number of seated players the table doesn't play for
+func (t *Table) humanCount() int {
+	humans := 0
+	for _, player := range t.Players {
+		if !t.IsBot(player.ID) {
+			humans++
+		}
+	}
+	return humans
+}
+
+// IsFillBot checks if the player is a bot seated by bot fill
+func (t *Table) IsFillBot(playerID string) bool {
+	_, isFillBot := t.fillBots[playerID]
+	return isFillBot
+}
+
+// handleBotFillSeat sends a fill bot away for each player who joins, and starts waiting for bots when a player sits alone
+func (t *Table) handleBotFillSeat(playerID string) {
+	if t.IsBot(playerID) {
+		return
+	}
+
+	if t.humanCount() > 1 {
+		t.cancelBotFill()
+	}
+	t.releaseFillBot()
+	t.scheduleBotFill()
+}
+
+// handleBotFillLeave keeps the fill bots only while a player is left to play with them
+func (t *Table) handleBotFillLeave(playerID string, wasBot bool) {
+	if t.IsFillBot(playerID) {
+		delete(t.fillBots, playerID)
+		return
+	}
+	if wasBot {
+		return
+	}
+
+	if t.humanCount() == 0 {
+		t.cancelBotFill()
+		for len(t.fillBots) > 0 && t.releaseFillBot() {
+		}
+		return
+	}
+
+	// The player who stayed may be alone again
+	t.scheduleBotFill()
+}
+
+// scheduleBotFill starts waiting for bots when the table's only player is seated alone
+func (t *Table) scheduleBotFill() {
+	fill := t.Rules.BotFill
+	if !t.BotFillAllowed || fill.After <= 0 || t.fillTimer != nil || t.IsDraining() {
+		return
+	}
+	if t.Status != TableStatusWaiting && t.Status != TableStatusPlaying {
+		return
+	}
+	if len(t.fillBots) > 0 || len(t.Players) != 1 || t.humanCount() != 1 {
+		return
+	}
+
+	t.fillTimer = t.clock().AfterFunc(fill.After, func() {
+		t.guard("bot fill", func() error {
+			t.fillTimer = nil
+			return t.fillWithBots()
+		})
+	})
+}
+
+// cancelBotFill stops waiting for bots
+func (t *Table) cancelBotFill() {
+	if t.fillTimer == nil {
+		return
+	}
+	t.fillTimer.Stop()
+	t.fillTimer = nil
+}
+
+// fillWithBots seats the bots for the player still waiting alone
+func (t *Table) fillWithBots() error {
+	if len(t.Players) != 1 || t.humanCount() != 1 || t.Status == TableStatusEnded || t.IsDraining() {
+		return nil
+	}
+	waiting := t.Players[0]
+
+	fill := t.Rules.BotFill
+	count := min(max(fill.Bots, 1), maxFillBots)
+	strategy := fill.Strategy
+	if strategy == "" {
+		strategy = EVStrategy{}.Name()
+	}
+	stack := fill.Stack
+	if stack <= 0 {
+		stack = t.GetPlayerBuyIn(waiting.ID)
+	}
+	if stack <= 0 {
+		stack = t.Rules.AnteValue * defaultFillStackAntes
+	}
+
+	if t.fillBots == nil {
+		t.fillBots = make(map[string]bool)
+	}
+
+	botIDs := []string{}
+	for i := range count {
+		bot, err := t.SeatBot(fmt.Sprintf(fillBotName, i+1), strategy, stack)
+		if err != nil {
+			return err
+		}
+		t.fillBots[bot.ID] = false
+		botIDs = append(botIDs, bot.ID)
+	}
+
+	t.emitEvent(events.FillBotsSeated{
+		TableID:         t.ID,
+		BotIDs:          botIDs,
+		WaitingPlayerID: waiting.ID,
+		At:              t.clock().Now(),
+	})
+
+	return nil
+}
+
+// releaseFillBot sends one fill bot away: right away when it isn't playing, after the hand otherwise.
+// It reports whether a bot was sent away.
+func (t *Table) releaseFillBot() bool {
+	for _, player := range t.Players {
+		leaving, isFillBot := t.fillBots[player.ID]
+		if !isFillBot || leaving {
+			continue
+		}
+
+		if hand := t.ActiveHand; hand != nil && !hand.HasEnded() && hand.HasPlayer(player.ID) {
+			t.fillBots[player.ID] = true
+			return true
+		}
+
+		t.removePlayer(t.seatIndex(player.ID))
+		return true
+	}
+	return false
+}
+
+// removeLeavingFillBots takes the fill bots sent away during the hand off the table, once it is over
+func (t *Table) removeLeavingFillBots() {
+	for playerID, leaving := range t.fillBots {
+		if leaving {
+			t.removePlayer(t.seatIndex(playerID))
+		}
+	}
+}
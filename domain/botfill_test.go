@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBotFillTable creates a table that seats bots for a player waiting alone for a minute
+func setupBotFillTable(fill BotFill) (*Table, *ManualClock) {
+	clock := NewManualClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	table := NewTestTable()
+	table.Clock = clock
+	table.BotFillAllowed = true
+	fill.After = time.Minute
+	table.Rules.BotFill = fill
+	return table, clock
+}
+
+func TestBotFill(t *testing.T) {
+	t.Run("Bots join a player waiting alone", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{Bots: 2})
+		require.NoError(t, table.SeatPlayer(&Player{ID: "human"}))
+		table.IncreasePlayerBuyIn("human", 300)
+
+		// Act
+		clock.Advance(59 * time.Second)
+		playersBefore := len(table.Players)
+		clock.Advance(time.Second)
+
+		// Assert
+		assert.Equal(t, 1, playersBefore)
+		require.Len(t, table.Players, 3)
+		event, found := findEventOfType(table.Events, events.FillBotsSeated{}.Name())
+		require.True(t, found)
+		seated := event.(events.FillBotsSeated)
+		assert.Equal(t, "human", seated.WaitingPlayerID)
+		for i, botID := range seated.BotIDs {
+			assert.True(t, table.IsFillBot(botID))
+			assert.Equal(t, 300, table.GetPlayerBuyIn(botID))
+			assert.Equal(t, fmt.Sprintf("House bot %d", i+1), table.Players[1+i].Name)
+		}
+	})
+
+	t.Run("No bots without the site-wide switch", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{})
+		table.BotFillAllowed = false
+		table.SeatPlayer(&Player{ID: "human"})
+
+		// Act
+		clock.Advance(time.Hour)
+
+		// Assert
+		assert.Len(t, table.Players, 1)
+		assert.Zero(t, clock.Pending())
+	})
+
+	t.Run("No bots once a second player joins in time", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{})
+		table.SeatPlayer(&Player{ID: "human-1"})
+
+		// Act
+		clock.Advance(30 * time.Second)
+		table.SeatPlayer(&Player{ID: "human-2"})
+		clock.Advance(time.Hour)
+
+		// Assert
+		assert.Len(t, table.Players, 2)
+		_, found := findEventOfType(table.Events, events.FillBotsSeated{}.Name())
+		assert.False(t, found)
+	})
+
+	t.Run("A bot leaves for each player joining", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{Bots: 2})
+		table.SeatPlayer(&Player{ID: "human-1"})
+		clock.Advance(time.Minute)
+
+		// Act
+		table.SeatPlayer(&Player{ID: "human-2"})
+
+		// Assert
+		assert.Len(t, table.Players, 3)
+		assert.Equal(t, 2, table.humanCount())
+		assert.Len(t, table.fillBots, 1)
+	})
+
+	t.Run("A bot in a hand leaves once the hand is over", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{})
+		table.SeatPlayer(&Player{ID: "human-1"})
+		clock.Advance(time.Minute)
+		botID := table.Players[1].ID
+		table.Status = TableStatusPlaying
+		hand, err := table.StartNewHand()
+		require.NoError(t, err)
+
+		// Act
+		table.SeatPlayer(&Player{ID: "human-2"})
+		stillSeated := table.seatIndex(botID) != -1
+		table.Status = TableStatusEnded // Keep the next hand from being dealt
+		hand.TransitionToEndedPhase()
+
+		// Assert
+		assert.True(t, stillSeated)
+		assert.Equal(t, -1, table.seatIndex(botID))
+		assert.Empty(t, table.fillBots)
+	})
+
+	t.Run("Bots leave with the last player", func(t *testing.T) {
+		// Setup
+		table, clock := setupBotFillTable(BotFill{Bots: 2})
+		table.SeatPlayer(&Player{ID: "human"})
+		clock.Advance(time.Minute)
+
+		// Act
+		err := table.PlayerLeaves("human")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, table.Players)
+		assert.Empty(t, table.Bots)
+	})
+
+	t.Run("Invalid rules are rejected", func(t *testing.T) {
+		// Assert
+		assert.NoError(t, BotFill{After: time.Minute, Bots: 2, Strategy: "tight-fold"}.Validate())
+		assert.ErrorIs(t, BotFill{Bots: 3}.Validate(), ErrInvalidBotFill)
+		assert.ErrorIs(t, BotFill{After: -time.Second}.Validate(), ErrInvalidBotFill)
+		assert.ErrorIs(t, BotFill{Strategy: "aggressive"}.Validate(), ErrUnknownBotStrategy)
+	})
+}
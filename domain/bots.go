@@ -0,0 +1,307 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	randv2 "math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+)
+
+// ErrUnknownBotStrategy is returned when a bot is given a strategy that doesn't exist
+var ErrUnknownBotStrategy = errors.New("unknown bot strategy")
+
+// ErrNotABot is returned when changing the strategy of a player the table doesn't play for
+var ErrNotABot = errors.New("player is not a bot")
+
+// botDelay is how long a bot waits before acting, so its action is not applied while the hand is still emitting
+var botDelay = 500 * time.Millisecond
+
+// BotStrategy decides how a bot plays. Bots always post their ante and pick the best community cards,
+// strategies only differ in which continuation bets they pay.
+type BotStrategy interface {
+	// Name identifies the strategy in the admin API
+	Name() string
+	// Continues decides whether the player pays the continuation bet rather than folding
+	Continues(h *Hand, playerID string) bool
+}
+
+// Built-in strategies, by name
+var botStrategies = map[string]BotStrategy{
+	AlwaysCall{}.Name(): AlwaysCall{},
+	TightFold{}.Name():  TightFold{},
+	EVStrategy{}.Name(): EVStrategy{},
+}
+
+// BotStrategyByName returns the built-in strategy with the given name
+func BotStrategyByName(name string) (BotStrategy, error) {
+	strategy, exists := botStrategies[name]
+	if !exists {
+		return nil, ErrUnknownBotStrategy
+	}
+	return strategy, nil
+}
+
+// BotStrategyNames lists the built-in strategies, in name order
+func BotStrategyNames() []string {
+	names := make([]string, 0, len(botStrategies))
+	for name := range botStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AlwaysCall pays every continuation bet
+type AlwaysCall struct{}
+
+func (AlwaysCall) Name() string { return "always-call" }
+
+func (AlwaysCall) Continues(h *Hand, playerID string) bool { return true }
+
+// TightFold only continues with a pocket pair or two cards ten or higher
+type TightFold struct{}
+
+func (TightFold) Name() string { return "tight-fold" }
+
+func (TightFold) Continues(h *Hand, playerID string) bool {
+	holeCards := h.HoleCards[playerID]
+	if len(holeCards) != 2 {
+		return false
+	}
+
+	if holeCards[0].Value == holeCards[1].Value {
+		return true
+	}
+
+	broadway := []cards.Value{cards.Ten, cards.Jack, cards.Queen, cards.King, cards.Ace}
+	return slices.Contains(broadway, holeCards[0].Value) && slices.Contains(broadway, holeCards[1].Value)
+}
+
+// defaultEVSamples is how many deals EVStrategy simulates when its Samples is zero
+const defaultEVSamples = 100
+
+// EVStrategy continues when the bet has a positive expected value: its share of the pot, estimated by
+// dealing the unseen cards at random and comparing the best hands, is worth more than the bet.
+// The pot counts the bets of the players still to decide as if they all paid.
+type EVStrategy struct {
+	Samples int
+}
+
+func (EVStrategy) Name() string { return "ev" }
+
+func (s EVStrategy) Continues(h *Hand, playerID string) bool {
+	cost := h.continuationBetAmount(playerID)
+	if cost <= 0 {
+		return true
+	}
+
+	pot := h.Pot
+	for _, player := range h.Players {
+		if h.IsPlayerActive(player.ID) && !h.hasAlreadyPlacedContinuationBet(player.ID) {
+			pot += cost
+		}
+	}
+
+	return s.equity(h, playerID)*float64(pot) >= float64(cost)
+}
+
+// equity estimates the share of the pot the player wins at showdown against the other active players
+func (s EVStrategy) equity(h *Hand, playerID string) float64 {
+	holeCards := h.HoleCards[playerID]
+
+	// Only the player's own cards are known to them
+	unseen := cards.Stack{}
+	for _, card := range cards.NewDeck52() {
+		if !stackContainsAll(holeCards, cards.Stack{card}) {
+			unseen = append(unseen, card)
+		}
+	}
+
+	opponents := []string{}
+	for _, player := range h.Players {
+		if player.ID != playerID && h.IsPlayerActive(player.ID) {
+			opponents = append(opponents, player.ID)
+		}
+	}
+
+	samples := s.Samples
+	if samples <= 0 {
+		samples = defaultEVSamples
+	}
+
+	won := 0.0
+	for range samples {
+		randv2.Shuffle(len(unseen), func(i, j int) { unseen[i], unseen[j] = unseen[j], unseen[i] })
+
+		deal := unseen
+		community := deal.DealCards(h.TableRules.communityCardCount())
+		showdown := map[string]cards.Stack{playerID: bestHandWith(holeCards, community)}
+		for _, opponentID := range opponents {
+			showdown[opponentID] = bestHandWith(deal.DealCards(2), community)
+		}
+
+		winners, wins := 0, false
+		for _, result := range hands.CompareHands(showdown) {
+			if result.PlaceIndex == 0 {
+				winners++
+				wins = wins || result.PlayerID == playerID
+			}
+		}
+		if wins {
+			won += 1 / float64(winners)
+		}
+	}
+
+	return won / float64(samples)
+}
+
+// bestHandWith returns the strongest hand made of both hole cards and three community cards
+func bestHandWith(holeCards cards.Stack, community cards.Stack) cards.Stack {
+	available := append(cards.Stack{}, holeCards...)
+	available = append(available, community...)
+
+	for _, candidate := range hands.ListAllPossibleHands(available) {
+		if stackContainsAll(candidate.Cards, holeCards) {
+			return candidate.Cards
+		}
+	}
+	return available
+}
+
+// SeatBot seats a player the table plays for with the given strategy. Bots bring their own stack,
+// it doesn't come from a bankroll and isn't cashed out when they leave.
+func (t *Table) SeatBot(name string, strategy string, stack int) (*Player, error) {
+	botStrategy, err := BotStrategyByName(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	bot := &Player{ID: "bot-" + uuid.NewString(), Name: name}
+	if bot.Name == "" {
+		bot.Name = "Bot (" + strategy + ")"
+	}
+
+	// A bot from the start, so it isn't taken for a player joining the table
+	if t.Bots == nil {
+		t.Bots = make(map[string]BotStrategy)
+	}
+	t.Bots[bot.ID] = botStrategy
+
+	if err := t.SeatPlayer(bot); err != nil {
+		delete(t.Bots, bot.ID)
+		return nil, err
+	}
+
+	t.IncreasePlayerBuyIn(bot.ID, stack)
+
+	return bot, nil
+}
+
+// SetBotStrategy switches a bot to another strategy, from its next decision on
+func (t *Table) SetBotStrategy(playerID string, strategy string) error {
+	if !t.IsBot(playerID) {
+		return ErrNotABot
+	}
+
+	botStrategy, err := BotStrategyByName(strategy)
+	if err != nil {
+		return err
+	}
+	t.Bots[playerID] = botStrategy
+	return nil
+}
+
+// IsBot checks if the table plays for the player
+func (t *Table) IsBot(playerID string) bool {
+	_, isBot := t.Bots[playerID]
+	return isBot
+}
+
+// handleBotEvent schedules the bots' actions when the hand is waiting on them
+func (t *Table) handleBotEvent(event events.Event) {
+	if len(t.Bots) == 0 || t.ActiveHand == nil {
+		return
+	}
+
+	switch ev := event.(type) {
+	case events.PlayerTurnStarted:
+		if t.IsBot(ev.PlayerID) {
+			t.scheduleBotAction(t.ActiveHand, ev.PlayerID)
+		}
+	case events.CommunitySelectionStarted:
+		for playerID := range t.Bots {
+			if t.ActiveHand.IsPlayerActive(playerID) {
+				t.scheduleBotAction(t.ActiveHand, playerID)
+			}
+		}
+	}
+}
+
+// scheduleBotAction has the bot act after a short delay, see botDelay
+func (t *Table) scheduleBotAction(hand *Hand, playerID string) {
+	t.clock().AfterFunc(botDelay, func() {
+		err := t.guard("bot", func() error {
+			strategy, isBot := t.Bots[playerID]
+			if t.ActiveHand != hand || !isBot {
+				return nil
+			}
+			return hand.botPlay(playerID, strategy)
+		})
+		if err != nil {
+			fmt.Println("Bot", playerID, "failed to act:", err)
+		}
+	})
+}
+
+// botPlay acts for a bot in the current phase of the hand
+func (h *Hand) botPlay(playerID string, strategy BotStrategy) error {
+	if !h.IsPlayerActive(playerID) {
+		return nil
+	}
+
+	switch h.Phase {
+	case HandPhase_Antes:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasAlreadyPlacedAnte(playerID) {
+			return h.PlayerPlacesAnte(playerID, max(min(h.TableRules.AnteValue, h.Table.BuyIns[playerID]), 0))
+		}
+
+	case HandPhase_Continuation:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasAlreadyPlacedContinuationBet(playerID) {
+			if strategy.Continues(h, playerID) {
+				return h.PlayerPlacesContinuationBet(playerID, h.continuationBetAmount(playerID))
+			}
+			return h.PlayerFolds(playerID)
+		}
+
+	case HandPhase_Discard:
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.hasPaidDiscardCost(playerID) {
+			return h.PlayerSkipsDiscard(playerID)
+		}
+
+	case HandPhase_CommunitySelection:
+		for _, card := range h.bestCommunitySelection(playerID) {
+			if err := h.PlayerSelectsCommunityCard(playerID, card); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// continuationBetAmount is the continuation bet the player would pay: the ante times the table's multiplier,
+// all in when their stack is short
+func (h *Hand) continuationBetAmount(playerID string) int {
+	amount := h.TableRules.AnteValue * max(h.TableRules.ContinuationBetMultiplier, 1)
+	if h.Table != nil {
+		amount = max(min(amount, h.Table.BuyIns[playerID]), 0)
+	}
+	return amount
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mustStack parses card shorthands such as "As" or "10h"
+func mustStack(t *testing.T, shorthands ...string) cards.Stack {
+	stack := cards.Stack{}
+	for _, shorthand := range shorthands {
+		card, err := cards.CardFromString(shorthand)
+		require.NoError(t, err)
+		stack = append(stack, card)
+	}
+	return stack
+}
+
+func TestBotStrategies(t *testing.T) {
+	t.Run("Strategies are found by name", func(t *testing.T) {
+		// Act
+		strategy, err := BotStrategyByName("tight-fold")
+		_, unknownErr := BotStrategyByName("aggressive")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, TightFold{}, strategy)
+		assert.ErrorIs(t, unknownErr, ErrUnknownBotStrategy)
+		assert.Equal(t, []string{"always-call", "ev", "tight-fold"}, BotStrategyNames())
+	})
+
+	t.Run("Tight-fold only continues with pairs and high cards", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+		hand.HoleCards["player-1"] = mustStack(t, "7s", "7h")
+		hand.HoleCards["player-2"] = mustStack(t, "Ks", "9h")
+
+		// Assert
+		assert.True(t, TightFold{}.Continues(hand, "player-1"))
+		assert.False(t, TightFold{}.Continues(hand, "player-2"))
+		assert.True(t, AlwaysCall{}.Continues(hand, "player-2"))
+	})
+
+	t.Run("EV continues with strong cards and folds weak ones", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+		hand.TableRules.AnteValue = 10
+		hand.TableRules.ContinuationBetMultiplier = 2
+		hand.HoleCards["player-1"] = mustStack(t, "As", "Ah")
+		hand.HoleCards["player-2"] = mustStack(t, "2c", "3d")
+		strategy := EVStrategy{Samples: 100}
+
+		// Act
+		strongEquity := strategy.equity(hand, "player-1")
+		weakEquity := strategy.equity(hand, "player-2")
+
+		// Assert
+		assert.Greater(t, strongEquity, weakEquity)
+		assert.True(t, strategy.Continues(hand, "player-1"))
+		assert.False(t, strategy.Continues(hand, "player-2"))
+	})
+}
+
+func TestBotPlay(t *testing.T) {
+	t.Run("Bot pays the continuation bet its strategy accepts", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		hand.TableRules.AnteValue = 10
+		hand.TableRules.ContinuationBetMultiplier = 2
+		botID := hand.CurrentBettor
+
+		// Act
+		err := hand.botPlay(botID, AlwaysCall{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 20, hand.ContinuationBets[botID])
+		assert.Equal(t, 980, table.BuyIns[botID])
+	})
+
+	t.Run("Bot folds the continuation bet its strategy rejects", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+		botID := hand.CurrentBettor
+		hand.HoleCards[botID] = mustStack(t, "7s", "2h")
+
+		// Act
+		err := hand.botPlay(botID, TightFold{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, hand.IsPlayerActive(botID))
+	})
+
+	t.Run("Bot picks its community cards", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupSelectionPhaseHand()
+
+		// Act
+		err := hand.botPlay("player-3", TightFold{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, hand.CommunitySelections["player-3"], 3)
+	})
+}
+
+func TestSeatBot(t *testing.T) {
+	t.Run("Bot is seated with its own stack", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		bot, err := table.SeatBot("", "ev", 500)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, table.IsBot(bot.ID))
+		assert.Equal(t, "Bot (ev)", bot.Name)
+		assert.Equal(t, 500, table.GetPlayerBuyIn(bot.ID))
+	})
+
+	t.Run("Unknown strategy is rejected", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		_, err := table.SeatBot("Robot", "aggressive", 500)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownBotStrategy)
+		assert.Empty(t, table.Players)
+	})
+
+	t.Run("Strategy can be changed for bots only", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		bot, _ := table.SeatBot("Robot", "ev", 500)
+		table.SeatPlayer(&Player{ID: "human"})
+
+		// Act
+		err := table.SetBotStrategy(bot.ID, "always-call")
+		humanErr := table.SetBotStrategy("human", "always-call")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, AlwaysCall{}, table.Bots[bot.ID])
+		assert.ErrorIs(t, humanErr, ErrNotABot)
+	})
+
+	t.Run("Leaving bot isn't cashed out", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		table.Wallet = wallet.NewLedger()
+		bot, _ := table.SeatBot("Robot", "ev", 500)
+
+		// Act
+		err := table.PlayerLeaves(bot.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, table.IsBot(bot.ID))
+		assert.Zero(t, table.Wallet.Balance(bot.ID))
+	})
+}
@@ -1,8 +1,8 @@
 package cards
 
 import (
-	"math/rand"
-	"time"
+	crand "crypto/rand"
+	randv2 "math/rand/v2"
 )
 
 // NewDeck52 creates a standard deck of 52 cards
@@ -20,9 +20,24 @@ func NewDeck52() Stack {
 	return deck
 }
 
-// ShuffleCards shuffles a deck of cards randomly
+// ShuffleCards shuffles a deck of cards from a fresh secure seed
 func ShuffleCards(cards []Card) []Card {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return ShuffleCardsWithSeed(cards, NewSeed())
+}
+
+// NewSeed returns a fresh shuffle seed read from the system's secure random source
+func NewSeed() [32]byte {
+	var seed [32]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		panic("cannot read random seed: " + err.Error())
+	}
+	return seed
+}
+
+// ShuffleCardsWithSeed shuffles a deck of cards deterministically from the given seed,
+// so the exact deal can be reproduced when the seed is revealed
+func ShuffleCardsWithSeed(cards []Card, seed [32]byte) []Card {
+	r := randv2.New(randv2.NewChaCha8(seed))
 
 	shuffled := make([]Card, len(cards))
 	copy(shuffled, cards)
@@ -67,3 +67,52 @@ func TestDealCards(t *testing.T) {
 			initialLength-count, len(remainingDeck))
 	}
 }
+
+func TestShuffleCardsWithSeed(t *testing.T) {
+	seed := NewSeed()
+
+	first := ShuffleCardsWithSeed(NewDeck52(), seed)
+	second := ShuffleCardsWithSeed(NewDeck52(), seed)
+
+	for i := range first {
+		if !first[i].Equals(second[i]) {
+			t.Fatalf("Expected the same seed to produce the same deck, cards differ at position %d", i)
+		}
+	}
+
+	other := ShuffleCardsWithSeed(NewDeck52(), NewSeed())
+	differences := 0
+	for i := range first {
+		if !first[i].Equals(other[i]) {
+			differences++
+		}
+	}
+
+	if differences == 0 {
+		t.Error("Expected different seeds to produce different decks")
+	}
+}
+
+func TestSeededShuffler(t *testing.T) {
+	first := NewSeededShuffler([32]byte{7})
+	second := NewSeededShuffler([32]byte{7})
+
+	seeds := map[[32]byte]bool{}
+	for i := 0; i < 5; i++ {
+		seed := first.NextSeed()
+		if seed != second.NextSeed() {
+			t.Fatalf("Expected both shufflers to give the same seed at step %d", i)
+		}
+		if seeds[seed] {
+			t.Errorf("Expected a new seed at step %d", i)
+		}
+		seeds[seed] = true
+	}
+}
+
+func TestSecureShuffler(t *testing.T) {
+	shuffler := SecureShuffler{}
+	if shuffler.NextSeed() == shuffler.NextSeed() {
+		t.Error("Expected two secure seeds to differ")
+	}
+}
@@ -0,0 +1,42 @@
+package cards
+
+import (
+	randv2 "math/rand/v2"
+	"sync"
+)
+
+// Shuffler picks the seed each deck is shuffled from. Decks are always shuffled with
+// ShuffleCardsWithSeed, so the seed alone is enough to replay a deal.
+type Shuffler interface {
+	NextSeed() [32]byte
+}
+
+// SecureShuffler draws every seed from the system's secure random source, for real money play
+type SecureShuffler struct{}
+
+// NextSeed returns a fresh secure seed
+func (SecureShuffler) NextSeed() [32]byte {
+	return NewSeed()
+}
+
+// SeededShuffler derives a reproducible sequence of seeds from a single seed, so simulations
+// and tests get the exact same deals on every run. It must never be used for real play.
+type SeededShuffler struct {
+	mu     sync.Mutex
+	source *randv2.ChaCha8
+}
+
+// NewSeededShuffler creates a shuffler whose seeds all follow from the given one
+func NewSeededShuffler(seed [32]byte) *SeededShuffler {
+	return &SeededShuffler{source: randv2.NewChaCha8(seed)}
+}
+
+// NextSeed returns the next seed of the sequence
+func (s *SeededShuffler) NextSeed() [32]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var seed [32]byte
+	s.source.Read(seed[:])
+	return seed
+}
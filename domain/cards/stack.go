@@ -38,6 +38,12 @@ func (stack *Stack) Shuffle() {
 	*stack = Stack(shuffled)
 }
 
+func (stack *Stack) ShuffleWithSeed(seed [32]byte) {
+	deck := *stack
+	shuffled := ShuffleCardsWithSeed(deck, seed)
+	*stack = Stack(shuffled)
+}
+
 func (stack Stack) String() string {
 	var s string
 	for _, c := range stack {
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and schedules the timers of the lobby, its tables and their hands.
+// Tests and simulations swap it for a ManualClock to run timed phases without waiting.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a scheduled call that can be cancelled
+type Timer interface {
+	Stop() bool
+}
+
+// SystemClock is the wall clock, backed by the time package
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time                            { return time.Now() }
+func (SystemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// clock returns the table's clock, the system clock unless one was injected
+func (t *Table) clock() Clock {
+	if t.Clock == nil {
+		return SystemClock{}
+	}
+	return t.Clock
+}
+
+// clock returns the clock of the hand's table, the system clock for hands without one
+func (h *Hand) clock() Clock {
+	if h.Table == nil {
+		return SystemClock{}
+	}
+	return h.Table.clock()
+}
+
+// clock returns the lobby's clock, the system clock unless one was injected
+func (l *Lobby) clock() Clock {
+	if l.Clock == nil {
+		return SystemClock{}
+	}
+	return l.Clock
+}
+
+// ManualClock only moves when told to. Timers run from Advance, on the caller's goroutine, once their time has come.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	clock   *ManualClock
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+// NewManualClock creates a clock standing still at start
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the time the clock was advanced to
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock is advanced by d
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &manualTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward by d, running the timers that fall due in the order they are due.
+// Timers scheduled by those run as well if they fall due within d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next := c.nextDue(end)
+		if next == nil {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		next.stopped = true
+		c.now = next.at
+		c.mu.Unlock()
+
+		next.f()
+	}
+}
+
+// Pending returns how many timers are waiting to run
+func (c *ManualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune()
+	return len(c.timers)
+}
+
+// nextDue returns the earliest timer due by end, nil if there is none. c.mu must be held.
+func (c *ManualClock) nextDue(end time.Time) *manualTimer {
+	c.prune()
+	sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].at.Before(c.timers[j].at) })
+	if len(c.timers) == 0 || c.timers[0].at.After(end) {
+		return nil
+	}
+	return c.timers[0]
+}
+
+// prune forgets the timers that ran or were stopped. c.mu must be held.
+func (c *ManualClock) prune() {
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if !timer.stopped {
+			pending = append(pending, timer)
+		}
+	}
+	c.timers = pending
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasPending := !t.stopped
+	t.stopped = true
+	return wasPending
+}
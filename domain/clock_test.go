@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	t.Run("Timers run in the order they are due once the clock reaches them", func(t *testing.T) {
+		// Setup
+		clock := NewManualClock(start)
+		ran := []string{}
+		clock.AfterFunc(2*time.Second, func() { ran = append(ran, "second") })
+		clock.AfterFunc(time.Second, func() { ran = append(ran, "first") })
+		clock.AfterFunc(time.Minute, func() { ran = append(ran, "later") })
+
+		// Act
+		clock.Advance(5 * time.Second)
+
+		// Assert
+		assert.Equal(t, []string{"first", "second"}, ran)
+		assert.Equal(t, start.Add(5*time.Second), clock.Now())
+		assert.Equal(t, 1, clock.Pending())
+	})
+
+	t.Run("Timers see the time they were due at", func(t *testing.T) {
+		// Setup
+		clock := NewManualClock(start)
+		var seen time.Time
+		clock.AfterFunc(time.Second, func() { seen = clock.Now() })
+
+		// Act
+		clock.Advance(time.Hour)
+
+		// Assert
+		assert.Equal(t, start.Add(time.Second), seen)
+	})
+
+	t.Run("Timers scheduled by a timer run within the same advance", func(t *testing.T) {
+		// Setup
+		clock := NewManualClock(start)
+		ran := 0
+		clock.AfterFunc(time.Second, func() {
+			ran++
+			clock.AfterFunc(time.Second, func() { ran++ })
+		})
+
+		// Act
+		clock.Advance(3 * time.Second)
+
+		// Assert
+		assert.Equal(t, 2, ran)
+	})
+
+	t.Run("Stopped timers don't run", func(t *testing.T) {
+		// Setup
+		clock := NewManualClock(start)
+		ran := false
+		timer := clock.AfterFunc(time.Second, func() { ran = true })
+
+		// Act
+		stopped := timer.Stop()
+		clock.Advance(time.Minute)
+
+		// Assert
+		assert.True(t, stopped)
+		assert.False(t, ran)
+		assert.False(t, timer.Stop(), "the timer was already stopped")
+	})
+
+	t.Run("A table fast-forwards through a turn timeout", func(t *testing.T) {
+		// Setup
+		clock := NewManualClock(start)
+		hand, table := setupAntesPhaseHand(3)
+		table.Clock = clock
+		table.Players = hand.Players
+		table.ActiveHand = hand
+		hand.RegisterEventHandler(table.handleHandEvent)
+		hand.emitEvent(events.PlayerTurnStarted{
+			TableID:  hand.TableID,
+			HandID:   hand.ID,
+			PlayerID: hand.CurrentBettor,
+			Phase:    string(hand.Phase),
+			Timeout:  hand.turnTimeout(),
+			At:       clock.Now(),
+		})
+		timedOutID := hand.CurrentBettor
+
+		// Act
+		clock.Advance(hand.turnTimeout())
+
+		// Assert
+		event, found := findEventOfType(hand.Events, events.PlayerTimedOut{}.Name())
+		require.True(t, found)
+		assert.Equal(t, timedOutID, event.(events.PlayerTimedOut).PlayerID)
+		assert.Equal(t, start.Add(hand.turnTimeout()), event.Timestamp())
+	})
+}
@@ -1,14 +1,32 @@
 package commands
 
-import "github.com/lazharichir/poker/domain/cards"
+import (
+	"encoding/json"
+
+	"github.com/lazharichir/poker/domain/cards"
+)
 
 type Command interface {
 	Name() string
 }
 
+// Registered returns the zero value of every command clients may send, in the order they are declared
+func Registered() []Command {
+	return []Command{
+		EnterLobby{}, LeaveLobby{}, ResumeSession{}, RefreshToken{},
+		PlayerSeats{}, PlayerLeavesTable{}, PlayerBuysIn{}, TopUp{}, ProposeRematch{}, AcceptRematch{},
+		PlayerFolds{}, PlayerPlacesAnte{}, PlayerPlacesContinuationBet{}, PlayerSelectsCommunityCard{},
+		ConfirmAction{}, TimeSync{}, BlockPlayer{}, UnblockPlayer{}, PlayerReady{}, CommandBatch{},
+		SpectateTable{}, StopSpectating{}, SubscribeEvents{}, UpdatePrivacy{}, GetHandView{},
+	}
+}
+
 type EnterLobby struct {
 	PlayerID   string
 	PlayerName string
+	Country    string // ISO 3166-1 alpha-2 code, optional
+	Locale     string // BCP 47 language tag such as "pt-BR", optional
+	TimeZone   string // IANA time zone such as "Europe/Paris", optional: times are shown in UTC without it
 }
 
 func (e EnterLobby) Name() string { return "ENTER_LOBBY" }
@@ -19,6 +37,20 @@ type LeaveLobby struct {
 
 func (l LeaveLobby) Name() string { return "LEAVE_LOBBY" }
 
+// ResumeSession binds a new connection to the player of a dropped one, with the token from SESSION_STARTED
+type ResumeSession struct {
+	Token string
+}
+
+func (r ResumeSession) Name() string { return "RESUME_SESSION" }
+
+// RefreshToken hands the server a fresh ID token from the identity provider, before the connection's expires
+type RefreshToken struct {
+	IDToken string
+}
+
+func (r RefreshToken) Name() string { return "REFRESH_TOKEN" }
+
 type PlayerSeats struct {
 	PlayerID string
 	TableID  string
@@ -41,37 +73,158 @@ type PlayerBuysIn struct {
 
 func (p PlayerBuysIn) Name() string { return "PLAYER_BUYS_IN" }
 
-type PlayerFolds struct {
+// TopUp adds chips to a seated player's stack, applied once the current hand is over
+type TopUp struct {
 	PlayerID string
 	TableID  string
-	HandID   string
+	Amount   int
 }
 
-func (p PlayerFolds) Name() string { return "PLAYER_FOLDS" }
+func (t TopUp) Name() string { return "TOP_UP" }
 
-type PlayerPlacesAnte struct {
+// ProposeRematch asks the players of a finished private table to play again, each buying in for BuyIn
+type ProposeRematch struct {
 	PlayerID string
 	TableID  string
-	HandID   string
-	Amount   int
+	BuyIn    int
 }
 
-func (p PlayerPlacesAnte) Name() string { return "PLAYER_PLACES_ANTE" }
+func (p ProposeRematch) Name() string { return "PROPOSE_REMATCH" }
 
-type PlayerPlacesContinuationBet struct {
+// AcceptRematch accepts the rematch proposed at a table, which starts once every seated player has
+type AcceptRematch struct {
 	PlayerID string
 	TableID  string
-	HandID   string
-	Amount   int
+}
+
+func (a AcceptRematch) Name() string { return "ACCEPT_REMATCH" }
+
+type PlayerFolds struct {
+	PlayerID    string
+	TableID     string
+	HandID      string
+	Phase       string // Phase of the hand the player acted in
+	LastEventID string // Last event the client saw, optional
+}
+
+func (p PlayerFolds) Name() string { return "PLAYER_FOLDS" }
+
+type PlayerPlacesAnte struct {
+	PlayerID    string
+	TableID     string
+	HandID      string
+	Phase       string // Phase of the hand the player acted in
+	LastEventID string // Last event the client saw, optional
+	Amount      int
+}
+
+func (p PlayerPlacesAnte) Name() string { return "PLAYER_PLACES_ANTE" }
+
+type PlayerPlacesContinuationBet struct {
+	PlayerID    string
+	TableID     string
+	HandID      string
+	Phase       string // Phase of the hand the player acted in
+	LastEventID string // Last event the client saw, optional
+	Amount      int
 }
 
 func (p PlayerPlacesContinuationBet) Name() string { return "PLAYER_PLACES_CONTINUATION_BET" }
 
 type PlayerSelectsCommunityCard struct {
+	PlayerID    string
+	TableID     string
+	HandID      string
+	Phase       string // Phase of the hand the player acted in
+	LastEventID string // Last event the client saw, optional
+	Card        cards.Card
+}
+
+func (p PlayerSelectsCommunityCard) Name() string { return "PLAYER_SELECTS_COMMUNITY_CARD" }
+
+type ConfirmAction struct {
+	PlayerID string
+	TableID  string
+	Token    string
+}
+
+func (c ConfirmAction) Name() string { return "CONFIRM_ACTION" }
+
+type TimeSync struct {
+	ClientTime int64 // Unix milliseconds, client clock
+}
+
+func (t TimeSync) Name() string { return "TIME_SYNC" }
+
+type BlockPlayer struct {
+	PlayerID        string
+	TableID         string
+	TargetPlayerID  string // The blocked player
+	Reason          string
+	Note            string
+	DurationSeconds int // zero for a permanent block
+}
+
+func (b BlockPlayer) Name() string { return "BLOCK_PLAYER" }
+
+type UnblockPlayer struct {
+	PlayerID       string
+	TableID        string
+	TargetPlayerID string // The unblocked player
+}
+
+func (u UnblockPlayer) Name() string { return "UNBLOCK_PLAYER" }
+
+type PlayerReady struct {
 	PlayerID string
 	TableID  string
 	HandID   string
-	Card     cards.Card
 }
 
-func (p PlayerSelectsCommunityCard) Name() string { return "PLAYER_SELECTS_COMMUNITY_CARD" }
+func (p PlayerReady) Name() string { return "PLAYER_READY" }
+
+// CommandBatch carries several commands in one message, they run in order and stop at the first failure
+type CommandBatch struct {
+	Commands []json.RawMessage // Each one a complete command message, with its name
+}
+
+func (c CommandBatch) Name() string { return "COMMAND_BATCH" }
+
+// SpectateTable watches a table without a seat: public events only, and no hole cards
+type SpectateTable struct {
+	TableID string
+}
+
+func (s SpectateTable) Name() string { return "SPECTATE_TABLE" }
+
+type StopSpectating struct {
+	TableID string
+}
+
+func (s StopSpectating) Name() string { return "STOP_SPECTATING" }
+
+// SubscribeEvents sets the optional event categories the connection doesn't want, e.g. "dealer" or "pot".
+// Each subscription replaces the previous one, an empty Exclude gets every event again.
+type SubscribeEvents struct {
+	Exclude []string
+}
+
+func (s SubscribeEvents) Name() string { return "SUBSCRIBE_EVENTS" }
+
+// UpdatePrivacy replaces the player's privacy settings
+type UpdatePrivacy struct {
+	PlayerID             string
+	HideStats            bool // Other players can't see their stats
+	HideFromLeaderboards bool // Left out of the leaderboard and of the big pots feed
+	HideCountry          bool // Other players can't see their country
+}
+
+func (u UpdatePrivacy) Name() string { return "UPDATE_PRIVACY" }
+
+// GetHandView asks for the player's view of the hand in progress at a table, as pushed after every change to it
+type GetHandView struct {
+	PlayerID string
+	TableID  string
+}
+
+func (g GetHandView) Name() string { return "GET_HAND_VIEW" }
@@ -0,0 +1,59 @@
+package commands_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/domain/internal/schematest"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden schema file")
+
+// allCommands must list every command type, TestCommandSchemas fails if one is missing
+var allCommands = []schematest.Message{
+	commands.EnterLobby{},
+	commands.LeaveLobby{},
+	commands.ResumeSession{},
+	commands.RefreshToken{},
+	commands.PlayerSeats{},
+	commands.PlayerLeavesTable{},
+	commands.PlayerBuysIn{},
+	commands.TopUp{},
+	commands.ProposeRematch{},
+	commands.AcceptRematch{},
+	commands.PlayerFolds{},
+	commands.PlayerPlacesAnte{},
+	commands.PlayerPlacesContinuationBet{},
+	commands.PlayerSelectsCommunityCard{},
+	commands.ConfirmAction{},
+	commands.TimeSync{},
+	commands.BlockPlayer{},
+	commands.UnblockPlayer{},
+	commands.PlayerReady{},
+	commands.CommandBatch{},
+	commands.SpectateTable{},
+	commands.StopSpectating{},
+	commands.SubscribeEvents{},
+	commands.UpdatePrivacy{},
+	commands.GetHandView{},
+}
+
+func TestCommandSchemas(t *testing.T) {
+	t.Run("Every command is registered", func(t *testing.T) {
+		assert.Equal(t, schematest.DeclaredMessages(t, "."), schematest.TypeNames(allCommands))
+	})
+
+	t.Run("Every command is in the registry", func(t *testing.T) {
+		registered := []schematest.Message{}
+		for _, message := range commands.Registered() {
+			registered = append(registered, message)
+		}
+		assert.Equal(t, schematest.TypeNames(allCommands), schematest.TypeNames(registered))
+	})
+
+	t.Run("Wire formats match the golden file", func(t *testing.T) {
+		schematest.Check(t, "testdata/commands.golden.json", "testdata/migrations.md", allCommands, *update)
+	})
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+)
+
+// ErrInvalidCommunityRules is returned for community card counts or selection windows a table can't play
+var ErrInvalidCommunityRules = errors.New("invalid community card rules")
+
+// Community cards of a hand, unless the table says otherwise
+const (
+	defaultCommunityCardCount = 8
+	defaultSelectionCount     = 3
+	defaultSelectionTimeLimit = 5 * time.Second
+)
+
+// minSelectionCount is the fewest community cards players may select: with their 2 hole cards, they must make a 5-card hand
+const minSelectionCount = 3
+
+// communityCardCount returns how many community cards each hand deals
+func (r TableRules) communityCardCount() int {
+	if r.CommunityCards > 0 {
+		return r.CommunityCards
+	}
+	return defaultCommunityCardCount
+}
+
+// selectionCount returns how many community cards each player selects
+func (r TableRules) selectionCount() int {
+	if r.SelectedCards > 0 {
+		return r.SelectedCards
+	}
+	return defaultSelectionCount
+}
+
+// ValidateCommunity checks that the table's community cards can be played: players select at least 3 cards
+// and no more than are dealt, and the deck holds enough cards to deal every seat and burn along the way
+func (r TableRules) ValidateCommunity() error {
+	if r.CommunityCards < 0 || r.SelectedCards < 0 || r.SelectionWindow < 0 {
+		return ErrInvalidCommunityRules
+	}
+
+	dealt, selected := r.communityCardCount(), r.selectionCount()
+	if selected < minSelectionCount {
+		return fmt.Errorf("%w: players select at least %d community cards", ErrInvalidCommunityRules, minSelectionCount)
+	}
+	if selected > dealt {
+		return fmt.Errorf("%w: players can't select %d of %d community cards", ErrInvalidCommunityRules, selected, dealt)
+	}
+
+	burnt := 0
+	switch r.BurnPolicy {
+	case BurnNone:
+	case BurnBeforeEachWave:
+		burnt = max(len(r.CommunityWaves), 1)
+	default:
+		burnt = 1
+	}
+
+	needed := dealt + burnt + 2*max(r.MaxPlayers, 2)
+	if deckSize := len(cards.NewDeck52()); needed > deckSize {
+		return fmt.Errorf("%w: %d community cards, %d burnt and 2 hole cards for %d players need %d cards, the deck has %d",
+			ErrInvalidCommunityRules, dealt, burnt, max(r.MaxPlayers, 2), needed, deckSize)
+	}
+	return nil
+}
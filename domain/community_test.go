@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommunity(t *testing.T) {
+	t.Run("Accepts the defaults and variants the deck can deal", func(t *testing.T) {
+		for _, rules := range []TableRules{
+			{MaxPlayers: 6},
+			{MaxPlayers: 6, CommunityCards: 10, SelectedCards: 4, SelectionWindow: 8 * time.Second},
+			{MaxPlayers: 2, CommunityCards: 5, SelectedCards: 5},
+		} {
+			// Act & Assert
+			assert.NoError(t, rules.ValidateCommunity())
+		}
+	})
+
+	t.Run("Rejects variants that can't be played", func(t *testing.T) {
+		for _, rules := range []TableRules{
+			{MaxPlayers: 6, SelectedCards: 2},
+			{MaxPlayers: 6, CommunityCards: 4, SelectedCards: 5},
+			{MaxPlayers: 10, CommunityCards: 32},
+			{MaxPlayers: 6, CommunityCards: -1},
+			{MaxPlayers: 6, SelectionWindow: -time.Second},
+		} {
+			// Act & Assert
+			assert.ErrorIs(t, rules.ValidateCommunity(), ErrInvalidCommunityRules)
+		}
+	})
+}
+
+func TestCommunityVariants(t *testing.T) {
+	t.Run("Deals and selects the table's community card counts", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+		hand.TableRules.CommunityCards = 10
+		hand.TableRules.SelectedCards = 4
+		hand.TableRules.SelectionWindow = 8 * time.Second
+		deck := cards.NewDeck52()
+		hand.HoleCards["player-1"] = deck[:2]
+		hand.HoleCards["player-2"] = deck[2:4]
+		hand.Deck = deck[4:]
+		hand.Phase = HandPhase_CommunityDeal
+
+		// Act
+		err := hand.StartDealingCommunityCards()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, hand.CommunityCards, 10)
+		assert.Equal(t, HandPhase_CommunitySelection, hand.Phase)
+
+		event, found := findEventOfType(hand.Events, events.CommunitySelectionStarted{}.Name())
+		require.True(t, found)
+		assert.Equal(t, 4, event.(events.CommunitySelectionStarted).Picks)
+		assert.Equal(t, 8*time.Second, event.(events.CommunitySelectionStarted).TimeLimit)
+
+		for _, card := range hand.CommunityCards[:4] {
+			require.NoError(t, hand.PlayerSelectsCommunityCard("player-1", card))
+		}
+		assert.Error(t, hand.PlayerSelectsCommunityCard("player-1", hand.CommunityCards[4]))
+		assert.Len(t, hand.bestCommunitySelection("player-2"), 4)
+	})
+}
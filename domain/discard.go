@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Discard cost types, see TableRules.DiscardCostType. Multiples are given in percent by DiscardCostValue:
+// 50 is half, 200 twice.
+const (
+	DiscardCostFixed     = "fixed"      // DiscardCostValue chips
+	DiscardCostAnte      = "ante"       // A multiple of the ante
+	DiscardCostPlayerBet = "player_bet" // A multiple of what the player bet in the hand so far
+	DiscardCostPot       = "pot"        // A multiple of the pot
+)
+
+// defaultDiscardTimeLimit is how long each player has to decide on a discard when the table doesn't say
+const defaultDiscardTimeLimit = 3 * time.Second
+
+// discardsAllowed checks if the table plays a discard phase, which it does with a known discard cost type
+func (h *Hand) discardsAllowed() bool {
+	switch h.TableRules.DiscardCostType {
+	case DiscardCostFixed, DiscardCostAnte, DiscardCostPlayerBet, DiscardCostPot:
+		return true
+	}
+	return false
+}
+
+// DiscardCost returns what the player pays to discard a community card, as of now
+func (h *Hand) DiscardCost(playerID string) int {
+	value := h.TableRules.DiscardCostValue
+
+	switch h.TableRules.DiscardCostType {
+	case DiscardCostAnte:
+		return h.TableRules.AnteValue * value / 100
+	case DiscardCostPlayerBet:
+		return h.playerContributions()[playerID] * value / 100
+	case DiscardCostPot:
+		return h.Pot * value / 100
+	}
+	return value
+}
+
+// discardTimeLimit returns the time each player has to decide on a discard
+func (h *Hand) discardTimeLimit() time.Duration {
+	if h.TableRules.DiscardPhaseDuration > 0 {
+		return time.Duration(h.TableRules.DiscardPhaseDuration) * time.Second
+	}
+	return defaultDiscardTimeLimit
+}
+
+// TransitionToDiscardPhase lets the players, in turn from the left of the button, pay to take one community
+// card out of play before the selection starts
+func (h *Hand) TransitionToDiscardPhase() {
+	if !h.canTransitionTo(HandPhase_Discard) {
+		return
+	}
+
+	previousPhase := h.Phase
+	h.Phase = HandPhase_Discard
+	h.DiscardCosts = make(map[string]int)
+	h.DiscardDecided = make(map[string]bool)
+	h.DiscardedCards = cards.Stack{}
+
+	h.emitEvent(events.PhaseChanged{
+		TableID:       h.TableID,
+		HandID:        h.ID,
+		PreviousPhase: string(previousPhase),
+		NewPhase:      string(h.Phase),
+		At:            h.clock().Now(),
+	})
+
+	// Players who folded already don't get a turn
+	h.CurrentBettor = h.getPlayerLeftOfButton()
+	if !h.IsPlayerActive(h.CurrentBettor) {
+		h.CurrentBettor = h.getNextActiveBettor(h.CurrentBettor)
+	}
+
+	h.emitEvent(events.BettingRoundStarted{
+		TableID:    h.TableID,
+		HandID:     h.ID,
+		Phase:      string(h.Phase),
+		FirstToAct: h.CurrentBettor,
+		At:         h.clock().Now(),
+	})
+
+	h.startDiscardTurn()
+}
+
+// startDiscardTurn prompts the current bettor for their discard
+func (h *Hand) startDiscardTurn() {
+	h.emitEvent(events.PlayerTurnStarted{
+		TableID:   h.TableID,
+		HandID:    h.ID,
+		PlayerID:  h.CurrentBettor,
+		Phase:     string(h.Phase),
+		TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+		Timeout:   h.turnTimeout(),
+		At:        h.clock().Now(),
+	})
+}
+
+// checkDiscardTurn checks that the player may act on their discard now
+func (h *Hand) checkDiscardTurn(playerID string) error {
+	if !h.IsInPhase(HandPhase_Discard) {
+		return errors.New("not in discard phase")
+	}
+	if !h.IsPlayerTheCurrentBettor(playerID) {
+		return ErrNotYourTurn
+	}
+	if h.DiscardDecided[playerID] {
+		return errors.New("player already made discard decision")
+	}
+	return nil
+}
+
+// PlayerPaysDiscardCost records a player paying to discard a community card. The turn stays with
+// the player until they pick the card with PlayerDiscardsCard.
+func (h *Hand) PlayerPaysDiscardCost(playerID string, amount int) error {
+	if err := h.checkDiscardTurn(playerID); err != nil {
+		return err
+	}
+	if h.hasPaidDiscardCost(playerID) {
+		return errors.New("player already paid the discard cost")
+	}
+
+	// Players must still have enough community cards to select from
+	if len(h.CommunityCards) <= h.TableRules.selectionCount() {
+		return errors.New("no community card left to discard")
+	}
+
+	cost := h.DiscardCost(playerID)
+	if amount != cost {
+		return errors.New("discard cost does not match the table's")
+	}
+	if h.Table != nil && h.Table.GetPlayerBuyIn(playerID) < cost {
+		return errors.New("not enough chips to pay the discard cost")
+	}
+
+	h.fundEscrow(playerID, amount, "discard cost")
+	h.DiscardCosts[playerID] = amount
+	h.increasePot(amount)
+
+	h.emitEvent(events.DiscardCostPaid{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		Amount:   amount,
+		At:       h.clock().Now(),
+	})
+
+	return nil
+}
+
+// PlayerDiscardsCard takes a community card out of play for a player who paid the discard cost
+func (h *Hand) PlayerDiscardsCard(playerID string, card cards.Card) error {
+	if err := h.checkDiscardTurn(playerID); err != nil {
+		return err
+	}
+	if !h.hasPaidDiscardCost(playerID) {
+		return errors.New("player has not paid the discard cost")
+	}
+
+	i := slices.Index(h.CommunityCards, card)
+	if i < 0 {
+		return errors.New("discarded card is not a community card")
+	}
+
+	// A new slice, views and events may still hold the previous one
+	h.CommunityCards = slices.Delete(slices.Clone(h.CommunityCards), i, i+1)
+	h.DiscardedCards = append(h.DiscardedCards, card)
+	h.DiscardDecided[playerID] = true
+
+	h.emitEvent(events.CommunityCardDiscarded{
+		TableID:   h.TableID,
+		HandID:    h.ID,
+		PlayerID:  playerID,
+		Card:      card,
+		Remaining: len(h.CommunityCards),
+		At:        h.clock().Now(),
+	})
+
+	h.advanceDiscards(playerID)
+	return nil
+}
+
+// PlayerSkipsDiscard records a player passing on their discard. Players who paid must pick their card.
+func (h *Hand) PlayerSkipsDiscard(playerID string) error {
+	if err := h.checkDiscardTurn(playerID); err != nil {
+		return err
+	}
+	if h.hasPaidDiscardCost(playerID) {
+		return errors.New("player paid the discard cost and must pick a card")
+	}
+
+	h.skipDiscard(playerID, 0)
+	return nil
+}
+
+// skipDiscard ends the player's discard turn without a discard, refunding what they paid for it
+func (h *Hand) skipDiscard(playerID string, refund int) {
+	if refund > 0 {
+		h.releaseEscrow(playerID, refund, "discard refund")
+		h.decreasePot(refund)
+		delete(h.DiscardCosts, playerID)
+	}
+	h.DiscardDecided[playerID] = true
+
+	h.emitEvent(events.DiscardSkipped{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		Refund:   refund,
+		At:       h.clock().Now(),
+	})
+
+	h.advanceDiscards(playerID)
+}
+
+// handleDiscardTimeout skips the discard of a player whose time ran out. A player who paid but picked
+// no card took no action in the end, and gets the cost back.
+func (h *Hand) handleDiscardTimeout(playerID string) {
+	h.emitPlayerTimedOut(playerID, "skip")
+	h.skipDiscard(playerID, h.DiscardCosts[playerID])
+}
+
+// advanceDiscards moves the discard phase on once the player is done with it, to the next player or to the selection
+func (h *Hand) advanceDiscards(playerID string) {
+	h.CurrentBettor = h.getNextActiveBettor(playerID)
+
+	if h.CurrentBettor == "" || h.haveAllPlayersDecidedDiscards() {
+		h.sweepBetsIntoPot()
+
+		h.emitEvent(events.BettingRoundEnded{
+			TableID:   h.TableID,
+			HandID:    h.ID,
+			Phase:     string(h.Phase),
+			TotalBets: h.calculateTotalDiscardCosts(),
+			At:        h.clock().Now(),
+		})
+
+		h.TransitionToCommunitySelectionPhase()
+		return
+	}
+
+	h.startDiscardTurn()
+}
+
+func (h *Hand) hasPaidDiscardCost(playerID string) bool {
+	_, paid := h.DiscardCosts[playerID]
+	return paid
+}
+
+func (h *Hand) haveAllPlayersDecidedDiscards() bool {
+	for playerID, active := range h.ActivePlayers {
+		if active && !h.DiscardDecided[playerID] {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Hand) calculateTotalDiscardCosts() int {
+	total := 0
+	for _, amount := range h.DiscardCosts {
+		total += amount
+	}
+	return total
+}
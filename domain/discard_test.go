@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupDiscardPhaseHand deals the 8 community cards of a 3-player hand at a table playing discards
+// at a fixed cost of 50 chips, each player having paid a 100-chip ante
+func setupDiscardPhaseHand() (*Hand, *Table, *fakeClock) {
+	hand, table := setupContinuationPhaseHand(3)
+	clock := &fakeClock{}
+	table.Clock = clock
+	table.Players = hand.Players
+	table.ActiveHand = hand
+	hand.RegisterEventHandler(table.handleHandEvent)
+
+	hand.TableRules.AnteValue = 100
+	hand.TableRules.DiscardCostType = DiscardCostFixed
+	hand.TableRules.DiscardCostValue = 50
+	for _, player := range hand.Players {
+		hand.AntesPaid[player.ID] = 100
+	}
+	hand.Pot = 300
+
+	deck := cards.NewDeck52()
+	for i, player := range hand.Players {
+		hand.HoleCards[player.ID] = deck[i*2 : i*2+2]
+	}
+	hand.CommunityCards = deck[10:17]
+	hand.Deck = deck[17:]
+
+	hand.Phase = HandPhase_CommunityDeal
+	hand.DealCommunityCard()
+	return hand, table, clock
+}
+
+func TestDiscardPhase(t *testing.T) {
+	t.Run("Tables playing discards open the phase once the community cards are out", func(t *testing.T) {
+		// Setup
+		hand, _, clock := setupDiscardPhaseHand()
+
+		// Assert
+		assert.Equal(t, HandPhase_Discard, hand.Phase)
+		assert.Equal(t, "player-2", hand.CurrentBettor)
+		require.Len(t, clock.pending(), 1)
+		assert.Equal(t, defaultDiscardTimeLimit, clock.pending()[0].duration)
+		assert.ElementsMatch(t, []string{"pay_discard_cost", "skip_discard", "fold"}, hand.getAvailableActions("player-2"))
+		assert.Equal(t, []string{"fold"}, hand.getAvailableActions("player-3"))
+	})
+
+	t.Run("Tables without discards go straight to the selection", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+		deck := cards.NewDeck52()
+		hand.CommunityCards = deck[:7]
+		hand.Deck = deck[7:]
+		hand.Phase = HandPhase_CommunityDeal
+
+		// Act
+		err := hand.DealCommunityCard()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, HandPhase_CommunitySelection, hand.Phase)
+	})
+
+	t.Run("Paying and discarding takes the card out of play", func(t *testing.T) {
+		// Setup
+		hand, table, _ := setupDiscardPhaseHand()
+		card := hand.CommunityCards[2]
+
+		// Act
+		err := hand.PlayerPaysDiscardCost("player-2", 50)
+		require.NoError(t, err)
+		err = hand.PlayerDiscardsCard("player-2", card)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 350, hand.Pot)
+		assert.Equal(t, 950, table.BuyIns["player-2"])
+		assert.Len(t, hand.CommunityCards, 7)
+		assert.NotContains(t, hand.CommunityCards, card)
+		assert.Equal(t, cards.Stack{card}, hand.DiscardedCards)
+		assert.Equal(t, "player-3", hand.CurrentBettor)
+
+		event, found := findEventOfType(hand.Events, events.CommunityCardDiscarded{}.Name())
+		require.True(t, found)
+		assert.Equal(t, 7, event.(events.CommunityCardDiscarded).Remaining)
+	})
+
+	t.Run("Players must pay the table's cost on their turn", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupDiscardPhaseHand()
+
+		// Act & Assert
+		assert.ErrorIs(t, hand.PlayerPaysDiscardCost("player-3", 50), ErrNotYourTurn)
+		assert.Error(t, hand.PlayerPaysDiscardCost("player-2", 20))
+		assert.Error(t, hand.PlayerDiscardsCard("player-2", hand.CommunityCards[0]), "cost not paid")
+
+		require.NoError(t, hand.PlayerPaysDiscardCost("player-2", 50))
+		assert.Error(t, hand.PlayerSkipsDiscard("player-2"), "paid players must pick a card")
+		assert.Error(t, hand.PlayerDiscardsCard("player-2", hand.HoleCards["player-1"][0]), "not a community card")
+	})
+
+	t.Run("Costs follow the table's cost type", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupDiscardPhaseHand()
+		hand.ContinuationBets["player-2"] = 200
+
+		tests := []struct {
+			costType string
+			value    int
+			expected int
+		}{
+			{DiscardCostFixed, 25, 25},
+			{DiscardCostAnte, 50, 50},
+			{DiscardCostPlayerBet, 10, 30},
+			{DiscardCostPot, 25, 75},
+		}
+
+		for _, test := range tests {
+			hand.TableRules.DiscardCostType = test.costType
+			hand.TableRules.DiscardCostValue = test.value
+
+			// Act
+			cost := hand.DiscardCost("player-2")
+
+			// Assert
+			assert.Equal(t, test.expected, cost, test.costType)
+		}
+	})
+
+	t.Run("Selection starts once every player discarded or passed", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupDiscardPhaseHand()
+
+		// Act
+		require.NoError(t, hand.PlayerSkipsDiscard("player-2"))
+		require.NoError(t, hand.PlayerPaysDiscardCost("player-3", 50))
+		require.NoError(t, hand.PlayerDiscardsCard("player-3", hand.CommunityCards[0]))
+		require.NoError(t, hand.PlayerSkipsDiscard("player-1"))
+
+		// Assert
+		assert.Equal(t, HandPhase_CommunitySelection, hand.Phase)
+		assert.Len(t, hand.CommunityCards, 7)
+		assert.Equal(t, map[string]int{"player-1": 100, "player-2": 100, "player-3": 150}, hand.playerContributions())
+	})
+
+	t.Run("Folded players are skipped", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupDiscardPhaseHand()
+
+		// Act
+		require.NoError(t, hand.PlayerFolds("player-3"))
+		require.NoError(t, hand.PlayerSkipsDiscard("player-2"))
+
+		// Assert
+		assert.Equal(t, "player-1", hand.CurrentBettor)
+		require.NoError(t, hand.PlayerFolds("player-1"))
+		assert.NotEqual(t, HandPhase_Discard, hand.Phase, "the last player standing wins")
+	})
+
+	t.Run("Timeouts pass and refund a cost paid without a discard", func(t *testing.T) {
+		// Setup
+		hand, table, clock := setupDiscardPhaseHand()
+		require.NoError(t, hand.PlayerPaysDiscardCost("player-2", 50))
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		assert.Equal(t, 300, hand.Pot)
+		assert.Equal(t, 1000, table.BuyIns["player-2"])
+		assert.Len(t, hand.CommunityCards, 8)
+		assert.Equal(t, "player-3", hand.CurrentBettor)
+
+		event, found := findEventOfType(hand.Events, events.DiscardSkipped{}.Name())
+		require.True(t, found)
+		assert.Equal(t, 50, event.(events.DiscardSkipped).Refund)
+	})
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTableDraining is returned when a hand would start at a table that is draining
+var ErrTableDraining = errors.New("table is draining, no new hand is dealt")
+
+// drainPollInterval is how often Lobby.Drain checks whether the hands in progress have ended
+var drainPollInterval = 50 * time.Millisecond
+
+// Drain stops the table from dealing new hands, the hand in progress plays on. A countdown to the first hand is cancelled.
+func (t *Table) Drain() {
+	t.mu.Lock()
+	t.draining = true
+	t.mu.Unlock()
+
+	t.cancelFirstHand("table draining")
+}
+
+// IsDraining checks if the table stopped dealing new hands
+func (t *Table) IsDraining() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.draining
+}
+
+// AbortHand voids the hand in progress, every player gets their bets back
+func (t *Table) AbortHand(reason string) error {
+	t.mu.RLock()
+	hand := t.ActiveHand
+	t.mu.RUnlock()
+
+	if hand == nil || hand.IsInPhase(HandPhase_Ended) {
+		return nil
+	}
+	return hand.voidHand(reason)
+}
+
+// Drain stops every table from dealing new hands and waits for the hands in progress to end.
+// Hands still running when ctx is done are voided and their bets refunded, Drain then returns ctx's error.
+func (l *Lobby) Drain(ctx context.Context) error {
+	tables := l.GetTables()
+	for _, table := range tables {
+		table.Do("drain", func() error {
+			table.Drain()
+			return nil
+		})
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		playing := []*Table{}
+		for _, table := range tables {
+			if table.GetCurrentHandID() != "" {
+				playing = append(playing, table)
+			}
+		}
+		if len(playing) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, table := range playing {
+				table.Do("drain", func() error {
+					return table.AbortHand("server shutting down")
+				})
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
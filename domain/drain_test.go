@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain(t *testing.T) {
+	drainPollInterval = time.Millisecond
+
+	// setupDrainingLobby returns a lobby whose only table has a hand in progress
+	setupDrainingLobby := func() (*Lobby, *Hand, *Table) {
+		lobby := &Lobby{tables: map[string]*Table{}}
+		hand, table := setupContinuationPhaseHand(3)
+		attachHandToTable(hand, table)
+		table.Status = TableStatusPlaying
+		lobby.tables[table.ID] = table
+		return lobby, hand, table
+	}
+
+	t.Run("Draining table deals no new hand", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}}
+		table.Status = TableStatusPlaying
+
+		// Act
+		table.Drain()
+		_, err := table.StartNewHand()
+
+		// Assert
+		assert.ErrorIs(t, err, ErrTableDraining)
+		assert.True(t, table.IsDraining())
+		assert.Nil(t, table.ActiveHand)
+	})
+
+	t.Run("Drain waits for the hand in progress to end", func(t *testing.T) {
+		// Setup
+		lobby, hand, table := setupDrainingLobby()
+		drained := make(chan error, 1)
+
+		// Act
+		go func() { drained <- lobby.Drain(context.Background()) }()
+
+		// Assert
+		assert.Never(t, func() bool { return len(drained) > 0 }, 20*time.Millisecond, time.Millisecond)
+
+		table.Do("test", func() error {
+			hand.TransitionToEndedPhase()
+			return nil
+		})
+		assert.NoError(t, <-drained)
+		assert.Empty(t, table.GetCurrentHandID(), "no new hand is dealt")
+		assert.Len(t, table.Hands, 0)
+	})
+
+	t.Run("Hands still running when time is up are voided", func(t *testing.T) {
+		// Setup
+		lobby, _, table := setupDrainingLobby()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := lobby.Drain(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		_, voided := findEventOfType(table.Events, events.HandVoided{}.Name())
+		assert.True(t, voided)
+		assert.Empty(t, table.GetCurrentHandID())
+	})
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// GameEngine runs the hands of a table. The server drives hands through this interface only,
+// so a table can move to another engine implementation, or run one side by side, without the
+// command router noticing. Table is the engine of every table unless the lobby says otherwise.
+type GameEngine interface {
+	// StartHand deals the next hand and returns its ID
+	StartHand() (string, error)
+	// SubmitAction applies a player action to the current hand, ErrStaleAction if the hand has moved on
+	SubmitAction(action Action) error
+	// Snapshot returns a copy of the table state that is safe to hand out
+	Snapshot() TableSnapshot
+	// Subscribe registers a handler for every event the engine emits
+	Subscribe(handler events.EventHandler)
+}
+
+// BetConfirmer is implemented by engines that ask players to confirm large bets before applying them
+type BetConfirmer interface {
+	// RequiresConfirmation checks the action still applies to the current hand, and whether the player must confirm it first
+	RequiresConfirmation(action Action) (bool, error)
+}
+
+// ActionType is the kind of move a player makes in a hand
+type ActionType string
+
+const (
+	ActionReady               ActionType = "ready"
+	ActionAnte                ActionType = "ante"
+	ActionFold                ActionType = "fold"
+	ActionContinuationBet     ActionType = "continuation_bet"
+	ActionSelectCommunityCard ActionType = "select_community_card"
+	ActionPayDiscardCost      ActionType = "pay_discard_cost"
+	ActionDiscardCard         ActionType = "discard_card"
+	ActionSkipDiscard         ActionType = "skip_discard"
+)
+
+// Action is a player's move in a hand, as submitted to a GameEngine
+type Action struct {
+	Type        ActionType
+	PlayerID    string
+	HandID      string
+	Phase       string     // Phase of the hand the player acted in, not needed for ready-checks
+	LastEventID string     // Last event the player saw, optional
+	Amount      int        // Antes, continuation bets and discard costs
+	Card        cards.Card // Community card selections and discards
+}
+
+var (
+	_ GameEngine   = (*Table)(nil)
+	_ BetConfirmer = (*Table)(nil)
+)
+
+// StartHand deals the next hand of the table
+func (t *Table) StartHand() (string, error) {
+	handID := ""
+	err := t.guard("hand start", func() error {
+		hand, err := t.StartNewHand()
+		if err != nil {
+			return err
+		}
+		handID = hand.ID
+		return nil
+	})
+	return handID, err
+}
+
+// SubmitAction applies a player action to the active hand, as long as the player acted on its current state
+func (t *Table) SubmitAction(action Action) error {
+	return t.guard("action "+string(action.Type)+" of player "+action.PlayerID, func() error {
+		return t.submitAction(action)
+	})
+}
+
+func (t *Table) submitAction(action Action) error {
+	// Ready-checks run before the hand has a phase worth checking
+	if action.Type == ActionReady {
+		hand, err := t.GetHandByID(action.HandID)
+		if err != nil {
+			return err
+		}
+		return hand.PlayerReady(action.PlayerID)
+	}
+
+	hand, err := t.GetHandForAction(action.PlayerID, action.HandID, action.Phase, action.LastEventID)
+	if err != nil {
+		return err
+	}
+
+	switch action.Type {
+	case ActionAnte:
+		return hand.PlayerPlacesAnte(action.PlayerID, action.Amount)
+	case ActionFold:
+		return hand.PlayerFolds(action.PlayerID)
+	case ActionContinuationBet:
+		return hand.PlayerPlacesContinuationBet(action.PlayerID, action.Amount)
+	case ActionSelectCommunityCard:
+		return hand.PlayerSelectsCommunityCard(action.PlayerID, action.Card)
+	case ActionPayDiscardCost:
+		return hand.PlayerPaysDiscardCost(action.PlayerID, action.Amount)
+	case ActionDiscardCard:
+		return hand.PlayerDiscardsCard(action.PlayerID, action.Card)
+	case ActionSkipDiscard:
+		return hand.PlayerSkipsDiscard(action.PlayerID)
+	default:
+		return errors.New("unknown action: " + string(action.Type))
+	}
+}
+
+// RequiresConfirmation checks a continuation bet against the table's confirmation threshold
+func (t *Table) RequiresConfirmation(action Action) (bool, error) {
+	if _, err := t.GetHandForAction(action.PlayerID, action.HandID, action.Phase, action.LastEventID); err != nil {
+		return false, err
+	}
+	return action.Type == ActionContinuationBet && t.RequiresBetConfirmation(action.PlayerID, action.Amount), nil
+}
+
+// Subscribe registers a handler for the table's events
+func (t *Table) Subscribe(handler events.EventHandler) {
+	t.RegisterEventHandler(handler)
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEngine stands in for another engine implementation
+type recordingEngine struct {
+	actions []Action
+}
+
+func (e *recordingEngine) StartHand() (string, error)            { return "hand-1", nil }
+func (e *recordingEngine) Snapshot() TableSnapshot               { return TableSnapshot{} }
+func (e *recordingEngine) Subscribe(handler events.EventHandler) {}
+
+func (e *recordingEngine) SubmitAction(action Action) error {
+	e.actions = append(e.actions, action)
+	return nil
+}
+
+func TestTableEngine(t *testing.T) {
+	setup := func() (GameEngine, *Hand) {
+		hand, table := setupContinuationPhaseHand(3)
+		table.ActiveHand = hand
+		return table, hand
+	}
+
+	t.Run("Applies actions to the active hand", func(t *testing.T) {
+		// Setup
+		engine, hand := setup()
+
+		// Act
+		err := engine.SubmitAction(Action{
+			Type:     ActionFold,
+			PlayerID: "player-2",
+			HandID:   hand.ID,
+			Phase:    string(HandPhase_Continuation),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, hand.IsPlayerActive("player-2"))
+	})
+
+	t.Run("Rejects actions on a phase that is over", func(t *testing.T) {
+		// Setup
+		engine, hand := setup()
+
+		// Act
+		err := engine.SubmitAction(Action{
+			Type:     ActionAnte,
+			PlayerID: "player-2",
+			HandID:   hand.ID,
+			Phase:    string(HandPhase_Antes),
+			Amount:   10,
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrStaleAction)
+	})
+
+	t.Run("Rejects unknown actions", func(t *testing.T) {
+		// Setup
+		engine, hand := setup()
+
+		// Act
+		err := engine.SubmitAction(Action{
+			Type:     "raise",
+			PlayerID: "player-2",
+			HandID:   hand.ID,
+			Phase:    string(HandPhase_Continuation),
+		})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Asks to confirm bets above the table's threshold", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		table.ActiveHand = hand
+		table.Rules.ConfirmBetsAbove = 50
+		bet := Action{Type: ActionContinuationBet, PlayerID: "player-2", HandID: hand.ID, Phase: string(HandPhase_Continuation)}
+
+		// Act
+		bet.Amount = 600
+		large, err := table.RequiresConfirmation(bet)
+		require.NoError(t, err)
+		bet.Amount = 100
+		small, err := table.RequiresConfirmation(bet)
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, large)
+		assert.False(t, small)
+	})
+}
+
+func TestLobbyEngines(t *testing.T) {
+	t.Run("Tables run their own hands by default", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		table, err := lobby.NewTable("Test Table", TableRules{AnteValue: 10})
+		require.NoError(t, err)
+
+		// Act
+		engine, err := lobby.GetEngine(table.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Same(t, table, engine)
+	})
+
+	t.Run("A table can move to another engine", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		table, _ := lobby.NewTable("Test Table", TableRules{AnteValue: 10})
+		other := &recordingEngine{}
+		require.NoError(t, lobby.SetEngine(table.ID, other))
+
+		// Act
+		engine, err := lobby.GetEngine(table.ID)
+		require.NoError(t, err)
+		engine.SubmitAction(Action{Type: ActionFold, PlayerID: "player-1"})
+
+		// Assert
+		assert.Len(t, other.actions, 1)
+	})
+
+	t.Run("Engines can only be set for existing tables", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+
+		// Act
+		err := lobby.SetEngine("unknown-table", &recordingEngine{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package escrow
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+const hkdfInfo = "poker shuffle seed escrow"
+
+// SealedSeed is a shuffle seed encrypted to the operator escrow key.
+// Only the holders of both private key shares can open it.
+type SealedSeed struct {
+	EphemeralPublicKey []byte
+	Nonce              []byte
+	Ciphertext         []byte
+}
+
+// IsEmpty checks if nothing was sealed
+func (s SealedSeed) IsEmpty() bool {
+	return len(s.Ciphertext) == 0
+}
+
+// Sealer encrypts shuffle seeds for escrow
+type Sealer interface {
+	Seal(seed []byte) (SealedSeed, error)
+}
+
+// Escrow seals seeds to the operator's public key. The server never holds the private key.
+type Escrow struct {
+	publicKey *ecdh.PublicKey
+}
+
+// NewEscrow creates an escrow from the operator's X25519 public key
+func NewEscrow(publicKey []byte) (*Escrow, error) {
+	pub, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Escrow{publicKey: pub}, nil
+}
+
+// Seal encrypts the seed with a key derived from an ephemeral key exchange with the escrow public key
+func (e *Escrow) Seal(seed []byte) (SealedSeed, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return SealedSeed{}, err
+	}
+
+	shared, err := ephemeral.ECDH(e.publicKey)
+	if err != nil {
+		return SealedSeed{}, err
+	}
+
+	aead, err := newAEAD(shared, ephemeral.PublicKey().Bytes())
+	if err != nil {
+		return SealedSeed{}, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return SealedSeed{}, err
+	}
+
+	return SealedSeed{
+		EphemeralPublicKey: ephemeral.PublicKey().Bytes(),
+		Nonce:              nonce,
+		Ciphertext:         aead.Seal(nil, nonce, seed, nil),
+	}, nil
+}
+
+// GenerateKeyShares creates a new escrow key pair and splits the private key into two shares,
+// to be handed to two different operators. Both shares are required to open a sealed seed.
+func GenerateKeyShares() (publicKey []byte, shareA []byte, shareB []byte, err error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key := private.Bytes()
+
+	shareA = make([]byte, len(key))
+	if _, err := rand.Read(shareA); err != nil {
+		return nil, nil, nil, err
+	}
+
+	shareB = xorBytes(key, shareA)
+
+	return private.PublicKey().Bytes(), shareA, shareB, nil
+}
+
+// Open decrypts a sealed seed using both private key shares (dual control)
+func Open(sealed SealedSeed, shareA []byte, shareB []byte) ([]byte, error) {
+	if sealed.IsEmpty() {
+		return nil, errors.New("nothing was sealed")
+	}
+
+	if len(shareA) == 0 || len(shareA) != len(shareB) {
+		return nil, errors.New("both key shares are required")
+	}
+
+	private, err := ecdh.X25519().NewPrivateKey(xorBytes(shareA, shareB))
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed.EphemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := private.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(shared, sealed.EphemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("cannot open sealed seed with the given key shares")
+	}
+
+	return seed, nil
+}
+
+func newAEAD(shared []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, shared, salt, hkdfInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func xorBytes(a []byte, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package escrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscrow(t *testing.T) {
+	publicKey, shareA, shareB, err := GenerateKeyShares()
+	require.NoError(t, err)
+
+	e, err := NewEscrow(publicKey)
+	require.NoError(t, err)
+
+	seed := []byte("0123456789abcdef0123456789abcdef")
+
+	sealed, err := e.Seal(seed)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed.Ciphertext), string(seed))
+
+	t.Run("Both shares open the seed", func(t *testing.T) {
+		opened, err := Open(sealed, shareA, shareB)
+		assert.NoError(t, err)
+		assert.Equal(t, seed, opened)
+	})
+
+	t.Run("A single share is not enough", func(t *testing.T) {
+		_, err := Open(sealed, shareA, nil)
+		assert.Error(t, err)
+
+		_, err = Open(sealed, shareA, shareA)
+		assert.Error(t, err)
+	})
+
+	t.Run("Shares of another key cannot open the seed", func(t *testing.T) {
+		_, otherA, otherB, err := GenerateKeyShares()
+		require.NoError(t, err)
+
+		_, err = Open(sealed, otherA, otherB)
+		assert.Error(t, err)
+	})
+}
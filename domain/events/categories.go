@@ -0,0 +1,44 @@
+package events
+
+// Category groups events clients may choose not to receive, e.g. to save bandwidth
+type Category string
+
+const (
+	CategoryGame    Category = "game"    // Everything needed to follow and play a hand, can't be opted out
+	CategoryDealer  Category = "dealer"  // Dealer narration: shuffles and burnt cards
+	CategoryPot     Category = "pot"     // Pot animations: bets swept in, running totals, side pot breakdowns
+	CategorySession Category = "session" // Session summaries
+)
+
+// categories lists the events outside CategoryGame, by name
+var categories = map[string]Category{
+	DeckShuffled{}.Name():            CategoryDealer,
+	CardBurned{}.Name():              CategoryDealer,
+	BetsSweptIntoPot{}.Name():        CategoryPot,
+	PotChanged{}.Name():              CategoryPot,
+	PotBrokenDown{}.Name():           CategoryPot,
+	PlayerSessionSummarized{}.Name(): CategorySession,
+}
+
+// CategoryOf returns the category of an event by name, CategoryGame for events not listed
+// and for anything that isn't an event, such as envelope chunks
+func CategoryOf(eventName string) Category {
+	if category, ok := categories[eventName]; ok {
+		return category
+	}
+	return CategoryGame
+}
+
+// Optional reports whether clients may opt out of the category
+func (c Category) Optional() bool {
+	return c == CategoryDealer || c == CategoryPot || c == CategorySession
+}
+
+// ParseCategory returns the category with the given name, false if there is none
+func ParseCategory(name string) (Category, bool) {
+	switch category := Category(name); category {
+	case CategoryGame, CategoryDealer, CategoryPot, CategorySession:
+		return category, true
+	}
+	return "", false
+}
@@ -0,0 +1,32 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryOf(t *testing.T) {
+	t.Run("Animations and narration can be opted out", func(t *testing.T) {
+		assert.Equal(t, events.CategoryDealer, events.CategoryOf(events.DeckShuffled{}.Name()))
+		assert.Equal(t, events.CategoryPot, events.CategoryOf(events.PotChanged{}.Name()))
+		assert.True(t, events.CategoryOf(events.CardBurned{}.Name()).Optional())
+	})
+
+	t.Run("Game events and unknown names are mandatory", func(t *testing.T) {
+		assert.Equal(t, events.CategoryGame, events.CategoryOf(events.PlayerFolded{}.Name()))
+		assert.Equal(t, events.CategoryGame, events.CategoryOf(events.PotAmountAwarded{}.Name()))
+		assert.Equal(t, events.CategoryGame, events.CategoryOf("ENVELOPE_CHUNK"))
+		assert.False(t, events.CategoryGame.Optional())
+	})
+
+	t.Run("Categories are parsed by name", func(t *testing.T) {
+		category, ok := events.ParseCategory("pot")
+		assert.True(t, ok)
+		assert.Equal(t, events.CategoryPot, category)
+
+		_, ok = events.ParseCategory("chat")
+		assert.False(t, ok)
+	})
+}
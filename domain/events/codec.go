@@ -0,0 +1,62 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// decodable lists the events that can be read back from storage, keyed by name
+var decodable = map[string]reflect.Type{}
+
+func init() {
+	for _, event := range []Event{
+		PlayerEnteredLobby{}, PlayerLeftLobby{}, PlayerBanned{}, PlayerUnbanned{}, PlayerPrivacyChanged{},
+		PlayerJoinedTable{}, PlayerLeftTable{}, PlayerCashedOut{}, PlayerSessionSummarized{}, PlayerChipsChanged{},
+		TableCreated{}, TableStartingSoon{}, TableStartCancelled{}, FillBotsSeated{}, TableClosed{},
+		PlayerBlockedFromTable{}, PlayerUnblockedFromTable{}, PlayerAutoPlayToggled{},
+		PlayerBlindingOff{}, AbsentStackBlindedOff{}, PlayerEliminated{}, BombPotStarted{}, AnteScaled{}, PlayerToppedUp{},
+		RematchProposed{}, RematchAccepted{}, RematchStarted{}, RematchCancelled{},
+		HandStarted{}, PhaseChanged{}, HandEnded{}, HandVoided{},
+		ReadyCheckStarted{}, PlayerReady{}, ReadyCheckCompleted{},
+		AntePlaced{}, PlayerFolded{}, ContinuationBetPlaced{}, CommunityCardSelected{}, PlayerTimedOut{},
+		DiscardCostPaid{}, CommunityCardDiscarded{}, DiscardSkipped{},
+		HoleCardDealt{}, HoleCardsDealt{}, DeckShuffled{}, CardBurned{}, CommunityCardDealt{},
+		PlayerTurnStarted{}, BettingRoundStarted{}, BettingRoundEnded{},
+		CommunitySelectionStarted{}, CommunitySelectionEnded{},
+		HandsEvaluated{}, ShowdownStarted{}, PlayerShowedHand{},
+		BetsSweptIntoPot{}, PotChanged{}, PotBrokenDown{}, PotAmountAwarded{}, SingleWinnerDetermined{},
+		EscrowFunded{}, EscrowReleased{},
+		TournamentCreated{}, TournamentPlayerRegistered{}, TournamentPlayerUnregistered{}, TournamentStarted{},
+		TournamentLevelRaised{}, TournamentPlayerMoved{}, TournamentPlayerBusted{}, TournamentFinished{},
+	} {
+		decodable[event.Name()] = reflect.TypeOf(event)
+	}
+}
+
+// Registered returns the zero value of every event Decode can read, sorted by name
+func Registered() []Event {
+	registered := make([]Event, 0, len(decodable))
+	for _, typ := range decodable {
+		registered = append(registered, reflect.New(typ).Elem().Interface().(Event))
+	}
+	sort.Slice(registered, func(i, j int) bool { return registered[i].Name() < registered[j].Name() })
+	return registered
+}
+
+// Encoded is an event serialized for storage: its name and schema version tell how to read its payload back
+type Encoded struct {
+	Name    string
+	Version int // Zero for events stored before versions were recorded, read as version 1
+	Payload json.RawMessage
+}
+
+// Encode serializes an event for storage at its current version, along with what Decode needs to read it back
+func Encode(event Event) (Encoded, error) {
+	return currentSchema().Encode(event)
+}
+
+// Decode rebuilds a stored event, upcasting it first if it was stored at an older version
+func Decode(encoded Encoded) (Event, error) {
+	return currentSchema().Decode(encoded)
+}
@@ -1,6 +1,9 @@
 package events
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
 
 // Helper function to extract table ID from events
 func ExtractTableID(event Event) string {
@@ -24,3 +27,38 @@ func ExtractTableID(event Event) string {
 
 	return ""
 }
+
+// ExtractHandID returns the ID of the hand an event belongs to, or an empty string for events outside a hand
+func ExtractHandID(event Event) string {
+	val := reflect.ValueOf(event)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	handID := val.FieldByName("HandID")
+	if handID.IsValid() && handID.Kind() == reflect.String {
+		return handID.String()
+	}
+
+	return ""
+}
+
+// ExtractDeadline returns the time by which clients are expected to act on a deadline-bearing event
+func ExtractDeadline(event Event) (time.Time, bool) {
+	switch e := event.(type) {
+	case PlayerTurnStarted:
+		return e.TimeoutAt, !e.TimeoutAt.IsZero()
+	case CommunitySelectionStarted:
+		return e.At.Add(e.TimeLimit), e.TimeLimit > 0
+	case TableStartingSoon:
+		return e.StartsAt, !e.StartsAt.IsZero()
+	case ReadyCheckStarted:
+		return e.Deadline, !e.Deadline.IsZero()
+	}
+
+	return time.Time{}, false
+}
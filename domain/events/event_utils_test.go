@@ -41,3 +41,38 @@ func TestExtractTableID(t *testing.T) {
 		assert.Equal(t, "", id)
 	})
 }
+
+func TestExtractHandID(t *testing.T) {
+	t.Run("struct with HandID field", func(t *testing.T) {
+		e := events.PlayerFolded{TableID: "table123", HandID: "hand123"}
+		id := events.ExtractHandID(e)
+		assert.Equal(t, "hand123", id)
+	})
+
+	t.Run("struct without HandID field", func(t *testing.T) {
+		e := events.TableClosed{TableID: "table123"}
+		id := events.ExtractHandID(e)
+		assert.Equal(t, "", id)
+	})
+}
+
+func TestExtractDeadline(t *testing.T) {
+	now := time.Now()
+
+	t.Run("turn timeout", func(t *testing.T) {
+		deadline, ok := events.ExtractDeadline(events.PlayerTurnStarted{TimeoutAt: now})
+		assert.True(t, ok)
+		assert.Equal(t, now, deadline)
+	})
+
+	t.Run("community selection time limit", func(t *testing.T) {
+		deadline, ok := events.ExtractDeadline(events.CommunitySelectionStarted{At: now, TimeLimit: 5 * time.Second})
+		assert.True(t, ok)
+		assert.Equal(t, now.Add(5*time.Second), deadline)
+	})
+
+	t.Run("event without deadline", func(t *testing.T) {
+		_, ok := events.ExtractDeadline(events.PlayerFolded{At: now})
+		assert.False(t, ok)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
 	"github.com/lazharichir/poker/domain/hands"
 )
 
@@ -16,6 +17,8 @@ type Event interface {
 
 // Lobby events
 type PlayerEnteredLobby struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	PlayerID string
 	At       time.Time
 }
@@ -24,6 +27,8 @@ func (p PlayerEnteredLobby) Name() string         { return "PLAYER_ENTERED_LOBBY
 func (p PlayerEnteredLobby) Timestamp() time.Time { return p.At }
 
 type PlayerLeftLobby struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	PlayerID string
 	At       time.Time
 }
@@ -31,17 +36,61 @@ type PlayerLeftLobby struct {
 func (p PlayerLeftLobby) Name() string         { return "PLAYER_LEFT_LOBBY" }
 func (p PlayerLeftLobby) Timestamp() time.Time { return p.At }
 
+type PlayerBanned struct {
+	ID        string
+	Seq       Sequence `json:"-"`
+	PlayerID  string
+	Reason    string
+	Note      string
+	BannedBy  string
+	ExpiresAt time.Time // zero for a permanent ban
+	At        time.Time
+}
+
+func (p PlayerBanned) Name() string         { return "PLAYER_BANNED" }
+func (p PlayerBanned) Timestamp() time.Time { return p.At }
+
+type PlayerUnbanned struct {
+	ID         string
+	Seq        Sequence `json:"-"`
+	PlayerID   string
+	UnbannedBy string
+	At         time.Time
+}
+
+func (p PlayerUnbanned) Name() string         { return "PLAYER_UNBANNED" }
+func (p PlayerUnbanned) Timestamp() time.Time { return p.At }
+
+// PlayerPrivacyChanged carries a player's new privacy settings
+type PlayerPrivacyChanged struct {
+	ID                   string
+	Seq                  Sequence `json:"-"`
+	PlayerID             string
+	HideStats            bool
+	HideFromLeaderboards bool
+	HideCountry          bool
+	At                   time.Time
+}
+
+func (p PlayerPrivacyChanged) Name() string         { return "PLAYER_PRIVACY_CHANGED" }
+func (p PlayerPrivacyChanged) Timestamp() time.Time { return p.At }
+
 // Existing events
 type PlayerJoinedTable struct {
-	TableID string
-	UserID  string
-	At      time.Time
+	ID           string
+	Seq          Sequence `json:"-"`
+	TableID      string
+	UserID       string
+	NextHandOnly bool // Sat down during a hand, dealt in from the next one
+	At           time.Time
 }
 
 func (u PlayerJoinedTable) Name() string         { return "PLAYER_JOINED_TABLE" }
 func (u PlayerJoinedTable) Timestamp() time.Time { return u.At }
 
 type PlayerLeftTable struct {
+	ID      string
+	Seq     Sequence `json:"-"`
 	UserID  string
 	TableID string
 	At      time.Time
@@ -50,7 +99,43 @@ type PlayerLeftTable struct {
 func (u PlayerLeftTable) Name() string         { return "PLAYER_LEFT_TABLE" }
 func (u PlayerLeftTable) Timestamp() time.Time { return u.At }
 
+// PlayerCashedOut tells a leaving player their stack went back to their bankroll
+type PlayerCashedOut struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Amount   int // The stack cashed out
+	Balance  int // The player's balance afterwards
+	At       time.Time
+}
+
+func (p PlayerCashedOut) Name() string         { return "PLAYER_CASHED_OUT" }
+func (p PlayerCashedOut) Timestamp() time.Time { return p.At }
+
+// PlayerSessionSummarized closes a player's session at a table, when they leave or the table closes
+type PlayerSessionSummarized struct {
+	ID             string
+	Seq            Sequence `json:"-"`
+	TableID        string
+	PlayerID       string
+	Reason         string
+	StartedAt      time.Time
+	Duration       time.Duration
+	HandsPlayed    int
+	NetResult      int // Chips won minus chips put in the pot
+	FinalStack     int
+	BiggestPotWon  int
+	BiggestPotLost int
+	At             time.Time
+}
+
+func (s PlayerSessionSummarized) Name() string         { return "PLAYER_SESSION_SUMMARIZED" }
+func (s PlayerSessionSummarized) Timestamp() time.Time { return s.At }
+
 type PlayerChipsChanged struct {
+	ID      string
+	Seq     Sequence `json:"-"`
 	UserID  string
 	TableID string
 	At      time.Time
@@ -63,8 +148,96 @@ type PlayerChipsChanged struct {
 func (p PlayerChipsChanged) Name() string         { return "PLAYER_CHIPS_CHANGED" }
 func (p PlayerChipsChanged) Timestamp() time.Time { return p.At }
 
+type TableStartingSoon struct {
+	ID          string
+	Seq         Sequence `json:"-"`
+	TableID     string
+	PlayerCount int
+	StartsAt    time.Time
+	At          time.Time
+}
+
+func (t TableStartingSoon) Name() string         { return "TABLE_STARTING_SOON" }
+func (t TableStartingSoon) Timestamp() time.Time { return t.At }
+
+type TableStartCancelled struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	Reason  string
+	At      time.Time
+}
+
+func (t TableStartCancelled) Name() string         { return "TABLE_START_CANCELLED" }
+func (t TableStartCancelled) Timestamp() time.Time { return t.At }
+
+// FillBotsSeated announces house bots seated so a player waiting alone can play, they leave as other players join
+type FillBotsSeated struct {
+	ID              string
+	Seq             Sequence `json:"-"`
+	TableID         string
+	BotIDs          []string
+	WaitingPlayerID string
+	At              time.Time
+}
+
+func (f FillBotsSeated) Name() string         { return "FILL_BOTS_SEATED" }
+func (f FillBotsSeated) Timestamp() time.Time { return f.At }
+
+type TableClosed struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	Reason  string
+	At      time.Time
+}
+
+func (t TableClosed) Name() string         { return "TABLE_CLOSED" }
+func (t TableClosed) Timestamp() time.Time { return t.At }
+
+type PlayerBlockedFromTable struct {
+	ID        string
+	Seq       Sequence `json:"-"`
+	TableID   string
+	PlayerID  string
+	Reason    string
+	Note      string
+	BlockedBy string
+	ExpiresAt time.Time // zero for a permanent block
+	At        time.Time
+}
+
+func (p PlayerBlockedFromTable) Name() string         { return "PLAYER_BLOCKED_FROM_TABLE" }
+func (p PlayerBlockedFromTable) Timestamp() time.Time { return p.At }
+
+type PlayerUnblockedFromTable struct {
+	ID          string
+	Seq         Sequence `json:"-"`
+	TableID     string
+	PlayerID    string
+	UnblockedBy string
+	At          time.Time
+}
+
+func (p PlayerUnblockedFromTable) Name() string         { return "PLAYER_UNBLOCKED_FROM_TABLE" }
+func (p PlayerUnblockedFromTable) Timestamp() time.Time { return p.At }
+
+type PlayerAutoPlayToggled struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Enabled  bool
+	At       time.Time
+}
+
+func (p PlayerAutoPlayToggled) Name() string         { return "PLAYER_AUTO_PLAY_TOGGLED" }
+func (p PlayerAutoPlayToggled) Timestamp() time.Time { return p.At }
+
 // Hand Phase Events
 type HandStarted struct {
+	ID      string
+	Seq     Sequence `json:"-"`
 	TableID string
 	HandID  string
 	Players []string
@@ -75,6 +248,8 @@ func (h HandStarted) Name() string         { return "HAND_STARTED" }
 func (h HandStarted) Timestamp() time.Time { return h.At }
 
 type PhaseChanged struct {
+	ID            string
+	Seq           Sequence `json:"-"`
 	TableID       string
 	HandID        string
 	PreviousPhase string
@@ -86,12 +261,15 @@ func (p PhaseChanged) Name() string         { return "PHASE_CHANGED" }
 func (p PhaseChanged) Timestamp() time.Time { return p.At }
 
 type HandEnded struct {
-	TableID  string
-	HandID   string
-	Duration int64 // in milliseconds
-	FinalPot int
-	Winners  []string
-	At       time.Time
+	ID         string
+	Seq        Sequence `json:"-"`
+	TableID    string
+	HandID     string
+	Duration   int64 // in milliseconds
+	FinalPot   int
+	Winners    []string
+	LowWinners []string // Hi-lo split only
+	At         time.Time
 }
 
 func (h HandEnded) Name() string         { return "HAND_ENDED" }
@@ -99,6 +277,8 @@ func (h HandEnded) Timestamp() time.Time { return h.At }
 
 // Player Action Events
 type AntePlaced struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
@@ -110,6 +290,8 @@ func (a AntePlaced) Name() string         { return "ANTE_PLACED" }
 func (a AntePlaced) Timestamp() time.Time { return a.At }
 
 type PlayerFolded struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
@@ -121,6 +303,8 @@ func (p PlayerFolded) Name() string         { return "PLAYER_FOLDED" }
 func (p PlayerFolded) Timestamp() time.Time { return p.At }
 
 type ContinuationBetPlaced struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
@@ -131,7 +315,52 @@ type ContinuationBetPlaced struct {
 func (c ContinuationBetPlaced) Name() string         { return "CONTINUATION_BET_PLACED" }
 func (c ContinuationBetPlaced) Timestamp() time.Time { return c.At }
 
+// DiscardCostPaid is a player paying to discard a community card, the card follows in CommunityCardDiscarded
+type DiscardCostPaid struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	Amount   int
+	At       time.Time
+}
+
+func (d DiscardCostPaid) Name() string         { return "DISCARD_COST_PAID" }
+func (d DiscardCostPaid) Timestamp() time.Time { return d.At }
+
+// CommunityCardDiscarded is a community card a player took out of play, nobody may select it anymore
+type CommunityCardDiscarded struct {
+	ID        string
+	Seq       Sequence `json:"-"`
+	TableID   string
+	HandID    string
+	PlayerID  string
+	Card      cards.Card
+	Remaining int // Community cards left to select from
+	At        time.Time
+}
+
+func (c CommunityCardDiscarded) Name() string         { return "COMMUNITY_CARD_DISCARDED" }
+func (c CommunityCardDiscarded) Timestamp() time.Time { return c.At }
+
+// DiscardSkipped is a player passing on their discard, on purpose or because their time ran out
+type DiscardSkipped struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	Refund   int // Discard cost given back to a player who paid but picked no card in time
+	At       time.Time
+}
+
+func (d DiscardSkipped) Name() string         { return "DISCARD_SKIPPED" }
+func (d DiscardSkipped) Timestamp() time.Time { return d.At }
+
 type CommunityCardSelected struct {
+	ID             string
+	Seq            Sequence `json:"-"`
 	TableID        string
 	HandID         string
 	PlayerID       string
@@ -144,6 +373,8 @@ func (c CommunityCardSelected) Name() string         { return "COMMUNITY_CARD_SE
 func (c CommunityCardSelected) Timestamp() time.Time { return c.At }
 
 type PlayerTimedOut struct {
+	ID            string
+	Seq           Sequence `json:"-"`
 	TableID       string
 	HandID        string
 	PlayerID      string
@@ -157,6 +388,8 @@ func (p PlayerTimedOut) Timestamp() time.Time { return p.At }
 
 // Dealing Events
 type HoleCardDealt struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
@@ -168,6 +401,8 @@ func (h HoleCardDealt) Name() string         { return "HOLE_CARD_DEALT" }
 func (h HoleCardDealt) Timestamp() time.Time { return h.At }
 
 type HoleCardsDealt struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	DealOrder map[string]int // PlayerID to dealing position
@@ -177,16 +412,37 @@ type HoleCardsDealt struct {
 func (h HoleCardsDealt) Name() string         { return "HOLE_CARDS_DEALT" }
 func (h HoleCardsDealt) Timestamp() time.Time { return h.At }
 
+// DeckShuffled is the audit entry for a hand's shuffle: a public commitment
+// to the seed and the seed itself, sealed to the operator escrow key
+type DeckShuffled struct {
+	ID             string
+	Seq            Sequence `json:"-"`
+	TableID        string
+	HandID         string
+	SeedCommitment string // hex encoded SHA-256 of the seed
+	SealedSeed     escrow.SealedSeed
+	At             time.Time
+}
+
+func (d DeckShuffled) Name() string         { return "DECK_SHUFFLED" }
+func (d DeckShuffled) Timestamp() time.Time { return d.At }
+
 type CardBurned struct {
-	TableID string
-	HandID  string
-	At      time.Time
+	ID            string
+	Seq           Sequence `json:"-"`
+	TableID       string
+	HandID        string
+	Wave          int // Community wave the burn comes before, starting at 0
+	DeckRemaining int // Cards left in the deck after the burn
+	At            time.Time
 }
 
 func (c CardBurned) Name() string         { return "CARD_BURNED" }
 func (c CardBurned) Timestamp() time.Time { return c.At }
 
 type CommunityCardDealt struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	CardIndex int
@@ -199,11 +455,14 @@ func (c CommunityCardDealt) Timestamp() time.Time { return c.At }
 
 // Turn Management Events
 type PlayerTurnStarted struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	PlayerID  string
 	Phase     string
 	TimeoutAt time.Time
+	Timeout   time.Duration // Time the player was given to act, it can shrink as the hand progresses
 	At        time.Time
 }
 
@@ -211,6 +470,8 @@ func (p PlayerTurnStarted) Name() string         { return "PLAYER_TURN_STARTED"
 func (p PlayerTurnStarted) Timestamp() time.Time { return p.At }
 
 type BettingRoundStarted struct {
+	ID         string
+	Seq        Sequence `json:"-"`
 	TableID    string
 	HandID     string
 	Phase      string
@@ -222,6 +483,8 @@ func (b BettingRoundStarted) Name() string         { return "BETTING_ROUND_START
 func (b BettingRoundStarted) Timestamp() time.Time { return b.At }
 
 type BettingRoundEnded struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	Phase     string
@@ -233,9 +496,12 @@ func (b BettingRoundEnded) Name() string         { return "BETTING_ROUND_ENDED"
 func (b BettingRoundEnded) Timestamp() time.Time { return b.At }
 
 type CommunitySelectionStarted struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	TimeLimit time.Duration
+	Picks     int // Community cards each player selects
 	At        time.Time
 }
 
@@ -243,6 +509,8 @@ func (c CommunitySelectionStarted) Name() string         { return "COMMUNITY_SEL
 func (c CommunitySelectionStarted) Timestamp() time.Time { return c.At }
 
 type CommunitySelectionEnded struct {
+	ID      string
+	Seq     Sequence `json:"-"`
 	TableID string
 	HandID  string
 	At      time.Time
@@ -253,6 +521,8 @@ func (c CommunitySelectionEnded) Timestamp() time.Time { return c.At }
 
 // Evaluation Events
 type HandsEvaluated struct {
+	ID      string
+	Seq     Sequence `json:"-"`
 	TableID string
 	HandID  string
 	Results map[string]hands.HandComparisonResult // playerID => HandComparisonResult
@@ -263,6 +533,8 @@ func (h HandsEvaluated) Name() string         { return "HANDS_EVALUATED" }
 func (h HandsEvaluated) Timestamp() time.Time { return h.At }
 
 type ShowdownStarted struct {
+	ID            string
+	Seq           Sequence `json:"-"`
 	TableID       string
 	HandID        string
 	ActivePlayers []string
@@ -273,6 +545,8 @@ func (s ShowdownStarted) Name() string         { return "SHOWDOWN_STARTED" }
 func (s ShowdownStarted) Timestamp() time.Time { return s.At }
 
 type PlayerShowedHand struct {
+	ID                     string
+	Seq                    Sequence `json:"-"`
 	TableID                string
 	HandID                 string
 	PlayerID               string
@@ -285,7 +559,25 @@ func (p PlayerShowedHand) Name() string         { return "PLAYER_SHOWED_HAND" }
 func (p PlayerShowedHand) Timestamp() time.Time { return p.At }
 
 // Pot Events
+// BetsSweptIntoPot lists each player's bets moved into the pot at the end of a betting round
+type BetsSweptIntoPot struct {
+	ID            string
+	Seq           Sequence `json:"-"`
+	TableID       string
+	HandID        string
+	Phase         string
+	Contributions map[string]int // playerID => amount bet this round
+	Total         int
+	PotAfter      int
+	At            time.Time
+}
+
+func (b BetsSweptIntoPot) Name() string         { return "BETS_SWEPT_INTO_POT" }
+func (b BetsSweptIntoPot) Timestamp() time.Time { return b.At }
+
 type PotChanged struct {
+	ID             string
+	Seq            Sequence `json:"-"`
 	TableID        string
 	HandID         string
 	PreviousAmount int
@@ -296,10 +588,20 @@ type PotChanged struct {
 func (p PotChanged) Name() string         { return "POT_CHANGED" }
 func (p PotChanged) Timestamp() time.Time { return p.At }
 
+// SidePot is one of the pots of a hand played with unequal stacks, the first one is the main pot
+type SidePot struct {
+	Amount   int
+	Eligible []string       // Players who matched the pot and were still in the hand
+	Winners  map[string]int // Amount won by each winner of the pot
+}
+
 type PotBrokenDown struct {
+	ID        string
+	Seq       Sequence `json:"-"`
 	TableID   string
 	HandID    string
 	Breakdown map[string]int
+	Pots      []SidePot // Main pot then side pots, empty when the whole pot was contested by everyone
 	At        time.Time
 }
 
@@ -307,18 +609,55 @@ func (p PotBrokenDown) Name() string         { return "POT_BROKEN_DOWN" }
 func (p PotBrokenDown) Timestamp() time.Time { return p.At }
 
 type PotAmountAwarded struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
 	Amount   int
 	Reason   string
+	Side     string // "high" or "low" in hi-lo split pots, empty otherwise
 	At       time.Time
 }
 
 func (p PotAmountAwarded) Name() string         { return "POT_AMOUNT_AWARDED" }
 func (p PotAmountAwarded) Timestamp() time.Time { return p.At }
 
+// EscrowFunded records chips a player committed to a hand, held in the hand's escrow until it pays out or is voided
+type EscrowFunded struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	Amount   int
+	Held     int // Everything the player has in the escrow so far
+	Reason   string
+	At       time.Time
+}
+
+func (e EscrowFunded) Name() string         { return "ESCROW_FUNDED" }
+func (e EscrowFunded) Timestamp() time.Time { return e.At }
+
+// EscrowReleased records chips paid out of a hand's escrow, as winnings or as a refund
+type EscrowReleased struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	Amount   int
+	Balance  int // Left in the escrow
+	Reason   string
+	At       time.Time
+}
+
+func (e EscrowReleased) Name() string         { return "ESCROW_RELEASED" }
+func (e EscrowReleased) Timestamp() time.Time { return e.At }
+
 type SingleWinnerDetermined struct {
+	ID       string
+	Seq      Sequence `json:"-"`
 	TableID  string
 	HandID   string
 	PlayerID string
@@ -328,3 +667,323 @@ type SingleWinnerDetermined struct {
 
 func (s SingleWinnerDetermined) Name() string         { return "SINGLE_WINNER_DETERMINED" }
 func (s SingleWinnerDetermined) Timestamp() time.Time { return s.At }
+
+type HandVoided struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	HandID  string
+	Reason  string
+	Refunds map[string]int // playerID => amount returned
+	At      time.Time
+}
+
+func (h HandVoided) Name() string         { return "HAND_VOIDED" }
+func (h HandVoided) Timestamp() time.Time { return h.At }
+
+// ReadyCheckStarted asks the seated players to confirm they are ready before the hand is dealt
+type ReadyCheckStarted struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	Players  []string
+	Deadline time.Time
+	At       time.Time
+}
+
+func (r ReadyCheckStarted) Name() string         { return "READY_CHECK_STARTED" }
+func (r ReadyCheckStarted) Timestamp() time.Time { return r.At }
+
+type PlayerReady struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	At       time.Time
+}
+
+func (p PlayerReady) Name() string         { return "PLAYER_READY" }
+func (p PlayerReady) Timestamp() time.Time { return p.At }
+
+// ReadyCheckCompleted closes the ready-check, players who did not confirm sit the hand out
+type ReadyCheckCompleted struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	HandID  string
+	Ready   []string
+	SatOut  []string
+	At      time.Time
+}
+
+func (r ReadyCheckCompleted) Name() string         { return "READY_CHECK_COMPLETED" }
+func (r ReadyCheckCompleted) Timestamp() time.Time { return r.At }
+
+// PlayerBlindingOff tells the table that an absent player's stack stays in play until it is blinded off
+type PlayerBlindingOff struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Stack    int
+	Reason   string
+	At       time.Time
+}
+
+func (p PlayerBlindingOff) Name() string         { return "PLAYER_BLINDING_OFF" }
+func (p PlayerBlindingOff) Timestamp() time.Time { return p.At }
+
+type AbsentStackBlindedOff struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	HandID   string
+	PlayerID string
+	Amount   int // Forced bet posted for the absent player
+	Stack    int // What is left of their stack
+	At       time.Time
+}
+
+func (a AbsentStackBlindedOff) Name() string         { return "ABSENT_STACK_BLINDED_OFF" }
+func (a AbsentStackBlindedOff) Timestamp() time.Time { return a.At }
+
+type PlayerEliminated struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Reason   string
+	At       time.Time
+}
+
+func (p PlayerEliminated) Name() string         { return "PLAYER_ELIMINATED" }
+func (p PlayerEliminated) Timestamp() time.Time { return p.At }
+
+// BombPotStarted tells the table the hand is a bomb pot: every player posts Amount, or all they have if less,
+// and the community cards follow the hole cards without continuation betting
+type BombPotStarted struct {
+	ID         string
+	Seq        Sequence `json:"-"`
+	TableID    string
+	HandID     string
+	HandNumber int
+	Multiplier int // Antes posted by each player
+	Amount     int
+	At         time.Time
+}
+
+func (b BombPotStarted) Name() string         { return "BOMB_POT_STARTED" }
+func (b BombPotStarted) Timestamp() time.Time { return b.At }
+
+// AnteScaled tells the table that the ante goes up from the next hand, as pots have been too small
+type AnteScaled struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TableID      string
+	PreviousAnte int
+	NewAnte      int
+	AveragePot   int // Average final pot over the hands considered
+	Hands        int
+	At           time.Time
+}
+
+func (a AnteScaled) Name() string         { return "ANTE_SCALED" }
+func (a AnteScaled) Timestamp() time.Time { return a.At }
+
+type TableCreated struct {
+	ID          string
+	Seq         Sequence `json:"-"`
+	TableID     string
+	TableName   string
+	MaxPlayers  int
+	AnteValue   int
+	FeedPrivacy string // Whether the table's big pots show in the site-wide feed: "", "anonymous" or "public"
+	At          time.Time
+}
+
+func (t TableCreated) Name() string         { return "TABLE_CREATED" }
+func (t TableCreated) Timestamp() time.Time { return t.At }
+
+// PlayerToppedUp adds chips from the player's balance to their stack, between hands
+type PlayerToppedUp struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Amount   int
+	Stack    int  // The player's stack after the top-up
+	Queued   bool // Asked for during a hand, applied when it ended
+	At       time.Time
+}
+
+func (p PlayerToppedUp) Name() string         { return "PLAYER_TOPPED_UP" }
+func (p PlayerToppedUp) Timestamp() time.Time { return p.At }
+
+// RematchProposed asks the players of a finished private table to play again, each with a fresh stack of BuyIn
+type RematchProposed struct {
+	ID         string
+	Seq        Sequence `json:"-"`
+	TableID    string
+	ProposedBy string
+	BuyIn      int
+	Players    []string // The players the rematch waits for, in seat order
+	At         time.Time
+}
+
+func (r RematchProposed) Name() string         { return "REMATCH_PROPOSED" }
+func (r RematchProposed) Timestamp() time.Time { return r.At }
+
+// RematchAccepted tells the table a player is in for the rematch
+type RematchAccepted struct {
+	ID       string
+	Seq      Sequence `json:"-"`
+	TableID  string
+	PlayerID string
+	Pending  []string // Players who have yet to accept
+	At       time.Time
+}
+
+func (r RematchAccepted) Name() string         { return "REMATCH_ACCEPTED" }
+func (r RematchAccepted) Timestamp() time.Time { return r.At }
+
+// RematchStarted resets the table for the rematch: stacks were cashed out and bought in again, and the table
+// waits for its first hand
+type RematchStarted struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	BuyIn   int
+	Stacks  map[string]int // The players' new stacks, by player ID
+	At      time.Time
+}
+
+func (r RematchStarted) Name() string         { return "REMATCH_STARTED" }
+func (r RematchStarted) Timestamp() time.Time { return r.At }
+
+// RematchCancelled drops a proposed rematch, e.g. when players left and too few are still seated
+type RematchCancelled struct {
+	ID      string
+	Seq     Sequence `json:"-"`
+	TableID string
+	Reason  string
+	At      time.Time
+}
+
+func (r RematchCancelled) Name() string         { return "REMATCH_CANCELLED" }
+func (r RematchCancelled) Timestamp() time.Time { return r.At }
+
+// Tournament events
+type TournamentCreated struct {
+	ID             string
+	Seq            Sequence `json:"-"`
+	TournamentID   string
+	TournamentName string
+	BuyIn          int // Taken from each entrant's balance, it makes up the prize pool
+	StartingStack  int
+	MaxEntrants    int  // Zero for no cap
+	SitAndGo       bool // Starts as soon as MaxEntrants players registered, at a single table
+	At             time.Time
+}
+
+func (t TournamentCreated) Name() string         { return "TOURNAMENT_CREATED" }
+func (t TournamentCreated) Timestamp() time.Time { return t.At }
+
+type TournamentPlayerRegistered struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	PlayerID     string
+	Entrants     int // Registered players, this one included
+	At           time.Time
+}
+
+func (t TournamentPlayerRegistered) Name() string         { return "TOURNAMENT_PLAYER_REGISTERED" }
+func (t TournamentPlayerRegistered) Timestamp() time.Time { return t.At }
+
+// TournamentPlayerUnregistered refunds the buy-in of a player who left before the start
+type TournamentPlayerUnregistered struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	PlayerID     string
+	Entrants     int
+	At           time.Time
+}
+
+func (t TournamentPlayerUnregistered) Name() string         { return "TOURNAMENT_PLAYER_UNREGISTERED" }
+func (t TournamentPlayerUnregistered) Timestamp() time.Time { return t.At }
+
+type TournamentStarted struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	TableIDs     []string
+	Entrants     int
+	PrizePool    int
+	At           time.Time
+}
+
+func (t TournamentStarted) Name() string         { return "TOURNAMENT_STARTED" }
+func (t TournamentStarted) Timestamp() time.Time { return t.At }
+
+// TournamentLevelRaised sets the ante of every tournament table, from their next hand
+type TournamentLevelRaised struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	Level        int // Zero-based index in the schedule
+	Ante         int
+	At           time.Time
+}
+
+func (t TournamentLevelRaised) Name() string         { return "TOURNAMENT_LEVEL_RAISED" }
+func (t TournamentLevelRaised) Timestamp() time.Time { return t.At }
+
+// TournamentPlayerMoved takes a player and their stack to another table, to balance the tables or break one
+type TournamentPlayerMoved struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	PlayerID     string
+	FromTableID  string
+	ToTableID    string
+	Chips        int
+	At           time.Time
+}
+
+func (t TournamentPlayerMoved) Name() string         { return "TOURNAMENT_PLAYER_MOVED" }
+func (t TournamentPlayerMoved) Timestamp() time.Time { return t.At }
+
+type TournamentPlayerBusted struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	TableID      string
+	PlayerID     string
+	Place        int // Finishing place, 1 being the winner
+	At           time.Time
+}
+
+func (t TournamentPlayerBusted) Name() string         { return "TOURNAMENT_PLAYER_BUSTED" }
+func (t TournamentPlayerBusted) Timestamp() time.Time { return t.At }
+
+type TournamentFinished struct {
+	ID           string
+	Seq          Sequence `json:"-"`
+	TournamentID string
+	PrizePool    int
+	Payouts      []TournamentPayout // Paid places, first place first
+	At           time.Time
+}
+
+func (t TournamentFinished) Name() string         { return "TOURNAMENT_FINISHED" }
+func (t TournamentFinished) Timestamp() time.Time { return t.At }
+
+// TournamentPayout is what a paid place won, credited to the player's balance
+type TournamentPayout struct {
+	Place    int
+	PlayerID string
+	Amount   int
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"crypto/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// IDGenerator hands out ULIDs that are strictly increasing for a single emitter,
+// even when several events are emitted within the same millisecond
+type IDGenerator struct {
+	mu      sync.Mutex
+	entropy *ulid.MonotonicEntropy
+}
+
+// NewIDGenerator creates a new monotonic ULID generator
+func NewIDGenerator() *IDGenerator {
+	return &IDGenerator{
+		entropy: ulid.Monotonic(rand.Reader, 0),
+	}
+}
+
+// Next returns the next ULID for the given time
+func (g *IDGenerator) Next(at time.Time) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return ulid.MustNew(ulid.Timestamp(at), g.entropy).String()
+}
+
+// Stamp assigns a fresh ID to the event unless it already carries one
+func (g *IDGenerator) Stamp(event Event) Event {
+	if ExtractEventID(event) != "" {
+		return event
+	}
+	return WithEventID(event, g.Next(event.Timestamp()))
+}
+
+// ExtractEventID returns the ID of an event, or an empty string if the event has none
+func ExtractEventID(event Event) string {
+	val := reflect.ValueOf(event)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	id := val.FieldByName("ID")
+	if id.IsValid() && id.Kind() == reflect.String {
+		return id.String()
+	}
+
+	return ""
+}
+
+// WithEventID returns a copy of the event with its ID field set. Events without an ID field are returned as is.
+func WithEventID(event Event, id string) Event {
+	return withField(event, "ID", reflect.ValueOf(id))
+}
+
+// withField returns a copy of the event with the named field set, or the event as is if it has no such field
+func withField(event Event, name string, value reflect.Value) Event {
+	val := reflect.ValueOf(event)
+
+	// Pointer events can be updated in place
+	if val.Kind() == reflect.Ptr {
+		elem := val.Elem()
+		if elem.Kind() == reflect.Struct {
+			if field := elem.FieldByName(name); field.IsValid() && field.Type() == value.Type() && field.CanSet() {
+				field.Set(value)
+			}
+		}
+		return event
+	}
+
+	if val.Kind() != reflect.Struct {
+		return event
+	}
+
+	// Value events are copied, updated and returned
+	cp := reflect.New(val.Type()).Elem()
+	cp.Set(val)
+
+	field := cp.FieldByName(name)
+	if !field.IsValid() || field.Type() != value.Type() || !field.CanSet() {
+		return event
+	}
+	field.Set(value)
+
+	stamped, ok := cp.Interface().(Event)
+	if !ok {
+		return event
+	}
+	return stamped
+}
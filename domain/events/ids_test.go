@@ -0,0 +1,73 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDGenerator(t *testing.T) {
+	t.Run("ids are monotonic within the same millisecond", func(t *testing.T) {
+		gen := events.NewIDGenerator()
+		at := time.Now()
+
+		previous := gen.Next(at)
+		for i := 0; i < 100; i++ {
+			next := gen.Next(at)
+			assert.Greater(t, next, previous)
+			previous = next
+		}
+	})
+
+	t.Run("stamp assigns an id to value events", func(t *testing.T) {
+		gen := events.NewIDGenerator()
+		e := events.PlayerFolded{TableID: "table123", At: time.Now()}
+
+		stamped := gen.Stamp(e)
+
+		assert.NotEmpty(t, events.ExtractEventID(stamped))
+		assert.Equal(t, "table123", stamped.(events.PlayerFolded).TableID)
+		assert.Empty(t, e.ID, "original event should not be modified")
+	})
+
+	t.Run("stamp keeps an existing id", func(t *testing.T) {
+		gen := events.NewIDGenerator()
+		e := events.PlayerFolded{ID: "existing", At: time.Now()}
+
+		stamped := gen.Stamp(e)
+
+		assert.Equal(t, "existing", events.ExtractEventID(stamped))
+	})
+
+	t.Run("stamp ignores events without an id field", func(t *testing.T) {
+		gen := events.NewIDGenerator()
+		e := noTableID{OtherField: "noID"}
+
+		stamped := gen.Stamp(e)
+
+		assert.Equal(t, e, stamped)
+		assert.Equal(t, "", events.ExtractEventID(stamped))
+	})
+}
+
+func TestWithSequence(t *testing.T) {
+	t.Run("sets the sequence of a copy of the event", func(t *testing.T) {
+		e := events.PlayerFolded{TableID: "table123", At: time.Now()}
+
+		sequenced := events.WithSequence(e, events.Sequence{Table: 4, Hand: 2})
+
+		assert.Equal(t, events.Sequence{Table: 4, Hand: 2}, events.ExtractSequence(sequenced))
+		assert.Zero(t, events.ExtractSequence(e))
+	})
+
+	t.Run("ignores events without a sequence", func(t *testing.T) {
+		e := noTableID{OtherField: "noSeq"}
+
+		sequenced := events.WithSequence(e, events.Sequence{Table: 1})
+
+		assert.Equal(t, e, sequenced)
+		assert.Zero(t, events.ExtractSequence(sequenced))
+	})
+}
@@ -0,0 +1,105 @@
+package events_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/internal/schematest"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden schema file")
+
+// allEvents must list every event type, TestEventSchemas fails if one is missing
+var allEvents = []schematest.Message{
+	events.PlayerEnteredLobby{},
+	events.PlayerLeftLobby{},
+	events.PlayerBanned{},
+	events.PlayerUnbanned{},
+	events.PlayerPrivacyChanged{},
+	events.PlayerJoinedTable{},
+	events.PlayerLeftTable{},
+	events.PlayerCashedOut{},
+	events.PlayerSessionSummarized{},
+	events.PlayerChipsChanged{},
+	events.TableStartingSoon{},
+	events.TableStartCancelled{},
+	events.FillBotsSeated{},
+	events.TableClosed{},
+	events.PlayerBlockedFromTable{},
+	events.PlayerUnblockedFromTable{},
+	events.PlayerAutoPlayToggled{},
+	events.HandStarted{},
+	events.PhaseChanged{},
+	events.HandEnded{},
+	events.AntePlaced{},
+	events.PlayerFolded{},
+	events.ContinuationBetPlaced{},
+	events.DiscardCostPaid{},
+	events.CommunityCardDiscarded{},
+	events.DiscardSkipped{},
+	events.CommunityCardSelected{},
+	events.PlayerTimedOut{},
+	events.HoleCardDealt{},
+	events.HoleCardsDealt{},
+	events.DeckShuffled{},
+	events.CardBurned{},
+	events.CommunityCardDealt{},
+	events.PlayerTurnStarted{},
+	events.BettingRoundStarted{},
+	events.BettingRoundEnded{},
+	events.CommunitySelectionStarted{},
+	events.CommunitySelectionEnded{},
+	events.HandsEvaluated{},
+	events.ShowdownStarted{},
+	events.PlayerShowedHand{},
+	events.BetsSweptIntoPot{},
+	events.PotChanged{},
+	events.PotBrokenDown{},
+	events.PotAmountAwarded{},
+	events.SingleWinnerDetermined{},
+	events.EscrowFunded{},
+	events.EscrowReleased{},
+	events.HandVoided{},
+	events.ReadyCheckStarted{},
+	events.PlayerReady{},
+	events.ReadyCheckCompleted{},
+	events.PlayerBlindingOff{},
+	events.AbsentStackBlindedOff{},
+	events.PlayerEliminated{},
+	events.BombPotStarted{},
+	events.AnteScaled{},
+	events.PlayerToppedUp{},
+	events.RematchProposed{},
+	events.RematchAccepted{},
+	events.RematchStarted{},
+	events.RematchCancelled{},
+	events.TableCreated{},
+	events.TournamentCreated{},
+	events.TournamentPlayerRegistered{},
+	events.TournamentPlayerUnregistered{},
+	events.TournamentStarted{},
+	events.TournamentLevelRaised{},
+	events.TournamentPlayerMoved{},
+	events.TournamentPlayerBusted{},
+	events.TournamentFinished{},
+}
+
+func TestEventSchemas(t *testing.T) {
+	t.Run("Every event is registered", func(t *testing.T) {
+		assert.Equal(t, schematest.DeclaredMessages(t, "."), schematest.TypeNames(allEvents))
+	})
+
+	t.Run("Every event is in the registry", func(t *testing.T) {
+		registered := []schematest.Message{}
+		for _, message := range events.Registered() {
+			registered = append(registered, message)
+		}
+		assert.Equal(t, schematest.TypeNames(allEvents), schematest.TypeNames(registered))
+	})
+
+	t.Run("Wire formats match the golden file", func(t *testing.T) {
+		schematest.Check(t, "testdata/events.golden.json", "testdata/migrations.md", allEvents, *update)
+	})
+}
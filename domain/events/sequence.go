@@ -0,0 +1,35 @@
+package events
+
+import "reflect"
+
+// Sequence is an event's position in the streams it belongs to, assigned by its emitter. Clients order
+// events by it and spot the ones they missed. It travels in the envelope rather than in the payload.
+type Sequence struct {
+	Table uint64 // Position among the table's events, from 1
+	Hand  uint64 // Position among the hand's events from 1, zero for events outside a hand
+}
+
+// ExtractSequence returns the sequence of an event, zero if it has none
+func ExtractSequence(event Event) Sequence {
+	val := reflect.ValueOf(event)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return Sequence{}
+	}
+
+	field := val.FieldByName("Seq")
+	if !field.IsValid() || !field.CanInterface() {
+		return Sequence{}
+	}
+
+	seq, _ := field.Interface().(Sequence)
+	return seq
+}
+
+// WithSequence returns a copy of the event with its Seq field set. Events without one are returned as is.
+func WithSequence(event Event, seq Sequence) Event {
+	return withField(event, "Seq", reflect.ValueOf(seq))
+}
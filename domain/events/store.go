@@ -0,0 +1,121 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StoredEvent is an event at its position in a table's stream
+type StoredEvent struct {
+	TableID  string
+	Sequence int64 // Position in the table's stream, starting at 1
+	Event    Event
+	StoredAt time.Time
+}
+
+// EventStore keeps an append-only stream of events per table, so a table's history outlives the process.
+// Tables are not rebuilt from their stream on startup: a restart ends the tables in play, their events stay stored.
+type EventStore interface {
+	// Append adds events at the end of the table's stream, in order, and returns the sequence of the last one
+	Append(ctx context.Context, tableID string, events ...Event) (int64, error)
+	// LoadEvents returns the table's events from the given sequence on, in order
+	LoadEvents(ctx context.Context, tableID string, fromSequence int64) ([]StoredEvent, error)
+	// Tables returns the IDs of the tables that have a stream
+	Tables(ctx context.Context) ([]string, error)
+	// Subscribe streams the table's events from the given sequence on, in order: the stored ones first,
+	// then the ones appended later as they come. The channel is closed once ctx is done, or if the stream
+	// can't be read any further. Subscribers that don't keep up hold back their own channel only.
+	Subscribe(ctx context.Context, tableID string, fromSequence int64) (<-chan StoredEvent, error)
+}
+
+// MemoryStore is an EventStore that only lives as long as the process
+type MemoryStore struct {
+	mu       sync.RWMutex
+	streams  map[string][]StoredEvent
+	appended chan struct{} // Closed and replaced on every append, to wake the subscribers up
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{streams: make(map[string][]StoredEvent), appended: make(chan struct{})}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, tableID string, events ...Event) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream := s.streams[tableID]
+	now := time.Now()
+	for _, event := range events {
+		stream = append(stream, StoredEvent{
+			TableID:  tableID,
+			Sequence: int64(len(stream)) + 1,
+			Event:    event,
+			StoredAt: now,
+		})
+	}
+	s.streams[tableID] = stream
+
+	close(s.appended)
+	s.appended = make(chan struct{})
+
+	return int64(len(stream)), nil
+}
+
+func (s *MemoryStore) LoadEvents(ctx context.Context, tableID string, fromSequence int64) ([]StoredEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stream := s.streams[tableID]
+	start := min(max(fromSequence-1, 0), int64(len(stream)))
+
+	loaded := make([]StoredEvent, len(stream[start:]))
+	copy(loaded, stream[start:])
+	return loaded, nil
+}
+
+func (s *MemoryStore) Tables(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tableIDs := make([]string, 0, len(s.streams))
+	for tableID := range s.streams {
+		tableIDs = append(tableIDs, tableID)
+	}
+	return tableIDs, nil
+}
+
+func (s *MemoryStore) Subscribe(ctx context.Context, tableID string, fromSequence int64) (<-chan StoredEvent, error) {
+	subscription := make(chan StoredEvent)
+
+	go func() {
+		defer close(subscription)
+
+		next := max(fromSequence, 1)
+		for {
+			// Taken with the events, so an append right after them still wakes us up
+			s.mu.RLock()
+			stream := s.streams[tableID]
+			appended := s.appended
+			s.mu.RUnlock()
+
+			for _, stored := range stream[min(next-1, int64(len(stream))):] {
+				select {
+				case subscription <- stored:
+					next = stored.Sequence + 1
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-appended:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return subscription, nil
+}
@@ -0,0 +1,175 @@
+package events_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventCodec(t *testing.T) {
+	t.Run("Every event can be read back", func(t *testing.T) {
+		for _, message := range allEvents {
+			// Setup
+			event := message.(events.Event)
+
+			// Act
+			encoded, err := events.Encode(event)
+			require.NoError(t, err)
+			decoded, err := events.Decode(encoded)
+
+			// Assert
+			require.NoError(t, err, encoded.Name)
+			assert.Equal(t, reflect.TypeOf(event), reflect.TypeOf(decoded))
+		}
+	})
+
+	t.Run("Round trips the event fields", func(t *testing.T) {
+		// Setup
+		at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		event := events.AntePlaced{ID: "01J", TableID: "table-1", HandID: "hand-1", PlayerID: "player-1", Amount: 10, At: at}
+
+		// Act
+		encoded, err := events.Encode(event)
+		require.NoError(t, err)
+		decoded, err := events.Decode(encoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, encoded.Version)
+		assert.Equal(t, event, decoded)
+	})
+
+	t.Run("Rejects unknown events", func(t *testing.T) {
+		// Act
+		_, err := events.Decode(events.Encoded{Name: "NOT_AN_EVENT", Payload: []byte("{}")})
+
+		// Assert
+		assert.ErrorIs(t, err, events.ErrUnknownEvent)
+		assert.EqualError(t, err, "unknown event: NOT_AN_EVENT")
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Keeps a sequenced stream per table", func(t *testing.T) {
+		// Setup
+		store := events.NewMemoryStore()
+
+		// Act
+		last, err := store.Append(ctx, "table-1", events.HandStarted{HandID: "hand-1"}, events.PhaseChanged{HandID: "hand-1"})
+		require.NoError(t, err)
+		_, err = store.Append(ctx, "table-2", events.HandStarted{HandID: "hand-2"})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int64(2), last)
+		stream, err := store.LoadEvents(ctx, "table-1", 0)
+		require.NoError(t, err)
+		require.Len(t, stream, 2)
+		assert.Equal(t, int64(1), stream[0].Sequence)
+		assert.Equal(t, events.PhaseChanged{}.Name(), stream[1].Event.Name())
+
+		tables, err := store.Tables(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"table-1", "table-2"}, tables)
+	})
+
+	t.Run("Loads from a sequence on", func(t *testing.T) {
+		// Setup
+		store := events.NewMemoryStore()
+		_, err := store.Append(ctx, "table-1", events.HandStarted{}, events.PhaseChanged{}, events.HandEnded{})
+		require.NoError(t, err)
+
+		// Act
+		tail, err := store.LoadEvents(ctx, "table-1", 3)
+		require.NoError(t, err)
+		beyond, err := store.LoadEvents(ctx, "table-1", 10)
+		require.NoError(t, err)
+
+		// Assert
+		require.Len(t, tail, 1)
+		assert.Equal(t, int64(3), tail[0].Sequence)
+		assert.Empty(t, beyond)
+	})
+}
+
+func TestMemoryStoreSubscribe(t *testing.T) {
+	// receive reads the next event of a subscription, failing if none comes
+	receive := func(t *testing.T, subscription <-chan events.StoredEvent) events.StoredEvent {
+		select {
+		case stored, ok := <-subscription:
+			require.True(t, ok, "subscription closed")
+			return stored
+		case <-time.After(time.Second):
+			require.FailNow(t, "no event received")
+			return events.StoredEvent{}
+		}
+	}
+
+	t.Run("Streams the stored events then the new ones", func(t *testing.T) {
+		// Setup
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		store := events.NewMemoryStore()
+		_, err := store.Append(ctx, "table-1", events.HandStarted{}, events.PhaseChanged{})
+		require.NoError(t, err)
+
+		// Act
+		subscription, err := store.Subscribe(ctx, "table-1", 2)
+		require.NoError(t, err)
+		stored := receive(t, subscription)
+		_, err = store.Append(ctx, "table-2", events.HandStarted{})
+		require.NoError(t, err)
+		_, err = store.Append(ctx, "table-1", events.HandEnded{})
+		require.NoError(t, err)
+		appended := receive(t, subscription)
+
+		// Assert
+		assert.Equal(t, int64(2), stored.Sequence)
+		assert.Equal(t, events.PhaseChanged{}.Name(), stored.Event.Name())
+		assert.Equal(t, int64(3), appended.Sequence)
+		assert.Equal(t, "table-1", appended.TableID)
+		assert.Equal(t, events.HandEnded{}.Name(), appended.Event.Name())
+	})
+
+	t.Run("Waits for a table that has no stream yet", func(t *testing.T) {
+		// Setup
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		store := events.NewMemoryStore()
+		subscription, err := store.Subscribe(ctx, "table-1", 0)
+		require.NoError(t, err)
+
+		// Act
+		_, err = store.Append(ctx, "table-1", events.HandStarted{})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int64(1), receive(t, subscription).Sequence)
+	})
+
+	t.Run("Closes once the context is done", func(t *testing.T) {
+		// Setup
+		ctx, cancel := context.WithCancel(context.Background())
+		store := events.NewMemoryStore()
+		subscription, err := store.Subscribe(ctx, "table-1", 0)
+		require.NoError(t, err)
+
+		// Act
+		cancel()
+
+		// Assert
+		select {
+		case _, ok := <-subscription:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			assert.Fail(t, "subscription still open")
+		}
+	})
+}
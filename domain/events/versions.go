@@ -0,0 +1,134 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrUnknownEvent is returned when decoding an event whose name isn't registered
+var ErrUnknownEvent = errors.New("unknown event")
+
+// ErrNewerVersion is returned when decoding an event stored by a newer release, which this one can't read
+var ErrNewerVersion = errors.New("event stored at a newer version")
+
+// ErrInvalidSchemaChange is returned when the schema changes of an event don't follow each other
+var ErrInvalidSchemaChange = errors.New("invalid schema change")
+
+// schemaChanges lists the breaking changes made to stored events, oldest first. Changing an event struct in
+// a way older payloads can't be decoded into (a field renamed, its type or meaning changed) bumps the event's
+// version with an entry here, so the events stored before the change can still be read back.
+// Fields that are only added need no entry: older payloads decode with the new fields left empty.
+var schemaChanges = []SchemaChange{}
+
+// currentSchema is the schema of the events of this release, built once the events are registered
+var currentSchema = sync.OnceValue(func() *Schema { return mustNewSchema(schemaChanges...) })
+
+// Upcaster rewrites the fields of an event stored at the version before its SchemaChange into the new version
+type Upcaster func(fields map[string]json.RawMessage) error
+
+// SchemaChange is a breaking change made to an event, from Version-1 to Version
+type SchemaChange struct {
+	Event   string // Event name, e.g. "ANTE_PLACED"
+	Version int    // The version the change brings the event to, from 2 on
+	Upcast  Upcaster
+}
+
+// Schema knows the current version of every event, and how to upcast the payloads stored at older ones
+type Schema struct {
+	versions  map[string]int
+	upcasters map[string]map[int]Upcaster // By event name, then by the version they upcast from
+}
+
+// NewSchema builds the schema resulting from the given changes, which must bump each event's version one at a time
+func NewSchema(changes ...SchemaChange) (*Schema, error) {
+	s := &Schema{versions: map[string]int{}, upcasters: map[string]map[int]Upcaster{}}
+
+	for _, change := range changes {
+		if _, ok := decodable[change.Event]; !ok {
+			return nil, fmt.Errorf("%w: %w: %s", ErrInvalidSchemaChange, ErrUnknownEvent, change.Event)
+		}
+		if change.Version != s.Version(change.Event)+1 || change.Upcast == nil {
+			return nil, fmt.Errorf("%w: %s can't go from version %d to %d", ErrInvalidSchemaChange, change.Event, s.Version(change.Event), change.Version)
+		}
+
+		if s.upcasters[change.Event] == nil {
+			s.upcasters[change.Event] = map[int]Upcaster{}
+		}
+		s.upcasters[change.Event][change.Version-1] = change.Upcast
+		s.versions[change.Event] = change.Version
+	}
+
+	return s, nil
+}
+
+func mustNewSchema(changes ...SchemaChange) *Schema {
+	s, err := NewSchema(changes...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Version returns the current version of an event, 1 until it changes
+func (s *Schema) Version(name string) int {
+	if version, ok := s.versions[name]; ok {
+		return version
+	}
+	return 1
+}
+
+// Encode serializes an event at its current version
+func (s *Schema) Encode(event Event) (Encoded, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return Encoded{}, err
+	}
+	return Encoded{Name: event.Name(), Version: s.Version(event.Name()), Payload: data}, nil
+}
+
+// Decode rebuilds an event, upcasting its payload to the current version first
+func (s *Schema) Decode(encoded Encoded) (Event, error) {
+	typ, ok := decodable[encoded.Name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, encoded.Name)
+	}
+
+	payload, err := s.upcast(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.New(typ)
+	if err := json.Unmarshal(payload, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	return value.Elem().Interface().(Event), nil
+}
+
+// upcast brings a payload from the version it was stored at to the current one, one version at a time
+func (s *Schema) upcast(encoded Encoded) ([]byte, error) {
+	version := max(encoded.Version, 1)
+	current := s.Version(encoded.Name)
+	if version > current {
+		return nil, fmt.Errorf("%w: %s version %d, this release reads up to %d", ErrNewerVersion, encoded.Name, version, current)
+	}
+	if version == current {
+		return encoded.Payload, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded.Payload, &fields); err != nil {
+		return nil, err
+	}
+	for ; version < current; version++ {
+		if err := s.upcasters[encoded.Name][version](fields); err != nil {
+			return nil, fmt.Errorf("upcasting %s from version %d: %w", encoded.Name, version, err)
+		}
+	}
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,89 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renameField is an upcaster moving a field to a new name
+func renameField(from string, to string) events.Upcaster {
+	return func(fields map[string]json.RawMessage) error {
+		fields[to] = fields[from]
+		delete(fields, from)
+		return nil
+	}
+}
+
+func TestSchema(t *testing.T) {
+	t.Run("Events are at version 1 until they change", func(t *testing.T) {
+		// Setup
+		schema, err := events.NewSchema()
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 1, schema.Version(events.AntePlaced{}.Name()))
+	})
+
+	t.Run("Upcasts events stored at older versions", func(t *testing.T) {
+		// Setup
+		schema, err := events.NewSchema(
+			events.SchemaChange{Event: "ANTE_PLACED", Version: 2, Upcast: renameField("Player", "Seat")},
+			events.SchemaChange{Event: "ANTE_PLACED", Version: 3, Upcast: renameField("Seat", "PlayerID")},
+		)
+		require.NoError(t, err)
+		legacy := events.Encoded{Name: "ANTE_PLACED", Payload: []byte(`{"HandID":"hand-1","Player":"player-1","Amount":10}`)}
+
+		// Act
+		decoded, err := schema.Decode(legacy)
+		encoded, encodeErr := schema.Encode(decoded)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, encodeErr)
+		assert.Equal(t, events.AntePlaced{HandID: "hand-1", PlayerID: "player-1", Amount: 10}, decoded)
+		assert.Equal(t, 3, encoded.Version)
+	})
+
+	t.Run("Events at the current version are read as is", func(t *testing.T) {
+		// Setup
+		schema, err := events.NewSchema(events.SchemaChange{Event: "ANTE_PLACED", Version: 2, Upcast: renameField("Player", "PlayerID")})
+		require.NoError(t, err)
+		encoded, err := schema.Encode(events.AntePlaced{PlayerID: "player-1"})
+		require.NoError(t, err)
+
+		// Act
+		decoded, err := schema.Decode(encoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "player-1", decoded.(events.AntePlaced).PlayerID)
+	})
+
+	t.Run("Rejects events stored by a newer release", func(t *testing.T) {
+		// Setup
+		schema, err := events.NewSchema()
+		require.NoError(t, err)
+
+		// Act
+		_, err = schema.Decode(events.Encoded{Name: "ANTE_PLACED", Version: 2, Payload: []byte("{}")})
+
+		// Assert
+		assert.ErrorIs(t, err, events.ErrNewerVersion)
+	})
+
+	t.Run("Changes must bump versions one at a time", func(t *testing.T) {
+		// Act
+		_, skipped := events.NewSchema(events.SchemaChange{Event: "ANTE_PLACED", Version: 3, Upcast: renameField("a", "b")})
+		_, unknown := events.NewSchema(events.SchemaChange{Event: "NOT_AN_EVENT", Version: 2, Upcast: renameField("a", "b")})
+		_, missing := events.NewSchema(events.SchemaChange{Event: "ANTE_PLACED", Version: 2})
+
+		// Assert
+		assert.ErrorIs(t, skipped, events.ErrInvalidSchemaChange)
+		assert.ErrorIs(t, unknown, events.ErrUnknownEvent)
+		assert.ErrorIs(t, missing, events.ErrInvalidSchemaChange)
+	})
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"github.com/lazharichir/poker/domain/escrow"
+)
+
+// Visibility says who may see an event
+type Visibility string
+
+const (
+	VisibilityPublic Visibility = "public" // Players at the table and spectators
+	VisibilityOwner  Visibility = "owner"  // Only the player the event is about
+)
+
+// Classify returns who may see an event, and the player owning it if only they may.
+// Events not listed here are public, those carrying server-only fields are redacted by Redact.
+func Classify(event Event) (Visibility, string) {
+	switch e := event.(type) {
+	case HoleCardDealt:
+		return VisibilityOwner, e.PlayerID
+	case PlayerSessionSummarized:
+		return VisibilityOwner, e.PlayerID
+	case PlayerCashedOut:
+		return VisibilityOwner, e.PlayerID
+	case PlayerEnteredLobby:
+		return VisibilityOwner, e.PlayerID
+	case PlayerLeftLobby:
+		return VisibilityOwner, e.PlayerID
+	case PlayerBanned:
+		return VisibilityOwner, e.PlayerID
+	case PlayerUnbanned:
+		return VisibilityOwner, e.PlayerID
+	case PlayerPrivacyChanged:
+		return VisibilityOwner, e.PlayerID
+	}
+	return VisibilityPublic, ""
+}
+
+// Redact clears the fields of an event only the server may read, the event is returned as is if it has none
+func Redact(event Event) Event {
+	switch e := event.(type) {
+	case DeckShuffled:
+		// The commitment lets players check the shuffle once the seed is revealed, the sealed seed is for auditors
+		e.SealedSeed = escrow.SealedSeed{}
+		return e
+	}
+	return event
+}
+
+// VisibleTo returns an event as a player may see it, false if they may not see it at all.
+// An empty player ID stands for a spectator, who only sees public events.
+func VisibleTo(event Event, playerID string) (Event, bool) {
+	visibility, owner := Classify(event)
+	if visibility == VisibilityOwner && (playerID == "" || playerID != owner) {
+		return nil, false
+	}
+	return Redact(event), true
+}
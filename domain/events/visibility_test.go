@@ -0,0 +1,56 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/escrow"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisibleTo(t *testing.T) {
+	t.Run("Owner-only events only reach their owner", func(t *testing.T) {
+		// Setup
+		holeCard := events.HoleCardDealt{TableID: "table-1", PlayerID: "player-1"}
+
+		// Act
+		_, ownerSees := events.VisibleTo(holeCard, "player-1")
+		_, otherSees := events.VisibleTo(holeCard, "player-2")
+		_, spectatorSees := events.VisibleTo(holeCard, "")
+
+		// Assert
+		assert.True(t, ownerSees)
+		assert.False(t, otherSees)
+		assert.False(t, spectatorSees)
+	})
+
+	t.Run("Public events reach everyone", func(t *testing.T) {
+		// Setup
+		folded := events.PlayerFolded{TableID: "table-1", PlayerID: "player-1"}
+
+		// Act
+		event, ok := events.VisibleTo(folded, "")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, folded, event)
+	})
+
+	t.Run("Server-only fields are redacted", func(t *testing.T) {
+		// Setup
+		shuffled := events.DeckShuffled{
+			TableID:        "table-1",
+			SeedCommitment: "commitment",
+			SealedSeed:     escrow.SealedSeed{Ciphertext: []byte("seed")},
+		}
+
+		// Act
+		event, ok := events.VisibleTo(shuffled, "player-1")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "commitment", event.(events.DeckShuffled).SeedCommitment)
+		assert.True(t, event.(events.DeckShuffled).SealedSeed.IsEmpty())
+		assert.False(t, shuffled.SealedSeed.IsEmpty(), "the original event is left untouched")
+	})
+}
@@ -1,13 +1,17 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
 	"github.com/lazharichir/poker/domain/events"
 	"github.com/lazharichir/poker/domain/hands"
+	"github.com/lazharichir/poker/domain/logging"
 )
 
 type HandPhase string
@@ -18,6 +22,7 @@ const (
 	HandPhase_Hole               HandPhase = "hole"
 	HandPhase_Continuation       HandPhase = "continuation"
 	HandPhase_CommunityDeal      HandPhase = "community.deal"
+	HandPhase_Discard            HandPhase = "discard"
 	HandPhase_CommunitySelection HandPhase = "community.selection"
 	HandPhase_HandReveal         HandPhase = "hand.reveal"
 	HandPhase_Decision           HandPhase = "decision"
@@ -25,26 +30,42 @@ const (
 	HandPhase_Ended              HandPhase = "ended"
 )
 
+// ErrNotYourTurn is returned when a player acts while another player is to act
+var ErrNotYourTurn = errors.New("not this player's turn to act")
+
+// ErrHandAlreadyInitialized is returned when a hand is initialized again, which would deal it anew
+var ErrHandAlreadyInitialized = errors.New("hand was already initialized")
+
+// ErrHandPastStart is returned when a hand is reset after it left the start phase, once chips are committed
+var ErrHandPastStart = errors.New("hand is past its start phase")
+
 // Hand represents a hand of poker being played
 type Hand struct {
 	ID         string
+	Number     int // Position of the hand among those played at its table, from 1
 	Table      *Table
 	TableID    string
 	Phase      HandPhase
 	TableRules TableRules
 	StartedAt  time.Time
+	BombPot    bool // Everyone posts up front, and there is no continuation betting
 
 	// events
 	Events        []events.Event
 	eventHandlers []events.EventHandler
+	lastSeq       uint64 // Sequence of the hand's latest event
 
 	//
 	Players        []*Player
-	Deck           cards.Stack
+	Deck           cards.Stack `json:"-"` // Never serialized, DeckRemaining tells how many cards are left
 	CommunityCards cards.Stack
+	BurnedCards    cards.Stack `json:"-"` // Never revealed, kept for deck accounting
 	HoleCards      map[string]cards.Stack
 	Pot            int
+	Escrow         HandEscrow // The chips behind the pot, by player, see HandEscrow
 	Results        []hands.HandComparisonResult
+	evaluated      bool // Results are cached once the showdown is evaluated
+	initialized    bool // Set once the hand was dealt a deck, until it is reset
 
 	// New fields for tracking bets
 	ActivePlayers               map[string]bool // Maps player IDs to active status (still in the hand)
@@ -52,8 +73,19 @@ type Hand struct {
 	ButtonPosition              int             // Index of button player in the Players slice
 	AntesPaid                   map[string]int  // Maps player IDs to ante amounts
 	ContinuationBets            map[string]int  // Maps player IDs to continuation bet amounts
+	DiscardCosts                map[string]int  // Maps player IDs to the discard costs they paid
+	DiscardDecided              map[string]bool // Players done with the discard phase, whether they discarded or not
+	DiscardedCards              cards.Stack     // Community cards taken out of play, in the order they were discarded
 	CommunitySelections         map[string]cards.Stack
 	CommunitySelectionStartedAt time.Time
+	ReadyPlayers                map[string]bool // Players who confirmed the ready-check, nil when no check is running
+
+	readyTimer Timer
+
+	// Shuffle audit
+	Shuffler       cards.Shuffler    // Picks the shuffle seed, a secure random one when nil
+	SeedCommitment string            // hex encoded SHA-256 of the shuffle seed
+	SealedSeed     escrow.SealedSeed // shuffle seed sealed to the operator escrow key
 }
 
 // RegisterEventHandler registers a callback function that will be called when events occur
@@ -63,6 +95,15 @@ func (h *Hand) RegisterEventHandler(handler events.EventHandler) {
 
 // emitEvent notifies all registered handlers of a new event
 func (h *Hand) emitEvent(event events.Event) {
+	// IDs are scoped to the table so they stay monotonic across hands
+	if h.Table != nil {
+		event = h.Table.stampEvent(event)
+	}
+	h.lastSeq++
+	seq := events.ExtractSequence(event)
+	seq.Hand = h.lastSeq
+	event = events.WithSequence(event, seq)
+
 	// Add event to hand's event log
 	h.Events = append(h.Events, event)
 
@@ -72,11 +113,25 @@ func (h *Hand) emitEvent(event events.Event) {
 	}
 }
 
-// InitializeHand initializes a new hand with a fresh deck and activates all players
-func (h *Hand) InitializeHand() {
-	// Initialize a new shuffled deck
+// InitializeHand initializes a new hand with a fresh deck and activates all players.
+// It runs once, in the start phase: a hand cancelled before being dealt is Reset to be initialized again.
+func (h *Hand) InitializeHand() error {
+	if !h.IsInPhase(HandPhase_Start) || h.initialized {
+		return ErrHandAlreadyInitialized
+	}
+	h.initialized = true
+
+	// Initialize a new shuffled deck from a fresh seed, and keep an audit trail of it
+	h.StartedAt = h.clock().Now()
+
+	shuffler := h.Shuffler
+	if shuffler == nil {
+		shuffler = cards.SecureShuffler{}
+	}
+
+	seed := shuffler.NextSeed()
 	h.Deck = cards.NewDeck52()
-	h.Deck.Shuffle()
+	h.Deck.ShuffleWithSeed(seed)
 
 	// Initialize the community cards as empty
 	h.CommunityCards = []cards.Card{}
@@ -84,6 +139,11 @@ func (h *Hand) InitializeHand() {
 	// Initialize hole cards map for each player
 	h.HoleCards = make(map[string]cards.Stack)
 
+	// Players who sat down during the last hand are dealt in from this one
+	if h.Table != nil {
+		h.Table.dealInLateJoiners(h)
+	}
+
 	// Set all players to active at the start of the hand
 	h.ActivePlayers = make(map[string]bool)
 	for _, player := range h.Players {
@@ -93,8 +153,12 @@ func (h *Hand) InitializeHand() {
 
 	// Initialize betting maps
 	h.Results = []hands.HandComparisonResult{}
+	h.evaluated = false
 	h.AntesPaid = make(map[string]int)
 	h.ContinuationBets = make(map[string]int)
+	h.DiscardCosts = make(map[string]int)
+	h.DiscardDecided = make(map[string]bool)
+	h.DiscardedCards = cards.Stack{}
 	h.CommunitySelections = make(map[string]cards.Stack)
 
 	// Set the current bettor to the player left of the button
@@ -110,14 +174,69 @@ func (h *Hand) InitializeHand() {
 		TableID: h.TableID,
 		HandID:  h.ID,
 		Players: playerIDs,
-		At:      time.Now(),
+		At:      h.clock().Now(),
 	})
 
+	h.recordShuffle(seed)
+
 	h.resetPot()
+
+	return nil
 }
 
-func (h *Hand) TransitionToAntesPhase() {
+// Reset undoes InitializeHand for a hand cancelled before it left the start phase, such as one whose
+// ready-check was called off, so it can be initialized again. Later on chips are committed and the hand
+// has to be voided instead.
+func (h *Hand) Reset() error {
 	if !h.IsInPhase(HandPhase_Start) {
+		return ErrHandPastStart
+	}
+
+	if h.readyTimer != nil {
+		h.readyTimer.Stop()
+		h.readyTimer = nil
+	}
+
+	h.initialized = false
+	h.StartedAt = time.Time{}
+	h.Deck = cards.NewDeck52()
+	h.CommunityCards = []cards.Card{}
+	h.HoleCards = make(map[string]cards.Stack)
+	h.ActivePlayers = make(map[string]bool)
+	h.ReadyPlayers = nil
+	h.CurrentBettor = ""
+	h.SeedCommitment = ""
+	h.SealedSeed = escrow.SealedSeed{}
+
+	return nil
+}
+
+// recordShuffle commits to the shuffle seed and escrows it, so a disputed deal can be replayed by an authorized audit
+func (h *Hand) recordShuffle(seed [32]byte) {
+	commitment := sha256.Sum256(seed[:])
+	h.SeedCommitment = hex.EncodeToString(commitment[:])
+	h.SealedSeed = escrow.SealedSeed{}
+
+	if h.Table != nil && h.Table.SeedEscrow != nil {
+		sealed, err := h.Table.SeedEscrow.Seal(seed[:])
+		if err != nil {
+			fmt.Println("Could not escrow shuffle seed for hand", h.ID, ":", err)
+		} else {
+			h.SealedSeed = sealed
+		}
+	}
+
+	h.emitEvent(events.DeckShuffled{
+		TableID:        h.TableID,
+		HandID:         h.ID,
+		SeedCommitment: h.SeedCommitment,
+		SealedSeed:     h.SealedSeed,
+		At:             h.clock().Now(),
+	})
+}
+
+func (h *Hand) TransitionToAntesPhase() {
+	if !h.canTransitionTo(HandPhase_Antes) {
 		return
 	}
 
@@ -130,16 +249,21 @@ func (h *Hand) TransitionToAntesPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
+	if h.BombPot {
+		h.postBombPot()
+		return
+	}
+
 	// Emit BettingRoundStarted event
 	h.emitEvent(events.BettingRoundStarted{
 		TableID:    h.TableID,
 		HandID:     h.ID,
 		Phase:      string(h.Phase),
 		FirstToAct: h.getPlayerLeftOfButton(),
-		At:         time.Now(),
+		At:         h.clock().Now(),
 	})
 
 	// Emit PlayerTurnStarted for the first player
@@ -148,8 +272,9 @@ func (h *Hand) TransitionToAntesPhase() {
 		HandID:    h.ID,
 		PlayerID:  h.CurrentBettor,
 		Phase:     string(h.Phase),
-		TimeoutAt: time.Now().Add(h.TableRules.PlayerTimeout),
-		At:        time.Now(),
+		TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+		Timeout:   h.turnTimeout(),
+		At:        h.clock().Now(),
 	})
 
 	// The actual ante collection would happen in the game loop,
@@ -167,7 +292,7 @@ func (h *Hand) PlayerPlacesAnte(playerID string, amount int) error {
 
 	// Check if it's the player's turn to act
 	if !h.IsPlayerTheCurrentBettor(playerID) {
-		return errors.New("not this player's turn to act")
+		return ErrNotYourTurn
 	}
 
 	// Check if player already paid ante
@@ -176,7 +301,7 @@ func (h *Hand) PlayerPlacesAnte(playerID string, amount int) error {
 	}
 
 	// Record the ante
-	h.Table.DecreasePlayerBuyIn(playerID, amount)
+	h.fundEscrow(playerID, amount, "ante")
 	h.addToPlayerAntesPaid(playerID, amount)
 	h.increasePot(amount)
 
@@ -186,9 +311,16 @@ func (h *Hand) PlayerPlacesAnte(playerID string, amount int) error {
 		HandID:   h.ID,
 		PlayerID: playerID,
 		Amount:   amount,
-		At:       time.Now(),
+		At:       h.clock().Now(),
 	})
 
+	h.advanceAntes(playerID)
+
+	return nil
+}
+
+// advanceAntes moves the ante round on once the player is done with it, to the next player or to the hole cards
+func (h *Hand) advanceAntes(playerID string) {
 	// Find next player to act
 	h.CurrentBettor = h.getNextActiveBettor(playerID)
 
@@ -199,25 +331,26 @@ func (h *Hand) PlayerPlacesAnte(playerID string, amount int) error {
 			HandID:    h.ID,
 			PlayerID:  h.CurrentBettor,
 			Phase:     string(h.Phase),
-			TimeoutAt: time.Now().Add(h.TableRules.PlayerTimeout),
-			At:        time.Now(),
+			TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+			Timeout:   h.turnTimeout(),
+			At:        h.clock().Now(),
 		})
 	}
 
 	// Check if all antes have been paid
 	if h.areAllAntesPaid() {
+		h.sweepBetsIntoPot()
+
 		// Emit BettingRoundEnded event
 		h.emitEvent(events.BettingRoundEnded{
 			TableID:   h.TableID,
 			HandID:    h.ID,
 			Phase:     string(h.Phase),
 			TotalBets: h.Pot,
-			At:        time.Now(),
+			At:        h.clock().Now(),
 		})
 		h.TransitionToHolePhase()
 	}
-
-	return nil
 }
 
 // HandleAntePhaseTimeout handles the case where the ante phase timer expires
@@ -238,18 +371,20 @@ func (h *Hand) HandleAntePhaseTimeout() error {
 				PlayerID:      player.ID,
 				Phase:         string(h.Phase),
 				DefaultAction: "fold", // Assuming default action is fold
-				At:            time.Now(),
+				At:            h.clock().Now(),
 			})
 		}
 	}
 
+	h.sweepBetsIntoPot()
+
 	// Emit BettingRoundEnded event
 	h.emitEvent(events.BettingRoundEnded{
 		TableID:   h.TableID,
 		HandID:    h.ID,
 		Phase:     string(h.Phase),
 		TotalBets: h.Pot,
-		At:        time.Now(),
+		At:        h.clock().Now(),
 	})
 
 	// If we have at least one active player, proceed
@@ -264,7 +399,7 @@ func (h *Hand) HandleAntePhaseTimeout() error {
 }
 
 func (h *Hand) TransitionToHolePhase() {
-	if !h.IsInPhase(HandPhase_Antes) {
+	if !h.canTransitionTo(HandPhase_Hole) {
 		return
 	}
 
@@ -277,7 +412,7 @@ func (h *Hand) TransitionToHolePhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Reset CurrentBettor for next phase
@@ -321,7 +456,7 @@ func (h *Hand) DealHoleCards() error {
 					HandID:   h.ID,
 					PlayerID: player.ID,
 					Card:     card,
-					At:       time.Now(),
+					At:       h.clock().Now(),
 				})
 			}
 		}
@@ -343,9 +478,15 @@ func (h *Hand) DealHoleCards() error {
 		TableID:   h.TableID,
 		HandID:    h.ID,
 		DealOrder: dealOrder,
-		At:        time.Now(),
+		At:        h.clock().Now(),
 	})
 
+	// Bomb pots skip the continuation betting
+	if h.BombPot {
+		h.TransitionToCommunityDealPhase()
+		return nil
+	}
+
 	// Transition to continuation phase
 	h.TransitionToContinuationPhase()
 
@@ -353,7 +494,7 @@ func (h *Hand) DealHoleCards() error {
 }
 
 func (h *Hand) TransitionToContinuationPhase() {
-	if !h.IsInPhase(HandPhase_Hole) {
+	if !h.canTransitionTo(HandPhase_Continuation) {
 		return
 	}
 
@@ -366,7 +507,7 @@ func (h *Hand) TransitionToContinuationPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Reset CurrentBettor for next phase
@@ -378,7 +519,7 @@ func (h *Hand) TransitionToContinuationPhase() {
 		HandID:     h.ID,
 		Phase:      string(h.Phase),
 		FirstToAct: h.CurrentBettor,
-		At:         time.Now(),
+		At:         h.clock().Now(),
 	})
 
 	// Emit PlayerTurnStarted for the first player
@@ -387,8 +528,9 @@ func (h *Hand) TransitionToContinuationPhase() {
 		HandID:    h.ID,
 		PlayerID:  h.CurrentBettor,
 		Phase:     string(h.Phase),
-		TimeoutAt: time.Now().Add(h.TableRules.PlayerTimeout),
-		At:        time.Now(),
+		TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+		Timeout:   h.turnTimeout(),
+		At:        h.clock().Now(),
 	})
 
 	// The actual continuation betting would happen in the game loop,
@@ -406,7 +548,7 @@ func (h *Hand) PlayerPlacesContinuationBet(playerID string, amount int) error {
 
 	// Check if it's the player's turn to act
 	if !h.IsPlayerTheCurrentBettor(playerID) {
-		return errors.New("not this player's turn to act")
+		return ErrNotYourTurn
 	}
 
 	// Check if player already made decision
@@ -415,7 +557,7 @@ func (h *Hand) PlayerPlacesContinuationBet(playerID string, amount int) error {
 	}
 
 	// Record the bet
-	h.Table.DecreasePlayerBuyIn(playerID, amount)
+	h.fundEscrow(playerID, amount, "continuation bet")
 	h.increasePot(amount)
 	h.ContinuationBets[playerID] = amount
 
@@ -425,7 +567,7 @@ func (h *Hand) PlayerPlacesContinuationBet(playerID string, amount int) error {
 		HandID:   h.ID,
 		PlayerID: playerID,
 		Amount:   amount,
-		At:       time.Now(),
+		At:       h.clock().Now(),
 	})
 
 	// Find next player to act
@@ -438,20 +580,23 @@ func (h *Hand) PlayerPlacesContinuationBet(playerID string, amount int) error {
 			HandID:    h.ID,
 			PlayerID:  h.CurrentBettor,
 			Phase:     string(h.Phase),
-			TimeoutAt: time.Now().Add(h.TableRules.PlayerTimeout),
-			At:        time.Now(),
+			TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+			Timeout:   h.turnTimeout(),
+			At:        h.clock().Now(),
 		})
 	}
 
 	// Check if all continuation bets are in
 	if h.haveAllPlayersDecided() {
+		h.sweepBetsIntoPot()
+
 		// Emit BettingRoundEnded event
 		h.emitEvent(events.BettingRoundEnded{
 			TableID:   h.TableID,
 			HandID:    h.ID,
 			Phase:     string(h.Phase),
 			TotalBets: h.calculateTotalContinuationBets(),
-			At:        time.Now(),
+			At:        h.clock().Now(),
 		})
 
 		h.TransitionToCommunityDealPhase()
@@ -467,6 +612,11 @@ func (h *Hand) PlayerFolds(playerID string) error {
 		return errors.New("player is not active in this hand")
 	}
 
+	// Once the community cards are out, players may concede without waiting for a turn
+	if h.IsInPhase(HandPhase_CommunityDeal) || h.IsInPhase(HandPhase_Discard) || h.IsInPhase(HandPhase_CommunitySelection) {
+		return h.concede(playerID)
+	}
+
 	// Check if it's appropriate phase for folding (continuation or discard)
 	if !h.IsInPhase(HandPhase_Continuation) {
 		return errors.New("cannot fold in current phase")
@@ -474,7 +624,7 @@ func (h *Hand) PlayerFolds(playerID string) error {
 
 	// Check if it's not the player's turn to act
 	if !h.IsPlayerTheCurrentBettor(playerID) {
-		return errors.New("not this player's turn to act")
+		return ErrNotYourTurn
 	}
 
 	// Mark player as inactive
@@ -486,7 +636,7 @@ func (h *Hand) PlayerFolds(playerID string) error {
 		HandID:   h.ID,
 		PlayerID: playerID,
 		Phase:    string(h.Phase),
-		At:       time.Now(),
+		At:       h.clock().Now(),
 	})
 
 	// Check if only one player remains
@@ -497,17 +647,18 @@ func (h *Hand) PlayerFolds(playerID string) error {
 			return err
 		}
 
+		h.sweepBetsIntoPot()
+
 		// Emit BettingRoundEnded event
 		h.emitEvent(events.BettingRoundEnded{
 			TableID:   h.TableID,
 			HandID:    h.ID,
 			Phase:     string(h.Phase),
 			TotalBets: h.calculateTotalContinuationBets(),
-			At:        time.Now(),
+			At:        h.clock().Now(),
 		})
 
-		h.handleSinglePlayerWin(lastActivePlayer.ID)
-		return nil
+		return h.handleSinglePlayerWin(lastActivePlayer.ID)
 	}
 
 	// Find next player to act
@@ -520,20 +671,23 @@ func (h *Hand) PlayerFolds(playerID string) error {
 			HandID:    h.ID,
 			PlayerID:  h.CurrentBettor,
 			Phase:     string(h.Phase),
-			TimeoutAt: time.Now().Add(h.TableRules.PlayerTimeout),
-			At:        time.Now(),
+			TimeoutAt: h.clock().Now().Add(h.turnTimeout()),
+			Timeout:   h.turnTimeout(),
+			At:        h.clock().Now(),
 		})
 	}
 
 	// Check if all continuation bets are in
 	if h.haveAllPlayersDecided() {
+		h.sweepBetsIntoPot()
+
 		// Emit BettingRoundEnded event
 		h.emitEvent(events.BettingRoundEnded{
 			TableID:   h.TableID,
 			HandID:    h.ID,
 			Phase:     string(h.Phase),
 			TotalBets: h.calculateTotalContinuationBets(),
-			At:        time.Now(),
+			At:        h.clock().Now(),
 		})
 
 		h.TransitionToCommunityDealPhase()
@@ -542,14 +696,53 @@ func (h *Hand) PlayerFolds(playerID string) error {
 	return nil
 }
 
+// concede folds a player during the community phases. Their selections are dropped so they are never
+// shown, and the hand moves on once the players left have all selected their cards.
+func (h *Hand) concede(playerID string) error {
+	h.setPlayerAsInactive(playerID)
+	delete(h.CommunitySelections, playerID)
+
+	h.emitEvent(events.PlayerFolded{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		Phase:    string(h.Phase),
+		At:       h.clock().Now(),
+	})
+
+	if h.countActivePlayers() == 1 {
+		lastActivePlayer, err := h.getLastActivePlayer()
+		if err != nil {
+			return err
+		}
+
+		if h.IsInPhase(HandPhase_CommunitySelection) {
+			h.emitEvent(events.CommunitySelectionEnded{
+				TableID: h.TableID,
+				HandID:  h.ID,
+				At:      h.clock().Now(),
+			})
+		}
+		return h.handleSinglePlayerWin(lastActivePlayer.ID)
+	}
+
+	if h.IsInPhase(HandPhase_Discard) && h.IsPlayerTheCurrentBettor(playerID) {
+		h.advanceDiscards(playerID)
+	}
+	if h.IsInPhase(HandPhase_CommunitySelection) && h.haveAllActivePlayersSelectedTheirCommunityCards() {
+		h.TransitionToDecisionPhase()
+	}
+	return nil
+}
+
 func (h *Hand) hasAlreadyPlacedContinuationBet(playerID string) bool {
 	_, decided := h.ContinuationBets[playerID]
 	return decided
 }
 
 func (h *Hand) haveAllPlayersDecided() bool {
-	for playerID := range h.ActivePlayers {
-		if _, decided := h.ContinuationBets[playerID]; !decided {
+	for playerID, active := range h.ActivePlayers {
+		if _, decided := h.ContinuationBets[playerID]; active && !decided {
 			return false
 		}
 	}
@@ -557,7 +750,7 @@ func (h *Hand) haveAllPlayersDecided() bool {
 }
 
 func (h *Hand) TransitionToCommunityDealPhase() {
-	if !h.IsInPhase(HandPhase_Continuation) {
+	if !h.canTransitionTo(HandPhase_CommunityDeal) {
 		return
 	}
 
@@ -570,7 +763,7 @@ func (h *Hand) TransitionToCommunityDealPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Reset CurrentBettor for next phase
@@ -585,21 +778,51 @@ func (h *Hand) TransitionToCommunityDealPhase() {
 }
 
 func (h *Hand) StartDealingCommunityCards() error {
-	// burn one card
-	if err := h.BurnCard(); err != nil {
-		return err
-	}
+	for wave, size := range h.communityWaves() {
+		if h.shouldBurnBefore(wave) {
+			if err := h.BurnCard(wave); err != nil {
+				return err
+			}
+		}
 
-	// deal 8 cards
-	for i := 0; i < 8; i++ {
-		if err := h.DealCommunityCard(); err != nil {
-			return err
+		for i := 0; i < size; i++ {
+			if err := h.DealCommunityCard(); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// communityWaves returns how many community cards each wave deals, the table's waves when they add up to all the cards
+func (h *Hand) communityWaves() []int {
+	total := 0
+	for _, size := range h.TableRules.CommunityWaves {
+		if size <= 0 {
+			return []int{h.TableRules.communityCardCount()}
+		}
+		total += size
+	}
+
+	if total != h.TableRules.communityCardCount() {
+		return []int{h.TableRules.communityCardCount()}
+	}
+	return h.TableRules.CommunityWaves
+}
+
+// shouldBurnBefore tells whether the table's burn policy burns a card before the given wave
+func (h *Hand) shouldBurnBefore(wave int) bool {
+	switch h.TableRules.BurnPolicy {
+	case BurnNone:
+		return false
+	case BurnBeforeEachWave:
+		return true
+	default:
+		return wave == 0
+	}
+}
+
 // DealCommunityCard deals a single community card
 func (h *Hand) DealCommunityCard() error {
 	if !h.IsInPhase(HandPhase_CommunityDeal) {
@@ -620,24 +843,28 @@ func (h *Hand) DealCommunityCard() error {
 		HandID:    h.ID,
 		CardIndex: len(h.CommunityCards) - 1, // Index of the card just dealt (0-based)
 		Card:      card,
-		At:        time.Now(),
+		At:        h.clock().Now(),
 	})
 
-	// Transition to decision phase if all community cards have been dealt
-	if len(h.CommunityCards) == 8 {
-		h.TransitionToCommunitySelectionPhase()
+	// Move on once all community cards have been dealt, to the discards if the table allows them
+	if len(h.CommunityCards) == h.TableRules.communityCardCount() {
+		if h.discardsAllowed() {
+			h.TransitionToDiscardPhase()
+		} else {
+			h.TransitionToCommunitySelectionPhase()
+		}
 	}
 	return nil
 }
 
 func (h *Hand) TransitionToCommunitySelectionPhase() {
-	if !h.IsInPhase(HandPhase_CommunityDeal) {
+	if !h.canTransitionTo(HandPhase_CommunitySelection) {
 		return
 	}
 
 	previousPhase := h.Phase
 	h.Phase = HandPhase_CommunitySelection
-	h.CommunitySelectionStartedAt = time.Now()
+	h.CommunitySelectionStartedAt = h.clock().Now()
 
 	// Emit phase changed event
 	h.emitEvent(events.PhaseChanged{
@@ -645,18 +872,20 @@ func (h *Hand) TransitionToCommunitySelectionPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
-	// in this phase, players have 5 seconds to select three
-	// community cards to form the best hand
+	// in this phase, players have 5 seconds (unless the table
+	// sets its own limit) to select three community cards (unless the table
+	// says otherwise) to form the best hand
 	// once a card is selected, they cannot change it
 
 	h.emitEvent(events.CommunitySelectionStarted{
 		TableID:   h.TableID,
 		HandID:    h.ID,
-		TimeLimit: 5 * time.Second,
-		At:        time.Now(),
+		TimeLimit: h.selectionTimeLimit(),
+		Picks:     h.TableRules.selectionCount(),
+		At:        h.clock().Now(),
 	})
 }
 
@@ -680,9 +909,9 @@ func (h *Hand) PlayerSelectsCommunityCard(playerID string, selectedCard cards.Ca
 		h.CommunitySelections[playerID] = []cards.Card{}
 	}
 
-	// Check if player has already selected 3 cards
-	if len(h.CommunitySelections[playerID]) >= 3 {
-		return errors.New("player has already selected 3 cards")
+	// Check if player has already selected all their cards
+	if len(h.CommunitySelections[playerID]) >= h.TableRules.selectionCount() {
+		return fmt.Errorf("player has already selected %d cards", h.TableRules.selectionCount())
 	}
 
 	// Check if player already selected this card (cannot select same card twice)
@@ -692,11 +921,23 @@ func (h *Hand) PlayerSelectsCommunityCard(playerID string, selectedCard cards.Ca
 		}
 	}
 
-	// Check it's within the 5s selection window
-	if time.Since(h.CommunitySelectionStartedAt) > 5*time.Second {
+	// Check it's within the selection window
+	if h.clock().Now().Sub(h.CommunitySelectionStartedAt) > h.selectionTimeLimit() {
 		return errors.New("selection window has closed")
 	}
 
+	h.addCommunitySelection(playerID, selectedCard)
+
+	// Transition to the decision phase if all players have selected their cards
+	if h.haveAllActivePlayersSelectedTheirCommunityCards() {
+		h.TransitionToDecisionPhase()
+	}
+
+	return nil
+}
+
+// addCommunitySelection records a community card picked by, or for, the player
+func (h *Hand) addCommunitySelection(playerID string, selectedCard cards.Card) {
 	// Add card to player's selections
 	h.CommunitySelections[playerID] = append(h.CommunitySelections[playerID], selectedCard)
 
@@ -707,15 +948,8 @@ func (h *Hand) PlayerSelectsCommunityCard(playerID string, selectedCard cards.Ca
 		PlayerID:       playerID,
 		Card:           selectedCard.String(),                // Assuming Card has a String() method
 		SelectionOrder: len(h.CommunitySelections[playerID]), // Order in which card was selected
-		At:             time.Now(),
+		At:             h.clock().Now(),
 	})
-
-	// Transition to the decision phase if all players have selected their cards
-	if h.haveAllActivePlayersSelectedTheirCommunityCards() {
-		h.TransitionToDecisionPhase()
-	}
-
-	return nil
 }
 
 func (h *Hand) checkIfValidCommunityCard(card cards.Card) bool {
@@ -728,13 +962,9 @@ func (h *Hand) checkIfValidCommunityCard(card cards.Card) bool {
 }
 
 func (h *Hand) haveAllActivePlayersSelectedTheirCommunityCards() bool {
-	if len(h.CommunitySelections) != len(h.ActivePlayers) {
-		return false
-	}
-
-	// they all must have selected 3 cards
-	for playerID := range h.ActivePlayers {
-		if len(h.CommunitySelections[playerID]) != 3 {
+	// they all must have selected all their cards
+	for playerID, active := range h.ActivePlayers {
+		if active && len(h.CommunitySelections[playerID]) != h.TableRules.selectionCount() {
 			return false
 		}
 	}
@@ -743,7 +973,7 @@ func (h *Hand) haveAllActivePlayersSelectedTheirCommunityCards() bool {
 }
 
 func (h *Hand) TransitionToDecisionPhase() {
-	if !h.IsInPhase(HandPhase_CommunitySelection) {
+	if !h.canTransitionTo(HandPhase_Decision) {
 		return
 	}
 
@@ -756,13 +986,13 @@ func (h *Hand) TransitionToDecisionPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	h.emitEvent(events.CommunitySelectionEnded{
 		TableID: h.TableID,
 		HandID:  h.ID,
-		At:      time.Now(),
+		At:      h.clock().Now(),
 	})
 
 	// Evaluate hands and determine the winner(s)
@@ -778,14 +1008,20 @@ func (h *Hand) TransitionToDecisionPhase() {
 	h.TransitionToEndedPhase()
 }
 
-// EvaluateHands evaluates all active players' hands and determines the winner(s)
+// EvaluateHands evaluates all active players' hands and determines the winner(s).
+// The results are computed once per hand, later calls return them without emitting events again.
 func (h *Hand) EvaluateHands() ([]hands.HandComparisonResult, error) {
+	if h.evaluated {
+		return h.Results, nil
+	}
+
 	// Create a map of player ID to their combined hole and community cards
 	playerCards := h.combineAllPlayerHoleAndSelectedCommunityCards()
 
 	// Use the hand evaluator to determine the best hand for each player
 	// (This assumes we have access to the hands package)
 	h.Results = h.comparePlayerHands(playerCards)
+	h.evaluated = true
 
 	// Emit HandsEvaluated event
 	handResults := make(map[string]hands.HandComparisonResult)
@@ -797,7 +1033,7 @@ func (h *Hand) EvaluateHands() ([]hands.HandComparisonResult, error) {
 		TableID: h.TableID,
 		HandID:  h.ID,
 		Results: handResults,
-		At:      time.Now(),
+		At:      h.clock().Now(),
 	})
 
 	h.emitShowdownEvents()
@@ -805,12 +1041,21 @@ func (h *Hand) EvaluateHands() ([]hands.HandComparisonResult, error) {
 	return h.Results, nil
 }
 
+// ReevaluateHands drops the cached results and evaluates the showdown again
+func (h *Hand) ReevaluateHands() ([]hands.HandComparisonResult, error) {
+	h.evaluated = false
+	return h.EvaluateHands()
+}
+
 func (h *Hand) comparePlayerHands(playerCards map[string]cards.Stack) []hands.HandComparisonResult {
+	if h.TableRules.HiLoSplit {
+		return hands.CompareHandsHiLo(playerCards)
+	}
 	return hands.CompareHands(playerCards)
 }
 
 func (h *Hand) TransitionToPayoutPhase() {
-	if !h.IsInPhase(HandPhase_Decision) {
+	if !h.canTransitionTo(HandPhase_Payout) {
 		return
 	}
 
@@ -823,7 +1068,7 @@ func (h *Hand) TransitionToPayoutPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Payout the pot to the winner(s)
@@ -848,6 +1093,16 @@ func (h *Hand) Payout() error {
 	if len(winners) == 0 {
 		// If no winners found (shouldn't happen), return error
 		return errors.New("no winners found")
+	} else if pots := h.SidePots(); len(pots) > 1 {
+		// Players put in different amounts, each pot goes to the best hand among those who matched it
+		if err := h.payoutSidePots(pots); err != nil {
+			return err
+		}
+	} else if lowWinners := h.lowWinners(); len(lowWinners) > 0 {
+		// Hi-lo split with a qualifying low
+		if err := h.payoutHiLo(winners, lowWinners); err != nil {
+			return err
+		}
 	} else if len(winners) == 1 {
 		// If one winner found
 		if err := h.awardPayout(winners[0], h.Pot, "winner takes all"); err != nil {
@@ -887,7 +1142,7 @@ func (h *Hand) Payout() error {
 			TableID:   h.TableID,
 			HandID:    h.ID,
 			Breakdown: breakdown,
-			At:        time.Now(),
+			At:        h.clock().Now(),
 		})
 	}
 
@@ -901,7 +1156,11 @@ func (h *Hand) Payout() error {
 }
 
 func (h *Hand) awardPayout(winnerID string, amount int, reason string) error {
-	h.Table.IncreasePlayerBuyIn(winnerID, amount)
+	return h.awardSidePayout(winnerID, amount, reason, "")
+}
+
+func (h *Hand) awardSidePayout(winnerID string, amount int, reason string, side string) error {
+	h.releaseEscrow(winnerID, amount, reason)
 
 	// Emit PotAmountAwarded event
 	h.emitEvent(events.PotAmountAwarded{
@@ -910,7 +1169,65 @@ func (h *Hand) awardPayout(winnerID string, amount int, reason string) error {
 		PlayerID: winnerID,
 		Amount:   amount,
 		Reason:   reason,
-		At:       time.Now(),
+		Side:     side,
+		At:       h.clock().Now(),
+	})
+
+	return nil
+}
+
+// lowWinners returns the players holding the best qualifying low, if the table plays hi-lo
+func (h *Hand) lowWinners() []string {
+	if !h.TableRules.HiLoSplit {
+		return nil
+	}
+
+	var winners []string
+	for _, result := range h.Results {
+		if result.IsLowWinner {
+			winners = append(winners, result.PlayerID)
+		}
+	}
+	return winners
+}
+
+// payoutHiLo splits the pot in half between the high and the low winners, the odd chip going high.
+// Ties split their half again, so a player sharing the low with one other gets a quarter of the pot.
+func (h *Hand) payoutHiLo(highWinners []string, lowWinners []string) error {
+	lowHalf := h.Pot / 2
+	highHalf := h.Pot - lowHalf
+
+	breakdown := make(map[string]int)
+
+	for _, side := range []struct {
+		name    string
+		winners []string
+		amount  int
+	}{
+		{"high", highWinners, highHalf},
+		{"low", lowWinners, lowHalf},
+	} {
+		share := side.amount / len(side.winners)
+		remainder := side.amount % len(side.winners)
+
+		for i, winnerID := range side.winners {
+			amount := share
+			if i == 0 {
+				amount += remainder
+			}
+
+			if err := h.awardSidePayout(winnerID, amount, side.name+" half", side.name); err != nil {
+				return err
+			}
+			breakdown[winnerID] += amount
+		}
+	}
+
+	h.emitEvent(events.PotBrokenDown{
+		TableID:   h.TableID,
+		HandID:    h.ID,
+		Breakdown: breakdown,
+		At:        h.clock().Now(),
 	})
 
 	return nil
@@ -942,7 +1259,7 @@ func (h *Hand) TransitionToEndedPhase() {
 		HandID:        h.ID,
 		PreviousPhase: string(previousPhase),
 		NewPhase:      string(h.Phase),
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Find winners
@@ -955,12 +1272,13 @@ func (h *Hand) TransitionToEndedPhase() {
 
 	// Emit HandEnded event
 	h.emitEvent(events.HandEnded{
-		TableID:  h.TableID,
-		HandID:   h.ID,
-		Duration: time.Since(h.StartedAt).Milliseconds(),
-		FinalPot: h.Pot,
-		Winners:  winners,
-		At:       time.Now(),
+		TableID:    h.TableID,
+		HandID:     h.ID,
+		Duration:   h.clock().Now().Sub(h.StartedAt).Milliseconds(),
+		FinalPot:   h.Pot,
+		Winners:    winners,
+		LowWinners: h.lowWinners(),
+		At:         h.clock().Now(),
 	})
 }
 
@@ -968,6 +1286,11 @@ func (h *Hand) IsPlayerActive(playerID string) bool {
 	return h.ActivePlayers[playerID]
 }
 
+// HasPlayer checks if the player was dealt in the hand, whether they folded since or not
+func (h *Hand) HasPlayer(playerID string) bool {
+	return h.getPlayerByID(playerID) != nil
+}
+
 func (h *Hand) setPlayerAsActive(playerID string) {
 	h.ActivePlayers[playerID] = true
 }
@@ -977,24 +1300,109 @@ func (h *Hand) setPlayerAsInactive(playerID string) {
 }
 
 // handleSinglePlayerWin handles case where only one player remains
-func (h *Hand) handleSinglePlayerWin(playerID string) {
+func (h *Hand) handleSinglePlayerWin(playerID string) error {
 	// Skip to the payout phase directly
 	h.Phase = HandPhase_Payout
 
+	// The fold policies only apply before any community card is out
+	policy := h.TableRules.FoldWinPolicy
+	if len(h.CommunityCards) > 0 {
+		policy = FoldWinPolicyAwardPot
+	}
+
+	if policy == FoldWinPolicyRefund {
+		return h.voidHand("all other players folded")
+	}
+
 	// Emit SingleWinnerDetermined event
 	h.emitEvent(events.SingleWinnerDetermined{
 		TableID:  h.TableID,
 		HandID:   h.ID,
 		PlayerID: playerID,
 		Reason:   "last player standing",
-		At:       time.Now(),
+		At:       h.clock().Now(),
 	})
 
-	// Handle payout logic
-	h.Payout()
+	if policy == FoldWinPolicyAwardNetBets {
+		return h.payoutNetBets(playerID)
+	}
+
+	return h.payoutToLastPlayerStanding(playerID)
+}
+
+// voidHand gives every player what they put in the escrow back and ends the hand without a winner
+func (h *Hand) voidHand(reason string) error {
+	refunds := make(map[string]int)
+	for playerID, amount := range h.Escrow.Funded {
+		if amount <= 0 {
+			continue
+		}
+		if err := h.awardPayout(playerID, amount, "refund"); err != nil {
+			return err
+		}
+		refunds[playerID] = amount
+	}
 
-	// End the hand
+	h.emitEvent(events.HandVoided{
+		TableID: h.TableID,
+		HandID:  h.ID,
+		Reason:  reason,
+		Refunds: refunds,
+		At:      h.clock().Now(),
+	})
+
+	h.Pot = 0
 	h.TransitionToEndedPhase()
+
+	return nil
+}
+
+// payoutNetBets pays the last player standing only what they matched from each opponent,
+// any bet above the winner's own contribution goes back to the player who made it
+func (h *Hand) payoutNetBets(winnerID string) error {
+	contributions := h.playerContributions()
+	winnerBet := contributions[winnerID]
+
+	returned := 0
+	for playerID, amount := range contributions {
+		if playerID == winnerID || amount <= winnerBet {
+			continue
+		}
+		if err := h.awardPayout(playerID, amount-winnerBet, "uncalled bet returned"); err != nil {
+			return err
+		}
+		returned += amount - winnerBet
+	}
+
+	if err := h.awardPayout(winnerID, h.Pot-returned, "last player standing"); err != nil {
+		return err
+	}
+
+	h.Pot = 0
+	h.TransitionToEndedPhase()
+
+	return nil
+}
+
+// playerContributions sums what each player put in the pot during the hand
+func (h *Hand) playerContributions() map[string]int {
+	contributions := make(map[string]int)
+	for playerID, amount := range h.AntesPaid {
+		if amount > 0 {
+			contributions[playerID] += amount
+		}
+	}
+	for playerID, amount := range h.ContinuationBets {
+		if amount > 0 {
+			contributions[playerID] += amount
+		}
+	}
+	for playerID, amount := range h.DiscardCosts {
+		if amount > 0 {
+			contributions[playerID] += amount
+		}
+	}
+	return contributions
 }
 
 // getPlayerLeftOfButton returns the player ID to the left of the button
@@ -1027,20 +1435,22 @@ func (h *Hand) IsWaitingForBet() bool {
 	}
 }
 
-// BurnCard removes the top card from the deck without revealing it
-func (h *Hand) BurnCard() error {
+// BurnCard removes the top card from the deck without revealing it, ahead of the given community wave
+func (h *Hand) BurnCard(wave int) error {
 	if len(h.Deck) == 0 {
 		return errors.New("no cards left in deck to burn")
 	}
 
-	// Remove top card without using it
-	h.Deck.BurnCard()
+	// Keep the top card face down so every card of the deck stays accounted for
+	h.BurnedCards = append(h.BurnedCards, h.Deck.DealCard())
 
 	// Emit CardBurned event
 	h.emitEvent(events.CardBurned{
-		TableID: h.TableID,
-		HandID:  h.ID,
-		At:      time.Now(),
+		TableID:       h.TableID,
+		HandID:        h.ID,
+		Wave:          wave,
+		DeckRemaining: h.DeckRemaining(),
+		At:            h.clock().Now(),
 	})
 
 	return nil
@@ -1058,7 +1468,13 @@ func (h *Hand) HasEnded() bool {
 	return h.IsInPhase(HandPhase_Ended)
 }
 
-// PrintState is a debugging function to print the current state of the hand in a string, over multiple lines and in a human-readable structured format
+// DeckRemaining returns how many cards are left in the deck, the only thing about the deck that may leave the hand
+func (h *Hand) DeckRemaining() int {
+	return len(h.Deck)
+}
+
+// PrintState is a debugging function to print the current state of the hand in a string, over multiple lines and in a human-readable structured format.
+// Hole cards are masked unless unsafe card logging is on, see logging.SetUnsafeCards.
 func (h *Hand) PrintState() string {
 	output := "Hand State:\n"
 	output += "--------------------------------------------------\n"
@@ -1084,11 +1500,12 @@ func (h *Hand) PrintState() string {
 
 	output += "Hole Cards:\n"
 	for playerID, cards := range h.HoleCards {
-		output += "  - Player: " + playerID + ", Cards: " + cards.String() + "\n"
+		output += "  - Player: " + playerID + ", Cards: " + logging.Cards(cards) + "\n"
 	}
 	output += "\n"
 
 	output += "Community Cards: " + h.CommunityCards.String() + "\n"
+	output += "Deck Remaining: " + fmt.Sprint(h.DeckRemaining()) + "\n"
 	output += "\n"
 
 	output += "Antes Paid:\n"
@@ -1167,7 +1584,7 @@ func (h *Hand) increasePot(amount int) {
 		HandID:         h.ID,
 		PreviousAmount: previousAmount,
 		NewAmount:      h.Pot,
-		At:             time.Now(),
+		At:             h.clock().Now(),
 	})
 }
 
@@ -1181,7 +1598,7 @@ func (h *Hand) decreasePot(amount int) {
 		HandID:         h.ID,
 		PreviousAmount: previousAmount,
 		NewAmount:      h.Pot,
-		At:             time.Now(),
+		At:             h.clock().Now(),
 	})
 }
 
@@ -1195,7 +1612,7 @@ func (h *Hand) resetPot() {
 		HandID:         h.ID,
 		PreviousAmount: previousAmount,
 		NewAmount:      h.Pot,
-		At:             time.Now(),
+		At:             h.clock().Now(),
 	})
 }
 
@@ -1209,12 +1626,50 @@ func (h *Hand) setPot(value int) {
 		HandID:         h.ID,
 		PreviousAmount: previousAmount,
 		NewAmount:      h.Pot,
-		At:             time.Now(),
+		At:             h.clock().Now(),
+	})
+}
+
+// sweepBetsIntoPot announces what each player put in during the betting round that is ending,
+// so clients can animate the chips moving into the pot
+func (h *Hand) sweepBetsIntoPot() {
+	var bets map[string]int
+	switch h.Phase {
+	case HandPhase_Antes:
+		bets = h.AntesPaid
+	case HandPhase_Continuation:
+		bets = h.ContinuationBets
+	case HandPhase_Discard:
+		bets = h.DiscardCosts
+	}
+
+	contributions := make(map[string]int)
+	total := 0
+	for playerID, amount := range bets {
+		if amount > 0 {
+			contributions[playerID] = amount
+			total += amount
+		}
+	}
+
+	h.emitEvent(events.BetsSweptIntoPot{
+		TableID:       h.TableID,
+		HandID:        h.ID,
+		Phase:         string(h.Phase),
+		Contributions: contributions,
+		Total:         total,
+		PotAfter:      h.Pot,
+		At:            h.clock().Now(),
 	})
 }
 
 func (h *Hand) areAllAntesPaid() bool {
-	return len(h.AntesPaid) == len(h.ActivePlayers)
+	for playerID, active := range h.ActivePlayers {
+		if _, paid := h.AntesPaid[playerID]; active && !paid {
+			return false
+		}
+	}
+	return true
 }
 
 func (h *Hand) addToPlayerAntesPaid(playerID string, amount int) {
@@ -1285,7 +1740,7 @@ func (h *Hand) emitShowdownEvents() {
 		TableID:       h.TableID,
 		HandID:        h.ID,
 		ActivePlayers: activePlayers,
-		At:            time.Now(),
+		At:            h.clock().Now(),
 	})
 
 	// Emit PlayerShowedHand event for each active player
@@ -1297,7 +1752,7 @@ func (h *Hand) emitShowdownEvents() {
 				PlayerID:               playerID,
 				HoleCards:              holeCards,
 				SelectedCommunityCards: h.CommunitySelections[playerID],
-				At:                     time.Now(),
+				At:                     h.clock().Now(),
 			})
 		}
 	}
@@ -1327,9 +1782,21 @@ type HandView struct {
 	OtherPlayers   []PlayerView
 	CommunityCards cards.Stack
 
-	Pot       int
-	MyChips   int
-	AnteValue int
+	Pot           int
+	MyChips       int
+	AnteValue     int
+	DeckRemaining int
+	BombPot       bool
+
+	// Table counters
+	HandNumber    int
+	HandsPlayed   int // Hands that ended at the table so far
+	TableOpenedAt time.Time
+
+	// The player's locale and time zone, the Local fields are the times above in that time zone
+	Locale             string
+	TimeZone           string
+	TableOpenedAtLocal string
 
 	ActionTimeout    time.Time      // When the current player's turn will timeout
 	AvailableActions []string       // Actions the player can take now
@@ -1346,9 +1813,11 @@ type PlayerView struct {
 	IsCurrent             bool
 	IsButton              bool
 	HasCards              bool
-	HoleCards             cards.Stack // Will be hidden unless it's the viewing player or showdown
-	AnteStatus            string      // "paid", "not_paid", "folded"
-	ContinuationBetStatus string      // "bet", "not_bet", "folded"
+	HoleCards             cards.Stack    // Will be hidden unless it's the viewing player or showdown
+	AnteStatus            string         // "paid", "not_paid", "folded"
+	ContinuationBetStatus string         // "bet", "not_bet", "folded"
+	Country               string         // Empty if unknown or hidden by the player
+	Session               *PlayerSession // Their results at the table so far, nil if hidden by the player
 }
 
 type PublicEvent struct {
@@ -1370,6 +1839,28 @@ func (h *Hand) BuildPlayerView(playerID string) HandView {
 		CommunityCards: h.CommunityCards,
 		Pot:            h.Pot,
 		AnteValue:      h.TableRules.AnteValue,
+		DeckRemaining:  h.DeckRemaining(),
+		BombPot:        h.BombPot,
+		HandNumber:     h.Number,
+	}
+
+	// Seated players waiting for the next hand aren't in it
+	viewer := h.getPlayerByID(playerID)
+	if viewer == nil && h.Table != nil {
+		if seat := h.Table.seatIndex(playerID); seat != -1 {
+			viewer = h.Table.Players[seat]
+		}
+	}
+	view.TimeZone = viewer.Location().String()
+	if viewer != nil {
+		view.Locale = viewer.Locale
+	}
+
+	if h.Table != nil {
+		clock := h.Table.RunClock()
+		view.HandsPlayed = clock.HandsPlayed
+		view.TableOpenedAt = clock.OpenedAt
+		view.TableOpenedAtLocal = viewer.LocalTime(clock.OpenedAt)
 	}
 
 	// Set player's hole cards if they exist
@@ -1386,7 +1877,12 @@ func (h *Hand) BuildPlayerView(playerID string) HandView {
 	}
 
 	// Set player's role
-	if view.MyPosition == h.ButtonPosition {
+	if !h.HasPlayer(playerID) {
+		view.MyRole = "spectator"
+		if h.Table != nil && h.Table.IsWaitingForNextHand(playerID) {
+			view.MyRole = "waiting"
+		}
+	} else if view.MyPosition == h.ButtonPosition {
 		view.MyRole = "button"
 	} else if h.IsPlayerActive(playerID) {
 		view.MyRole = "active"
@@ -1415,6 +1911,8 @@ func (h *Hand) BuildPlayerView(playerID string) HandView {
 				IsCurrent: h.IsPlayerTheCurrentBettor(player.ID),
 				IsButton:  i == h.ButtonPosition,
 				HasCards:  len(h.HoleCards[player.ID]) > 0,
+				Country:   countryFor(player, playerID),
+				Session:   h.Table.sessionFor(player, playerID),
 			}
 
 			// Only show other players' cards during showdown
@@ -1450,6 +1948,15 @@ func (h *Hand) BuildPlayerView(playerID string) HandView {
 	return view
 }
 
+// BuildSpectatorView constructs a view of the hand for someone watching the table without a seat:
+// every player is listed as another player, hole cards only show at the reveal, and events are public ones
+func (h *Hand) BuildSpectatorView() HandView {
+	view := h.BuildPlayerView("")
+	view.MyPosition = -1
+	view.MyRole = "spectator"
+	return view
+}
+
 // getAvailableActions determines what actions a player can take in the current state
 func (h *Hand) getAvailableActions(playerID string) []string {
 	actions := []string{}
@@ -1458,6 +1965,26 @@ func (h *Hand) getAvailableActions(playerID string) []string {
 		return actions // No actions for inactive players
 	}
 
+	// Community cards are selected by everyone at once, so these actions don't wait for a turn
+	if h.IsInPhase(HandPhase_CommunitySelection) {
+		if len(h.CommunitySelections[playerID]) < h.TableRules.selectionCount() {
+			actions = append(actions, "select_card")
+		}
+		return append(actions, "fold")
+	}
+
+	// Players may concede during the discards too, but only discard on their turn
+	if h.IsInPhase(HandPhase_Discard) {
+		if h.IsPlayerTheCurrentBettor(playerID) && !h.DiscardDecided[playerID] {
+			if h.hasPaidDiscardCost(playerID) {
+				actions = append(actions, "discard_card")
+			} else {
+				actions = append(actions, "pay_discard_cost", "skip_discard")
+			}
+		}
+		return append(actions, "fold")
+	}
+
 	if !h.IsPlayerTheCurrentBettor(playerID) {
 		return actions // No actions when it's not the player's turn
 	}
@@ -1472,21 +1999,41 @@ func (h *Hand) getAvailableActions(playerID string) []string {
 		if !h.hasAlreadyPlacedContinuationBet(playerID) {
 			actions = append(actions, "place_continuation_bet", "fold")
 		}
-
-	case HandPhase_CommunitySelection:
-		// Player can select up to 3 cards
-		if h.CommunitySelections[playerID] == nil || len(h.CommunitySelections[playerID]) < 3 {
-			actions = append(actions, "select_card")
-		}
 	}
 
 	return actions
 }
 
-// filterEventsForPlayer returns events relevant to this player
+// filterEventsForPlayer returns the events this player may see, other players' hole cards
+// and server-only fields taken out
 func (h *Hand) filterEventsForPlayer(playerID string) []events.Event {
-	// TODO: implement filtering, for now and for testing, return all events / perhaps using reflection to filter on PlayerID property and other player id attributes / and perhaps knowing which events are public and which are not
-	_ = playerID
-	allEvent := h.Events
-	return allEvent
+	visible := make([]events.Event, 0, len(h.Events))
+	for _, event := range h.Events {
+		if event, ok := events.VisibleTo(event, playerID); ok {
+			visible = append(visible, event)
+		}
+	}
+	return visible
+}
+
+// turnTimeout returns the time a player has to act in the current phase
+func (h *Hand) turnTimeout() time.Duration {
+	if timeout := h.TableRules.PhaseTimeouts[h.Phase]; timeout > 0 {
+		return timeout
+	}
+	if h.IsInPhase(HandPhase_Discard) {
+		return h.discardTimeLimit()
+	}
+	return h.TableRules.PlayerTimeout
+}
+
+// selectionTimeLimit returns the time players have to pick their community cards
+func (h *Hand) selectionTimeLimit() time.Duration {
+	if limit := h.TableRules.PhaseTimeouts[HandPhase_CommunitySelection]; limit > 0 {
+		return limit
+	}
+	if h.TableRules.SelectionWindow > 0 {
+		return h.TableRules.SelectionWindow
+	}
+	return defaultSelectionTimeLimit
 }
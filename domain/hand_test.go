@@ -1,14 +1,20 @@
 package domain
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
 	"github.com/lazharichir/poker/domain/events"
 	"github.com/lazharichir/poker/domain/hands"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func getDefaultTableRules() TableRules {
@@ -73,6 +79,16 @@ func setupContinuationPhaseHand(numPlayers int) (*Hand, *Table) {
 	return hand, table
 }
 
+// inLoop reads the table's state from its game loop, so the read doesn't race the table's timers
+func inLoop[T any](table *Table, read func() T) T {
+	var value T
+	table.Do("test", func() error {
+		value = read()
+		return nil
+	})
+	return value
+}
+
 // findEventOfType searches for an event of the specified type in the events slice
 func findEventOfType(events []events.Event, eventType string) (events.Event, bool) {
 	for _, event := range events {
@@ -615,6 +631,54 @@ func TestPlayerFolds(t *testing.T) {
 		_, found := findEventOfType(hand.Events, events.SingleWinnerDetermined{}.Name())
 		assert.True(t, found)
 	})
+
+	t.Run("Fold during community selection drops the selections", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupSelectionPhaseHand()
+
+		// Act
+		err := hand.PlayerFolds("player-2")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, hand.IsPlayerActive("player-2"))
+		assert.NotContains(t, hand.CommunitySelections, "player-2")
+		assert.Equal(t, HandPhase_CommunitySelection, hand.Phase)
+		assert.Contains(t, hand.getAvailableActions("player-3"), "fold")
+	})
+
+	t.Run("Fold during community selection moves on once the others have selected", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupSelectionPhaseHand()
+		hand.CommunitySelections["player-2"] = hand.CommunityCards[3:6]
+
+		// Act
+		err := hand.PlayerFolds("player-3")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotEqual(t, HandPhase_CommunitySelection, hand.Phase)
+		_, found := findEventOfType(hand.Events, events.CommunitySelectionEnded{}.Name())
+		assert.True(t, found)
+	})
+
+	t.Run("Last player standing during community selection wins", func(t *testing.T) {
+		// Setup
+		hand, _, _ := setupSelectionPhaseHand()
+
+		// Act
+		assert.NoError(t, hand.PlayerFolds("player-2"))
+		err := hand.PlayerFolds("player-3")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		_, found := findEventOfType(hand.Events, events.CommunitySelectionEnded{}.Name())
+		assert.True(t, found)
+		event, found := findEventOfType(hand.Events, events.SingleWinnerDetermined{}.Name())
+		require.True(t, found)
+		assert.Equal(t, "player-1", event.(events.SingleWinnerDetermined).PlayerID)
+	})
 }
 
 func TestPlayerSelectsCommunityCard(t *testing.T) {
@@ -745,6 +809,47 @@ func TestEvaluateHands(t *testing.T) {
 		// Verify player 1 has higher rank
 		assert.Greater(t, player1Result.HandRank, player2Result.HandRank, "Three of a kind should rank higher than two pair")
 	})
+
+	t.Run("Results are cached until explicitly re-evaluated", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+		hand.Phase = HandPhase_Decision
+		for i, player := range hand.Players {
+			hand.HoleCards[player.ID] = cards.Stack{
+				{Suit: cards.Hearts, Value: []cards.Value{cards.Ace, cards.King}[i]},
+				{Suit: cards.Spades, Value: []cards.Value{cards.Ace, cards.King}[i]},
+			}
+			hand.CommunitySelections[player.ID] = cards.Stack{
+				{Suit: cards.Clubs, Value: cards.Two},
+				{Suit: cards.Diamonds, Value: cards.Five},
+				{Suit: cards.Clubs, Value: cards.Nine},
+			}
+		}
+
+		countEvaluations := func() int {
+			count := 0
+			for _, event := range hand.Events {
+				if event.Name() == (events.HandsEvaluated{}).Name() {
+					count++
+				}
+			}
+			return count
+		}
+
+		// Act
+		first, err := hand.EvaluateHands()
+		require.NoError(t, err)
+		second, err := hand.EvaluateHands()
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, countEvaluations(), "HandsEvaluated is emitted once")
+
+		_, err = hand.ReevaluateHands()
+		require.NoError(t, err)
+		assert.Equal(t, 2, countEvaluations())
+	})
 }
 
 func TestPayout(t *testing.T) {
@@ -775,6 +880,77 @@ func TestPayout(t *testing.T) {
 		t.Skip("Not implemented yet")
 		// Setup for split pot scenario
 	})
+
+	t.Run("Hi-lo pot is split between high and low", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		hand.TableRules.HiLoSplit = true
+		hand.Phase = HandPhase_Payout
+		hand.Pot = 301
+
+		highID := hand.Players[0].ID
+		lowID := hand.Players[1].ID
+		hand.Results = []hands.HandComparisonResult{
+			{PlayerID: highID, IsWinner: true},
+			{PlayerID: lowID, HasQualifyingLow: true, IsLowWinner: true},
+		}
+
+		// Act
+		err := hand.Payout()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1000+151, table.GetPlayerBuyIn(highID), "odd chip goes to the high hand")
+		assert.Equal(t, 1000+150, table.GetPlayerBuyIn(lowID))
+
+		event, found := findEventOfType(hand.Events, events.HandEnded{}.Name())
+		assert.True(t, found)
+		assert.Equal(t, []string{lowID}, event.(events.HandEnded).LowWinners)
+	})
+
+	t.Run("Hi-lo low half is quartered between tied lows", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		hand.TableRules.HiLoSplit = true
+		hand.Phase = HandPhase_Payout
+		hand.Pot = 400
+
+		scoopID := hand.Players[0].ID
+		lowID := hand.Players[1].ID
+		hand.Results = []hands.HandComparisonResult{
+			{PlayerID: scoopID, IsWinner: true, HasQualifyingLow: true, IsLowWinner: true},
+			{PlayerID: lowID, HasQualifyingLow: true, IsLowWinner: true},
+		}
+
+		// Act
+		err := hand.Payout()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1000+300, table.GetPlayerBuyIn(scoopID), "high half plus a quarter")
+		assert.Equal(t, 1000+100, table.GetPlayerBuyIn(lowID), "a quarter")
+	})
+
+	t.Run("Hi-lo without a qualifying low pays the high hand", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		hand.TableRules.HiLoSplit = true
+		hand.Phase = HandPhase_Payout
+		hand.Pot = 300
+
+		highID := hand.Players[0].ID
+		hand.Results = []hands.HandComparisonResult{
+			{PlayerID: highID, IsWinner: true},
+			{PlayerID: hand.Players[1].ID},
+		}
+
+		// Act
+		err := hand.Payout()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1000+300, table.GetPlayerBuyIn(highID))
+	})
 }
 
 func TestBurnCard(t *testing.T) {
@@ -782,10 +958,79 @@ func TestBurnCard(t *testing.T) {
 		hand, _ := setupContinuationPhaseHand(2)
 		initialDeckSize := len(hand.Deck)
 
-		err := hand.BurnCard()
+		err := hand.BurnCard(0)
 
 		assert.NoError(t, err)
 		assert.Equal(t, initialDeckSize-1, len(hand.Deck))
+		assert.Len(t, hand.BurnedCards, 1)
+	})
+}
+
+func TestBurnPolicy(t *testing.T) {
+	// dealCommunity deals the community cards under the given rules, and returns the CardBurned events
+	dealCommunity := func(t *testing.T, policy BurnPolicy, waves []int) (*Hand, []events.CardBurned) {
+		hand, _ := setupContinuationPhaseHand(2)
+		hand.Phase = HandPhase_CommunityDeal
+		hand.TableRules.BurnPolicy = policy
+		hand.TableRules.CommunityWaves = waves
+
+		require.NoError(t, hand.StartDealingCommunityCards())
+
+		burns := []events.CardBurned{}
+		for _, event := range hand.Events {
+			if burn, ok := event.(events.CardBurned); ok {
+				burns = append(burns, burn)
+			}
+		}
+		return hand, burns
+	}
+
+	t.Run("Burns one card before the community deal by default", func(t *testing.T) {
+		// Act
+		hand, burns := dealCommunity(t, "", nil)
+
+		// Assert
+		require.Len(t, burns, 1)
+		assert.Equal(t, 0, burns[0].Wave)
+		assert.Equal(t, 51, burns[0].DeckRemaining)
+		assert.Len(t, hand.CommunityCards, 8)
+		assert.Equal(t, 52, len(hand.Deck)+len(hand.BurnedCards)+len(hand.CommunityCards))
+	})
+
+	t.Run("Burns before each wave", func(t *testing.T) {
+		// Act
+		hand, burns := dealCommunity(t, BurnBeforeEachWave, []int{3, 3, 2})
+
+		// Assert
+		require.Len(t, burns, 3)
+		assert.Equal(t, []int{0, 1, 2}, []int{burns[0].Wave, burns[1].Wave, burns[2].Wave})
+		assert.Equal(t, []int{51, 47, 43}, []int{burns[0].DeckRemaining, burns[1].DeckRemaining, burns[2].DeckRemaining})
+		assert.Len(t, hand.CommunityCards, 8)
+		assert.Len(t, hand.BurnedCards, 3)
+		assert.Equal(t, HandPhase_CommunitySelection, hand.Phase)
+	})
+
+	t.Run("Never burns with the none policy", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+		top := hand.Deck[0]
+
+		// Act
+		hand, burns := dealCommunity(t, BurnNone, []int{4, 4})
+
+		// Assert
+		assert.Empty(t, burns)
+		assert.Empty(t, hand.BurnedCards)
+		assert.Equal(t, top, hand.CommunityCards[0])
+	})
+
+	t.Run("Waves that don't add up to the community cards deal them in one wave", func(t *testing.T) {
+		// Act
+		hand, burns := dealCommunity(t, BurnBeforeEachWave, []int{3, 3})
+
+		// Assert
+		assert.Len(t, burns, 1)
+		assert.Len(t, hand.CommunityCards, 8)
 	})
 }
 
@@ -800,8 +1045,410 @@ func TestHandleView(t *testing.T) {
 		// Test player view construction
 	})
 
+	t.Run("BuildPlayerView only shows the player's own hole cards", func(t *testing.T) {
+		// Setup
+		hand, _ := setupAntesPhaseHand(3)
+		hand.Phase = HandPhase_Hole
+		require.NoError(t, hand.DealHoleCards())
+
+		// Act
+		view := hand.BuildPlayerView("player-1")
+
+		// Assert
+		dealt := 0
+		for _, event := range view.Events {
+			if holeCard, ok := event.(events.HoleCardDealt); ok {
+				assert.Equal(t, "player-1", holeCard.PlayerID)
+				dealt++
+			}
+		}
+		assert.Equal(t, 2, dealt)
+		_, found := findEventOfType(view.Events, events.HoleCardsDealt{}.Name())
+		assert.True(t, found, "public events are kept")
+	})
+
+	t.Run("BuildSpectatorView shows no hole cards and offers no actions", func(t *testing.T) {
+		// Setup
+		hand, _ := setupAntesPhaseHand(3)
+		hand.Phase = HandPhase_Hole
+		require.NoError(t, hand.DealHoleCards())
+
+		// Act
+		view := hand.BuildSpectatorView()
+
+		// Assert
+		assert.Equal(t, -1, view.MyPosition)
+		assert.Equal(t, "spectator", view.MyRole)
+		assert.Empty(t, view.MyHoleCards)
+		assert.Empty(t, view.AvailableActions)
+		assert.Len(t, view.OtherPlayers, 3)
+		for _, player := range view.OtherPlayers {
+			assert.True(t, player.HasCards)
+			assert.Empty(t, player.HoleCards)
+		}
+		_, found := findEventOfType(view.Events, events.HoleCardDealt{}.Name())
+		assert.False(t, found, "hole cards are private")
+		_, found = findEventOfType(view.Events, events.HoleCardsDealt{}.Name())
+		assert.True(t, found, "public events are kept")
+	})
+
 	t.Run("getAvailableActions returns correct actions", func(t *testing.T) {
 		t.Skip("Not implemented yet")
 		// Test available actions in different phases
 	})
 }
+
+func TestShuffleAudit(t *testing.T) {
+	t.Run("Hand commits to its shuffle seed", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		hand := &Hand{
+			ID:      "test-hand-id",
+			TableID: table.ID,
+			Table:   table,
+			Phase:   HandPhase_Start,
+			Players: []*Player{{ID: "player-1"}, {ID: "player-2"}},
+		}
+
+		// Act
+		require.NoError(t, hand.InitializeHand())
+
+		// Assert
+		event, found := findEventOfType(hand.Events, events.DeckShuffled{}.Name())
+		require.True(t, found)
+		shuffled := event.(events.DeckShuffled)
+		assert.Len(t, shuffled.SeedCommitment, 64)
+		assert.Equal(t, hand.SeedCommitment, shuffled.SeedCommitment)
+		assert.True(t, shuffled.SealedSeed.IsEmpty(), "nothing is sealed without an escrow")
+	})
+
+	t.Run("Seeded shuffler reproduces the deal", func(t *testing.T) {
+		// Setup
+		newHand := func() *Hand {
+			return &Hand{
+				ID:       "test-hand-id",
+				Phase:    HandPhase_Start,
+				Players:  []*Player{{ID: "player-1"}, {ID: "player-2"}},
+				Shuffler: cards.NewSeededShuffler([32]byte{42}),
+			}
+		}
+		first, second := newHand(), newHand()
+
+		// Act
+		require.NoError(t, first.InitializeHand())
+		require.NoError(t, second.InitializeHand())
+
+		// Assert
+		assert.Equal(t, first.Deck, second.Deck)
+		assert.Equal(t, first.SeedCommitment, second.SeedCommitment)
+	})
+
+	t.Run("Escrowed seed replays the deck with both key shares", func(t *testing.T) {
+		// Setup
+		publicKey, shareA, shareB, err := escrow.GenerateKeyShares()
+		require.NoError(t, err)
+		seedEscrow, err := escrow.NewEscrow(publicKey)
+		require.NoError(t, err)
+
+		table := NewTestTable()
+		table.SeedEscrow = seedEscrow
+		hand := &Hand{
+			ID:      "test-hand-id",
+			TableID: table.ID,
+			Table:   table,
+			Phase:   HandPhase_Start,
+			Players: []*Player{{ID: "player-1"}, {ID: "player-2"}},
+		}
+
+		// Act
+		require.NoError(t, hand.InitializeHand())
+		seed, err := escrow.Open(hand.SealedSeed, shareA, shareB)
+
+		// Assert
+		require.NoError(t, err)
+		commitment := sha256.Sum256(seed)
+		assert.Equal(t, hand.SeedCommitment, hex.EncodeToString(commitment[:]))
+
+		var fixedSeed [32]byte
+		copy(fixedSeed[:], seed)
+		replayed := cards.ShuffleCardsWithSeed(cards.NewDeck52(), fixedSeed)
+		assert.Equal(t, cards.Stack(replayed), hand.Deck)
+
+		_, err = escrow.Open(hand.SealedSeed, shareA, shareA)
+		assert.Error(t, err, "a single share must not open the seed")
+	})
+}
+
+func TestInitializeHandGuard(t *testing.T) {
+	newHand := func() *Hand {
+		return &Hand{
+			ID:      "test-hand-id",
+			Phase:   HandPhase_Start,
+			Players: []*Player{{ID: "player-1"}, {ID: "player-2"}},
+		}
+	}
+
+	t.Run("A hand is only initialized once", func(t *testing.T) {
+		// Setup
+		hand := newHand()
+		require.NoError(t, hand.InitializeHand())
+		hand.HoleCards["player-1"] = cards.Stack{hand.Deck[0]}
+		deck := hand.Deck
+
+		// Act
+		err := hand.InitializeHand()
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandAlreadyInitialized)
+		assert.Equal(t, deck, hand.Deck)
+		assert.Len(t, hand.HoleCards["player-1"], 1, "hole cards must survive")
+	})
+
+	t.Run("A hand past its start phase cannot be initialized", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+		pot := hand.Pot
+
+		// Act
+		err := hand.InitializeHand()
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandAlreadyInitialized)
+		assert.Equal(t, pot, hand.Pot)
+	})
+
+	t.Run("A reset hand can be initialized again", func(t *testing.T) {
+		// Setup
+		hand := newHand()
+		require.NoError(t, hand.InitializeHand())
+		commitment := hand.SeedCommitment
+
+		// Act
+		require.NoError(t, hand.Reset())
+		err := hand.InitializeHand()
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, commitment, hand.SeedCommitment, "the hand is dealt from a fresh seed")
+		assert.True(t, hand.ActivePlayers["player-1"])
+	})
+
+	t.Run("A hand past its start phase cannot be reset", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+
+		// Act
+		err := hand.Reset()
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandPastStart)
+		assert.NotEmpty(t, hand.Deck)
+	})
+}
+
+func TestDeckRedaction(t *testing.T) {
+	t.Run("Deck cards never appear in outbound payloads", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		hand.Phase = HandPhase_Hole
+		require.NoError(t, hand.DealHoleCards())
+		require.NoError(t, hand.BurnCard(0))
+		require.NotEmpty(t, hand.Deck)
+
+		table.ActiveHand = hand
+		snapshot := table.Snapshot()
+		table.ActiveHand = nil // The table and its hand point to each other, which JSON can't encode
+
+		payloads := map[string]any{
+			"hand":     hand,
+			"snapshot": snapshot,
+		}
+		for _, player := range hand.Players {
+			payloads["view of "+player.ID] = hand.BuildPlayerView(player.ID)
+		}
+		for i, event := range hand.Events {
+			payloads[fmt.Sprintf("event %d (%s)", i, event.Name())] = event
+		}
+
+		// Act
+		encoded := map[string][]byte{}
+		for name, payload := range payloads {
+			data, err := json.Marshal(payload)
+			require.NoError(t, err, name)
+			encoded[name] = data
+		}
+
+		// Assert
+		hidden := append(cards.Stack{}, hand.Deck...)
+		hidden = append(hidden, hand.BurnedCards...)
+		for _, card := range hidden {
+			cardJSON, err := json.Marshal(card)
+			require.NoError(t, err)
+			for name, data := range encoded {
+				assert.False(t, bytes.Contains(data, cardJSON), "%s exposes %s", name, card)
+			}
+		}
+		assert.Contains(t, hand.PrintState(), fmt.Sprintf("Deck Remaining: %d", len(hand.Deck)))
+		assert.NotContains(t, hand.PrintState(), "Cards: "+hand.HoleCards["player-1"].String(), "hole cards are masked")
+		assert.Equal(t, len(hand.Deck), hand.BuildPlayerView("player-1").DeckRemaining)
+	})
+}
+
+func TestBetsSweptIntoPot(t *testing.T) {
+	t.Run("Antes are swept in when the last ante is placed", func(t *testing.T) {
+		// Setup
+		hand, _ := setupAntesPhaseHand(3)
+
+		// Act
+		for i := 0; i < 3; i++ {
+			err := hand.PlayerPlacesAnte(hand.CurrentBettor, hand.TableRules.AnteValue)
+			require.NoError(t, err)
+		}
+
+		// Assert
+		event, found := findEventOfType(hand.Events, events.BetsSweptIntoPot{}.Name())
+		require.True(t, found)
+		sweep := event.(events.BetsSweptIntoPot)
+		assert.Equal(t, string(HandPhase_Antes), sweep.Phase)
+		assert.Len(t, sweep.Contributions, 3)
+		assert.Equal(t, 3*hand.TableRules.AnteValue, sweep.Total)
+		for _, player := range hand.Players {
+			assert.Equal(t, hand.TableRules.AnteValue, sweep.Contributions[player.ID])
+		}
+	})
+}
+
+func TestFoldWinPolicy(t *testing.T) {
+	// setupFoldedHand prepares a heads-up hand where the folder bet more than the winner
+	setupFoldedHand := func(policy FoldWinPolicy) (*Hand, *Table, string, string) {
+		hand, table := setupContinuationPhaseHand(2)
+		hand.TableRules.FoldWinPolicy = policy
+
+		folderID := hand.CurrentBettor
+		winnerID := hand.Players[0].ID
+		if winnerID == folderID {
+			winnerID = hand.Players[1].ID
+		}
+
+		hand.AntesPaid[folderID] = 10
+		hand.AntesPaid[winnerID] = 10
+		hand.ContinuationBets[folderID] = 30
+		hand.ContinuationBets[winnerID] = 10
+		hand.Escrow.Funded = map[string]int{folderID: 40, winnerID: 20}
+		hand.Pot = 60
+
+		return hand, table, folderID, winnerID
+	}
+
+	t.Run("Default policy awards the whole pot", func(t *testing.T) {
+		// Setup
+		hand, table, folderID, winnerID := setupFoldedHand("")
+
+		// Act
+		err := hand.PlayerFolds(folderID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		assert.Equal(t, 0, hand.Pot)
+		assert.Equal(t, 1060, table.BuyIns[winnerID])
+		assert.Equal(t, 1000, table.BuyIns[folderID])
+	})
+
+	t.Run("Refund policy voids the hand", func(t *testing.T) {
+		// Setup
+		hand, table, folderID, winnerID := setupFoldedHand(FoldWinPolicyRefund)
+
+		// Act
+		err := hand.PlayerFolds(folderID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		assert.Equal(t, 0, hand.Pot)
+		assert.Equal(t, 1020, table.BuyIns[winnerID])
+		assert.Equal(t, 1040, table.BuyIns[folderID])
+
+		event, found := findEventOfType(hand.Events, events.HandVoided{}.Name())
+		require.True(t, found)
+		voided := event.(events.HandVoided)
+		assert.Equal(t, map[string]int{winnerID: 20, folderID: 40}, voided.Refunds)
+
+		_, found = findEventOfType(hand.Events, events.SingleWinnerDetermined{}.Name())
+		assert.False(t, found)
+	})
+
+	t.Run("Net bets policy returns what the winner did not match", func(t *testing.T) {
+		// Setup
+		hand, table, folderID, winnerID := setupFoldedHand(FoldWinPolicyAwardNetBets)
+
+		// Act
+		err := hand.PlayerFolds(folderID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		assert.Equal(t, 0, hand.Pot)
+		assert.Equal(t, 1040, table.BuyIns[winnerID])
+		assert.Equal(t, 1020, table.BuyIns[folderID])
+	})
+
+	t.Run("Policies do not apply once community cards are out", func(t *testing.T) {
+		// Setup
+		hand, table, folderID, winnerID := setupFoldedHand(FoldWinPolicyRefund)
+		hand.CommunityCards = cards.Stack{{Suit: cards.Spades, Value: cards.Ace}}
+
+		// Act
+		err := hand.PlayerFolds(folderID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1060, table.BuyIns[winnerID])
+		_, found := findEventOfType(hand.Events, events.HandVoided{}.Name())
+		assert.False(t, found)
+	})
+}
+
+func TestPhaseTimeouts(t *testing.T) {
+	t.Run("Turns use the player timeout by default", func(t *testing.T) {
+		// Setup
+		hand, _ := setupAntesPhaseHand(3)
+
+		// Act
+		err := hand.PlayerPlacesAnte(hand.CurrentBettor, hand.TableRules.AnteValue)
+
+		// Assert
+		require.NoError(t, err)
+		event, found := findEventOfType(hand.Events, events.PlayerTurnStarted{}.Name())
+		require.True(t, found)
+		assert.Equal(t, hand.TableRules.PlayerTimeout, event.(events.PlayerTurnStarted).Timeout)
+	})
+
+	t.Run("Timeouts shrink as the hand progresses", func(t *testing.T) {
+		// Setup
+		hand, _ := setupAntesPhaseHand(2)
+		hand.TableRules.PhaseTimeouts = map[HandPhase]time.Duration{
+			HandPhase_Antes:              20 * time.Second,
+			HandPhase_Continuation:       10 * time.Second,
+			HandPhase_CommunitySelection: 3 * time.Second,
+		}
+
+		// Act
+		for i := 0; i < 2; i++ {
+			require.NoError(t, hand.PlayerPlacesAnte(hand.CurrentBettor, hand.TableRules.AnteValue))
+		}
+		require.NoError(t, hand.DealHoleCards())
+
+		// Assert
+		timeouts := map[string]time.Duration{}
+		for _, event := range hand.Events {
+			if turn, ok := event.(events.PlayerTurnStarted); ok {
+				timeouts[turn.Phase] = turn.Timeout
+				assert.WithinDuration(t, turn.At.Add(turn.Timeout), turn.TimeoutAt, 10*time.Millisecond)
+			}
+		}
+		assert.Equal(t, 20*time.Second, timeouts[string(HandPhase_Antes)])
+		assert.Equal(t, 10*time.Second, timeouts[string(HandPhase_Continuation)])
+		assert.Equal(t, 3*time.Second, hand.selectionTimeLimit())
+	})
+}
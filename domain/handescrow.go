@@ -0,0 +1,62 @@
+package domain
+
+import "github.com/lazharichir/poker/domain/events"
+
+// HandEscrow holds the chips committed to a hand until the hand pays them out or refunds them. Every chip
+// in and out is recorded with an event, so what a crashed hand owed can be rebuilt from the event store.
+type HandEscrow struct {
+	Funded   map[string]int // What each player put in, by player ID
+	Released map[string]int // What each player got out, as winnings or refunds, by player ID
+}
+
+// Balance returns the chips still held
+func (e HandEscrow) Balance() int {
+	balance := 0
+	for _, amount := range e.Funded {
+		balance += amount
+	}
+	for _, amount := range e.Released {
+		balance -= amount
+	}
+	return balance
+}
+
+// fundEscrow takes chips from the player's stack into the hand's escrow
+func (h *Hand) fundEscrow(playerID string, amount int, reason string) {
+	h.Table.DecreasePlayerBuyIn(playerID, amount)
+
+	if h.Escrow.Funded == nil {
+		h.Escrow.Funded = make(map[string]int)
+	}
+	h.Escrow.Funded[playerID] += amount
+
+	h.emitEvent(events.EscrowFunded{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		Amount:   amount,
+		Held:     h.Escrow.Funded[playerID],
+		Reason:   reason,
+		At:       h.clock().Now(),
+	})
+}
+
+// releaseEscrow pays chips out of the hand's escrow onto the player's stack
+func (h *Hand) releaseEscrow(playerID string, amount int, reason string) {
+	if h.Escrow.Released == nil {
+		h.Escrow.Released = make(map[string]int)
+	}
+	h.Escrow.Released[playerID] += amount
+
+	h.Table.IncreasePlayerBuyIn(playerID, amount)
+
+	h.emitEvent(events.EscrowReleased{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		Amount:   amount,
+		Balance:  h.Escrow.Balance(),
+		Reason:   reason,
+		At:       h.clock().Now(),
+	})
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandEscrow(t *testing.T) {
+	t.Run("Antes are held in the escrow", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		bettor := hand.CurrentBettor
+
+		// Act
+		require.NoError(t, hand.PlayerPlacesAnte(bettor, 10))
+
+		// Assert
+		assert.Equal(t, 10, hand.Escrow.Funded[bettor])
+		assert.Equal(t, 10, hand.Escrow.Balance())
+		assert.Equal(t, 990, table.GetPlayerBuyIn(bettor))
+
+		event, found := findEventOfType(hand.Events, events.EscrowFunded{}.Name())
+		require.True(t, found)
+		assert.Equal(t, events.EscrowFunded{
+			ID: event.(events.EscrowFunded).ID, Seq: event.(events.EscrowFunded).Seq, TableID: hand.TableID, HandID: hand.ID, PlayerID: bettor,
+			Amount: 10, Held: 10, Reason: "ante", At: event.(events.EscrowFunded).At,
+		}, event)
+	})
+
+	t.Run("Voiding the hand releases everything held", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		first := hand.CurrentBettor
+		require.NoError(t, hand.PlayerPlacesAnte(first, 10))
+		second := hand.CurrentBettor
+		require.NoError(t, hand.PlayerPlacesAnte(second, 10))
+
+		// Act
+		err := hand.voidHand("test")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, hand.Escrow.Balance())
+		assert.Equal(t, map[string]int{first: 10, second: 10}, hand.Escrow.Released)
+		assert.Equal(t, 1000, table.GetPlayerBuyIn(first))
+		assert.Equal(t, 1000, table.GetPlayerBuyIn(second))
+
+		released := 0
+		for _, event := range hand.Events {
+			if e, ok := event.(events.EscrowReleased); ok {
+				assert.Equal(t, "refund", e.Reason)
+				released++
+			}
+		}
+		assert.Equal(t, 2, released)
+	})
+
+	t.Run("Balance is what was funded less what was released", func(t *testing.T) {
+		// Setup
+		escrow := HandEscrow{
+			Funded:   map[string]int{"player-1": 30, "player-2": 20},
+			Released: map[string]int{"player-1": 45},
+		}
+
+		// Assert
+		assert.Equal(t, 5, escrow.Balance())
+	})
+}
@@ -0,0 +1,151 @@
+package handhistory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+)
+
+var ErrHandNotFound = errors.New("hand not found")
+
+// ErrNoShowdown is returned when explaining a hand that ended without its hands being evaluated
+var ErrNoShowdown = errors.New("hand ended without a showdown")
+
+// Hand is the record of a finished hand, kept for players to review
+type Hand struct {
+	HandID     string
+	TableID    string
+	Players    []string
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Voided     bool
+	Community  cards.Stack            // In layout order
+	HoleCards  map[string]cards.Stack // By player ID
+	Selections map[string][]string    // Community cards picked by each player, in selection order
+	Results    map[string]hands.HandComparisonResult
+	Winners    []string
+	LowWinners []string
+	Payouts    map[string]int // Chips awarded, or refunded when the hand was voided, by player ID
+	FinalPot   int
+	Events     []Entry // Every event of the table from the start to the end of the hand
+}
+
+// Summary is a hand as listed in a table's history
+type Summary struct {
+	HandID    string
+	TableID   string
+	Players   []string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Voided    bool
+	Winners   []string
+	FinalPot  int
+}
+
+// Entry is an event of the hand, serialized with its name so it can be decoded back
+type Entry struct {
+	Event events.Event
+}
+
+func (e Entry) MarshalJSON() ([]byte, error) {
+	encoded, err := events.Encode(e.Event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var encoded events.Encoded
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	event, err := events.Decode(encoded)
+	if err != nil {
+		return err
+	}
+	e.Event = event
+	return nil
+}
+
+// Store keeps the history of finished hands
+type Store interface {
+	// Save records a finished hand, saving the same hand twice keeps the first record
+	Save(ctx context.Context, hand Hand) error
+	// Hand returns a hand by ID, ErrHandNotFound if it is not kept
+	Hand(ctx context.Context, handID string) (Hand, error)
+	// TableHands returns up to limit hands played at a table, most recent first
+	TableHands(ctx context.Context, tableID string, limit int) ([]Summary, error)
+}
+
+// Summary returns the hand as listed in its table's history
+func (h Hand) Summary() Summary {
+	return Summary{
+		HandID:    h.HandID,
+		TableID:   h.TableID,
+		Players:   h.Players,
+		StartedAt: h.StartedAt,
+		EndedAt:   h.EndedAt,
+		Voided:    h.Voided,
+		Winners:   h.Winners,
+		FinalPot:  h.FinalPot,
+	}
+}
+
+// Public returns the hand as anyone may review it: hole cards are only kept for the players who
+// showed them, and events are filtered as they were for spectators during the hand.
+func (h Hand) Public() Hand {
+	showed := map[string]bool{}
+	public := make([]Entry, 0, len(h.Events))
+	for _, entry := range h.Events {
+		event, ok := events.VisibleTo(entry.Event, "")
+		if !ok {
+			continue
+		}
+		if e, ok := event.(events.PlayerShowedHand); ok {
+			showed[e.PlayerID] = true
+		}
+		public = append(public, Entry{Event: event})
+	}
+
+	holeCards := make(map[string]cards.Stack, len(showed))
+	for playerID, stack := range h.HoleCards {
+		if showed[playerID] {
+			holeCards[playerID] = stack
+		}
+	}
+
+	h.Events = public
+	h.HoleCards = holeCards
+	return h
+}
+
+// Explain replays the showdown of the hand from its record: the hole cards and community cards selected
+// by each player whose hand was evaluated. Support uses it to settle disputed pots.
+func (h Hand) Explain() (hands.Explanation, error) {
+	if len(h.Results) == 0 {
+		return hands.Explanation{}, ErrNoShowdown
+	}
+
+	playerCards := make(map[string]cards.Stack, len(h.Results))
+	for playerID := range h.Results {
+		stack := append(cards.Stack{}, h.HoleCards[playerID]...)
+		for _, selected := range h.Selections[playerID] {
+			card, err := cards.CardFromString(selected)
+			if err != nil {
+				return hands.Explanation{}, fmt.Errorf("selection of player %s: %w", playerID, err)
+			}
+			stack = append(stack, card)
+		}
+		playerCards[playerID] = stack
+	}
+
+	return hands.Explain(playerCards), nil
+}
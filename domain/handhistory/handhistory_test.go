@@ -0,0 +1,286 @@
+package handhistory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+	"github.com/lazharichir/poker/domain/projections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	aceOfSpades  = cards.Card{Suit: cards.Spades, Value: cards.Ace}
+	kingOfHearts = cards.Card{Suit: cards.Hearts, Value: cards.King}
+	twoOfClubs   = cards.Card{Suit: cards.Clubs, Value: cards.Two}
+)
+
+// playHand sends the events of a short hand: player-1 shows down and wins, player-2 folds
+func playHand(r *Recorder, tableID string, handID string) {
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, event := range []events.Event{
+		events.HandStarted{TableID: tableID, HandID: handID, Players: []string{"player-1", "player-2"}, At: at},
+		events.DeckShuffled{TableID: tableID, HandID: handID, SeedCommitment: "commitment", SealedSeed: escrow.SealedSeed{Ciphertext: []byte("seed")}, At: at},
+		events.HoleCardDealt{TableID: tableID, HandID: handID, PlayerID: "player-1", Card: aceOfSpades, At: at},
+		events.HoleCardDealt{TableID: tableID, HandID: handID, PlayerID: "player-2", Card: kingOfHearts, At: at},
+		events.CommunityCardDealt{TableID: tableID, HandID: handID, CardIndex: 0, Card: twoOfClubs, At: at},
+		events.CommunityCardSelected{TableID: tableID, HandID: handID, PlayerID: "player-1", Card: twoOfClubs.String(), At: at},
+		events.PlayerFolded{TableID: tableID, HandID: handID, PlayerID: "player-2", At: at},
+		events.PlayerShowedHand{TableID: tableID, HandID: handID, PlayerID: "player-1", HoleCards: cards.Stack{aceOfSpades}, At: at},
+		events.PotAmountAwarded{TableID: tableID, HandID: handID, PlayerID: "player-1", Amount: 40, At: at},
+		events.HandEnded{TableID: tableID, HandID: handID, FinalPot: 40, Winners: []string{"player-1"}, At: at.Add(time.Minute)},
+	} {
+		r.HandleEvent(event)
+	}
+}
+
+// saved waits for the recorder to save a hand
+func saved(t *testing.T, store Store, handID string) Hand {
+	t.Helper()
+
+	var hand Hand
+	require.Eventually(t, func() bool {
+		var err error
+		hand, err = store.Hand(context.Background(), handID)
+		return err == nil
+	}, time.Second, time.Millisecond)
+	return hand
+}
+
+func TestRecorder(t *testing.T) {
+	setup := func(t *testing.T) (*Recorder, *MemoryStore) {
+		store := NewMemoryStore()
+		recorder := NewRecorder(store)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go recorder.Start(ctx)
+		return recorder, store
+	}
+
+	t.Run("Records a finished hand", func(t *testing.T) {
+		// Setup
+		recorder, store := setup(t)
+
+		// Act
+		playHand(recorder, "table-1", "hand-1")
+
+		// Assert
+		hand := saved(t, store, "hand-1")
+		assert.Equal(t, "table-1", hand.TableID)
+		assert.Equal(t, []string{"player-1", "player-2"}, hand.Players)
+		assert.Equal(t, cards.Stack{aceOfSpades}, hand.HoleCards["player-1"])
+		assert.Equal(t, cards.Stack{kingOfHearts}, hand.HoleCards["player-2"])
+		assert.Equal(t, cards.Stack{twoOfClubs}, hand.Community)
+		assert.Equal(t, []string{twoOfClubs.String()}, hand.Selections["player-1"])
+		assert.Equal(t, map[string]int{"player-1": 40}, hand.Payouts)
+		assert.Equal(t, []string{"player-1"}, hand.Winners)
+		assert.Equal(t, time.Minute, hand.EndedAt.Sub(hand.StartedAt))
+		assert.Len(t, hand.Events, 10)
+	})
+
+	t.Run("Hands cut short by the table closing are not kept", func(t *testing.T) {
+		// Setup
+		recorder, store := setup(t)
+
+		// Act
+		recorder.HandleEvent(events.HandStarted{TableID: "table-1", HandID: "hand-1", At: time.Now()})
+		recorder.HandleEvent(events.TableClosed{TableID: "table-1", At: time.Now()})
+		recorder.HandleEvent(events.HandEnded{TableID: "table-1", HandID: "hand-1", At: time.Now()})
+		playHand(recorder, "table-1", "hand-2")
+
+		// Assert
+		saved(t, store, "hand-2")
+		_, err := store.Hand(context.Background(), "hand-1")
+		assert.ErrorIs(t, err, ErrHandNotFound)
+	})
+}
+
+func TestHand(t *testing.T) {
+	t.Run("The public view only shows the hole cards that were shown", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		recorder := NewRecorder(store)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go recorder.Start(ctx)
+		playHand(recorder, "table-1", "hand-1")
+		hand := saved(t, store, "hand-1")
+
+		// Act
+		public := hand.Public()
+
+		// Assert
+		assert.Equal(t, map[string]cards.Stack{"player-1": {aceOfSpades}}, public.HoleCards)
+		for _, entry := range public.Events {
+			assert.NotEqual(t, "HOLE_CARD_DEALT", entry.Event.Name())
+			if shuffle, ok := entry.Event.(events.DeckShuffled); ok {
+				assert.Equal(t, "commitment", shuffle.SeedCommitment)
+				assert.True(t, shuffle.SealedSeed.IsEmpty(), "the sealed seed is for auditors only")
+			}
+		}
+		assert.Len(t, hand.HoleCards, 2, "the record itself is left untouched")
+	})
+
+	t.Run("Events survive a JSON round trip", func(t *testing.T) {
+		// Setup
+		hand := Hand{HandID: "hand-1", Events: []Entry{
+			{Event: events.HoleCardDealt{TableID: "table-1", HandID: "hand-1", PlayerID: "player-1", Card: aceOfSpades}},
+			{Event: events.PotAmountAwarded{TableID: "table-1", HandID: "hand-1", PlayerID: "player-1", Amount: 40}},
+		}}
+
+		// Act
+		data, err := json.Marshal(hand)
+		require.NoError(t, err)
+		var decoded Hand
+		err = json.Unmarshal(data, &decoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, hand.Events, decoded.Events)
+	})
+
+	t.Run("Explains the showdown from the hole cards and selections", func(t *testing.T) {
+		// Setup
+		board := cards.Stack{
+			{Suit: cards.Diamonds, Value: cards.King}, {Suit: cards.Clubs, Value: cards.Nine}, {Suit: cards.Spades, Value: cards.Four},
+		}
+		selections := []string{board[0].String(), board[1].String(), board[2].String()}
+		hand := Hand{
+			HandID:    "hand-1",
+			Community: board,
+			HoleCards: map[string]cards.Stack{
+				"player-1": {kingOfHearts, {Suit: cards.Hearts, Value: cards.Queen}},
+				"player-2": {{Suit: cards.Clubs, Value: cards.King}, {Suit: cards.Hearts, Value: cards.Jack}},
+			},
+			Selections: map[string][]string{"player-1": selections, "player-2": selections},
+			Results:    map[string]hands.HandComparisonResult{"player-1": {PlayerID: "player-1"}, "player-2": {PlayerID: "player-2"}},
+		}
+
+		// Act
+		explanation, err := hand.Explain()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "player-1", explanation.Players[0].PlayerID)
+		assert.Equal(t, hands.OnePair, explanation.Players[0].Rank)
+		assert.Equal(t, hands.RuleKicker, explanation.DecidedBy)
+	})
+
+	t.Run("Hands won without a showdown have nothing to explain", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		recorder := NewRecorder(store)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go recorder.Start(ctx)
+		playHand(recorder, "table-1", "hand-1")
+		hand := saved(t, store, "hand-1")
+
+		// Act
+		_, err := hand.Explain()
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNoShowdown)
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("Lists a table's hands, most recent first", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		ctx := context.Background()
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1"}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-2", TableID: "table-2"}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-3", TableID: "table-1"}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-4", TableID: "table-1"}))
+
+		// Act
+		summaries, err := store.TableHands(ctx, "table-1", 2)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, summaries, 2)
+		assert.Equal(t, "hand-4", summaries[0].HandID)
+		assert.Equal(t, "hand-3", summaries[1].HandID)
+	})
+
+	t.Run("Saving a hand twice keeps the first record", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		ctx := context.Background()
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1", FinalPot: 10}))
+
+		// Act
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1", FinalPot: 20}))
+
+		// Assert
+		hand, err := store.Hand(ctx, "hand-1")
+		require.NoError(t, err)
+		assert.Equal(t, 10, hand.FinalPot)
+		summaries, _ := store.TableHands(ctx, "table-1", 10)
+		assert.Len(t, summaries, 1)
+	})
+}
+
+func TestProjectionStore(t *testing.T) {
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Lists a table's hands, most recent first", func(t *testing.T) {
+		// Setup
+		store := NewProjectionStore(projections.NewMemoryStore())
+		ctx := context.Background()
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1", EndedAt: at}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-2", TableID: "table-10", EndedAt: at.Add(time.Minute)}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-3", TableID: "table-1", EndedAt: at.Add(2 * time.Minute)}))
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-4", TableID: "table-1", EndedAt: at.Add(3 * time.Minute)}))
+
+		// Act
+		summaries, err := store.TableHands(ctx, "table-1", 2)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, summaries, 2)
+		assert.Equal(t, "hand-4", summaries[0].HandID)
+		assert.Equal(t, "hand-3", summaries[1].HandID)
+	})
+
+	t.Run("Saving a hand twice keeps the first record", func(t *testing.T) {
+		// Setup
+		store := NewProjectionStore(projections.NewMemoryStore())
+		ctx := context.Background()
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1", EndedAt: at, FinalPot: 10}))
+
+		// Act
+		require.NoError(t, store.Save(ctx, Hand{HandID: "hand-1", TableID: "table-1", EndedAt: at.Add(time.Minute), FinalPot: 20}))
+
+		// Assert
+		hand, err := store.Hand(ctx, "hand-1")
+		require.NoError(t, err)
+		assert.Equal(t, 10, hand.FinalPot)
+		summaries, _ := store.TableHands(ctx, "table-1", 10)
+		assert.Len(t, summaries, 1)
+		_, err = store.Hand(ctx, "hand-2")
+		assert.ErrorIs(t, err, ErrHandNotFound)
+	})
+
+	t.Run("Reads the index entries of migrated hands, which hold the whole record", func(t *testing.T) {
+		// Setup
+		backing := projections.NewMemoryStore()
+		ctx := context.Background()
+		hand := Hand{HandID: "hand-1", TableID: "table-1", EndedAt: at, Winners: []string{"player-1"}, FinalPot: 40}
+		require.NoError(t, projections.UpsertJSON(ctx, backing, "table-hands/table-1/2025-01-01T12:00:00.000000Z/hand-1", hand))
+
+		// Act
+		summaries, err := NewProjectionStore(backing).TableHands(ctx, "table-1", 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []Summary{hand.Summary()}, summaries)
+	})
+}
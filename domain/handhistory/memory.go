@@ -0,0 +1,62 @@
+package handhistory
+
+import (
+	"context"
+	"sync"
+)
+
+// maxMemoryHands is how many hands the in-memory store keeps, the oldest are dropped first
+const maxMemoryHands = 10000
+
+// MemoryStore is a Store that only lives as long as the process
+type MemoryStore struct {
+	mu    sync.RWMutex
+	hands map[string]Hand
+	order []string // Hand IDs, oldest first
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{hands: make(map[string]Hand)}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, hand Hand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.hands[hand.HandID]; exists {
+		return nil
+	}
+
+	s.hands[hand.HandID] = hand
+	s.order = append(s.order, hand.HandID)
+	if len(s.order) > maxMemoryHands {
+		delete(s.hands, s.order[0])
+		s.order = s.order[1:]
+	}
+	return nil
+}
+
+func (s *MemoryStore) Hand(ctx context.Context, handID string) (Hand, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hand, exists := s.hands[handID]
+	if !exists {
+		return Hand{}, ErrHandNotFound
+	}
+	return hand, nil
+}
+
+func (s *MemoryStore) TableHands(ctx context.Context, tableID string, limit int) ([]Summary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summaries := []Summary{}
+	for i := len(s.order) - 1; i >= 0 && len(summaries) < limit; i-- {
+		if hand := s.hands[s.order[i]]; hand.TableID == tableID {
+			summaries = append(summaries, hand.Summary())
+		}
+	}
+	return summaries, nil
+}
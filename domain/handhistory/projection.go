@@ -0,0 +1,77 @@
+package handhistory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lazharichir/poker/domain/projections"
+)
+
+// Key parts of the hand history records kept in a projections store
+const (
+	handsKey      = "hands"       // hands/<hand ID> holds the hand
+	tableHandsKey = "table-hands" // table-hands/<table ID>/<end time>/<hand ID> holds the hand's summary
+)
+
+// endedAtKeyLayout formats end times so keys sort in time order. Migrations copying hands into the store
+// must write the same format.
+const endedAtKeyLayout = "2006-01-02T15:04:05.000000Z"
+
+// ProjectionStore is a Store keeping hands in a projections store, with an index of each table's hands
+type ProjectionStore struct {
+	store projections.Store
+}
+
+// NewProjectionStore creates a hand history store on top of a projections store
+func NewProjectionStore(store projections.Store) *ProjectionStore {
+	return &ProjectionStore{store: store}
+}
+
+func (s *ProjectionStore) Save(ctx context.Context, hand Hand) error {
+	// Saving the same hand twice keeps the first record
+	if _, err := s.store.Get(ctx, projections.Key(handsKey, hand.HandID)); !errors.Is(err, projections.ErrNotFound) {
+		return err
+	}
+
+	// The index goes first, a hand that is listed but not kept yet is saved again by the next Save
+	if err := projections.UpsertJSON(ctx, s.store, tableHandKey(hand.TableID, hand.EndedAt, hand.HandID), hand.Summary()); err != nil {
+		return err
+	}
+	return projections.UpsertJSON(ctx, s.store, projections.Key(handsKey, hand.HandID), hand)
+}
+
+func (s *ProjectionStore) Hand(ctx context.Context, handID string) (Hand, error) {
+	var hand Hand
+	err := projections.GetJSON(ctx, s.store, projections.Key(handsKey, handID), &hand)
+	if errors.Is(err, projections.ErrNotFound) {
+		return Hand{}, ErrHandNotFound
+	}
+	return hand, err
+}
+
+func (s *ProjectionStore) TableHands(ctx context.Context, tableID string, limit int) ([]Summary, error) {
+	records, err := s.store.Scan(ctx, projections.Prefix(tableHandsKey, tableID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys sort by end time, most recent last
+	slices.Reverse(records)
+
+	summaries := []Summary{}
+	for _, record := range records[:max(min(limit, len(records)), 0)] {
+		var summary Summary
+		if err := json.Unmarshal(record.Value, &summary); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func tableHandKey(tableID string, endedAt time.Time, handID string) string {
+	return projections.Key(tableHandsKey, tableID, endedAt.UTC().Format(endedAtKeyLayout), handID)
+}
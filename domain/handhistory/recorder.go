@@ -0,0 +1,124 @@
+package handhistory
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// recorderBuffer is how many finished hands may wait for the store before the tables are held up
+const recorderBuffer = 256
+
+// Recorder builds the history of each hand from the table's events and saves it once the hand is over.
+// Hands are saved by a single writer, so a slow store never holds up the tables until the buffer is full.
+type Recorder struct {
+	store   Store
+	mu      sync.Mutex
+	playing map[string]*Hand // Hands in progress, by table ID
+	pending chan Hand
+}
+
+// NewRecorder creates a recorder, Start runs its writer
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{
+		store:   store,
+		playing: make(map[string]*Hand),
+		pending: make(chan Hand, recorderBuffer),
+	}
+}
+
+// HandleEvent adds table events to the hand being played, it is meant to be registered as an event handler
+func (r *Recorder) HandleEvent(event events.Event) {
+	tableID := events.ExtractTableID(event)
+	if tableID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	finished, ok := r.record(tableID, event)
+	r.mu.Unlock()
+
+	if ok {
+		r.pending <- finished
+	}
+}
+
+// record adds an event to the table's hand, and returns the hand once it is over
+func (r *Recorder) record(tableID string, event events.Event) (Hand, bool) {
+	if started, ok := event.(events.HandStarted); ok {
+		r.playing[tableID] = &Hand{
+			HandID:     started.HandID,
+			TableID:    tableID,
+			Players:    started.Players,
+			StartedAt:  started.At,
+			HoleCards:  make(map[string]cards.Stack),
+			Selections: make(map[string][]string),
+			Payouts:    make(map[string]int),
+		}
+	}
+
+	hand, playing := r.playing[tableID]
+	if !playing {
+		return Hand{}, false
+	}
+	hand.Events = append(hand.Events, Entry{Event: event})
+
+	switch e := event.(type) {
+	case events.HoleCardDealt:
+		hand.HoleCards[e.PlayerID] = append(hand.HoleCards[e.PlayerID], e.Card)
+	case events.CommunityCardDealt:
+		for len(hand.Community) <= e.CardIndex {
+			hand.Community = append(hand.Community, cards.Card{})
+		}
+		hand.Community[e.CardIndex] = e.Card
+	case events.CommunityCardSelected:
+		hand.Selections[e.PlayerID] = append(hand.Selections[e.PlayerID], e.Card)
+	case events.HandsEvaluated:
+		hand.Results = e.Results
+	case events.PotAmountAwarded:
+		hand.Payouts[e.PlayerID] += e.Amount
+	case events.HandEnded:
+		hand.EndedAt = e.At
+		hand.Winners = e.Winners
+		hand.LowWinners = e.LowWinners
+		hand.FinalPot = e.FinalPot
+		delete(r.playing, tableID)
+		return *hand, true
+	case events.HandVoided:
+		// Refunds were awarded one by one, the hand still ends as usual
+		hand.Voided = true
+	case events.TableClosed:
+		// Hands cut short by the table closing are not kept
+		delete(r.playing, tableID)
+	}
+
+	return Hand{}, false
+}
+
+// Start saves finished hands to the store until ctx is done, then saves the hands already finished and returns
+func (r *Recorder) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case hand := <-r.pending:
+					r.save(context.Background(), hand)
+				default:
+					return
+				}
+			}
+		case hand := <-r.pending:
+			r.save(ctx, hand)
+		}
+	}
+}
+
+func (r *Recorder) save(ctx context.Context, hand Hand) {
+	if err := r.store.Save(ctx, hand); err != nil {
+		log.Printf("Could not save the history of hand %s at table %s: %v", hand.HandID, hand.TableID, err)
+	}
+}
@@ -1,7 +1,10 @@
 package hands
 
 import (
+	"fmt"
+	"runtime"
 	"sort"
+	"sync"
 
 	"github.com/lazharichir/poker/domain/cards"
 )
@@ -22,6 +25,27 @@ const (
 	RoyalFlush
 )
 
+var handRankNames = map[HandRank]string{
+	HighCard:      "High Card",
+	OnePair:       "One Pair",
+	TwoPair:       "Two Pair",
+	ThreeOfAKind:  "Three of a Kind",
+	Straight:      "Straight",
+	Flush:         "Flush",
+	FullHouse:     "Full House",
+	FourOfAKind:   "Four of a Kind",
+	StraightFlush: "Straight Flush",
+	RoyalFlush:    "Royal Flush",
+}
+
+// String returns the name of the hand rank, e.g. "Full House"
+func (r HandRank) String() string {
+	if name, ok := handRankNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("HandRank(%d)", int(r))
+}
+
 // HandEvaluation represents the evaluation of a poker hand
 type HandEvaluation struct {
 	Rank      HandRank    // The hand rank (pair, flush, etc.)
@@ -31,22 +55,24 @@ type HandEvaluation struct {
 
 // valueToRank converts card values to numerical ranks (2=2, A=14)
 func valueToRank(value cards.Value) int {
-	valueMap := map[cards.Value]int{
-		cards.Two:   2,
-		cards.Three: 3,
-		cards.Four:  4,
-		cards.Five:  5,
-		cards.Six:   6,
-		cards.Seven: 7,
-		cards.Eight: 8,
-		cards.Nine:  9,
-		cards.Ten:   10,
-		cards.Jack:  11,
-		cards.Queen: 12,
-		cards.King:  13,
-		cards.Ace:   14,
-	}
-	return valueMap[value]
+	return valueRanks[value]
+}
+
+// valueRanks is built once, valueToRank runs for every card of every hand evaluated
+var valueRanks = map[cards.Value]int{
+	cards.Two:   2,
+	cards.Three: 3,
+	cards.Four:  4,
+	cards.Five:  5,
+	cards.Six:   6,
+	cards.Seven: 7,
+	cards.Eight: 8,
+	cards.Nine:  9,
+	cards.Ten:   10,
+	cards.Jack:  11,
+	cards.Queen: 12,
+	cards.King:  13,
+	cards.Ace:   14,
 }
 
 // sortCardsByRank sorts cards by rank in descending order
@@ -671,40 +697,80 @@ type HandComparisonResult struct {
 	HandCards  cards.Stack
 	IsWinner   bool
 	PlaceIndex int // 0 for first place, 1 for second place, etc.
+
+	// Hi-lo split only, see CompareHandsHiLo
+	HasQualifyingLow bool
+	LowCards         cards.Stack
+	IsLowWinner      bool
 }
 
-// compareHands compares multiple player hands and determines winners
-// playerCards is a map of player ID to their available cards
-// Returns the comparison results sorted by hand strength (best first)
-func CompareHands(playerCards map[string]cards.Stack) []HandComparisonResult {
-	if len(playerCards) == 0 {
-		return nil
-	}
+type playerHandEval struct {
+	playerID string
+	bestHand BestHandEvaluation
+}
 
-	type playerHandEval struct {
-		playerID string
-		bestHand BestHandEvaluation
+// bestHands finds the best hand of each player, in player ID order. Big showdowns are evaluated
+// in parallel by a pool of up to GOMAXPROCS workers.
+func bestHands(playerCards map[string]cards.Stack) []playerHandEval {
+	playerIDs := make([]string, 0, len(playerCards))
+	for playerID := range playerCards {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	// Each worker writes to the slots of the players it evaluates, which keeps the order
+	evaluated := make([][]BestHandEvaluation, len(playerIDs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(playerIDs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				evaluated[i] = ListAllPossibleHands(playerCards[playerIDs[i]])
+			}
+		}()
 	}
+	for i := range playerIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-	// Calculate best hand for each player
-	playerHands := make([]playerHandEval, 0, len(playerCards))
-	for playerID, cards := range playerCards {
-		possibleHands := ListAllPossibleHands(cards)
+	playerHands := make([]playerHandEval, 0, len(playerIDs))
+	for i, possibleHands := range evaluated {
 		if len(possibleHands) > 0 {
 			playerHands = append(playerHands, playerHandEval{
-				playerID: playerID,
+				playerID: playerIDs[i],
 				bestHand: possibleHands[0], // First hand is the best one due to sorting
 			})
 		}
 	}
+	return playerHands
+}
 
-	// Sort players by hand strength
-	sort.Slice(playerHands, func(i, j int) bool {
+// rankedBestHands finds the best hand of each player, sorted by hand strength (best first).
+// Ties are in player ID order so results don't depend on map iteration.
+func rankedBestHands(playerCards map[string]cards.Stack) []playerHandEval {
+	playerHands := bestHands(playerCards)
+	sort.SliceStable(playerHands, func(i, j int) bool {
 		return compareHandEvaluations(
 			playerHands[i].bestHand.Evaluation,
 			playerHands[j].bestHand.Evaluation,
 		) > 0
 	})
+	return playerHands
+}
+
+// compareHands compares multiple player hands and determines winners
+// playerCards is a map of player ID to their available cards
+// Returns the comparison results sorted by hand strength (best first)
+func CompareHands(playerCards map[string]cards.Stack) []HandComparisonResult {
+	if len(playerCards) == 0 {
+		return nil
+	}
+
+	playerHands := rankedBestHands(playerCards)
 
 	// Create results with place indices
 	results := make([]HandComparisonResult, len(playerHands))
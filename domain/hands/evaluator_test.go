@@ -1,6 +1,7 @@
 package hands
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/lazharichir/poker/domain/cards"
@@ -234,3 +235,48 @@ func TestCompareHands_HighestThreeOfAKindWins(t *testing.T) {
 	assert.Equal(t, ThreeOfAKind, result[1].HandRank)
 	assert.False(t, result[1].IsWinner)
 }
+
+func TestCompareHands_TiesAreOrderedByPlayerID(t *testing.T) {
+	playerCards := map[string]cards.Stack{}
+	for _, playerID := range []string{"player4", "player2", "player3", "player1"} {
+		playerCards[playerID] = cards.Stack{
+			{Suit: cards.Hearts, Value: cards.Ace},
+			{Suit: cards.Spades, Value: cards.King},
+			{Suit: cards.Hearts, Value: cards.Nine},
+			{Suit: cards.Diamonds, Value: cards.Seven},
+			{Suit: cards.Clubs, Value: cards.Four},
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		result := CompareHands(playerCards)
+
+		assert.Equal(t, 4, len(result), "Expected 4 results")
+		for place, playerID := range []string{"player1", "player2", "player3", "player4"} {
+			assert.Equal(t, playerID, result[place].PlayerID)
+			assert.True(t, result[place].IsWinner)
+		}
+	}
+}
+
+// BenchmarkCompareHands_NinePlayers benchmarks a full table showdown, each player holding
+// two hole cards and the community cards they may pick from
+func BenchmarkCompareHands_NinePlayers(b *testing.B) {
+	for _, cardsPerPlayer := range []int{5, 7} {
+		b.Run(fmt.Sprintf("%d cards", cardsPerPlayer), func(b *testing.B) {
+			// Two decks, so nine players get seven cards each
+			deck := append(cards.NewDeck52(), cards.NewDeck52()...)
+			deck.ShuffleWithSeed([32]byte{1})
+
+			playerCards := map[string]cards.Stack{}
+			for p := 0; p < 9; p++ {
+				playerCards[fmt.Sprintf("player%d", p+1)] = deck[p*cardsPerPlayer : (p+1)*cardsPerPlayer]
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				CompareHands(playerCards)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package hands
+
+import (
+	"fmt"
+
+	"github.com/lazharichir/poker/domain/cards"
+)
+
+// Rules a showdown is decided by, see Explanation.DecidedBy. Ties within a rank are broken by
+// the values the rank is compared on, named after what they are: "pair", "three of a kind", "kicker"...
+const (
+	RuleRank   = "rank"   // The hand ranks differ, e.g. a flush beats a straight
+	RuleKicker = "kicker" // The cards left outside the hand's pairs, trips or quads
+	RuleTie    = "tie"    // The hands are equal, the pot is split
+)
+
+// tiebreakRules names the values hands of each rank are compared on, in the order they are compared
+var tiebreakRules = map[HandRank][]string{
+	StraightFlush: {"high card"},
+	FourOfAKind:   {"four of a kind", RuleKicker},
+	FullHouse:     {"three of a kind", "pair"},
+	Flush:         {"high card", RuleKicker, RuleKicker, RuleKicker, RuleKicker},
+	Straight:      {"high card"},
+	ThreeOfAKind:  {"three of a kind", RuleKicker, RuleKicker},
+	TwoPair:       {"high pair", "low pair", RuleKicker},
+	OnePair:       {"pair", RuleKicker, RuleKicker, RuleKicker},
+	HighCard:      {"high card", RuleKicker, RuleKicker, RuleKicker, RuleKicker},
+}
+
+// rankValueNames names the values kickers hold, a 5-high straight counts its ace as 5
+var rankValueNames = map[int]string{
+	2: "Two", 3: "Three", 4: "Four", 5: "Five", 6: "Six", 7: "Seven", 8: "Eight",
+	9: "Nine", 10: "Ten", 11: "Jack", 12: "Queen", 13: "King", 14: "Ace",
+}
+
+// PlayerExplanation is a player's best hand as the evaluator chose it
+type PlayerExplanation struct {
+	PlayerID   string
+	Rank       HandRank
+	RankName   string      // e.g. "Full House"
+	Cards      cards.Stack // The 5 cards of the hand, as in the hand's results
+	Kickers    []int       // Values the hand is compared on within its rank, from 2 to 14 (ace)
+	PlaceIndex int         // 0 for first place, players who tie share their place
+	IsWinner   bool
+}
+
+// ComparisonStep is one comparison between the top two hands: their ranks, then the values the rank is compared on
+type ComparisonStep struct {
+	Rule        string // RuleRank, or what the compared values are, e.g. "pair" or RuleKicker
+	First       int    // The first hand's value: its HandRank for the rank step, a card value otherwise
+	Second      int    // The second hand's value
+	Result      int    // 1 if the first hand wins the step, -1 if the second does, 0 if they are equal
+	Description string // e.g. "kicker: Queen beats Jack"
+}
+
+// Explanation shows how a showdown was decided: the hand chosen for each player and the steps
+// that ordered the top two hands
+type Explanation struct {
+	Players   []PlayerExplanation // Best hand first, as CompareHands orders them
+	Steps     []ComparisonStep    // Up to the step that told the top two hands apart, every step on a tie
+	DecidedBy string              // Rule of the deciding step, RuleTie if the top two hands are equal, empty with one player
+}
+
+// Explain evaluates the players' hands the way CompareHands does, and explains the outcome.
+// Hi-lo tables split the pot with CompareHandsHiLo, Explain only covers the high hands.
+func Explain(playerCards map[string]cards.Stack) Explanation {
+	playerHands := rankedBestHands(playerCards)
+
+	explanation := Explanation{Players: make([]PlayerExplanation, 0, len(playerHands)), Steps: []ComparisonStep{}}
+	placeIndex := 0
+	for i, player := range playerHands {
+		evaluation := player.bestHand.Evaluation
+		if i > 0 && compareHandEvaluations(evaluation, playerHands[i-1].bestHand.Evaluation) != 0 {
+			placeIndex = i
+		}
+
+		explanation.Players = append(explanation.Players, PlayerExplanation{
+			PlayerID:   player.playerID,
+			Rank:       evaluation.Rank,
+			RankName:   evaluation.Rank.String(),
+			Cards:      player.bestHand.Cards,
+			Kickers:    evaluation.Kickers,
+			PlaceIndex: placeIndex,
+			IsWinner:   placeIndex == 0,
+		})
+	}
+
+	if len(playerHands) < 2 {
+		return explanation
+	}
+
+	explanation.Steps, explanation.DecidedBy = comparisonSteps(playerHands[0].bestHand.Evaluation, playerHands[1].bestHand.Evaluation)
+	return explanation
+}
+
+// comparisonSteps replays compareHandEvaluations step by step, and returns the rule of the deciding step
+func comparisonSteps(first, second HandEvaluation) ([]ComparisonStep, string) {
+	rankStep := ComparisonStep{
+		Rule:   RuleRank,
+		First:  int(first.Rank),
+		Second: int(second.Rank),
+		Result: compareInt(int(first.Rank), int(second.Rank)),
+	}
+	rankStep.Description = describeStep(RuleRank, first.Rank.String(), second.Rank.String(), rankStep.Result)
+
+	steps := []ComparisonStep{rankStep}
+	if rankStep.Result != 0 {
+		return steps, RuleRank
+	}
+
+	// Hands of the same rank are compared value by value, the first difference decides
+	for i, rule := range tiebreakRules[first.Rank] {
+		if i >= len(first.Kickers) || i >= len(second.Kickers) {
+			break
+		}
+
+		step := ComparisonStep{
+			Rule:   rule,
+			First:  first.Kickers[i],
+			Second: second.Kickers[i],
+			Result: compareInt(first.Kickers[i], second.Kickers[i]),
+		}
+		step.Description = describeStep(rule, rankValueNames[step.First], rankValueNames[step.Second], step.Result)
+		steps = append(steps, step)
+
+		if step.Result != 0 {
+			return steps, rule
+		}
+	}
+
+	return steps, RuleTie
+}
+
+func describeStep(rule string, first string, second string, result int) string {
+	switch {
+	case result > 0:
+		return fmt.Sprintf("%s: %s beats %s", rule, first, second)
+	case result < 0:
+		return fmt.Sprintf("%s: %s loses to %s", rule, first, second)
+	default:
+		return fmt.Sprintf("%s: both %s", rule, first)
+	}
+}
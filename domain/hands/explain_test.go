@@ -0,0 +1,103 @@
+package hands
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func card(value cards.Value, suit cards.Suit) cards.Card {
+	return cards.Card{Suit: suit, Value: value}
+}
+
+func TestExplain(t *testing.T) {
+	t.Run("Decided by rank", func(t *testing.T) {
+		// Setup
+		playerCards := map[string]cards.Stack{
+			"player1": { // Flush
+				card(cards.Ace, cards.Hearts), card(cards.Nine, cards.Hearts), card(cards.Seven, cards.Hearts),
+				card(cards.Four, cards.Hearts), card(cards.Two, cards.Hearts),
+			},
+			"player2": { // Straight
+				card(cards.Nine, cards.Spades), card(cards.Eight, cards.Clubs), card(cards.Seven, cards.Diamonds),
+				card(cards.Six, cards.Spades), card(cards.Five, cards.Hearts),
+			},
+		}
+
+		// Act
+		explanation := Explain(playerCards)
+
+		// Assert
+		require.Len(t, explanation.Players, 2)
+		assert.Equal(t, "player1", explanation.Players[0].PlayerID)
+		assert.Equal(t, "Flush", explanation.Players[0].RankName)
+		assert.True(t, explanation.Players[0].IsWinner)
+		assert.False(t, explanation.Players[1].IsWinner)
+		assert.Equal(t, RuleRank, explanation.DecidedBy)
+		require.Len(t, explanation.Steps, 1)
+		assert.Equal(t, "rank: Flush beats Straight", explanation.Steps[0].Description)
+	})
+
+	t.Run("Decided by a kicker out of the best 5 cards", func(t *testing.T) {
+		// Setup
+		board := cards.Stack{card(cards.King, cards.Spades), card(cards.Nine, cards.Diamonds), card(cards.Four, cards.Clubs)}
+		playerCards := map[string]cards.Stack{
+			"player1": append(cards.Stack{card(cards.King, cards.Hearts), card(cards.Queen, cards.Clubs), card(cards.Two, cards.Spades)}, board...),
+			"player2": append(cards.Stack{card(cards.King, cards.Clubs), card(cards.Jack, cards.Hearts), card(cards.Three, cards.Spades)}, board...),
+		}
+
+		// Act
+		explanation := Explain(playerCards)
+
+		// Assert
+		assert.Equal(t, "player1", explanation.Players[0].PlayerID)
+		assert.Len(t, explanation.Players[0].Cards, 5)
+		assert.NotContains(t, explanation.Players[0].Cards, card(cards.Two, cards.Spades), "the 2 doesn't play")
+		assert.Equal(t, RuleKicker, explanation.DecidedBy)
+		require.Len(t, explanation.Steps, 3)
+		assert.Equal(t, "pair: both King", explanation.Steps[1].Description)
+		assert.Equal(t, "kicker: Queen beats Jack", explanation.Steps[2].Description)
+	})
+
+	t.Run("Ties share first place", func(t *testing.T) {
+		// Setup
+		board := cards.Stack{
+			card(cards.Ace, cards.Spades), card(cards.King, cards.Spades), card(cards.Queen, cards.Hearts),
+			card(cards.Jack, cards.Diamonds), card(cards.Ten, cards.Clubs),
+		}
+		playerCards := map[string]cards.Stack{
+			"player1": append(cards.Stack{card(cards.Two, cards.Hearts)}, board...),
+			"player2": append(cards.Stack{card(cards.Three, cards.Hearts)}, board...),
+		}
+
+		// Act
+		explanation := Explain(playerCards)
+
+		// Assert
+		assert.Equal(t, RuleTie, explanation.DecidedBy)
+		assert.True(t, explanation.Players[0].IsWinner)
+		assert.True(t, explanation.Players[1].IsWinner)
+		assert.Equal(t, 0, explanation.Players[1].PlaceIndex)
+	})
+
+	t.Run("Agrees with CompareHands", func(t *testing.T) {
+		// Setup
+		deck := cards.NewDeck52()
+		playerCards := map[string]cards.Stack{"player1": deck[0:7], "player2": deck[7:14], "player3": deck[14:21]}
+
+		// Act
+		explanation := Explain(playerCards)
+		results := CompareHands(playerCards)
+
+		// Assert
+		require.Len(t, explanation.Players, len(results))
+		for i, result := range results {
+			assert.Equal(t, result.PlayerID, explanation.Players[i].PlayerID)
+			assert.Equal(t, result.HandCards, explanation.Players[i].Cards)
+			assert.Equal(t, result.IsWinner, explanation.Players[i].IsWinner)
+			assert.Equal(t, result.PlaceIndex, explanation.Players[i].PlaceIndex)
+		}
+	})
+}
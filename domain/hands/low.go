@@ -0,0 +1,117 @@
+package hands
+
+import (
+	"sort"
+
+	"github.com/lazharichir/poker/domain/cards"
+)
+
+// LowQualifier is the highest card a qualifying low may contain (8-or-better)
+const LowQualifier = 8
+
+// LowEvaluation represents a qualifying ace-to-five low hand
+type LowEvaluation struct {
+	HandCards cards.Stack // The 5 cards that make up the low
+	Ranks     []int       // Card ranks with aces low (A=1), highest first
+}
+
+// lowRank converts a card value to its ace-to-five lowball rank (A=1, K=13)
+func lowRank(value cards.Value) int {
+	if value == cards.Ace {
+		return 1
+	}
+	return valueToRank(value)
+}
+
+// evaluateLow checks if 5 cards make a qualifying low: five distinct ranks, none above eight.
+// Straights and flushes don't count against a low.
+func evaluateLow(hand cards.Stack) (LowEvaluation, bool) {
+	if len(hand) != 5 {
+		return LowEvaluation{}, false
+	}
+
+	seen := make(map[int]bool, 5)
+	ranks := make([]int, 0, 5)
+	for _, card := range hand {
+		rank := lowRank(card.Value)
+		if rank > LowQualifier || seen[rank] {
+			return LowEvaluation{}, false
+		}
+		seen[rank] = true
+		ranks = append(ranks, rank)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
+
+	return LowEvaluation{HandCards: hand, Ranks: ranks}, true
+}
+
+// compareLows compares two lows: returns 1 if low1 is better (lower), -1 if low2 is better, 0 if tied
+func compareLows(low1, low2 LowEvaluation) int {
+	for i := 0; i < len(low1.Ranks) && i < len(low2.Ranks); i++ {
+		if low1.Ranks[i] != low2.Ranks[i] {
+			return compareInt(low2.Ranks[i], low1.Ranks[i])
+		}
+	}
+	return 0
+}
+
+// BestLow finds the best qualifying low among all 5-card combinations of the given cards
+func BestLow(cardSet cards.Stack) (LowEvaluation, bool) {
+	var best LowEvaluation
+	found := false
+
+	for _, combo := range combinations(len(cardSet), 5) {
+		hand := make(cards.Stack, 5)
+		for i, idx := range combo {
+			hand[i] = cardSet[idx]
+		}
+
+		low, ok := evaluateLow(hand)
+		if !ok {
+			continue
+		}
+
+		if !found || compareLows(low, best) > 0 {
+			best = low
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// CompareHandsHiLo compares hands for a hi-lo split pot. High results are the same as
+// CompareHands, and each result also carries the player's qualifying low, if any,
+// with IsLowWinner set for the best low(s).
+func CompareHandsHiLo(playerCards map[string]cards.Stack) []HandComparisonResult {
+	results := CompareHands(playerCards)
+
+	var bestLow LowEvaluation
+	found := false
+	lows := make(map[string]LowEvaluation, len(results))
+
+	for i := range results {
+		low, ok := BestLow(playerCards[results[i].PlayerID])
+		if !ok {
+			continue
+		}
+
+		lows[results[i].PlayerID] = low
+		results[i].HasQualifyingLow = true
+		results[i].LowCards = low.HandCards
+
+		if !found || compareLows(low, bestLow) > 0 {
+			bestLow = low
+			found = true
+		}
+	}
+
+	for i := range results {
+		if low, ok := lows[results[i].PlayerID]; ok && compareLows(low, bestLow) == 0 {
+			results[i].IsLowWinner = true
+		}
+	}
+
+	return results
+}
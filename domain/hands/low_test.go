@@ -0,0 +1,104 @@
+package hands
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBestLow(t *testing.T) {
+	t.Run("Wheel is the best low", func(t *testing.T) {
+		low, ok := BestLow(cards.Stack{
+			{Suit: cards.Hearts, Value: cards.Ace},
+			{Suit: cards.Hearts, Value: cards.Two},
+			{Suit: cards.Hearts, Value: cards.Three},
+			{Suit: cards.Hearts, Value: cards.Four},
+			{Suit: cards.Hearts, Value: cards.Five},
+		})
+
+		assert.True(t, ok, "straights and flushes don't spoil a low")
+		assert.Equal(t, []int{5, 4, 3, 2, 1}, low.Ranks)
+	})
+
+	t.Run("Nine high does not qualify", func(t *testing.T) {
+		_, ok := BestLow(cards.Stack{
+			{Suit: cards.Hearts, Value: cards.Ace},
+			{Suit: cards.Spades, Value: cards.Two},
+			{Suit: cards.Hearts, Value: cards.Three},
+			{Suit: cards.Clubs, Value: cards.Four},
+			{Suit: cards.Hearts, Value: cards.Nine},
+		})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Paired cards do not qualify", func(t *testing.T) {
+		_, ok := BestLow(cards.Stack{
+			{Suit: cards.Hearts, Value: cards.Ace},
+			{Suit: cards.Spades, Value: cards.Ace},
+			{Suit: cards.Hearts, Value: cards.Three},
+			{Suit: cards.Clubs, Value: cards.Four},
+			{Suit: cards.Hearts, Value: cards.Five},
+		})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Picks the lowest five of seven cards", func(t *testing.T) {
+		low, ok := BestLow(cards.Stack{
+			{Suit: cards.Hearts, Value: cards.King},
+			{Suit: cards.Spades, Value: cards.Eight},
+			{Suit: cards.Hearts, Value: cards.Seven},
+			{Suit: cards.Clubs, Value: cards.Four},
+			{Suit: cards.Hearts, Value: cards.Three},
+			{Suit: cards.Diamonds, Value: cards.Two},
+			{Suit: cards.Clubs, Value: cards.Ace},
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, []int{7, 4, 3, 2, 1}, low.Ranks)
+	})
+}
+
+func TestCompareHandsHiLo(t *testing.T) {
+	playerCards := map[string]cards.Stack{
+		"high": { // Flush, no low
+			{Suit: cards.Hearts, Value: cards.King},
+			{Suit: cards.Hearts, Value: cards.Jack},
+			{Suit: cards.Hearts, Value: cards.Nine},
+			{Suit: cards.Hearts, Value: cards.Six},
+			{Suit: cards.Hearts, Value: cards.Two},
+		},
+		"seven-low": {
+			{Suit: cards.Spades, Value: cards.Seven},
+			{Suit: cards.Clubs, Value: cards.Five},
+			{Suit: cards.Spades, Value: cards.Four},
+			{Suit: cards.Clubs, Value: cards.Two},
+			{Suit: cards.Diamonds, Value: cards.Ace},
+		},
+		"eight-low": {
+			{Suit: cards.Spades, Value: cards.Eight},
+			{Suit: cards.Clubs, Value: cards.Three},
+			{Suit: cards.Spades, Value: cards.Two},
+			{Suit: cards.Clubs, Value: cards.Four},
+			{Suit: cards.Diamonds, Value: cards.Six},
+		},
+	}
+
+	results := CompareHandsHiLo(playerCards)
+
+	byPlayer := make(map[string]HandComparisonResult)
+	for _, r := range results {
+		byPlayer[r.PlayerID] = r
+	}
+
+	assert.True(t, byPlayer["high"].IsWinner)
+	assert.False(t, byPlayer["high"].HasQualifyingLow)
+
+	assert.True(t, byPlayer["seven-low"].IsLowWinner)
+	assert.Len(t, byPlayer["seven-low"].LowCards, 5)
+
+	assert.True(t, byPlayer["eight-low"].HasQualifyingLow)
+	assert.False(t, byPlayer["eight-low"].IsLowWinner)
+}
@@ -0,0 +1,235 @@
+// Package schematest freezes the wire format of commands and events in golden files.
+//
+// Each message type is reduced to its JSON shape (field names and types, not values) and compared
+// with the golden file. When a change is intended, run the tests with -update. Changing or removing
+// a message also needs a note in the package's testdata/migrations.md, on a line starting with
+// "- <MESSAGE_NAME>:", so clients know how to migrate. New messages need no note.
+package schematest
+
+import (
+	"bufio"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Message is a command or an event
+type Message interface {
+	Name() string
+}
+
+// Describe returns the JSON shape of a value's type
+func Describe(value any) any {
+	return describeType(reflect.TypeOf(value), map[reflect.Type]bool{})
+}
+
+func describeType(t reflect.Type, seen map[reflect.Type]bool) any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return describeType(t.Elem(), seen)
+
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"[]": describeType(t.Elem(), seen)}
+
+	case reflect.Map:
+		return map[string]any{"map[" + t.Key().Kind().String() + "]": describeType(t.Elem(), seen)}
+
+	case reflect.Struct:
+		if seen[t] {
+			return "recursive " + t.String()
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		fields := map[string]any{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := field.Name
+			if tag := field.Tag.Get("json"); tag != "" {
+				tagName, _, _ := strings.Cut(tag, ",")
+				if tagName == "-" {
+					continue
+				}
+				if tagName != "" {
+					name = tagName
+				}
+			}
+			fields[name] = describeType(field.Type, seen)
+		}
+		return fields
+
+	case reflect.Interface:
+		return "any"
+	}
+
+	// Named basic types keep their name, so a change of meaning shows up
+	if t.Name() != "" && t.Name() != t.Kind().String() {
+		return t.String() + "(" + t.Kind().String() + ")"
+	}
+	return t.Kind().String()
+}
+
+// Check compares the messages' shapes with the golden file, or rewrites it when update is set
+func Check(t *testing.T, goldenPath string, migrationsPath string, messages []Message, update bool) {
+	t.Helper()
+
+	current := map[string]any{}
+	for _, message := range messages {
+		if _, duplicate := current[message.Name()]; duplicate {
+			t.Fatalf("%s is registered twice", message.Name())
+		}
+		current[message.Name()] = Describe(message)
+	}
+
+	data, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+
+	frozen := map[string]any{}
+	if golden, err := os.ReadFile(goldenPath); err == nil {
+		if err := json.Unmarshal(golden, &frozen); err != nil {
+			t.Fatalf("Invalid golden file %s: %v", goldenPath, err)
+		}
+	} else if !update {
+		t.Fatalf("Missing golden file %s, run the tests with -update", goldenPath)
+	}
+
+	changed := changedMessages(frozen, current)
+
+	if update {
+		notes := migrationNotes(t, migrationsPath)
+		for _, name := range changed {
+			if !notes[name] {
+				t.Fatalf("%s changed its wire format, add a line starting with \"- %s:\" to %s before updating", name, name, migrationsPath)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenPath, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	for name := range current {
+		if _, ok := frozen[name]; !ok {
+			t.Errorf("%s is not in %s, run the tests with -update", name, goldenPath)
+		}
+	}
+	for _, name := range changed {
+		t.Errorf("%s changed its wire format. If this is intended, note it in %s and run the tests with -update", name, migrationsPath)
+	}
+}
+
+// changedMessages lists the frozen messages that were removed or whose shape changed
+func changedMessages(frozen map[string]any, current map[string]any) []string {
+	changed := []string{}
+	for name, shape := range frozen {
+		now, ok := current[name]
+		if !ok {
+			changed = append(changed, name)
+			continue
+		}
+
+		// Compare through JSON so both sides have the same types
+		a, _ := json.Marshal(shape)
+		b, _ := json.Marshal(now)
+		if string(a) != string(b) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// migrationNotes returns the message names that have a note in the migrations file
+func migrationNotes(t *testing.T, path string) map[string]bool {
+	t.Helper()
+
+	notes := map[string]bool{}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return notes
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "- ") {
+			continue
+		}
+		if name, _, ok := strings.Cut(line[2:], ":"); ok {
+			notes[strings.TrimSpace(name)] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return notes
+}
+
+// DeclaredMessages lists the types of a package directory that have a Name method,
+// so tests can check that every message is registered
+func DeclaredMessages(t *testing.T, dir string) []string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := []string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || fn.Name.Name != "Name" {
+					continue
+				}
+				if ident, ok := fn.Recv.List[0].Type.(*ast.Ident); ok {
+					types = append(types, ident.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// TypeNames returns the Go type names of the messages
+func TypeNames(messages []Message) []string {
+	names := make([]string, 0, len(messages))
+	for _, message := range messages {
+		names = append(names, reflect.TypeOf(message).Name())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,28 @@
+package domain
+
+// waitForNextHand flags a player sitting down while a hand is being played, who is left out of it
+// (dealing, turn order, views) until the next one. It reports whether the player has to wait.
+func (t *Table) waitForNextHand(playerID string) bool {
+	hand := t.ActiveHand
+	if hand == nil || hand.HasEnded() || hand.HasPlayer(playerID) {
+		return false
+	}
+
+	if t.NextHand == nil {
+		t.NextHand = make(map[string]bool)
+	}
+	t.NextHand[playerID] = true
+	return true
+}
+
+// IsWaitingForNextHand checks if a player sat down during the hand in progress and waits to be dealt in
+func (t *Table) IsWaitingForNextHand(playerID string) bool {
+	return t.NextHand[playerID]
+}
+
+// dealInLateJoiners clears the flag of the waiting players the hand deals in
+func (t *Table) dealInLateJoiners(hand *Hand) {
+	for _, player := range hand.Players {
+		delete(t.NextHand, player.ID)
+	}
+}
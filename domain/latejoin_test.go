@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLateJoiners(t *testing.T) {
+	setup := func() (*Hand, *Table) {
+		hand, table := setupContinuationPhaseHand(3)
+		table.Players = hand.Players
+		table.ActiveHand = hand
+		return hand, table
+	}
+
+	t.Run("A player sitting down during a hand waits for the next one", func(t *testing.T) {
+		// Setup
+		hand, table := setup()
+
+		// Act
+		err := table.SeatPlayer(&Player{ID: "late"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, table.IsWaitingForNextHand("late"))
+		assert.False(t, hand.HasPlayer("late"))
+		assert.False(t, hand.IsPlayerActive("late"))
+
+		event, found := findEventOfType(table.Events, events.PlayerJoinedTable{}.Name())
+		require.True(t, found)
+		assert.True(t, event.(events.PlayerJoinedTable).NextHandOnly)
+	})
+
+	t.Run("A player sitting down between hands is dealt in right away", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		err := table.SeatPlayer(&Player{ID: "player-1"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, table.IsWaitingForNextHand("player-1"))
+	})
+
+	t.Run("The waiting player gets no actions and a waiting role", func(t *testing.T) {
+		// Setup
+		hand, table := setup()
+		table.SeatPlayer(&Player{ID: "late"})
+
+		// Act
+		view := hand.BuildPlayerView("late")
+
+		// Assert
+		assert.Equal(t, "waiting", view.MyRole)
+		assert.Empty(t, view.AvailableActions)
+		assert.Empty(t, view.MyHoleCards)
+		for _, other := range view.OtherPlayers {
+			assert.NotEqual(t, "late", other.ID)
+		}
+		assert.True(t, table.Snapshot().Players[3].NextHandOnly)
+	})
+
+	t.Run("The next hand deals the waiting player in", func(t *testing.T) {
+		// Setup
+		_, table := setup()
+		table.SeatPlayer(&Player{ID: "late"})
+		table.BuyIns["late"] = 1000
+		table.ActiveHand = nil
+		table.Status = TableStatusPlaying
+
+		// Act
+		next, err := table.StartNewHand()
+		require.NoError(t, err)
+		require.NoError(t, next.InitializeHand())
+
+		// Assert
+		assert.False(t, table.IsWaitingForNextHand("late"))
+		assert.True(t, next.HasPlayer("late"))
+		assert.True(t, next.IsPlayerActive("late"))
+	})
+
+	t.Run("Leaving while waiting clears the flag", func(t *testing.T) {
+		// Setup
+		_, table := setup()
+		table.SeatPlayer(&Player{ID: "late"})
+
+		// Act
+		err := table.PlayerLeaves("late")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, table.IsWaitingForNextHand("late"))
+	})
+}
@@ -2,27 +2,51 @@ package domain
 
 import (
 	"errors"
-	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
 	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
 )
 
-// Lobby represents the poker game lobby
+// Lobby represents the poker game lobby: the players connected to the site and the open tables.
+// It is safe for concurrent use, events are emitted outside of its lock so handlers may call back into it.
 type Lobby struct {
+	mu      sync.RWMutex // guards tables, players and bans
 	tables  map[string]*Table
 	players map[string]*Player
+	bans    map[string]Ban        // Operator bans, by player ID
+	engines map[string]GameEngine // Tables whose hands run on another engine than the table itself, by table ID
+
+	// SeedEscrow is handed to every table created by the lobby
+	SeedEscrow escrow.Sealer
+
+	// Shuffler is handed to every table created by the lobby, e.g. a seeded one for simulations
+	Shuffler cards.Shuffler
+
+	// Wallet is handed to every table created by the lobby, nil keeps bankrolls in Player.Balance
+	Wallet wallet.Wallet
+
+	// Clock stamps the lobby's events and is handed to every table created by the lobby, the system clock when nil
+	Clock Clock
+
+	// BotFill is handed to every table created by the lobby, it lets the tables whose rules ask for it fill with bots
+	BotFill bool
 
 	// Events
+	eventsMu      sync.Mutex // guards Events, eventHandlers and eventIDs against concurrent emitters and pruning
 	Events        []events.Event
 	eventHandlers []events.EventHandler
+	eventIDs      *events.IDGenerator
 }
 
 // IsInLobby checks if a player is in the lobby
 func (l *Lobby) IsInLobby(playerID string) bool {
-	if l.players == nil {
-		return false
-	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
 	_, exists := l.players[playerID]
 	return exists
@@ -34,39 +58,72 @@ func (l *Lobby) EntersLobby(player *Player) error {
 		return errors.New("player is nil")
 	}
 
+	if l.IsBanned(player.ID) {
+		return errors.New("player is banned")
+	}
+
+	l.mu.Lock()
 	if l.players == nil {
 		l.players = make(map[string]*Player)
 	}
 
 	if _, exists := l.players[player.ID]; exists {
+		l.mu.Unlock()
 		return errors.New("player is already in the lobby")
 	}
 
 	l.players[player.ID] = player
+	l.mu.Unlock()
 
 	l.emitEvent(events.PlayerEnteredLobby{
 		PlayerID: player.ID,
-		At:       time.Now(),
+		At:       l.clock().Now(),
 	})
 
 	return nil
 }
 
-func (l *Lobby) LeavesLobby(playerID string) error {
-	if l.players == nil {
-		l.players = make(map[string]*Player)
+// GetPlayer returns a player in the lobby
+func (l *Lobby) GetPlayer(playerID string) (*Player, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	player, exists := l.players[playerID]
+	if !exists {
+		return nil, errors.New("player not found")
 	}
+	return player, nil
+}
+
+// GetPlayers returns the players in the lobby, by ID
+func (l *Lobby) GetPlayers() []*Player {
+	l.mu.RLock()
+	players := make([]*Player, 0, len(l.players))
+	for _, player := range l.players {
+		players = append(players, player)
+	}
+	l.mu.RUnlock()
+
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].ID < players[j].ID
+	})
+	return players
+}
 
+func (l *Lobby) LeavesLobby(playerID string) error {
+	l.mu.Lock()
 	_, exists := l.players[playerID]
 	if !exists {
+		l.mu.Unlock()
 		return errors.New("player not found")
 	}
 
 	delete(l.players, playerID)
+	l.mu.Unlock()
 
 	l.emitEvent(events.PlayerLeftLobby{
 		PlayerID: playerID,
-		At:       time.Now(),
+		At:       l.clock().Now(),
 	})
 
 	return nil
@@ -74,41 +131,58 @@ func (l *Lobby) LeavesLobby(playerID string) error {
 
 // NewTable creates a new table with the given name and rules
 func (l *Lobby) NewTable(name string, rules TableRules) (*Table, error) {
-	if l.tables == nil {
-		l.tables = make(map[string]*Table)
-	}
-
 	// Create a new table
 	table := NewTable(name, rules)
 	if table == nil {
 		return nil, errors.New("failed to create table")
 	}
 
+	table.SeedEscrow = l.SeedEscrow
+	table.Shuffler = l.Shuffler
+	table.Wallet = l.Wallet
+	table.BotFillAllowed = l.BotFill
+	if l.Clock != nil {
+		table.Clock = l.Clock
+		table.runClock.OpenedAt = l.Clock.Now()
+	}
 	table.RegisterEventHandler(l.handleTableEvent)
 
 	// Add to tables map
+	l.mu.Lock()
+	if l.tables == nil {
+		l.tables = make(map[string]*Table)
+	}
 	l.tables[table.ID] = table
+	l.mu.Unlock()
+
+	l.emitEvent(events.TableCreated{
+		TableID:     table.ID,
+		TableName:   table.Name,
+		MaxPlayers:  rules.MaxPlayers,
+		AnteValue:   rules.AnteValue,
+		FeedPrivacy: string(rules.FeedPrivacy),
+		At:          l.clock().Now(),
+	})
 
 	return table, nil
 }
 
 func (l *Lobby) handleTableEvent(event events.Event) {
-	fmt.Println("---")
-	fmt.Println("Game received event from table:", event.Name())
-
 	l.emitEvent(event)
 
 	switch ev := event.(type) {
-	default:
-		_ = ev
+	case events.TableClosed:
+		l.mu.Lock()
+		delete(l.tables, ev.TableID)
+		delete(l.engines, ev.TableID)
+		l.mu.Unlock()
 	}
 }
 
 // GetTable retrieves a table by ID
 func (l *Lobby) GetTable(tableID string) (*Table, error) {
-	if l.tables == nil {
-		l.tables = make(map[string]*Table)
-	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 
 	table, exists := l.tables[tableID]
 	if !exists {
@@ -118,24 +192,82 @@ func (l *Lobby) GetTable(tableID string) (*Table, error) {
 	return table, nil
 }
 
+// GetEngine returns the engine running a table's hands, the table itself unless another engine was set
+func (l *Lobby) GetEngine(tableID string) (GameEngine, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if engine, exists := l.engines[tableID]; exists {
+		return engine, nil
+	}
+
+	table, exists := l.tables[tableID]
+	if !exists {
+		return nil, errors.New("table not found")
+	}
+	return table, nil
+}
+
+// SetEngine runs a table's hands on another engine, e.g. to migrate tables one at a time
+func (l *Lobby) SetEngine(tableID string, engine GameEngine) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.tables[tableID]; !exists {
+		return errors.New("table not found")
+	}
+
+	if l.engines == nil {
+		l.engines = make(map[string]GameEngine)
+	}
+	l.engines[tableID] = engine
+	return nil
+}
+
+// CloseTable closes a table, which leaves the lobby once its TableClosed event is out
+func (l *Lobby) CloseTable(tableID string, reason string) error {
+	table, err := l.GetTable(tableID)
+	if err != nil {
+		return err
+	}
+
+	table.Close(reason)
+	return nil
+}
+
 // AddEventHandler adds an event handler to the lobby
 func (l *Lobby) AddEventHandler(handler events.EventHandler) {
+	l.eventsMu.Lock()
+	defer l.eventsMu.Unlock()
+
 	l.eventHandlers = append(l.eventHandlers, handler)
 }
 
 // emitEvent notifies all registered handlers of a new event
 func (l *Lobby) emitEvent(event events.Event) {
+	// Table events arrive already stamped, lobby events get their ID here
+	l.eventsMu.Lock()
+	if l.eventIDs == nil {
+		l.eventIDs = events.NewIDGenerator()
+	}
+	event = l.eventIDs.Stamp(event)
+
 	// Add event to game's event log
 	l.Events = append(l.Events, event)
+	handlers := l.eventHandlers
+	l.eventsMu.Unlock()
 
 	// Notify all handlers
-	for _, handler := range l.eventHandlers {
+	for _, handler := range handlers {
 		handler(event)
 	}
 }
 
 // GetTables returns all tables in the lobby
 func (l *Lobby) GetTables() []*Table {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	tables := make([]*Table, 0, len(l.tables))
 	for _, table := range l.tables {
 		tables = append(tables, table)
@@ -145,24 +277,18 @@ func (l *Lobby) GetTables() []*Table {
 
 // CreateTable creates a new table in the lobby
 func (l *Lobby) CreateTable(name string, maxPlayers int, minBuyIn int) (*Table, error) {
-	if l.tables == nil {
-		l.tables = make(map[string]*Table)
-	}
+	return l.NewTable(name, DefaultTableRules(maxPlayers, minBuyIn))
+}
 
-	// Create table rules
-	rules := TableRules{
+// DefaultTableRules returns the rules of the tables players create, for callers to adjust before NewTable
+func DefaultTableRules(maxPlayers int, minBuyIn int) TableRules {
+	return TableRules{
 		AnteValue:                 minBuyIn / 10,   // 10% of min buy-in
 		ContinuationBetMultiplier: 2,               // Double ante for continuation bet
 		PlayerTimeout:             time.Second * 5, // 5s timeout
 		MaxPlayers:                maxPlayers,
+		StartCountdown:            time.Second * 10, // 10s for more players to join
+		ConfirmBetsAbove:          50,               // Confirm bets over half the stack
+		CloseWhenEmptyAfter:       time.Minute * 10, // Close tables nobody sat at for 10min
 	}
-
-	// Create the table
-	table := NewTable(name, rules)
-
-	table.RegisterEventHandler(l.handleTableEvent)
-
-	l.tables[table.ID] = table
-
-	return table, nil
 }
@@ -1,11 +1,14 @@
 package domain
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/lazharichir/poker/domain/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockEvent implements events.Event interface for testing purposes
@@ -156,3 +159,109 @@ func TestLobby_MultipleEventHandlers(t *testing.T) {
 	assert.True(t, handler1Called)
 	assert.True(t, handler2Called)
 }
+
+func TestLobbyPlayers(t *testing.T) {
+	t.Run("Players are listed by ID while they are in the lobby", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+
+		// Act
+		require.NoError(t, lobby.EntersLobby(&Player{ID: "player-2"}))
+		require.NoError(t, lobby.EntersLobby(&Player{ID: "player-1"}))
+		require.NoError(t, lobby.EntersLobby(&Player{ID: "player-3"}))
+		require.NoError(t, lobby.LeavesLobby("player-3"))
+
+		// Assert
+		players := lobby.GetPlayers()
+		require.Len(t, players, 2)
+		assert.Equal(t, "player-1", players[0].ID)
+		assert.Equal(t, "player-2", players[1].ID)
+		assert.False(t, lobby.IsInLobby("player-3"))
+	})
+
+	t.Run("Entering twice is an error", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		require.NoError(t, lobby.EntersLobby(&Player{ID: "player-1"}))
+
+		// Act
+		err := lobby.EntersLobby(&Player{ID: "player-1"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestLobbyTableLifecycle(t *testing.T) {
+	t.Run("Creating a table emits TableCreated", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+
+		// Act
+		table, err := lobby.CreateTable("Test Table", 6, 100)
+
+		// Assert
+		require.NoError(t, err)
+		event, found := findEventOfType(lobby.Events, events.TableCreated{}.Name())
+		require.True(t, found)
+		assert.Equal(t, table.ID, event.(events.TableCreated).TableID)
+		assert.Equal(t, 6, event.(events.TableCreated).MaxPlayers)
+		assert.Equal(t, 10, event.(events.TableCreated).AnteValue)
+	})
+
+	t.Run("Closed tables leave the lobby", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		table, _ := lobby.NewTable("Test Table", TableRules{})
+
+		// Act
+		err := lobby.CloseTable(table.ID, "maintenance")
+
+		// Assert
+		require.NoError(t, err)
+		_, err = lobby.GetTable(table.ID)
+		assert.Error(t, err)
+		_, found := findEventOfType(lobby.Events, events.TableClosed{}.Name())
+		assert.True(t, found)
+	})
+
+	t.Run("Closing an unknown table is an error", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+
+		// Act
+		err := lobby.CloseTable("non-existent-id", "maintenance")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestLobbyConcurrency(t *testing.T) {
+	t.Run("Players and tables can be managed from several goroutines", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		lobby.AddEventHandler(func(event events.Event) {})
+
+		// Act
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				playerID := fmt.Sprintf("player-%d", i)
+				lobby.EntersLobby(&Player{ID: playerID})
+				table, _ := lobby.NewTable("Table "+playerID, TableRules{})
+				lobby.GetTables()
+				lobby.GetPlayers()
+				lobby.IsBanned(playerID)
+				lobby.CloseTable(table.ID, "done")
+			}(i)
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Len(t, lobby.GetPlayers(), 20)
+		assert.Empty(t, lobby.GetTables())
+	})
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// ErrInvalidLocale is returned for a locale that isn't a BCP 47 language tag such as "fr" or "pt-BR"
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// ErrInvalidTimeZone is returned for a time zone missing from the IANA database, e.g. "Europe/Paris"
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// localePattern is the shape of a BCP 47 language tag: a language, then subtags such as the region
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// SetLocale records the language and time zone the player reads times in, either may be empty if unknown
+func (p *Player) SetLocale(locale string, timeZone string) error {
+	if locale != "" && !localePattern.MatchString(locale) {
+		return ErrInvalidLocale
+	}
+
+	location := time.UTC
+	if timeZone != "" {
+		loaded, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return ErrInvalidTimeZone
+		}
+		location = loaded
+	}
+
+	p.Locale = locale
+	p.TimeZone = timeZone
+	p.location = location
+	return nil
+}
+
+// Location returns the player's time zone, UTC if they haven't set one or for no player at all
+func (p *Player) Location() *time.Location {
+	if p == nil || p.location == nil {
+		return time.UTC
+	}
+	return p.location
+}
+
+// LocalTime formats a timestamp in the player's time zone as RFC 3339, for clients that don't convert
+// times themselves. The zero time gives an empty string.
+func (p *Player) LocalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(p.Location()).Format(time.RFC3339)
+}
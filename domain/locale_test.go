@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerLocale(t *testing.T) {
+	t.Run("Times are shown in the player's time zone", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "player-1"}
+		at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		// Act
+		err := player.SetLocale("fr-FR", "Europe/Paris")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "fr-FR", player.Locale)
+		assert.Equal(t, "Europe/Paris", player.TimeZone)
+		assert.Equal(t, "2025-01-01T13:00:00+01:00", player.LocalTime(at))
+	})
+
+	t.Run("Players without a time zone see UTC", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "player-1"}
+		var nobody *Player
+		at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+		// Assert
+		assert.Equal(t, "2025-01-01T11:00:00Z", player.LocalTime(at))
+		assert.Equal(t, time.UTC, nobody.Location())
+		assert.Empty(t, player.LocalTime(time.Time{}))
+	})
+
+	t.Run("Invalid settings are rejected and the previous ones kept", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "player-1"}
+		require.NoError(t, player.SetLocale("en", "America/New_York"))
+
+		// Act
+		zoneErr := player.SetLocale("en", "Mars/Olympus_Mons")
+		localeErr := player.SetLocale("not a locale", "")
+
+		// Assert
+		assert.ErrorIs(t, zoneErr, ErrInvalidTimeZone)
+		assert.ErrorIs(t, localeErr, ErrInvalidLocale)
+		assert.Equal(t, "America/New_York", player.Location().String())
+	})
+
+	t.Run("Hand views carry the player's time zone", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(2)
+		table.runClock.OpenedAt = time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC)
+		require.NoError(t, hand.Players[0].SetLocale("ja", "Asia/Tokyo"))
+
+		// Act
+		view := hand.BuildPlayerView("player-1")
+		otherView := hand.BuildPlayerView("player-2")
+
+		// Assert
+		assert.Equal(t, "ja", view.Locale)
+		assert.Equal(t, "Asia/Tokyo", view.TimeZone)
+		assert.Equal(t, "2025-06-02T03:30:00+09:00", view.TableOpenedAtLocal)
+		assert.Equal(t, "UTC", otherView.TimeZone)
+		assert.Equal(t, "2025-06-01T18:30:00Z", otherView.TableOpenedAtLocal)
+	})
+}
@@ -0,0 +1,90 @@
+// Package logging keeps hole cards out of the logs. Card values are masked unless unsafe card logging
+// is turned on, which is meant for a developer's machine only: anyone reading the logs of a live server
+// would otherwise see every player's hand.
+package logging
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Masked stands for a card whose value is kept out of the logs
+const Masked = "??"
+
+var unsafeCards atomic.Bool
+
+// SetUnsafeCards turns the logging of card values on or off, they are masked by default
+func SetUnsafeCards(enabled bool) {
+	unsafeCards.Store(enabled)
+}
+
+// UnsafeCards checks if card values are logged as they are
+func UnsafeCards() bool {
+	return unsafeCards.Load()
+}
+
+// Card returns a card as it may be logged
+func Card(card cards.Card) string {
+	if UnsafeCards() {
+		return card.String()
+	}
+	return Masked
+}
+
+// Cards returns cards as they may be logged, how many there are is kept
+func Cards(stack cards.Stack) string {
+	if UnsafeCards() {
+		return stack.String()
+	}
+	return strings.TrimSpace(strings.Repeat(Masked+" ", len(stack)))
+}
+
+// Event returns an event as it may be logged, its name then its fields in JSON. The cards of events
+// only their owner may see (see events.Classify) are masked, public events are logged as they are.
+func Event(event events.Event) string {
+	data, err := json.Marshal(events.Redact(event))
+	if err != nil {
+		return event.Name()
+	}
+
+	if visibility, _ := events.Classify(event); visibility == events.VisibilityOwner && !UnsafeCards() {
+		var fields any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return event.Name()
+		}
+		if data, err = json.Marshal(maskCards(fields)); err != nil {
+			return event.Name()
+		}
+	}
+
+	return event.Name() + " " + string(data)
+}
+
+// maskCards replaces every card in a decoded JSON value, wherever it is nested
+func maskCards(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if isCard(v) {
+			return Masked
+		}
+		for key, field := range v {
+			v[key] = maskCards(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = maskCards(item)
+		}
+	}
+	return value
+}
+
+// isCard checks if a decoded JSON object is a cards.Card
+func isCard(object map[string]any) bool {
+	_, suit := object["Suit"]
+	_, value := object["Value"]
+	return suit && value && len(object) == 2
+}
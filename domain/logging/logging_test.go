@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogging(t *testing.T) {
+	aceOfSpades := cards.Card{Suit: cards.Spades, Value: cards.Ace}
+	kingOfHearts := cards.Card{Suit: cards.Hearts, Value: cards.King}
+
+	t.Run("Cards are masked by default", func(t *testing.T) {
+		// Assert
+		assert.Equal(t, Masked, Card(aceOfSpades))
+		assert.Equal(t, "?? ??", Cards(cards.Stack{aceOfSpades, kingOfHearts}))
+		assert.Empty(t, Cards(cards.Stack{}))
+	})
+
+	t.Run("Unsafe card logging shows card values", func(t *testing.T) {
+		// Setup
+		SetUnsafeCards(true)
+		defer SetUnsafeCards(false)
+
+		// Assert
+		assert.Equal(t, aceOfSpades.String(), Card(aceOfSpades))
+		assert.Equal(t, cards.Stack{aceOfSpades, kingOfHearts}.String(), Cards(cards.Stack{aceOfSpades, kingOfHearts}))
+	})
+
+	t.Run("Cards of owner-only events are masked", func(t *testing.T) {
+		// Setup
+		event := events.HoleCardDealt{PlayerID: "player-1", Card: aceOfSpades}
+
+		// Act
+		logged := Event(event)
+
+		// Assert
+		assert.Contains(t, logged, event.Name())
+		assert.Contains(t, logged, `"Card":"??"`)
+		assert.Contains(t, logged, `"PlayerID":"player-1"`)
+		assert.NotContains(t, logged, string(cards.Spades))
+	})
+
+	t.Run("Public events are logged as they are", func(t *testing.T) {
+		// Setup
+		event := events.CommunityCardDealt{Card: kingOfHearts}
+
+		// Act
+		logged := Event(event)
+
+		// Assert
+		assert.NotContains(t, logged, Masked)
+		assert.Contains(t, logged, string(cards.Hearts))
+	})
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests are meant to be run with the race detector as well: go test -race ./domain
+func TestGameLoop(t *testing.T) {
+	t.Run("The same action sent many times at once is applied once", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(4)
+		attachHandToTable(hand, table)
+		table.Rules.AutoPlayWhenAway = false
+		bettor := hand.CurrentBettor
+
+		// Act
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		applied := 0
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := table.SubmitAction(Action{
+					Type:     ActionFold,
+					PlayerID: bettor,
+					HandID:   hand.ID,
+					Phase:    string(HandPhase_Continuation),
+				})
+				if err == nil {
+					mu.Lock()
+					applied++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Equal(t, 1, applied)
+		folds := 0
+		for _, event := range table.Events {
+			if folded, ok := event.(events.PlayerFolded); ok && folded.PlayerID == bettor {
+				folds++
+			}
+		}
+		assert.Equal(t, 1, folds)
+	})
+
+	t.Run("Players sitting down at once are all seated", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				player := &Player{ID: fmt.Sprintf("player-%d", i+1)}
+				table.Do("seat", func() error { return table.SeatPlayer(player) })
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Len(t, table.GetPlayers(), 10)
+		table.Do("check", func() error {
+			assert.Len(t, table.sessions, 10)
+			return nil
+		})
+	})
+
+	t.Run("Callers wait for each other", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		running, most := 0, 0
+
+		// Act
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				table.Do("count", func() error {
+					running++
+					most = max(most, running)
+					running--
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		table.Do("check", func() error {
+			require.Equal(t, 1, most)
+			return nil
+		})
+	})
+}
@@ -1,11 +1,26 @@
 package domain
 
+import (
+	"errors"
+	"time"
+
+	"github.com/lazharichir/poker/domain/wallet"
+)
+
 // Player represents a player in the game
 type Player struct {
 	ID      string
 	Name    string
 	Status  string
-	Balance int
+	Balance int    // Bankroll when there is no wallet, see Bankroll
+	Country string // ISO 3166-1 alpha-2 code, empty if unknown
+	Privacy Privacy
+
+	// Locale and TimeZone are how the player reads times, see SetLocale
+	Locale   string // BCP 47 language tag, empty if unknown
+	TimeZone string // IANA time zone, empty if unknown and read as UTC
+
+	location *time.Location
 }
 
 // AddToBalance adds amount to player balance
@@ -17,3 +32,38 @@ func (p *Player) AddToBalance(amount int) {
 func (p *Player) RemoveFromBalance(amount int) {
 	p.Balance -= amount
 }
+
+// Bankroll returns what the player has to buy in with: their wallet balance when there is a wallet, Balance otherwise.
+// Balance doesn't mirror the wallet, the player may be seated at tables whose loops would write it at once.
+func (p *Player) Bankroll(w wallet.Wallet) int {
+	if w == nil {
+		return p.Balance
+	}
+	return w.Balance(p.ID)
+}
+
+// Withdraw takes amount from the player's bankroll: through the wallet when there is one, from Balance otherwise.
+// The key makes the withdrawal happen once, however often it is retried.
+func (p *Player) Withdraw(w wallet.Wallet, amount int, key string, reason string) error {
+	if w == nil {
+		if p.Balance < amount {
+			return errors.New("player does not have enough balance")
+		}
+		p.RemoveFromBalance(amount)
+		return nil
+	}
+
+	_, err := w.Debit(p.ID, amount, key, reason)
+	return err
+}
+
+// Deposit adds amount to the player's bankroll: through the wallet when there is one, to Balance otherwise
+func (p *Player) Deposit(w wallet.Wallet, amount int, key string, reason string) error {
+	if w == nil {
+		p.AddToBalance(amount)
+		return nil
+	}
+
+	_, err := w.Credit(p.ID, amount, key, reason)
+	return err
+}
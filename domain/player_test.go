@@ -3,6 +3,7 @@ package domain
 import (
 	"testing"
 
+	"github.com/lazharichir/poker/domain/wallet"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +32,38 @@ func TestRemoveFromBalance(t *testing.T) {
 
 	assert.Equal(t, 50, player.Balance)
 }
+
+func TestWithdrawAndDeposit(t *testing.T) {
+	t.Run("Without a wallet the balance field is the bankroll", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "1", Balance: 100}
+
+		// Act
+		tooMuch := player.Withdraw(nil, 150, "", "buy-in")
+		withdrawn := player.Withdraw(nil, 60, "", "buy-in")
+		deposited := player.Deposit(nil, 10, "", "cash-out")
+
+		// Assert
+		assert.Error(t, tooMuch)
+		assert.NoError(t, withdrawn)
+		assert.NoError(t, deposited)
+		assert.Equal(t, 50, player.Balance)
+	})
+
+	t.Run("With a wallet the bankroll is the wallet balance", func(t *testing.T) {
+		// Setup
+		ledger := wallet.NewLedger()
+		player := &Player{ID: "1"}
+
+		// Act
+		player.Deposit(ledger, 1000, "welcome/1", "starting balance")
+		player.Deposit(ledger, 1000, "welcome/1", "starting balance")
+		err := player.Withdraw(ledger, 300, "buy-in/1", "buy-in")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 700, ledger.Balance("1"), "the retried deposit is only credited once")
+		assert.Equal(t, 700, player.Bankroll(ledger))
+		assert.Zero(t, player.Balance, "the player is shared by the tables they sit at, the wallet is the one place it changes")
+	})
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ErrPlayerNotInLobby is returned for players who have not entered the lobby
+var ErrPlayerNotInLobby = errors.New("player not in lobby")
+
+// Privacy holds what a player keeps from the other players. The zero value shares everything.
+type Privacy struct {
+	HideStats            bool // Other players can't see their stats
+	HideFromLeaderboards bool // Left out of the leaderboard and of the winners of the big pots feed
+	HideCountry          bool // Other players can't see their country
+}
+
+// SetPlayerPrivacy replaces a player's privacy settings, and announces them so the projections showing the player follow
+func (l *Lobby) SetPlayerPrivacy(playerID string, privacy Privacy) error {
+	l.mu.Lock()
+	player, exists := l.players[playerID]
+	if !exists {
+		l.mu.Unlock()
+		return ErrPlayerNotInLobby
+	}
+	player.Privacy = privacy
+	l.mu.Unlock()
+
+	l.emitEvent(events.PlayerPrivacyChanged{
+		PlayerID:             playerID,
+		HideStats:            privacy.HideStats,
+		HideFromLeaderboards: privacy.HideFromLeaderboards,
+		HideCountry:          privacy.HideCountry,
+		At:                   l.clock().Now(),
+	})
+
+	return nil
+}
+
+// countryFor returns the player's country as the viewer may see it
+func countryFor(player *Player, viewerID string) string {
+	if player.Privacy.HideCountry && player.ID != viewerID {
+		return ""
+	}
+	return player.Country
+}
+
+// sessionFor returns a copy of the player's session at the table as the viewer may see it,
+// nil if they are not seated or hide their stats from the viewer
+func (t *Table) sessionFor(player *Player, viewerID string) *PlayerSession {
+	if player.Privacy.HideStats && player.ID != viewerID {
+		return nil
+	}
+
+	session, ok := t.sessions[player.ID]
+	if !ok {
+		return nil
+	}
+	copied := *session
+	return &copied
+}
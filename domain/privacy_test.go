@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerPrivacy(t *testing.T) {
+	t.Run("Setting the privacy updates the player and announces it", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		player := &Player{ID: "player-1"}
+		lobby.EntersLobby(player)
+
+		// Act
+		err := lobby.SetPlayerPrivacy(player.ID, Privacy{HideStats: true, HideCountry: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, Privacy{HideStats: true, HideCountry: true}, player.Privacy)
+		event, found := findEventOfType(lobby.Events, events.PlayerPrivacyChanged{}.Name())
+		require.True(t, found)
+		changed := event.(events.PlayerPrivacyChanged)
+		assert.True(t, changed.HideStats)
+		assert.False(t, changed.HideFromLeaderboards)
+		assert.True(t, changed.HideCountry)
+	})
+
+	t.Run("Players outside the lobby can't set their privacy", func(t *testing.T) {
+		lobby := &Lobby{}
+		err := lobby.SetPlayerPrivacy("player-1", Privacy{HideStats: true})
+		assert.ErrorIs(t, err, ErrPlayerNotInLobby)
+	})
+
+	t.Run("Other players see the country and session of players who share them", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(2)
+		hand.Players[1].Country = "FR"
+		table.startSession("player-2", time.Now())
+
+		// Act
+		view := hand.BuildPlayerView("player-1")
+
+		// Assert
+		require.Len(t, view.OtherPlayers, 1)
+		assert.Equal(t, "FR", view.OtherPlayers[0].Country)
+		assert.NotNil(t, view.OtherPlayers[0].Session)
+	})
+
+	t.Run("Hidden country and stats are left out of other players' views", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(2)
+		hand.Players[1].Country = "FR"
+		hand.Players[1].Privacy = Privacy{HideStats: true, HideCountry: true}
+		table.startSession("player-2", time.Now())
+
+		// Act
+		view := hand.BuildPlayerView("player-1")
+		spectatorView := hand.BuildSpectatorView()
+
+		// Assert
+		require.Len(t, view.OtherPlayers, 1)
+		assert.Empty(t, view.OtherPlayers[0].Country)
+		assert.Nil(t, view.OtherPlayers[0].Session)
+		for _, other := range spectatorView.OtherPlayers {
+			assert.Empty(t, other.Country)
+			if other.ID == "player-2" {
+				assert.Nil(t, other.Session)
+			}
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package projections
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+)
+
+// maxBigPots is how many notable hands the feed keeps
+const maxBigPots = 50
+
+// Values of TableCreated.FeedPrivacy that opt a table in to the feed
+const (
+	feedAnonymous = "anonymous"
+	feedPublic    = "public"
+)
+
+// NotableHand is a hand of the big pots feed. Hands of anonymous tables leave out the table and the winners.
+type NotableHand struct {
+	HandID      string
+	TableID     string   `json:",omitempty"`
+	TableName   string   `json:",omitempty"`
+	Winners     []string `json:",omitempty"` // Player IDs
+	WinnerCount int
+	Pot         int
+	HandRank    string `json:",omitempty"` // Best winning hand, empty if nobody showed down
+	BigPot      bool   // The pot reached the feed's threshold
+	StrongHand  bool   // The winning hand reached the feed's threshold
+	EndedAt     time.Time
+}
+
+type feedTable struct {
+	name    string
+	privacy string
+}
+
+type feedHand struct {
+	tableID  string
+	bestRank hands.HandRank
+	showdown bool
+	voided   bool
+}
+
+// BigPots projects the notable hands of the tables that opted in to the site-wide feed
+type BigPots struct {
+	mu      sync.RWMutex
+	minPot  int            // Pots from this amount make the feed, zero leaves pots out
+	minRank hands.HandRank // Winning hands from this rank make the feed
+	tables  map[string]feedTable
+	hands   map[string]*feedHand // Hands being played at opted-in tables, by hand ID
+	recent  []NotableHand        // Most recent first
+	hidden  map[string]bool      // Players who opted out of leaderboards and feeds, by ID
+}
+
+// NewBigPots creates an empty feed of the hands won with a pot of at least minPot, or with at least a minRank hand
+func NewBigPots(minPot int, minRank hands.HandRank) *BigPots {
+	return &BigPots{
+		minPot:  minPot,
+		minRank: minRank,
+		tables:  make(map[string]feedTable),
+		hands:   make(map[string]*feedHand),
+		hidden:  make(map[string]bool),
+	}
+}
+
+// HandleEvent updates the projection, it is meant to be registered as an event handler
+func (p *BigPots) HandleEvent(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e := event.(type) {
+	case events.TableCreated:
+		if e.FeedPrivacy == feedAnonymous || e.FeedPrivacy == feedPublic {
+			p.tables[e.TableID] = feedTable{name: e.TableName, privacy: e.FeedPrivacy}
+		}
+
+	case events.HandStarted:
+		if _, optedIn := p.tables[e.TableID]; optedIn {
+			p.hands[e.HandID] = &feedHand{tableID: e.TableID}
+		}
+
+	case events.HandsEvaluated:
+		hand, exists := p.hands[e.HandID]
+		if !exists {
+			return
+		}
+		for _, result := range e.Results {
+			if result.IsWinner && (!hand.showdown || result.HandRank > hand.bestRank) {
+				hand.bestRank = result.HandRank
+				hand.showdown = true
+			}
+		}
+
+	case events.HandVoided:
+		if hand, exists := p.hands[e.HandID]; exists {
+			hand.voided = true
+		}
+
+	case events.HandEnded:
+		hand, exists := p.hands[e.HandID]
+		if !exists {
+			return
+		}
+		delete(p.hands, e.HandID)
+		if hand.voided {
+			return
+		}
+
+		notable := NotableHand{
+			HandID:      e.HandID,
+			WinnerCount: len(e.Winners),
+			Pot:         e.FinalPot,
+			BigPot:      p.minPot > 0 && e.FinalPot >= p.minPot,
+			StrongHand:  hand.showdown && hand.bestRank >= p.minRank,
+			EndedAt:     e.At,
+		}
+		if !notable.BigPot && !notable.StrongHand {
+			return
+		}
+		if hand.showdown {
+			notable.HandRank = hand.bestRank.String()
+		}
+		if table := p.tables[e.TableID]; table.privacy == feedPublic {
+			notable.TableID = e.TableID
+			notable.TableName = table.name
+			notable.Winners = append([]string{}, e.Winners...)
+		}
+
+		p.recent = append([]NotableHand{notable}, p.recent...)
+		if len(p.recent) > maxBigPots {
+			p.recent = p.recent[:maxBigPots]
+		}
+
+	case events.PlayerPrivacyChanged:
+		p.hidden[e.PlayerID] = e.HideFromLeaderboards
+
+	case events.TableClosed:
+		// Hands cut short by the table closing are not shown
+		delete(p.tables, e.TableID)
+		for handID, hand := range p.hands {
+			if hand.tableID == e.TableID {
+				delete(p.hands, handID)
+			}
+		}
+	}
+}
+
+// Recent returns up to limit notable hands, most recent first. Winners who opted out of the feed are
+// left out of Winners, even from hands they won before opting out, but still count in WinnerCount.
+func (p *BigPots) Recent(limit int) []NotableHand {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	limit = max(min(limit, len(p.recent)), 0)
+	recent := make([]NotableHand, limit)
+	for i, notable := range p.recent[:limit] {
+		if notable.Winners != nil {
+			notable.Winners = slices.DeleteFunc(slices.Clone(notable.Winners), func(playerID string) bool { return p.hidden[playerID] })
+		}
+		recent[i] = notable
+	}
+	return recent
+}
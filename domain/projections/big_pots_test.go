@@ -0,0 +1,106 @@
+package projections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/hands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playFeedHand plays a hand at a table, won by player-1 with the given rank
+func playFeedHand(p *BigPots, tableID string, handID string, pot int, rank hands.HandRank) {
+	p.HandleEvent(events.HandStarted{TableID: tableID, HandID: handID, Players: []string{"player-1", "player-2"}})
+	p.HandleEvent(events.HandsEvaluated{TableID: tableID, HandID: handID, Results: map[string]hands.HandComparisonResult{
+		"player-1": {PlayerID: "player-1", HandRank: rank, IsWinner: true},
+		"player-2": {PlayerID: "player-2", HandRank: hands.OnePair},
+	}})
+	p.HandleEvent(events.HandEnded{TableID: tableID, HandID: handID, FinalPot: pot, Winners: []string{"player-1"}, At: time.Now()})
+}
+
+func TestBigPots(t *testing.T) {
+	setup := func(privacy string) *BigPots {
+		p := NewBigPots(1000, hands.FourOfAKind)
+		p.HandleEvent(events.TableCreated{TableID: "table-1", TableName: "High Rollers", FeedPrivacy: privacy})
+		return p
+	}
+
+	t.Run("Lists big pots and strong hands, most recent first", func(t *testing.T) {
+		// Setup
+		p := setup("public")
+
+		// Act
+		playFeedHand(p, "table-1", "hand-1", 1500, hands.TwoPair)
+		playFeedHand(p, "table-1", "hand-2", 100, hands.TwoPair)
+		playFeedHand(p, "table-1", "hand-3", 100, hands.RoyalFlush)
+
+		// Assert
+		recent := p.Recent(10)
+		require.Len(t, recent, 2)
+		assert.Equal(t, "hand-3", recent[0].HandID)
+		assert.True(t, recent[0].StrongHand)
+		assert.False(t, recent[0].BigPot)
+		assert.Equal(t, "Royal Flush", recent[0].HandRank)
+		assert.Equal(t, "hand-1", recent[1].HandID)
+		assert.True(t, recent[1].BigPot)
+		assert.Equal(t, "High Rollers", recent[1].TableName)
+		assert.Equal(t, []string{"player-1"}, recent[1].Winners)
+	})
+
+	t.Run("Anonymous tables leave out the table and the winners", func(t *testing.T) {
+		// Setup
+		p := setup("anonymous")
+
+		// Act
+		playFeedHand(p, "table-1", "hand-1", 1500, hands.TwoPair)
+
+		// Assert
+		recent := p.Recent(10)
+		require.Len(t, recent, 1)
+		assert.Empty(t, recent[0].TableID)
+		assert.Empty(t, recent[0].TableName)
+		assert.Empty(t, recent[0].Winners)
+		assert.Equal(t, 1, recent[0].WinnerCount)
+		assert.Equal(t, 1500, recent[0].Pot)
+	})
+
+	t.Run("Tables that didn't opt in are never shown", func(t *testing.T) {
+		// Setup
+		p := setup("")
+
+		// Act
+		playFeedHand(p, "table-1", "hand-1", 5000, hands.RoyalFlush)
+
+		// Assert
+		assert.Empty(t, p.Recent(10))
+	})
+
+	t.Run("Voided hands are not shown", func(t *testing.T) {
+		// Setup
+		p := setup("public")
+		p.HandleEvent(events.HandStarted{TableID: "table-1", HandID: "hand-1"})
+
+		// Act
+		p.HandleEvent(events.HandVoided{TableID: "table-1", HandID: "hand-1"})
+		p.HandleEvent(events.HandEnded{TableID: "table-1", HandID: "hand-1", FinalPot: 5000})
+
+		// Assert
+		assert.Empty(t, p.Recent(10))
+	})
+	t.Run("Winners who opted out are left out of the winners", func(t *testing.T) {
+		// Setup
+		p := setup("public")
+		playFeedHand(p, "table-1", "hand-1", 1500, hands.TwoPair)
+
+		// Act
+		p.HandleEvent(events.PlayerPrivacyChanged{PlayerID: "player-1", HideFromLeaderboards: true})
+
+		// Assert
+		recent := p.Recent(10)
+		require.Len(t, recent, 1)
+		assert.Empty(t, recent[0].Winners)
+		assert.Equal(t, 1, recent[0].WinnerCount)
+	})
+}
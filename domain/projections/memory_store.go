@@ -0,0 +1,52 @@
+package projections
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is a Store that only lives as long as the process
+type MemoryStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Upsert(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = slices.Clone(value)
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, exists := s.values[key]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(value), nil
+}
+
+func (s *MemoryStore) Scan(ctx context.Context, prefix string) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := []Record{}
+	for key, value := range s.values {
+		if strings.HasPrefix(key, prefix) {
+			records = append(records, Record{Key: key, Value: slices.Clone(value)})
+		}
+	}
+	slices.SortFunc(records, func(a, b Record) int { return strings.Compare(a.Key, b.Key) })
+	return records, nil
+}
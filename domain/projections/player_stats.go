@@ -0,0 +1,182 @@
+package projections
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// PlayerTotals is a player's results over all their finished sessions
+type PlayerTotals struct {
+	PlayerID       string
+	Sessions       int
+	HandsPlayed    int
+	NetResult      int // Chips won minus chips put in the pot
+	BiggestPotWon  int
+	BiggestPotLost int
+}
+
+// PlayerStats projects session summaries into per-player totals and a leaderboard of net results.
+// Players who hide their stats are left out of Stats, those who opt out of leaderboards out of Leaderboard.
+type PlayerStats struct {
+	mu      sync.RWMutex
+	players map[string]*PlayerTotals
+	privacy map[string]events.PlayerPrivacyChanged // Latest settings, by player ID
+	changed map[string]bool                        // Players whose totals or settings changed since the last Save
+}
+
+// storedPlayer is a player's part of the projection, as kept in a Store under Key(playerStatsKey, playerID)
+type storedPlayer struct {
+	Totals  *PlayerTotals               `json:"totals,omitempty"`
+	Privacy events.PlayerPrivacyChanged `json:"privacy"`
+}
+
+// playerStatsKey is the first key part of the projection's records
+const playerStatsKey = "player-stats"
+
+// NewPlayerStats creates an empty projection
+func NewPlayerStats() *PlayerStats {
+	return &PlayerStats{
+		players: make(map[string]*PlayerTotals),
+		privacy: make(map[string]events.PlayerPrivacyChanged),
+		changed: make(map[string]bool),
+	}
+}
+
+// HandleEvent updates the projection, it is meant to be registered as an event handler
+func (p *PlayerStats) HandleEvent(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e := event.(type) {
+	case events.PlayerSessionSummarized:
+		totals, exists := p.players[e.PlayerID]
+		if !exists {
+			totals = &PlayerTotals{PlayerID: e.PlayerID}
+			p.players[e.PlayerID] = totals
+		}
+		totals.Sessions++
+		totals.HandsPlayed += e.HandsPlayed
+		totals.NetResult += e.NetResult
+		totals.BiggestPotWon = max(totals.BiggestPotWon, e.BiggestPotWon)
+		totals.BiggestPotLost = max(totals.BiggestPotLost, e.BiggestPotLost)
+		p.changed[e.PlayerID] = true
+
+	case events.PlayerPrivacyChanged:
+		p.privacy[e.PlayerID] = e
+		p.changed[e.PlayerID] = true
+	}
+}
+
+// Load adds the players kept in a store to the projection, before it handles events
+func (p *PlayerStats) Load(ctx context.Context, store Store) error {
+	records, err := store.Scan(ctx, Prefix(playerStatsKey))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, record := range records {
+		var stored storedPlayer
+		if err := json.Unmarshal(record.Value, &stored); err != nil {
+			return err
+		}
+
+		playerID := strings.TrimPrefix(record.Key, Prefix(playerStatsKey))
+		if stored.Totals != nil {
+			p.players[playerID] = stored.Totals
+		}
+		p.privacy[playerID] = stored.Privacy
+	}
+	return nil
+}
+
+// Save keeps the players who changed since the last Save in a store
+func (p *PlayerStats) Save(ctx context.Context, store Store) error {
+	p.mu.Lock()
+	changed := make(map[string]storedPlayer, len(p.changed))
+	for playerID := range p.changed {
+		stored := storedPlayer{Privacy: p.privacy[playerID]}
+		if totals, exists := p.players[playerID]; exists {
+			copied := *totals
+			stored.Totals = &copied
+		}
+		changed[playerID] = stored
+	}
+	p.changed = make(map[string]bool)
+	p.mu.Unlock()
+
+	for playerID, stored := range changed {
+		if err := UpsertJSON(ctx, store, Key(playerStatsKey, playerID), stored); err != nil {
+			// Saved again next time
+			p.mu.Lock()
+			for playerID := range changed {
+				p.changed[playerID] = true
+			}
+			p.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// Start saves the projection to a store every interval until ctx is done, then saves it one last time and returns
+func (p *PlayerStats) Start(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := p.Save(context.Background(), store); err != nil {
+				log.Printf("Could not save the player stats: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := p.Save(ctx, store); err != nil {
+				log.Printf("Could not save the player stats: %v", err)
+			}
+		}
+	}
+}
+
+// Stats returns a player's totals as others may see them, false if they have none or hide them
+func (p *PlayerStats) Stats(playerID string) (PlayerTotals, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	totals, exists := p.players[playerID]
+	if !exists || p.privacy[playerID].HideStats {
+		return PlayerTotals{}, false
+	}
+	return *totals, true
+}
+
+// Leaderboard returns up to limit players by net result, best first, leaving out those who opted out
+func (p *PlayerStats) Leaderboard(limit int) []PlayerTotals {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	board := make([]PlayerTotals, 0, len(p.players))
+	for playerID, totals := range p.players {
+		if !p.privacy[playerID].HideFromLeaderboards {
+			board = append(board, *totals)
+		}
+	}
+
+	slices.SortFunc(board, func(a, b PlayerTotals) int {
+		return cmp.Or(cmp.Compare(b.NetResult, a.NetResult), cmp.Compare(a.PlayerID, b.PlayerID))
+	})
+
+	limit = max(min(limit, len(board)), 0)
+	return board[:limit]
+}
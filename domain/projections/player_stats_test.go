@@ -0,0 +1,101 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerStats(t *testing.T) {
+	setup := func() *PlayerStats {
+		p := NewPlayerStats()
+		p.HandleEvent(events.PlayerSessionSummarized{PlayerID: "player-1", HandsPlayed: 10, NetResult: 300, BiggestPotWon: 200})
+		p.HandleEvent(events.PlayerSessionSummarized{PlayerID: "player-1", HandsPlayed: 5, NetResult: -100, BiggestPotLost: 150})
+		p.HandleEvent(events.PlayerSessionSummarized{PlayerID: "player-2", HandsPlayed: 8, NetResult: 500})
+		return p
+	}
+
+	t.Run("Adds up the sessions of each player", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		stats, ok := p.Stats("player-1")
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, 2, stats.Sessions)
+		assert.Equal(t, 15, stats.HandsPlayed)
+		assert.Equal(t, 200, stats.NetResult)
+		assert.Equal(t, 200, stats.BiggestPotWon)
+		assert.Equal(t, 150, stats.BiggestPotLost)
+	})
+
+	t.Run("Ranks players by net result", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		board := p.Leaderboard(10)
+
+		// Assert
+		require.Len(t, board, 2)
+		assert.Equal(t, "player-2", board[0].PlayerID)
+		assert.Equal(t, "player-1", board[1].PlayerID)
+		assert.Len(t, p.Leaderboard(1), 1)
+	})
+
+	t.Run("Players hiding their stats are not found", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		p.HandleEvent(events.PlayerPrivacyChanged{PlayerID: "player-1", HideStats: true})
+
+		// Assert
+		_, ok := p.Stats("player-1")
+		assert.False(t, ok)
+		_, ok = p.Stats("player-2")
+		assert.True(t, ok)
+	})
+
+	t.Run("Players who opted out are left off the leaderboard until they opt back in", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		p.HandleEvent(events.PlayerPrivacyChanged{PlayerID: "player-2", HideFromLeaderboards: true})
+
+		// Assert
+		board := p.Leaderboard(10)
+		require.Len(t, board, 1)
+		assert.Equal(t, "player-1", board[0].PlayerID)
+
+		p.HandleEvent(events.PlayerPrivacyChanged{PlayerID: "player-2"})
+		assert.Len(t, p.Leaderboard(10), 2)
+	})
+
+	t.Run("Survives a restart through the store", func(t *testing.T) {
+		// Setup
+		ctx := context.Background()
+		store := NewMemoryStore()
+		p := setup()
+		p.HandleEvent(events.PlayerPrivacyChanged{PlayerID: "player-2", HideFromLeaderboards: true})
+		require.NoError(t, p.Save(ctx, store))
+
+		// Act
+		restarted := NewPlayerStats()
+		require.NoError(t, restarted.Load(ctx, store))
+
+		// Assert
+		stats, ok := restarted.Stats("player-1")
+		require.True(t, ok)
+		assert.Equal(t, 200, stats.NetResult)
+		assert.Equal(t, p.Leaderboard(10), restarted.Leaderboard(10))
+		assert.Len(t, restarted.Leaderboard(10), 1, "player-2 still opted out")
+		assert.Empty(t, p.changed, "saved players are only saved again once they change")
+	})
+}
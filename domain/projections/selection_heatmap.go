@@ -0,0 +1,218 @@
+package projections
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// maxHeatmapHands is how many hands keep their own selection breakdown, older hands only count in the totals
+const maxHeatmapHands = 1000
+
+// CardSelections is how often one community card was picked in a hand
+type CardSelections struct {
+	Card       string
+	Position   int // Index of the card in the community layout
+	Selections int
+}
+
+// HandSelections ranks the community cards of a hand, most selected first
+type HandSelections struct {
+	TableID string
+	HandID  string
+	Cards   []CardSelections
+}
+
+// SelectionRate is how often cards at a layout position, or of a rank, get picked once dealt
+type SelectionRate struct {
+	Key      string // Layout position or card rank
+	Dealt    int
+	Selected int
+	Rate     float64 // Selections per dealt card
+}
+
+// SelectionHeatmap is the popularity of community cards across all hands seen
+type SelectionHeatmap struct {
+	Hands      int
+	ByPosition []SelectionRate
+	ByRank     []SelectionRate
+}
+
+type handLayout struct {
+	tableID    string
+	positions  map[string]int    // Card to layout position
+	ranks      map[string]string // Card to rank
+	selections map[string]int    // Card to number of selections
+}
+
+type selectionCounts struct {
+	dealt    int
+	selected int
+}
+
+// SelectionHeatmaps projects community card selections, to tune the community layout
+type SelectionHeatmaps struct {
+	mu         sync.RWMutex
+	hands      map[string]*handLayout // Hands being played, by hand ID
+	finished   []HandSelections       // Most recent first
+	handsSeen  int
+	byPosition map[int]*selectionCounts
+	byRank     map[string]*selectionCounts
+}
+
+// NewSelectionHeatmaps creates an empty projection
+func NewSelectionHeatmaps() *SelectionHeatmaps {
+	return &SelectionHeatmaps{
+		hands:      make(map[string]*handLayout),
+		byPosition: make(map[int]*selectionCounts),
+		byRank:     make(map[string]*selectionCounts),
+	}
+}
+
+// HandleEvent updates the projection, it is meant to be registered as an event handler
+func (p *SelectionHeatmaps) HandleEvent(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e := event.(type) {
+	case events.CommunityCardDealt:
+		hand := p.hand(e.TableID, e.HandID)
+		hand.positions[e.Card.String()] = e.CardIndex
+		hand.ranks[e.Card.String()] = string(e.Card.Value)
+		p.positionCounts(e.CardIndex).dealt++
+		p.rankCounts(string(e.Card.Value)).dealt++
+
+	case events.CommunityCardSelected:
+		hand, exists := p.hands[e.HandID]
+		if !exists {
+			return
+		}
+		position, dealt := hand.positions[e.Card]
+		if !dealt {
+			return
+		}
+		hand.selections[e.Card]++
+		p.positionCounts(position).selected++
+		p.rankCounts(hand.ranks[e.Card]).selected++
+
+	case events.HandEnded:
+		hand, exists := p.hands[e.HandID]
+		if !exists {
+			return
+		}
+		delete(p.hands, e.HandID)
+
+		p.handsSeen++
+		p.finished = append([]HandSelections{hand.summary(e.HandID)}, p.finished...)
+		if len(p.finished) > maxHeatmapHands {
+			p.finished = p.finished[:maxHeatmapHands]
+		}
+
+	case events.TableClosed:
+		// Hands cut short by the table closing are not counted
+		for handID, hand := range p.hands {
+			if hand.tableID == e.TableID {
+				delete(p.hands, handID)
+			}
+		}
+	}
+}
+
+func (p *SelectionHeatmaps) hand(tableID string, handID string) *handLayout {
+	hand, exists := p.hands[handID]
+	if !exists {
+		hand = &handLayout{
+			tableID:    tableID,
+			positions:  make(map[string]int),
+			ranks:      make(map[string]string),
+			selections: make(map[string]int),
+		}
+		p.hands[handID] = hand
+	}
+	return hand
+}
+
+func (p *SelectionHeatmaps) positionCounts(position int) *selectionCounts {
+	if _, exists := p.byPosition[position]; !exists {
+		p.byPosition[position] = &selectionCounts{}
+	}
+	return p.byPosition[position]
+}
+
+func (p *SelectionHeatmaps) rankCounts(rank string) *selectionCounts {
+	if _, exists := p.byRank[rank]; !exists {
+		p.byRank[rank] = &selectionCounts{}
+	}
+	return p.byRank[rank]
+}
+
+func (h *handLayout) summary(handID string) HandSelections {
+	summary := HandSelections{TableID: h.tableID, HandID: handID}
+	for card, position := range h.positions {
+		summary.Cards = append(summary.Cards, CardSelections{
+			Card:       card,
+			Position:   position,
+			Selections: h.selections[card],
+		})
+	}
+
+	sort.Slice(summary.Cards, func(i, j int) bool {
+		if summary.Cards[i].Selections != summary.Cards[j].Selections {
+			return summary.Cards[i].Selections > summary.Cards[j].Selections
+		}
+		return summary.Cards[i].Position < summary.Cards[j].Position
+	})
+
+	return summary
+}
+
+// Heatmap returns the selection rates of every layout position and card rank
+func (p *SelectionHeatmaps) Heatmap() SelectionHeatmap {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	heatmap := SelectionHeatmap{Hands: p.handsSeen}
+
+	positions := make([]int, 0, len(p.byPosition))
+	for position := range p.byPosition {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+	for _, position := range positions {
+		heatmap.ByPosition = append(heatmap.ByPosition, newSelectionRate(strconv.Itoa(position), p.byPosition[position]))
+	}
+
+	ranks := make([]string, 0, len(p.byRank))
+	for rank := range p.byRank {
+		ranks = append(ranks, rank)
+	}
+	sort.Strings(ranks)
+	for _, rank := range ranks {
+		heatmap.ByRank = append(heatmap.ByRank, newSelectionRate(rank, p.byRank[rank]))
+	}
+
+	return heatmap
+}
+
+// Hand returns the selection breakdown of a finished hand, if it is still kept
+func (p *SelectionHeatmaps) Hand(handID string) (HandSelections, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, hand := range p.finished {
+		if hand.HandID == handID {
+			return hand, true
+		}
+	}
+	return HandSelections{}, false
+}
+
+func newSelectionRate(key string, counts *selectionCounts) SelectionRate {
+	rate := SelectionRate{Key: key, Dealt: counts.dealt, Selected: counts.selected}
+	if counts.dealt > 0 {
+		rate.Rate = float64(counts.selected) / float64(counts.dealt)
+	}
+	return rate
+}
@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playSelections deals the layout of a hand, then has each pick select a community card
+func playSelections(p *SelectionHeatmaps, handID string, layout cards.Stack, picks []int) {
+	for i, card := range layout {
+		p.HandleEvent(events.CommunityCardDealt{TableID: "table-1", HandID: handID, CardIndex: i, Card: card})
+	}
+	for _, pick := range picks {
+		p.HandleEvent(events.CommunityCardSelected{TableID: "table-1", HandID: handID, PlayerID: "player-1", Card: layout[pick].String()})
+	}
+	p.HandleEvent(events.HandEnded{TableID: "table-1", HandID: handID})
+}
+
+func TestSelectionHeatmaps(t *testing.T) {
+	layout := cards.Stack{
+		{Suit: cards.Spades, Value: cards.Ace},
+		{Suit: cards.Hearts, Value: cards.Ten},
+		{Suit: cards.Clubs, Value: cards.Two},
+	}
+
+	t.Run("Ranks the community cards of a hand by selections", func(t *testing.T) {
+		// Setup
+		p := NewSelectionHeatmaps()
+
+		// Act
+		playSelections(p, "hand-1", layout, []int{1, 1, 0})
+
+		// Assert
+		hand, found := p.Hand("hand-1")
+		require.True(t, found)
+		require.Len(t, hand.Cards, 3)
+		assert.Equal(t, CardSelections{Card: layout[1].String(), Position: 1, Selections: 2}, hand.Cards[0])
+		assert.Equal(t, CardSelections{Card: layout[0].String(), Position: 0, Selections: 1}, hand.Cards[1])
+		assert.Equal(t, 0, hand.Cards[2].Selections)
+	})
+
+	t.Run("Aggregates selection rates by position and rank across hands", func(t *testing.T) {
+		// Setup
+		p := NewSelectionHeatmaps()
+
+		// Act
+		playSelections(p, "hand-1", layout, []int{0})
+		playSelections(p, "hand-2", layout, []int{0, 1})
+
+		// Assert
+		heatmap := p.Heatmap()
+		assert.Equal(t, 2, heatmap.Hands)
+		require.Len(t, heatmap.ByPosition, 3)
+		assert.Equal(t, SelectionRate{Key: "0", Dealt: 2, Selected: 2, Rate: 1}, heatmap.ByPosition[0])
+		assert.Equal(t, SelectionRate{Key: "1", Dealt: 2, Selected: 1, Rate: 0.5}, heatmap.ByPosition[1])
+		assert.Equal(t, SelectionRate{Key: "2", Dealt: 2, Selected: 0, Rate: 0}, heatmap.ByPosition[2])
+		assert.Contains(t, heatmap.ByRank, SelectionRate{Key: string(cards.Ace), Dealt: 2, Selected: 2, Rate: 1})
+	})
+
+	t.Run("Forgets hands cut short by the table closing", func(t *testing.T) {
+		// Setup
+		p := NewSelectionHeatmaps()
+		p.HandleEvent(events.CommunityCardDealt{TableID: "table-1", HandID: "hand-1", Card: layout[0]})
+
+		// Act
+		p.HandleEvent(events.TableClosed{TableID: "table-1"})
+		p.HandleEvent(events.HandEnded{TableID: "table-1", HandID: "hand-1"})
+
+		// Assert
+		_, found := p.Hand("hand-1")
+		assert.False(t, found)
+		assert.Equal(t, 0, p.Heatmap().Hands)
+	})
+}
@@ -0,0 +1,224 @@
+package projections
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Extent of the site-wide statistics
+const (
+	statsHours       = 24        // Hours of pot totals kept
+	maxBusiestTables = 5         // Tables listed as the busiest
+	busiestWindow    = time.Hour // Tables are ranked by the hands they ended over this long
+)
+
+// HourlyPots is the chips won in the hands ended within an hour
+type HourlyPots struct {
+	Hour  time.Time // Start of the hour, UTC
+	Hands int
+	Total int
+}
+
+// BusyTable is a table ranked by the hands it played recently
+type BusyTable struct {
+	TableID   string
+	TableName string
+	Hands     int // Ended over the last hour
+	Players   int // Seated now
+}
+
+// BiggestHand is the hand won with the biggest pot of the day
+type BiggestHand struct {
+	HandID    string
+	TableID   string
+	TableName string
+	Pot       int
+	EndedAt   time.Time
+}
+
+// SiteStatsSnapshot sums up the activity of the whole site
+type SiteStatsSnapshot struct {
+	PlayersOnline  int // In the lobby
+	PlayersSeated  int // At a table, each player counted once
+	Tables         int // Open
+	PotsPerHour    []HourlyPots
+	BusiestTables  []BusyTable
+	BiggestToday   *BiggestHand // nil until a hand ends today, days start at midnight UTC
+	HandsPlayed    int          // Since the server started
+	ChipsWonInPots int          // Since the server started
+}
+
+type statsTable struct {
+	name    string
+	seated  map[string]bool
+	endings []time.Time // Hands ended over the last busiestWindow, oldest first
+}
+
+// SiteStats projects the events of every table into site-wide statistics, kept up to date as events arrive
+type SiteStats struct {
+	mu      sync.RWMutex
+	online  map[string]bool
+	tables  map[string]*statsTable
+	voided  map[string]bool // Hands voided and not ended yet, by hand ID
+	hourly  []HourlyPots    // Oldest first, at most statsHours
+	biggest *BiggestHand
+	hands   int
+	chips   int
+}
+
+// NewSiteStats creates an empty projection
+func NewSiteStats() *SiteStats {
+	return &SiteStats{
+		online: make(map[string]bool),
+		tables: make(map[string]*statsTable),
+		voided: make(map[string]bool),
+	}
+}
+
+// HandleEvent updates the projection, it is meant to be registered as an event handler
+func (p *SiteStats) HandleEvent(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e := event.(type) {
+	case events.PlayerEnteredLobby:
+		p.online[e.PlayerID] = true
+
+	case events.PlayerLeftLobby:
+		delete(p.online, e.PlayerID)
+
+	case events.TableCreated:
+		p.table(e.TableID).name = e.TableName
+
+	case events.TableClosed:
+		delete(p.tables, e.TableID)
+
+	case events.PlayerJoinedTable:
+		p.table(e.TableID).seated[e.UserID] = true
+
+	case events.PlayerLeftTable:
+		if table, exists := p.tables[e.TableID]; exists {
+			delete(table.seated, e.UserID)
+		}
+
+	case events.HandVoided:
+		p.voided[e.HandID] = true
+
+	case events.HandEnded:
+		if p.voided[e.HandID] {
+			delete(p.voided, e.HandID)
+			return
+		}
+		p.recordHand(e)
+	}
+}
+
+// table returns the state of a table, created on first sight
+func (p *SiteStats) table(tableID string) *statsTable {
+	table, exists := p.tables[tableID]
+	if !exists {
+		table = &statsTable{seated: make(map[string]bool)}
+		p.tables[tableID] = table
+	}
+	return table
+}
+
+// recordHand adds a hand to the totals of its hour, its table and its day
+func (p *SiteStats) recordHand(e events.HandEnded) {
+	at := e.At.UTC()
+	p.hands++
+	p.chips += e.FinalPot
+
+	hour := at.Truncate(time.Hour)
+	if n := len(p.hourly); n == 0 || p.hourly[n-1].Hour.Before(hour) {
+		p.hourly = append(p.hourly, HourlyPots{Hour: hour})
+		if len(p.hourly) > statsHours {
+			p.hourly = p.hourly[len(p.hourly)-statsHours:]
+		}
+	}
+	for i := len(p.hourly) - 1; i >= 0; i-- {
+		if p.hourly[i].Hour.Equal(hour) {
+			p.hourly[i].Hands++
+			p.hourly[i].Total += e.FinalPot
+			break
+		}
+	}
+
+	table := p.table(e.TableID)
+	table.endings = append(pruneEndings(table.endings, at), at)
+
+	if p.biggest == nil || !sameDay(p.biggest.EndedAt, at) || e.FinalPot > p.biggest.Pot {
+		p.biggest = &BiggestHand{HandID: e.HandID, TableID: e.TableID, TableName: table.name, Pot: e.FinalPot, EndedAt: at}
+	}
+}
+
+// Snapshot returns the statistics as of now
+func (p *SiteStats) Snapshot(now time.Time) SiteStatsSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	now = now.UTC()
+	snapshot := SiteStatsSnapshot{
+		PlayersOnline:  len(p.online),
+		Tables:         len(p.tables),
+		PotsPerHour:    []HourlyPots{},
+		BusiestTables:  []BusyTable{},
+		HandsPlayed:    p.hands,
+		ChipsWonInPots: p.chips,
+	}
+
+	seated := map[string]bool{}
+	for tableID, table := range p.tables {
+		for playerID := range table.seated {
+			seated[playerID] = true
+		}
+
+		if hands := len(pruneEndings(table.endings, now)); hands > 0 {
+			snapshot.BusiestTables = append(snapshot.BusiestTables, BusyTable{
+				TableID:   tableID,
+				TableName: table.name,
+				Hands:     hands,
+				Players:   len(table.seated),
+			})
+		}
+	}
+	snapshot.PlayersSeated = len(seated)
+
+	slices.SortFunc(snapshot.BusiestTables, func(a, b BusyTable) int {
+		return cmp.Or(cmp.Compare(b.Hands, a.Hands), cmp.Compare(a.TableID, b.TableID))
+	})
+	if len(snapshot.BusiestTables) > maxBusiestTables {
+		snapshot.BusiestTables = snapshot.BusiestTables[:maxBusiestTables]
+	}
+
+	for _, hourly := range p.hourly {
+		if now.Sub(hourly.Hour) < statsHours*time.Hour {
+			snapshot.PotsPerHour = append(snapshot.PotsPerHour, hourly)
+		}
+	}
+
+	if p.biggest != nil && sameDay(p.biggest.EndedAt, now) {
+		biggest := *p.biggest
+		snapshot.BiggestToday = &biggest
+	}
+
+	return snapshot
+}
+
+// pruneEndings drops the hand endings that are older than busiestWindow as of now
+func pruneEndings(endings []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(endings) && now.Sub(endings[i]) >= busiestWindow {
+		i++
+	}
+	return endings[i:]
+}
+
+// sameDay reports whether two times fall on the same UTC day
+func sameDay(a time.Time, b time.Time) bool {
+	return a.UTC().Truncate(24 * time.Hour).Equal(b.UTC().Truncate(24 * time.Hour))
+}
@@ -0,0 +1,121 @@
+package projections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// endStatsHand ends a hand at a table with the given pot
+func endStatsHand(p *SiteStats, tableID string, handID string, pot int, at time.Time) {
+	p.HandleEvent(events.HandEnded{TableID: tableID, HandID: handID, FinalPot: pot, At: at})
+}
+
+func TestSiteStats(t *testing.T) {
+	noon := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	setup := func() *SiteStats {
+		p := NewSiteStats()
+		p.HandleEvent(events.TableCreated{TableID: "table-1", TableName: "Main"})
+		p.HandleEvent(events.TableCreated{TableID: "table-2", TableName: "Side"})
+		return p
+	}
+
+	t.Run("Counts players online and seated", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		p.HandleEvent(events.PlayerEnteredLobby{PlayerID: "player-1"})
+		p.HandleEvent(events.PlayerEnteredLobby{PlayerID: "player-2"})
+		p.HandleEvent(events.PlayerJoinedTable{TableID: "table-1", UserID: "player-1"})
+		p.HandleEvent(events.PlayerJoinedTable{TableID: "table-2", UserID: "player-1"})
+		p.HandleEvent(events.PlayerJoinedTable{TableID: "table-2", UserID: "player-2"})
+		p.HandleEvent(events.PlayerLeftTable{TableID: "table-2", UserID: "player-2"})
+		p.HandleEvent(events.PlayerLeftLobby{PlayerID: "player-2"})
+
+		// Assert
+		snapshot := p.Snapshot(noon)
+		assert.Equal(t, 1, snapshot.PlayersOnline)
+		assert.Equal(t, 1, snapshot.PlayersSeated)
+		assert.Equal(t, 2, snapshot.Tables)
+	})
+
+	t.Run("Totals pots by hour over the last day", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		endStatsHand(p, "table-1", "hand-1", 100, noon.Add(-25*time.Hour))
+		endStatsHand(p, "table-1", "hand-2", 200, noon.Add(-50*time.Minute))
+		endStatsHand(p, "table-2", "hand-3", 300, noon.Add(-40*time.Minute))
+		endStatsHand(p, "table-1", "hand-4", 400, noon.Add(10*time.Minute))
+
+		// Assert
+		snapshot := p.Snapshot(noon.Add(30 * time.Minute))
+		assert.Equal(t, []HourlyPots{
+			{Hour: noon.Add(-time.Hour), Hands: 2, Total: 500},
+			{Hour: noon, Hands: 1, Total: 400},
+		}, snapshot.PotsPerHour)
+		assert.Equal(t, 4, snapshot.HandsPlayed)
+		assert.Equal(t, 1000, snapshot.ChipsWonInPots)
+	})
+
+	t.Run("Ranks tables by the hands of the last hour", func(t *testing.T) {
+		// Setup
+		p := setup()
+		p.HandleEvent(events.PlayerJoinedTable{TableID: "table-2", UserID: "player-1"})
+
+		// Act
+		endStatsHand(p, "table-1", "hand-1", 100, noon.Add(-2*time.Hour))
+		endStatsHand(p, "table-1", "hand-2", 100, noon.Add(-2*time.Hour))
+		endStatsHand(p, "table-1", "hand-3", 100, noon.Add(-10*time.Minute))
+		endStatsHand(p, "table-2", "hand-4", 100, noon.Add(-5*time.Minute))
+		endStatsHand(p, "table-2", "hand-5", 100, noon.Add(-time.Minute))
+
+		// Assert
+		busiest := p.Snapshot(noon).BusiestTables
+		require.Len(t, busiest, 2)
+		assert.Equal(t, BusyTable{TableID: "table-2", TableName: "Side", Hands: 2, Players: 1}, busiest[0])
+		assert.Equal(t, "table-1", busiest[1].TableID)
+	})
+
+	t.Run("Keeps the biggest hand of the day", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		endStatsHand(p, "table-1", "hand-1", 5000, noon.Add(-24*time.Hour))
+		endStatsHand(p, "table-1", "hand-2", 300, noon.Add(-time.Hour))
+		endStatsHand(p, "table-2", "hand-3", 700, noon)
+		endStatsHand(p, "table-1", "hand-4", 500, noon.Add(time.Hour))
+
+		// Assert
+		biggest := p.Snapshot(noon.Add(2 * time.Hour)).BiggestToday
+		require.NotNil(t, biggest)
+		assert.Equal(t, "hand-3", biggest.HandID)
+		assert.Equal(t, "Side", biggest.TableName)
+		assert.Equal(t, 700, biggest.Pot)
+		assert.Nil(t, p.Snapshot(noon.Add(24*time.Hour)).BiggestToday)
+	})
+
+	t.Run("Voided hands and closed tables are left out", func(t *testing.T) {
+		// Setup
+		p := setup()
+
+		// Act
+		p.HandleEvent(events.HandVoided{TableID: "table-1", HandID: "hand-1"})
+		endStatsHand(p, "table-1", "hand-1", 900, noon)
+		endStatsHand(p, "table-2", "hand-2", 100, noon)
+		p.HandleEvent(events.TableClosed{TableID: "table-2"})
+
+		// Assert
+		snapshot := p.Snapshot(noon)
+		assert.Equal(t, 1, snapshot.HandsPlayed)
+		assert.Equal(t, 1, snapshot.Tables)
+		assert.Empty(t, snapshot.BusiestTables)
+	})
+}
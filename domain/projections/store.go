@@ -0,0 +1,58 @@
+package projections
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrNotFound is returned by Store.Get for a key that holds no value
+var ErrNotFound = errors.New("projection not found")
+
+// Record is a value kept under its key in a Store
+type Record struct {
+	Key   string
+	Value []byte
+}
+
+// Store keeps the state of projections as values under keys, so every projection shares the same storage
+// whatever the database. Keys are made of parts joined by slashes, see Key, and a projection scans its own
+// records, or a subset of them, by key prefix.
+type Store interface {
+	// Upsert sets the value kept under a key
+	Upsert(ctx context.Context, key string, value []byte) error
+	// Get returns the value kept under a key, ErrNotFound if there is none
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Scan returns the records whose key starts with prefix, in key order
+	Scan(ctx context.Context, prefix string) ([]Record, error)
+}
+
+// Key joins key parts, e.g. Key("player-stats", playerID)
+func Key(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// Prefix is the prefix scanning every key under the given parts, e.g. Prefix("table-hands", "table-1")
+// finds the hands of table-1 but not those of table-10
+func Prefix(parts ...string) string {
+	return Key(parts...) + "/"
+}
+
+// UpsertJSON sets the value kept under a key to the JSON encoding of value
+func UpsertJSON(ctx context.Context, store Store, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return store.Upsert(ctx, key, data)
+}
+
+// GetJSON decodes the JSON value kept under a key into value, ErrNotFound if there is none
+func GetJSON(ctx context.Context, store Store, key string, value any) error {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,62 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Keeps the last value of each key", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		require.NoError(t, store.Upsert(ctx, Key("stats", "player-1"), []byte("1")))
+
+		// Act
+		require.NoError(t, store.Upsert(ctx, Key("stats", "player-1"), []byte("2")))
+
+		// Assert
+		value, err := store.Get(ctx, "stats/player-1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("2"), value)
+		_, err = store.Get(ctx, "stats/player-2")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Scans keys by prefix, in key order", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		for _, key := range []string{Key("hands", "table-1", "b"), Key("hands", "table-10", "a"), Key("hands", "table-1", "a"), Key("stats", "a")} {
+			require.NoError(t, store.Upsert(ctx, key, []byte(key)))
+		}
+
+		// Act
+		records, err := store.Scan(ctx, Prefix("hands", "table-1"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []Record{
+			{Key: "hands/table-1/a", Value: []byte("hands/table-1/a")},
+			{Key: "hands/table-1/b", Value: []byte("hands/table-1/b")},
+		}, records)
+	})
+
+	t.Run("Round-trips JSON values", func(t *testing.T) {
+		// Setup
+		store := NewMemoryStore()
+		totals := PlayerTotals{PlayerID: "player-1", Sessions: 2, NetResult: 150}
+		require.NoError(t, UpsertJSON(ctx, store, Key("totals", "player-1"), totals))
+
+		// Act
+		var loaded PlayerTotals
+		err := GetJSON(ctx, store, Key("totals", "player-1"), &loaded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, totals, loaded)
+	})
+}
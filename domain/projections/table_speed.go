@@ -0,0 +1,166 @@
+package projections
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// SpeedRating is the label shown in lobby listings
+type SpeedRating string
+
+const (
+	SpeedRatingUnknown SpeedRating = "unknown" // Not enough actions yet
+	SpeedRatingFast    SpeedRating = "fast"
+	SpeedRatingNormal  SpeedRating = "normal"
+	SpeedRatingSlow    SpeedRating = "slow"
+)
+
+// Thresholds used to rate tables
+const (
+	minActionsForRating   = 10
+	fastAverageActionTime = 3 * time.Second
+	slowAverageActionTime = 8 * time.Second
+	slowTimeoutsPerHour   = 6.0
+	minTimeoutRateHours   = 1.0 / 60 // Don't extrapolate timeouts over less than a minute
+)
+
+// TableSpeed is the speed summary of a table
+type TableSpeed struct {
+	Actions           int
+	AverageActionTime time.Duration
+	Timeouts          int // Turns the player did not act on
+	TimeoutsPerHour   float64
+	DidNotAct         map[string]int // Timeouts by player ID
+	Rating            SpeedRating
+}
+
+type turn struct {
+	playerID  string
+	startedAt time.Time
+}
+
+type tableSpeedState struct {
+	firstSeen   time.Time
+	lastSeen    time.Time
+	actions     int
+	actionTime  time.Duration
+	timeouts    int
+	didNotAct   map[string]int
+	currentTurn turn
+}
+
+// TableSpeeds projects table events into speed ratings, updated live as events arrive
+type TableSpeeds struct {
+	mu     sync.RWMutex
+	tables map[string]*tableSpeedState
+}
+
+// NewTableSpeeds creates an empty projection
+func NewTableSpeeds() *TableSpeeds {
+	return &TableSpeeds{
+		tables: make(map[string]*tableSpeedState),
+	}
+}
+
+// HandleEvent updates the projection, it is meant to be registered as an event handler
+func (p *TableSpeeds) HandleEvent(event events.Event) {
+	tableID := events.ExtractTableID(event)
+	if tableID == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, closed := event.(events.TableClosed); closed {
+		delete(p.tables, tableID)
+		return
+	}
+
+	state, exists := p.tables[tableID]
+	if !exists {
+		state = &tableSpeedState{
+			firstSeen: event.Timestamp(),
+			didNotAct: make(map[string]int),
+		}
+		p.tables[tableID] = state
+	}
+	state.lastSeen = event.Timestamp()
+
+	switch e := event.(type) {
+	case events.PlayerTurnStarted:
+		state.currentTurn = turn{playerID: e.PlayerID, startedAt: e.At}
+	case events.AntePlaced:
+		state.recordAction(e.PlayerID, e.At)
+	case events.ContinuationBetPlaced:
+		state.recordAction(e.PlayerID, e.At)
+	case events.PlayerFolded:
+		state.recordAction(e.PlayerID, e.At)
+	case events.PlayerTimedOut:
+		state.timeouts++
+		state.didNotAct[e.PlayerID]++
+		if state.currentTurn.playerID == e.PlayerID {
+			state.currentTurn = turn{}
+		}
+	}
+}
+
+// recordAction counts the time the player took since their turn started
+func (s *tableSpeedState) recordAction(playerID string, at time.Time) {
+	if s.currentTurn.playerID != playerID || s.currentTurn.startedAt.IsZero() {
+		return
+	}
+
+	s.actions++
+	s.actionTime += at.Sub(s.currentTurn.startedAt)
+	s.currentTurn = turn{}
+}
+
+// Get returns the speed of a table
+func (p *TableSpeeds) Get(tableID string) TableSpeed {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	state, exists := p.tables[tableID]
+	if !exists {
+		return TableSpeed{Rating: SpeedRatingUnknown, DidNotAct: map[string]int{}}
+	}
+
+	speed := TableSpeed{
+		Actions:   state.actions,
+		Timeouts:  state.timeouts,
+		DidNotAct: make(map[string]int, len(state.didNotAct)),
+	}
+	for playerID, count := range state.didNotAct {
+		speed.DidNotAct[playerID] = count
+	}
+
+	if state.actions > 0 {
+		speed.AverageActionTime = state.actionTime / time.Duration(state.actions)
+	}
+
+	hours := state.lastSeen.Sub(state.firstSeen).Hours()
+	speed.TimeoutsPerHour = float64(state.timeouts) / max(hours, minTimeoutRateHours)
+
+	speed.Rating = rateSpeed(speed)
+
+	return speed
+}
+
+func rateSpeed(speed TableSpeed) SpeedRating {
+	if speed.Actions < minActionsForRating {
+		return SpeedRatingUnknown
+	}
+
+	if speed.AverageActionTime >= slowAverageActionTime || speed.TimeoutsPerHour >= slowTimeoutsPerHour {
+		return SpeedRatingSlow
+	}
+
+	if speed.AverageActionTime < fastAverageActionTime {
+		return SpeedRatingFast
+	}
+
+	return SpeedRatingNormal
+}
@@ -0,0 +1,74 @@
+package projections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// playTurns feeds n turns where each player acts after the given delay
+func playTurns(p *TableSpeeds, start time.Time, n int, delay time.Duration) time.Time {
+	at := start
+	for i := 0; i < n; i++ {
+		p.HandleEvent(events.PlayerTurnStarted{TableID: "table-1", PlayerID: "player-1", At: at})
+		at = at.Add(delay)
+		p.HandleEvent(events.AntePlaced{TableID: "table-1", PlayerID: "player-1", At: at})
+	}
+	return at
+}
+
+func TestTableSpeeds(t *testing.T) {
+	t.Run("Quick actions rate the table fast", func(t *testing.T) {
+		// Setup
+		p := NewTableSpeeds()
+
+		// Act
+		playTurns(p, time.Now(), 10, time.Second)
+
+		// Assert
+		speed := p.Get("table-1")
+		assert.Equal(t, 10, speed.Actions)
+		assert.Equal(t, time.Second, speed.AverageActionTime)
+		assert.Equal(t, SpeedRatingFast, speed.Rating)
+	})
+
+	t.Run("Timeouts are counted per player and slow the table down", func(t *testing.T) {
+		// Setup
+		p := NewTableSpeeds()
+		start := time.Now()
+		at := playTurns(p, start, 10, time.Second)
+
+		// Act
+		for i := 0; i < 3; i++ {
+			p.HandleEvent(events.PlayerTurnStarted{TableID: "table-1", PlayerID: "player-2", At: at})
+			at = at.Add(5 * time.Second)
+			p.HandleEvent(events.PlayerTimedOut{TableID: "table-1", PlayerID: "player-2", At: at})
+		}
+
+		// Assert
+		speed := p.Get("table-1")
+		assert.Equal(t, 3, speed.Timeouts)
+		assert.Equal(t, 3, speed.DidNotAct["player-2"])
+		assert.Equal(t, 10, speed.Actions, "timeouts are not actions")
+		assert.Equal(t, SpeedRatingSlow, speed.Rating)
+	})
+
+	t.Run("Not enough data yet", func(t *testing.T) {
+		p := NewTableSpeeds()
+		playTurns(p, time.Now(), 2, time.Second)
+
+		assert.Equal(t, SpeedRatingUnknown, p.Get("table-1").Rating)
+		assert.Equal(t, SpeedRatingUnknown, p.Get("unknown-table").Rating)
+	})
+
+	t.Run("Closed tables are forgotten", func(t *testing.T) {
+		p := NewTableSpeeds()
+		playTurns(p, time.Now(), 10, time.Second)
+
+		p.HandleEvent(events.TableClosed{TableID: "table-1", At: time.Now()})
+
+		assert.Equal(t, 0, p.Get("table-1").Actions)
+	})
+}
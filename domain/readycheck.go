@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// StartReadyCheck asks the seated players to confirm they are ready, before the hand moves on to the antes.
+// The check completes as soon as everyone is ready, or when the table's ReadyCheck window runs out.
+func (h *Hand) StartReadyCheck() {
+	if !h.IsInPhase(HandPhase_Start) || h.ReadyPlayers != nil {
+		return
+	}
+
+	h.ReadyPlayers = make(map[string]bool)
+
+	playerIDs := make([]string, len(h.Players))
+	for i, player := range h.Players {
+		playerIDs[i] = player.ID
+	}
+
+	deadline := h.clock().Now().Add(h.TableRules.ReadyCheck)
+	h.readyTimer = h.clock().AfterFunc(h.TableRules.ReadyCheck, func() {
+		h.guard("ready-check timeout", func() error {
+			h.CompleteReadyCheck()
+			return nil
+		})
+	})
+
+	h.emitEvent(events.ReadyCheckStarted{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		Players:  playerIDs,
+		Deadline: deadline,
+		At:       h.clock().Now(),
+	})
+}
+
+// PlayerReady records a player confirming the ready-check
+func (h *Hand) PlayerReady(playerID string) error {
+	if !h.IsInPhase(HandPhase_Start) || h.ReadyPlayers == nil {
+		return errors.New("no ready-check in progress")
+	}
+
+	if h.getPlayerByID(playerID) == nil {
+		return errors.New("player is not in the hand")
+	}
+
+	if h.ReadyPlayers[playerID] {
+		return errors.New("player already confirmed")
+	}
+
+	h.ReadyPlayers[playerID] = true
+
+	h.emitEvent(events.PlayerReady{
+		TableID:  h.TableID,
+		HandID:   h.ID,
+		PlayerID: playerID,
+		At:       h.clock().Now(),
+	})
+
+	if len(h.ReadyPlayers) == len(h.Players) {
+		h.CompleteReadyCheck()
+	}
+
+	return nil
+}
+
+// CompleteReadyCheck sits out the players who did not confirm, then deals the hand if at least two players are ready
+func (h *Hand) CompleteReadyCheck() {
+	if !h.IsInPhase(HandPhase_Start) || h.ReadyPlayers == nil {
+		return
+	}
+
+	if h.readyTimer != nil {
+		h.readyTimer.Stop()
+		h.readyTimer = nil
+	}
+
+	ready := []string{}
+	satOut := []string{}
+	players := []*Player{}
+	for _, player := range h.Players {
+		if h.ReadyPlayers[player.ID] {
+			ready = append(ready, player.ID)
+			players = append(players, player)
+			continue
+		}
+
+		// Players sitting out take no part in the hand at all
+		satOut = append(satOut, player.ID)
+		delete(h.ActivePlayers, player.ID)
+		delete(h.HoleCards, player.ID)
+	}
+
+	// A new slice, as the hand shares its players slice with the table
+	h.Players = players
+	h.ReadyPlayers = nil
+	h.CurrentBettor = h.getPlayerLeftOfButton()
+
+	h.emitEvent(events.ReadyCheckCompleted{
+		TableID: h.TableID,
+		HandID:  h.ID,
+		Ready:   ready,
+		SatOut:  satOut,
+		At:      h.clock().Now(),
+	})
+
+	if len(ready) < 2 {
+		h.TransitionToEndedPhase()
+		return
+	}
+
+	h.TransitionToAntesPhase()
+}
+
+// getPlayerByID returns the player in the hand with the given ID, nil if there is none
+func (h *Hand) getPlayerByID(playerID string) *Player {
+	for _, player := range h.Players {
+		if player.ID == playerID {
+			return player
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyCheck(t *testing.T) {
+	setup := func(numPlayers int, window time.Duration) *Hand {
+		table := NewTestTable()
+		players := make([]*Player, numPlayers)
+		for i := 0; i < numPlayers; i++ {
+			players[i] = &Player{ID: "player-" + fmt.Sprint(1+i)}
+			table.BuyIns[players[i].ID] = 1000
+		}
+
+		hand := &Hand{
+			ID:         "test-hand-id",
+			TableID:    "test-table-id",
+			Table:      table,
+			Phase:      HandPhase_Start,
+			Players:    players,
+			TableRules: TableRules{AnteValue: 10, PlayerTimeout: 30 * time.Second, ReadyCheck: window},
+		}
+		require.NoError(t, hand.InitializeHand())
+		hand.StartReadyCheck()
+		return hand
+	}
+
+	t.Run("Deals once every player is ready", func(t *testing.T) {
+		// Setup
+		hand := setup(3, time.Minute)
+
+		// Act
+		for _, player := range hand.Players {
+			require.NoError(t, hand.PlayerReady(player.ID))
+		}
+
+		// Assert
+		assert.Equal(t, HandPhase_Antes, hand.Phase)
+		assert.Len(t, hand.Players, 3)
+		event, found := findEventOfType(hand.Events, events.ReadyCheckCompleted{}.Name())
+		require.True(t, found)
+		assert.Empty(t, event.(events.ReadyCheckCompleted).SatOut)
+	})
+
+	t.Run("Players who do not confirm sit the hand out", func(t *testing.T) {
+		// Setup
+		hand := setup(3, time.Minute)
+		seated := hand.Players
+		require.NoError(t, hand.PlayerReady("player-1"))
+		require.NoError(t, hand.PlayerReady("player-3"))
+
+		// Act
+		hand.CompleteReadyCheck()
+
+		// Assert
+		assert.Equal(t, HandPhase_Antes, hand.Phase)
+		assert.Len(t, hand.Players, 2)
+		assert.Len(t, seated, 3, "the table's seated players are left untouched")
+		assert.False(t, hand.IsPlayerActive("player-2"))
+		assert.NotContains(t, hand.HoleCards, "player-2")
+
+		event, found := findEventOfType(hand.Events, events.ReadyCheckCompleted{}.Name())
+		require.True(t, found)
+		completed := event.(events.ReadyCheckCompleted)
+		assert.Equal(t, []string{"player-1", "player-3"}, completed.Ready)
+		assert.Equal(t, []string{"player-2"}, completed.SatOut)
+
+		// The antes round only waits for the players who are in
+		for i := 0; i < 2; i++ {
+			require.NoError(t, hand.PlayerPlacesAnte(hand.CurrentBettor, hand.TableRules.AnteValue))
+		}
+		assert.Equal(t, HandPhase_Hole, hand.Phase)
+	})
+
+	t.Run("Ends the hand when fewer than two players are ready", func(t *testing.T) {
+		// Setup
+		hand := setup(3, time.Minute)
+		require.NoError(t, hand.PlayerReady("player-1"))
+
+		// Act
+		hand.CompleteReadyCheck()
+
+		// Assert
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		_, found := findEventOfType(hand.Events, events.ReadyCheckCompleted{}.Name())
+		assert.True(t, found)
+	})
+
+	t.Run("Rejects confirmations outside a ready-check", func(t *testing.T) {
+		// Setup
+		hand := setup(2, time.Minute)
+		require.NoError(t, hand.PlayerReady("player-1"))
+
+		// Act
+		errAgain := hand.PlayerReady("player-1")
+		errStranger := hand.PlayerReady("player-9")
+		require.NoError(t, hand.PlayerReady("player-2"))
+		errAfter := hand.PlayerReady("player-2")
+
+		// Assert
+		assert.EqualError(t, errAgain, "player already confirmed")
+		assert.EqualError(t, errStranger, "player is not in the hand")
+		assert.EqualError(t, errAfter, "no ready-check in progress")
+	})
+}
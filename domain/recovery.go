@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ErrHandCrashed is returned when the hand in progress was cancelled because the table hit an internal error
+var ErrHandCrashed = errors.New("hand cancelled after an internal error")
+
+// guard runs f on behalf of the table and contains any panic to it: the stack is printed with the
+// table and hand, and the hand in progress is cancelled so its players get their bets back.
+// Every entry point into a table's game loop (player actions, timers) goes through it, so a bug in
+// one hand can't take the other tables down with the process. Entry points take turns: f runs
+// once the previous one is done, so hands never see two changes at once.
+func (t *Table) guard(where string, f func() error) (err error) {
+	t.loop.Lock()
+	defer t.loop.Unlock()
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		hand := t.ActiveHand
+		handID := ""
+		if hand != nil {
+			handID = hand.ID
+		}
+		fmt.Printf("Panic in %s at table %s, hand %s: %v\n%s", where, t.ID, handID, recovered, debug.Stack())
+
+		t.cancelCrashedHand(hand)
+		err = ErrHandCrashed
+	}()
+
+	return f()
+}
+
+// Do runs f in the table's game loop, for callers outside the table such as command handlers
+// or another table's tournament. Code already running in the loop, event handlers included, calls
+// the table directly: calling Do from there would wait on itself.
+func (t *Table) Do(where string, f func() error) error {
+	return t.guard(where, f)
+}
+
+// cancelCrashedHand ends a hand left in an unknown state. Bets are refunded unless part of the pot
+// was already paid out, as refunding then would hand out chips twice. Should ending the hand panic
+// too, the table drops it so the next hand can start.
+func (t *Table) cancelCrashedHand(hand *Hand) {
+	if hand == nil || hand.IsInPhase(HandPhase_Ended) {
+		return
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Printf("Could not cancel hand %s at table %s, dropping it: %v\n%s", hand.ID, t.ID, recovered, debug.Stack())
+			t.dropHand(hand)
+		}
+	}()
+
+	for _, event := range hand.Events {
+		if _, paid := event.(events.PotAmountAwarded); paid {
+			hand.TransitionToEndedPhase()
+			return
+		}
+	}
+	if err := hand.voidHand("internal error"); err != nil {
+		fmt.Printf("Could not refund hand %s at table %s, dropping it: %v\n", hand.ID, t.ID, err)
+		t.dropHand(hand)
+	}
+}
+
+// dropHand lets go of a hand that could not be ended, so the next hand can start
+func (t *Table) dropHand(hand *Hand) {
+	t.stopTurnTimer()
+	t.mu.Lock()
+	if t.ActiveHand == hand {
+		t.ActiveHand = nil
+	}
+	t.mu.Unlock()
+}
+
+// guard runs f through the table's guard, hands without a table (in tests) run it as is
+func (h *Hand) guard(where string, f func() error) error {
+	if h.Table == nil {
+		return f()
+	}
+	return h.Table.guard(where, f)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	t.Run("A panic cancels the hand in progress and refunds the bets", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		table.ActiveHand = hand
+		bettor := hand.CurrentBettor
+		require.NoError(t, hand.PlayerPlacesAnte(bettor, 10))
+		require.Equal(t, 990, table.GetPlayerBuyIn(bettor))
+
+		// Act
+		err := table.guard("test", func() error { panic("boom") })
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandCrashed)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		assert.Equal(t, 1000, table.GetPlayerBuyIn(bettor))
+		event, found := findEventOfType(hand.Events, events.HandVoided{}.Name())
+		require.True(t, found)
+		assert.Equal(t, map[string]int{bettor: 10}, event.(events.HandVoided).Refunds)
+	})
+
+	t.Run("A hand that already paid out is ended without refunds", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		table.ActiveHand = hand
+		require.NoError(t, hand.PlayerPlacesAnte(hand.CurrentBettor, 10))
+		hand.Events = append(hand.Events, events.PotAmountAwarded{HandID: hand.ID, PlayerID: "player-1", Amount: 10, At: time.Now()})
+
+		// Act
+		err := table.guard("test", func() error { panic("boom") })
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandCrashed)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+		_, voided := findEventOfType(hand.Events, events.HandVoided{}.Name())
+		assert.False(t, voided)
+	})
+
+	t.Run("A panic between hands is contained", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		err := table.guard("test", func() error { panic("boom") })
+
+		// Assert
+		assert.ErrorIs(t, err, ErrHandCrashed)
+	})
+
+	t.Run("Errors go through untouched", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		failure := errors.New("failure")
+
+		// Act
+		err := table.guard("test", func() error { return failure })
+
+		// Assert
+		assert.Equal(t, failure, err)
+	})
+}
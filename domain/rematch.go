@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ErrRematchNotAllowed is returned when a rematch is proposed at a table that isn't private
+var ErrRematchNotAllowed = errors.New("rematches are only played at private tables")
+
+// ErrTableNotFinished is returned when a rematch is proposed while the players still have a game to play
+var ErrTableNotFinished = errors.New("table is not finished, at least two players still have chips")
+
+// ErrRematchPending is returned when a rematch is proposed at a table that already has one waiting
+var ErrRematchPending = errors.New("a rematch is already proposed")
+
+// ErrNoRematch is returned when a player accepts a rematch nobody proposed
+var ErrNoRematch = errors.New("no rematch is proposed")
+
+// rematch is a proposed rematch, waiting for every seated player to accept it
+type rematch struct {
+	buyIn    int
+	accepted map[string]bool
+}
+
+// IsFinished checks if the table's game is over: the table played, no hand is underway and fewer than two
+// players have chips left
+func (t *Table) IsFinished() bool {
+	if t.Status != TableStatusPlaying {
+		return false
+	}
+	if hand := t.ActiveHand; hand != nil && hand.initialized && !hand.HasEnded() {
+		return false
+	}
+
+	withChips := 0
+	for _, player := range t.Players {
+		if t.GetPlayerBuyIn(player.ID) > 0 {
+			withChips++
+		}
+	}
+	return withChips < 2
+}
+
+// ProposeRematch asks the players of a finished private table to play again, each buying in for buyIn.
+// The proposer and the table's bots accept straight away, the rematch starts once everyone seated has.
+func (t *Table) ProposeRematch(playerID string, buyIn int) error {
+	if !t.Rules.Private {
+		return ErrRematchNotAllowed
+	}
+	if !t.IsFinished() {
+		return ErrTableNotFinished
+	}
+	if t.rematch != nil {
+		return ErrRematchPending
+	}
+	if t.seatedPlayer(playerID) == nil {
+		return errors.New("player not found")
+	}
+	if buyIn <= 0 {
+		return errors.New("rematch buy-in must be positive")
+	}
+	if t.Rules.MaxBuyIn > 0 && buyIn > t.Rules.MaxBuyIn {
+		return errors.New("rematch buy-in exceeds the maximum buy-in")
+	}
+	if err := t.checkRematchBuyIn(playerID, buyIn); err != nil {
+		return err
+	}
+
+	t.rematch = &rematch{buyIn: buyIn, accepted: map[string]bool{playerID: true}}
+
+	players := make([]string, 0, len(t.Players))
+	for _, player := range t.Players {
+		players = append(players, player.ID)
+		if t.IsBot(player.ID) {
+			t.rematch.accepted[player.ID] = true
+		}
+	}
+
+	t.emitEvent(events.RematchProposed{
+		TableID:    t.ID,
+		ProposedBy: playerID,
+		BuyIn:      buyIn,
+		Players:    players,
+		At:         t.clock().Now(),
+	})
+
+	t.startRematchIfAccepted()
+	return nil
+}
+
+// AcceptRematch records a player accepting the proposed rematch, which starts once everyone seated has
+func (t *Table) AcceptRematch(playerID string) error {
+	if t.rematch == nil {
+		return ErrNoRematch
+	}
+	if t.seatedPlayer(playerID) == nil {
+		return errors.New("player not found")
+	}
+	if t.rematch.accepted[playerID] {
+		return nil
+	}
+	if err := t.checkRematchBuyIn(playerID, t.rematch.buyIn); err != nil {
+		return err
+	}
+
+	t.rematch.accepted[playerID] = true
+
+	t.emitEvent(events.RematchAccepted{
+		TableID:  t.ID,
+		PlayerID: playerID,
+		Pending:  t.pendingRematchPlayers(),
+		At:       t.clock().Now(),
+	})
+
+	t.startRematchIfAccepted()
+	return nil
+}
+
+// checkRematchBuyIn checks that the player can buy in for the rematch with what they have now and what they cash out.
+// Bots bring their own stack.
+func (t *Table) checkRematchBuyIn(playerID string, buyIn int) error {
+	player := t.seatedPlayer(playerID)
+	if t.IsBot(playerID) || t.Rules.TournamentChips {
+		return nil
+	}
+	if player.Bankroll(t.Wallet)+t.GetPlayerBuyIn(playerID) < buyIn {
+		return errors.New("player does not have enough balance for the rematch")
+	}
+	return nil
+}
+
+// pendingRematchPlayers returns the seated players who have yet to accept the rematch, in seat order
+func (t *Table) pendingRematchPlayers() []string {
+	pending := []string{}
+	for _, player := range t.Players {
+		if !t.rematch.accepted[player.ID] {
+			pending = append(pending, player.ID)
+		}
+	}
+	return pending
+}
+
+// handleRematchLeave drops a leaving player from the proposed rematch, which goes on without them
+// as long as two players are still seated
+func (t *Table) handleRematchLeave(playerID string) {
+	if t.rematch == nil {
+		return
+	}
+	delete(t.rematch.accepted, playerID)
+
+	if len(t.Players) < 2 {
+		t.rematch = nil
+		t.emitEvent(events.RematchCancelled{
+			TableID: t.ID,
+			Reason:  "not enough players",
+			At:      t.clock().Now(),
+		})
+		return
+	}
+
+	t.startRematchIfAccepted()
+}
+
+func (t *Table) startRematchIfAccepted() {
+	if len(t.Players) >= 2 && len(t.pendingRematchPlayers()) == 0 {
+		t.startRematch()
+	}
+}
+
+// startRematch resets the table with the rules it opened with: every player cashes out and buys in again for the
+// rematch's buy-in, their sessions start over, and the table waits for its first hand
+func (t *Table) startRematch() {
+	buyIn := t.rematch.buyIn
+	t.rematch = nil
+
+	// The hand dealt after the last one never started, there was nobody to play it
+	t.mu.Lock()
+	if hand := t.ActiveHand; hand != nil && !hand.initialized {
+		t.Hands = slices.DeleteFunc(t.Hands, func(h *Hand) bool { return h == hand })
+	}
+	t.ActiveHand = nil
+	t.Status = TableStatusWaiting
+	t.mu.Unlock()
+
+	if t.openingAnte > 0 {
+		t.Rules.AnteValue = t.openingAnte
+	}
+	t.recentPots = nil
+	t.anteCooldown = 0
+	t.pendingTopUps = nil
+	t.Left = nil
+	t.NextHand = nil
+
+	stacks := make(map[string]int, len(t.Players))
+	for _, player := range t.Players {
+		t.endSession(player.ID, "rematch")
+		if err := t.cashOut(player); err != nil {
+			// Rather than losing the stack, the player plays the rematch with it
+			fmt.Println("Could not cash player", player.ID, "out for the rematch at table", t.ID, ":", err)
+		} else {
+			t.removePlayerFromBuyIns(player.ID)
+
+			if t.IsBot(player.ID) || t.Rules.TournamentChips {
+				t.IncreasePlayerBuyIn(player.ID, buyIn)
+			} else if err := t.PlayerBuysIn(player.ID, buyIn); err != nil {
+				fmt.Println("Could not buy player", player.ID, "in for the rematch at table", t.ID, ":", err)
+			}
+		}
+
+		stacks[player.ID] = t.GetPlayerBuyIn(player.ID)
+		t.startSession(player.ID, t.clock().Now())
+	}
+
+	t.emitEvent(events.RematchStarted{
+		TableID: t.ID,
+		BuyIn:   buyIn,
+		Stacks:  stacks,
+		At:      t.clock().Now(),
+	})
+
+	t.scheduleFirstHand()
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRematch(t *testing.T) {
+	// setup seats alice and bob at a finished private table: alice won bob's 200 chips
+	setup := func(private bool) (*Table, *Player, *Player) {
+		table := NewTable("Friends Table", TableRules{AnteValue: 10, Private: private})
+		alice := &Player{ID: "alice", Balance: 1000}
+		bob := &Player{ID: "bob", Balance: 1000}
+		require.NoError(t, table.SeatPlayer(alice))
+		require.NoError(t, table.SeatPlayer(bob))
+		require.NoError(t, table.PlayerBuysIn(alice.ID, 200))
+		require.NoError(t, table.PlayerBuysIn(bob.ID, 200))
+		require.NoError(t, table.AllowPlaying())
+
+		table.BuyIns[alice.ID] = 400
+		table.BuyIns[bob.ID] = 0
+		table.Rules.AnteValue = 40
+		return table, alice, bob
+	}
+
+	t.Run("Only finished private tables play rematches", func(t *testing.T) {
+		// Setup
+		public, _, _ := setup(false)
+		playing, _, _ := setup(true)
+		playing.BuyIns["bob"] = 100
+
+		// Act & Assert
+		assert.ErrorIs(t, public.ProposeRematch("alice", 200), ErrRematchNotAllowed)
+		assert.ErrorIs(t, playing.ProposeRematch("alice", 200), ErrTableNotFinished)
+		assert.ErrorIs(t, playing.AcceptRematch("alice"), ErrNoRematch)
+	})
+
+	t.Run("Waits for every player to accept", func(t *testing.T) {
+		// Setup
+		table, alice, bob := setup(true)
+
+		// Act
+		require.NoError(t, table.ProposeRematch(alice.ID, 300))
+
+		// Assert
+		assert.ErrorIs(t, table.ProposeRematch(bob.ID, 300), ErrRematchPending)
+		assert.Equal(t, TableStatusPlaying, table.Status)
+		event, found := findEventOfType(table.Events, events.RematchProposed{}.Name())
+		require.True(t, found)
+		assert.Equal(t, "alice", event.(events.RematchProposed).ProposedBy)
+		assert.Equal(t, []string{"alice", "bob"}, event.(events.RematchProposed).Players)
+		_, found = findEventOfType(table.Events, events.RematchStarted{}.Name())
+		assert.False(t, found)
+	})
+
+	t.Run("A player the wallet can't cash out keeps their stack for the rematch", func(t *testing.T) {
+		// Setup
+		table, alice, bob := setup(true)
+		ledger := wallet.NewLedger()
+		ledger.Credit(alice.ID, 800, "welcome/alice", "starting balance")
+		ledger.Credit(bob.ID, 800, "welcome/bob", "starting balance")
+		table.Wallet = &unavailableWallet{Ledger: ledger, down: true}
+		require.NoError(t, table.ProposeRematch(alice.ID, 300))
+
+		// Act
+		err := table.AcceptRematch(bob.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 400, table.GetPlayerBuyIn(alice.ID), "alice's winnings aren't lost")
+		assert.Equal(t, 800, ledger.Balance(alice.ID))
+		assert.Equal(t, 300, table.GetPlayerBuyIn(bob.ID))
+		assert.Equal(t, 800-300, ledger.Balance(bob.ID))
+	})
+
+	t.Run("Resets the table with fresh stacks once everyone accepted", func(t *testing.T) {
+		// Setup
+		table, alice, bob := setup(true)
+		require.NoError(t, table.ProposeRematch(alice.ID, 300))
+
+		// Act
+		err := table.AcceptRematch(bob.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, TableStatusWaiting, table.Status)
+		assert.Equal(t, 10, table.Rules.AnteValue, "the ante goes back to the opening one")
+		assert.Equal(t, 300, table.GetPlayerBuyIn(alice.ID))
+		assert.Equal(t, 300, table.GetPlayerBuyIn(bob.ID))
+		assert.Equal(t, 1000-200+400-300, alice.Balance, "alice cashed out her winnings before buying in again")
+		assert.Equal(t, 1000-200-300, bob.Balance)
+		assert.Zero(t, table.sessions[alice.ID].HandsPlayed, "sessions start over")
+
+		accepted, found := findEventOfType(table.Events, events.RematchAccepted{}.Name())
+		require.True(t, found)
+		assert.Empty(t, accepted.(events.RematchAccepted).Pending)
+		started, found := findEventOfType(table.Events, events.RematchStarted{}.Name())
+		require.True(t, found)
+		assert.Equal(t, map[string]int{"alice": 300, "bob": 300}, started.(events.RematchStarted).Stacks)
+	})
+
+	t.Run("Players who can't afford the buy-in can't accept", func(t *testing.T) {
+		// Setup
+		table, alice, bob := setup(true)
+		bob.Balance = 100
+		require.NoError(t, table.ProposeRematch(alice.ID, 300))
+
+		// Act
+		err := table.AcceptRematch(bob.ID)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, TableStatusPlaying, table.Status)
+	})
+
+	t.Run("Is cancelled when too few players are left", func(t *testing.T) {
+		// Setup
+		table, alice, bob := setup(true)
+		require.NoError(t, table.ProposeRematch(alice.ID, 300))
+
+		// Act
+		require.NoError(t, table.PlayerLeaves(bob.ID))
+
+		// Assert
+		event, found := findEventOfType(table.Events, events.RematchCancelled{}.Name())
+		require.True(t, found)
+		assert.Equal(t, "not enough players", event.(events.RematchCancelled).Reason)
+		assert.ErrorIs(t, table.AcceptRematch(alice.ID), ErrNoRematch)
+	})
+}
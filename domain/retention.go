@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// RetentionPolicy bounds how much history tables and the lobby keep in memory
+type RetentionPolicy struct {
+	EventMaxAge time.Duration // Raw events older than this are pruned, zero keeps them forever
+	HandMaxAge  time.Duration // Completed hands (the per-hand summaries) older than this are dropped, zero keeps them forever
+}
+
+// PruneReport counts what a pruning pass removed, or would remove in dry-run mode
+type PruneReport struct {
+	EventsPruned int
+	HandsPruned  int
+	DryRun       bool
+}
+
+// Add accumulates another report into this one
+func (r *PruneReport) Add(other PruneReport) {
+	r.EventsPruned += other.EventsPruned
+	r.HandsPruned += other.HandsPruned
+}
+
+// Prune removes the table history that falls outside the policy.
+// The active hand is never touched. In dry-run mode nothing is removed.
+func (t *Table) Prune(policy RetentionPolicy, now time.Time, dryRun bool) PruneReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := PruneReport{DryRun: dryRun}
+
+	if policy.EventMaxAge > 0 {
+		cutoff := now.Add(-policy.EventMaxAge)
+
+		n := countEventsBefore(t.Events, cutoff)
+		report.EventsPruned += n
+		if !dryRun {
+			t.Events = t.Events[n:]
+		}
+
+		for _, hand := range t.Hands {
+			if hand == t.ActiveHand {
+				continue
+			}
+			n := countEventsBefore(hand.Events, cutoff)
+			report.EventsPruned += n
+			if !dryRun {
+				hand.Events = hand.Events[n:]
+			}
+		}
+	}
+
+	if policy.HandMaxAge > 0 {
+		cutoff := now.Add(-policy.HandMaxAge)
+
+		kept := make([]*Hand, 0, len(t.Hands))
+		for _, hand := range t.Hands {
+			if hand != t.ActiveHand && hand.StartedAt.Before(cutoff) {
+				report.HandsPruned++
+				continue
+			}
+			kept = append(kept, hand)
+		}
+		if !dryRun {
+			t.Hands = kept
+		}
+	}
+
+	return report
+}
+
+// Prune applies the retention policy to the lobby's event log and to every table
+func (l *Lobby) Prune(policy RetentionPolicy, now time.Time, dryRun bool) PruneReport {
+	report := PruneReport{DryRun: dryRun}
+
+	if policy.EventMaxAge > 0 {
+		l.eventsMu.Lock()
+		n := countEventsBefore(l.Events, now.Add(-policy.EventMaxAge))
+		report.EventsPruned += n
+		if !dryRun {
+			l.Events = l.Events[n:]
+		}
+		l.eventsMu.Unlock()
+	}
+
+	for _, table := range l.GetTables() {
+		report.Add(table.Prune(policy, now, dryRun))
+	}
+
+	return report
+}
+
+// countEventsBefore counts the leading events emitted before cutoff, logs being in emission order
+func countEventsBefore(log []events.Event, cutoff time.Time) int {
+	n := 0
+	for n < len(log) && log[n].Timestamp().Before(cutoff) {
+		n++
+	}
+	return n
+}
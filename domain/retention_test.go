@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTablePrune(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	setup := func() (*Table, *Hand) {
+		table := NewTable("Test Table", TableRules{})
+		table.Events = []events.Event{
+			events.PlayerJoinedTable{TableID: table.ID, UserID: "player-1", At: old},
+			events.PlayerJoinedTable{TableID: table.ID, UserID: "player-2", At: now},
+		}
+
+		completed := &Hand{
+			ID:        "old-hand",
+			StartedAt: old,
+			Events:    []events.Event{events.HandStarted{HandID: "old-hand", At: old}},
+		}
+		active := &Hand{
+			ID:        "active-hand",
+			StartedAt: old,
+			Events:    []events.Event{events.HandStarted{HandID: "active-hand", At: old}},
+		}
+		table.Hands = []*Hand{completed, active}
+		table.ActiveHand = active
+
+		return table, completed
+	}
+
+	t.Run("Prunes old events but keeps hand summaries", func(t *testing.T) {
+		// Setup
+		table, completed := setup()
+
+		// Act
+		report := table.Prune(RetentionPolicy{EventMaxAge: 24 * time.Hour}, now, false)
+
+		// Assert
+		assert.Equal(t, 2, report.EventsPruned)
+		assert.Equal(t, 0, report.HandsPruned)
+		assert.Len(t, table.Events, 1)
+		assert.Empty(t, completed.Events)
+		assert.Len(t, table.ActiveHand.Events, 1, "the active hand is never pruned")
+		assert.Len(t, table.Hands, 2)
+	})
+
+	t.Run("Drops old completed hands", func(t *testing.T) {
+		// Setup
+		table, _ := setup()
+
+		// Act
+		report := table.Prune(RetentionPolicy{HandMaxAge: 24 * time.Hour}, now, false)
+
+		// Assert
+		assert.Equal(t, 1, report.HandsPruned)
+		assert.Len(t, table.Hands, 1)
+		assert.Equal(t, "active-hand", table.Hands[0].ID)
+	})
+
+	t.Run("Dry run reports without removing", func(t *testing.T) {
+		// Setup
+		table, _ := setup()
+
+		// Act
+		report := table.Prune(RetentionPolicy{EventMaxAge: 24 * time.Hour, HandMaxAge: 24 * time.Hour}, now, true)
+
+		// Assert
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 2, report.EventsPruned)
+		assert.Equal(t, 1, report.HandsPruned)
+		assert.Len(t, table.Events, 2)
+		assert.Len(t, table.Hands, 2)
+	})
+}
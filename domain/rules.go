@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrInvalidRules is returned for table rules that make no sense, such as a zero ante or a negative timeout
+var ErrInvalidRules = errors.New("invalid table rules")
+
+// ErrUnknownRulesPreset is returned when asking for a rules preset that doesn't exist
+var ErrUnknownRulesPreset = errors.New("unknown rules preset")
+
+// Rules presets players pick from when they create a table
+const (
+	RulesPresetStandard = "standard" // The default rules
+	RulesPresetTurbo    = "turbo"    // Short clocks, and an ante that keeps going up
+	RulesPresetDeep     = "deep"     // Long clocks for deep-stacked, thoughtful play
+)
+
+// rulesPresets adjust the default rules of a table, by preset name
+var rulesPresets = map[string]func(rules *TableRules){
+	RulesPresetStandard: func(rules *TableRules) {},
+	RulesPresetTurbo: func(rules *TableRules) {
+		rules.PlayerTimeout = 3 * time.Second
+		rules.SelectionWindow = 3 * time.Second
+		rules.StartCountdown = 5 * time.Second
+		rules.AnteScaling = AnteScaling{
+			Hands:         3,
+			MinAveragePot: rules.AnteValue * 20,
+			Step:          rules.AnteValue,
+			MaxAnte:       rules.AnteValue * 10,
+			CooldownHands: 3,
+		}
+	},
+	RulesPresetDeep: func(rules *TableRules) {
+		rules.PlayerTimeout = 10 * time.Second
+		rules.SelectionWindow = 8 * time.Second
+		rules.StartCountdown = 20 * time.Second
+		rules.ConfirmBetsAbove = 25
+	},
+}
+
+// PresetTableRules returns the rules of the named preset, for a table with the given seats and ante.
+// An empty name is the standard preset.
+func PresetTableRules(name string, maxPlayers int, ante int) (TableRules, error) {
+	if name == "" {
+		name = RulesPresetStandard
+	}
+	preset, exists := rulesPresets[name]
+	if !exists {
+		return TableRules{}, fmt.Errorf("%w: %q", ErrUnknownRulesPreset, name)
+	}
+
+	rules := DefaultTableRules(maxPlayers, ante*10)
+	preset(&rules)
+	return rules, nil
+}
+
+// RulesPresetNames lists the rules presets, in name order
+func RulesPresetNames() []string {
+	names := make([]string, 0, len(rulesPresets))
+	for name := range rulesPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that a table can be played with the rules: amounts and durations can't be negative,
+// the ante must be positive, policies must be known ones, and the community cards and bot fill must be playable
+func (r TableRules) Validate() error {
+	if r.AnteValue <= 0 {
+		return fmt.Errorf("%w: the ante must be positive", ErrInvalidRules)
+	}
+	if r.MaxPlayers < 2 {
+		return fmt.Errorf("%w: a table seats at least 2 players", ErrInvalidRules)
+	}
+	if r.ContinuationBetMultiplier < 0 || r.MaxBuyIn < 0 || r.DiscardPhaseDuration < 0 || r.DiscardCostValue < 0 {
+		return fmt.Errorf("%w: amounts can't be negative", ErrInvalidRules)
+	}
+	if r.MaxBuyIn > 0 && r.MaxBuyIn < r.AnteValue {
+		return fmt.Errorf("%w: the maximum buy-in of %d doesn't cover the ante of %d", ErrInvalidRules, r.MaxBuyIn, r.AnteValue)
+	}
+	if r.ConfirmBetsAbove < 0 || r.ConfirmBetsAbove > 100 {
+		return fmt.Errorf("%w: bets are confirmed above a percentage of the stack, from 0 to 100", ErrInvalidRules)
+	}
+
+	if r.PlayerTimeout < 0 || r.StartCountdown < 0 || r.CloseWhenEmptyAfter < 0 || r.ReadyCheck < 0 {
+		return fmt.Errorf("%w: durations can't be negative", ErrInvalidRules)
+	}
+	for phase, timeout := range r.PhaseTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("%w: the %s phase timeout can't be negative", ErrInvalidRules, phase)
+		}
+	}
+
+	switch r.FoldWinPolicy {
+	case "", FoldWinPolicyAwardPot, FoldWinPolicyRefund, FoldWinPolicyAwardNetBets:
+	default:
+		return fmt.Errorf("%w: unknown fold win policy %q", ErrInvalidRules, r.FoldWinPolicy)
+	}
+	switch r.SelectionTimeoutPolicy {
+	case "", SelectionTimeoutAutoComplete, SelectionTimeoutFold:
+	default:
+		return fmt.Errorf("%w: unknown selection timeout policy %q", ErrInvalidRules, r.SelectionTimeoutPolicy)
+	}
+	switch r.BurnPolicy {
+	case "", BurnBeforeCommunity, BurnBeforeEachWave, BurnNone:
+	default:
+		return fmt.Errorf("%w: unknown burn policy %q", ErrInvalidRules, r.BurnPolicy)
+	}
+	switch r.FeedPrivacy {
+	case FeedPrivate, FeedAnonymous, FeedPublic:
+	default:
+		return fmt.Errorf("%w: unknown feed privacy %q", ErrInvalidRules, r.FeedPrivacy)
+	}
+	switch r.DiscardCostType {
+	case "", DiscardCostFixed, DiscardCostAnte, DiscardCostPlayerBet, DiscardCostPot:
+	default:
+		return fmt.Errorf("%w: unknown discard cost %q", ErrInvalidRules, r.DiscardCostType)
+	}
+
+	scaling := r.AnteScaling
+	if scaling.Hands < 0 || scaling.MinAveragePot < 0 || scaling.Step < 0 || scaling.MaxAnte < 0 || scaling.CooldownHands < 0 {
+		return fmt.Errorf("%w: ante scaling can't be negative", ErrInvalidRules)
+	}
+	if scaling.MaxAnte > 0 && scaling.MaxAnte < r.AnteValue {
+		return fmt.Errorf("%w: the ante can't scale up to %d, below the ante of %d", ErrInvalidRules, scaling.MaxAnte, r.AnteValue)
+	}
+	if r.BombPots.Every < 0 || r.BombPots.Multiplier < 0 {
+		return fmt.Errorf("%w: bomb pots can't be negative", ErrInvalidRules)
+	}
+
+	if len(r.CommunityWaves) > 0 {
+		total := 0
+		for _, size := range r.CommunityWaves {
+			if size <= 0 {
+				return fmt.Errorf("%w: community card waves deal at least one card", ErrInvalidRules)
+			}
+			total += size
+		}
+		if total != r.communityCardCount() {
+			return fmt.Errorf("%w: community card waves deal %d cards, not %d", ErrInvalidRules, total, r.communityCardCount())
+		}
+	}
+
+	if err := r.ValidateCommunity(); err != nil {
+		return err
+	}
+	return r.BotFill.Validate()
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableRulesValidate(t *testing.T) {
+	t.Run("Accepts every preset", func(t *testing.T) {
+		for _, name := range RulesPresetNames() {
+			// Setup
+			rules, err := PresetTableRules(name, 6, 10)
+			require.NoError(t, err)
+
+			// Act & Assert
+			assert.NoError(t, rules.Validate(), name)
+		}
+	})
+
+	t.Run("Rejects nonsensical rules", func(t *testing.T) {
+		for name, adjust := range map[string]func(rules *TableRules){
+			"zero ante":              func(r *TableRules) { r.AnteValue = 0 },
+			"single seat":            func(r *TableRules) { r.MaxPlayers = 1 },
+			"negative timeout":       func(r *TableRules) { r.PlayerTimeout = -time.Second },
+			"negative phase timeout": func(r *TableRules) { r.PhaseTimeouts = map[HandPhase]time.Duration{HandPhase_Antes: -time.Second} },
+			"buy-in below the ante":  func(r *TableRules) { r.MaxBuyIn = 5 },
+			"confirmation above 100": func(r *TableRules) { r.ConfirmBetsAbove = 150 },
+			"unknown burn policy":    func(r *TableRules) { r.BurnPolicy = "sometimes" },
+			"unknown discard cost":   func(r *TableRules) { r.DiscardCostType = "free" },
+			"ante scaling cap":       func(r *TableRules) { r.AnteScaling = AnteScaling{Hands: 3, Step: 5, MaxAnte: 5} },
+			"uneven waves":           func(r *TableRules) { r.CommunityWaves = []int{4, 3} },
+		} {
+			// Setup
+			rules := DefaultTableRules(6, 100)
+			adjust(&rules)
+
+			// Act & Assert
+			assert.ErrorIs(t, rules.Validate(), ErrInvalidRules, name)
+		}
+	})
+
+	t.Run("Reports community and bot fill errors", func(t *testing.T) {
+		// Setup
+		community := DefaultTableRules(6, 100)
+		community.SelectedCards = 2
+		botFill := DefaultTableRules(6, 100)
+		botFill.BotFill.Bots = 5
+
+		// Act & Assert
+		assert.ErrorIs(t, community.Validate(), ErrInvalidCommunityRules)
+		assert.ErrorIs(t, botFill.Validate(), ErrInvalidBotFill)
+	})
+}
+
+func TestPresetTableRules(t *testing.T) {
+	t.Run("Standard tables play the default rules", func(t *testing.T) {
+		// Act
+		rules, err := PresetTableRules("", 6, 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTableRules(6, 100), rules)
+	})
+
+	t.Run("Turbo tables play faster with a rising ante", func(t *testing.T) {
+		// Act
+		rules, err := PresetTableRules(RulesPresetTurbo, 6, 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 10, rules.AnteValue)
+		assert.Less(t, rules.PlayerTimeout, DefaultTableRules(6, 100).PlayerTimeout)
+		assert.True(t, rules.AnteScaling.enabled())
+	})
+
+	t.Run("Rejects unknown presets", func(t *testing.T) {
+		// Act
+		_, err := PresetTableRules("hyper", 6, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrUnknownRulesPreset)
+	})
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// RunClock holds the running counters of a table, for display and scheduling.
+// Hand counts are derived from the table's events as they are emitted, so they survive event retention.
+type RunClock struct {
+	OpenedAt    time.Time // When the table was created
+	HandsPlayed int       // Hands that ended since the table opened
+	HandNumber  int       // Number of the hand in progress or, between hands, of the last one. Zero before the first hand.
+}
+
+// Running returns how long the table has been open
+func (c RunClock) Running(now time.Time) time.Duration {
+	if c.OpenedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(c.OpenedAt)
+}
+
+// countEvent updates the counters with an event of the table
+func (c *RunClock) countEvent(event events.Event) {
+	switch event.(type) {
+	case events.HandStarted:
+		c.HandNumber++
+	case events.HandEnded:
+		c.HandsPlayed++
+	}
+}
+
+// RunClock returns the table's running counters
+func (t *Table) RunClock() RunClock {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.runClock
+}
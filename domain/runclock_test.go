@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunClock(t *testing.T) {
+	t.Run("Counts hands from the table's events", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+
+		// Act
+		table.emitEvent(events.HandStarted{TableID: table.ID, HandID: "hand-1"})
+		table.emitEvent(events.HandEnded{TableID: table.ID, HandID: "hand-1"})
+		table.emitEvent(events.HandStarted{TableID: table.ID, HandID: "hand-2"})
+
+		// Assert
+		clock := table.RunClock()
+		assert.Equal(t, 2, clock.HandNumber)
+		assert.Equal(t, 1, clock.HandsPlayed)
+	})
+
+	t.Run("Counters survive event retention", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		table.emitEvent(events.HandStarted{TableID: table.ID, HandID: "hand-1"})
+		table.emitEvent(events.HandEnded{TableID: table.ID, HandID: "hand-1"})
+
+		// Act
+		table.Events = nil
+
+		// Assert
+		assert.Equal(t, 1, table.RunClock().HandsPlayed)
+	})
+
+	t.Run("New hands are numbered after the last one", func(t *testing.T) {
+		// Setup
+		table := NewTestTable()
+		table.Status = TableStatusPlaying
+		table.emitEvent(events.HandStarted{TableID: table.ID, HandID: "hand-1"})
+		table.emitEvent(events.HandEnded{TableID: table.ID, HandID: "hand-1"})
+
+		// Act
+		hand, err := table.StartNewHand()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, hand.Number)
+		view := hand.BuildPlayerView("player-1")
+		assert.Equal(t, 2, view.HandNumber)
+		assert.Equal(t, 1, view.HandsPlayed)
+		assert.Equal(t, table.RunClock().OpenedAt, view.TableOpenedAt)
+	})
+
+	t.Run("Running time is measured from the table opening", func(t *testing.T) {
+		// Setup
+		openedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		clock := RunClock{OpenedAt: openedAt}
+
+		// Act
+		running := clock.Running(openedAt.Add(90 * time.Minute))
+
+		// Assert
+		assert.Equal(t, 90*time.Minute, running)
+		assert.Zero(t, RunClock{}.Running(openedAt))
+	})
+}
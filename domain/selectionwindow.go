@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// CloseCommunitySelection ends the selection window: players still missing cards get the best cards left
+// picked for them, or fold if the table says so, then the hands go to the decision phase
+func (h *Hand) CloseCommunitySelection() error {
+	if !h.IsInPhase(HandPhase_CommunitySelection) {
+		return errors.New("not in community card selection phase")
+	}
+
+	for _, player := range h.Players {
+		if !h.IsPlayerActive(player.ID) || len(h.CommunitySelections[player.ID]) >= h.TableRules.selectionCount() {
+			continue
+		}
+
+		if h.TableRules.SelectionTimeoutPolicy == SelectionTimeoutFold {
+			h.emitPlayerTimedOut(player.ID, "fold")
+			h.setPlayerAsInactive(player.ID)
+			continue
+		}
+
+		h.emitPlayerTimedOut(player.ID, "auto_select")
+		for _, card := range h.bestCommunitySelection(player.ID) {
+			h.addCommunitySelection(player.ID, card)
+		}
+	}
+
+	if h.countActivePlayers() >= 2 {
+		h.TransitionToDecisionPhase()
+		return nil
+	}
+
+	// Folds left nobody to compare hands with, the window still closes for everyone
+	h.emitEvent(events.CommunitySelectionEnded{
+		TableID: h.TableID,
+		HandID:  h.ID,
+		At:      h.clock().Now(),
+	})
+
+	switch h.countActivePlayers() {
+	case 0:
+		return h.voidHand("no player completed their community selection")
+	case 1:
+		lastActivePlayer, err := h.getLastActivePlayer()
+		if err != nil {
+			return err
+		}
+		return h.handleSinglePlayerWin(lastActivePlayer.ID)
+	}
+
+	return nil
+}
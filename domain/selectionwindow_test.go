@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSelectionPhaseHand creates a hand of three players waiting on their community cards,
+// wired to its table with a fake clock. Only player-1 has picked all three cards.
+func setupSelectionPhaseHand() (*Hand, *Table, *fakeClock) {
+	hand, table := setupContinuationPhaseHand(3)
+	clock := &fakeClock{}
+	table.Clock = clock
+	table.Players = hand.Players
+	table.ActiveHand = hand
+	hand.RegisterEventHandler(table.handleHandEvent)
+
+	deck := cards.NewDeck52()
+	for i, player := range hand.Players {
+		hand.HoleCards[player.ID] = deck[i*2 : i*2+2]
+	}
+	hand.CommunityCards = deck[10:18]
+	hand.CommunitySelections["player-1"] = hand.CommunityCards[:3]
+	hand.CommunitySelections["player-2"] = hand.CommunityCards[3:4]
+
+	hand.Phase = HandPhase_CommunityDeal
+	hand.TransitionToCommunitySelectionPhase()
+	return hand, table, clock
+}
+
+func TestCloseCommunitySelection(t *testing.T) {
+	t.Run("Selection window is timed", func(t *testing.T) {
+		// Setup
+		hand, _, clock := setupSelectionPhaseHand()
+
+		// Act
+		pending := clock.pending()
+
+		// Assert
+		require.Len(t, pending, 1)
+		assert.Equal(t, hand.selectionTimeLimit(), pending[0].duration)
+	})
+
+	t.Run("Missing picks are completed when the window closes", func(t *testing.T) {
+		// Setup
+		hand, _, clock := setupSelectionPhaseHand()
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		for _, player := range hand.Players {
+			assert.Len(t, hand.CommunitySelections[player.ID], 3)
+		}
+		_, found := findEventOfType(hand.Events, events.CommunitySelectionEnded{}.Name())
+		assert.True(t, found)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+	})
+
+	t.Run("Players missing picks fold when the table says so", func(t *testing.T) {
+		// Setup
+		hand, _, clock := setupSelectionPhaseHand()
+		hand.TableRules.SelectionTimeoutPolicy = SelectionTimeoutFold
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		assert.True(t, hand.IsPlayerActive("player-1"))
+		assert.False(t, hand.IsPlayerActive("player-2"))
+		assert.False(t, hand.IsPlayerActive("player-3"))
+
+		event, found := findEventOfType(hand.Events, events.SingleWinnerDetermined{}.Name())
+		require.True(t, found)
+		assert.Equal(t, "player-1", event.(events.SingleWinnerDetermined).PlayerID)
+	})
+
+	t.Run("Window closing early stops the timer", func(t *testing.T) {
+		// Setup
+		hand, _, clock := setupSelectionPhaseHand()
+		timer := clock.pending()[0]
+
+		// Act
+		for _, card := range hand.CommunityCards[4:6] {
+			require.NoError(t, hand.PlayerSelectsCommunityCard("player-2", card))
+		}
+		for _, card := range hand.CommunityCards[5:8] {
+			require.NoError(t, hand.PlayerSelectsCommunityCard("player-3", card))
+		}
+
+		// Assert
+		assert.True(t, timer.stopped)
+		assert.NotEqual(t, HandPhase_CommunitySelection, hand.Phase)
+	})
+
+	t.Run("Window can only close during the selection phase", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+
+		// Act
+		err := hand.CloseCommunitySelection()
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// PlayerSession tracks a player's results from the moment they sit until they leave
+type PlayerSession struct {
+	PlayerID       string
+	StartedAt      time.Time
+	HandsPlayed    int
+	NetResult      int // Chips won minus chips put in the pot, over all hands
+	BiggestPotWon  int // Largest net gain in a single hand
+	BiggestPotLost int // Largest net loss in a single hand, as a positive amount
+	ToppedUp       int // Chips added to the stack after sitting down
+}
+
+// startSession opens a new session for a player who just sat down
+func (t *Table) startSession(playerID string, at time.Time) {
+	if t.sessions == nil {
+		t.sessions = make(map[string]*PlayerSession)
+	}
+
+	t.sessions[playerID] = &PlayerSession{
+		PlayerID:  playerID,
+		StartedAt: at,
+	}
+}
+
+// recordSessionHand adds a finished hand to the session of every player dealt in
+func (t *Table) recordSessionHand(hand *Hand) {
+	if hand == nil {
+		return
+	}
+
+	won := make(map[string]int)
+	for _, event := range hand.Events {
+		if award, ok := event.(events.PotAmountAwarded); ok {
+			won[award.PlayerID] += award.Amount
+		}
+	}
+
+	contributions := hand.playerContributions()
+
+	for _, player := range hand.Players {
+		session, ok := t.sessions[player.ID]
+		if !ok {
+			continue
+		}
+
+		net := won[player.ID] - contributions[player.ID]
+
+		session.HandsPlayed++
+		session.NetResult += net
+		if net > session.BiggestPotWon {
+			session.BiggestPotWon = net
+		}
+		if -net > session.BiggestPotLost {
+			session.BiggestPotLost = -net
+		}
+	}
+}
+
+// endSession emits the player's session summary and forgets the session
+func (t *Table) endSession(playerID string, reason string) {
+	session, ok := t.sessions[playerID]
+	if !ok {
+		return
+	}
+	delete(t.sessions, playerID)
+
+	now := t.clock().Now()
+	t.emitEvent(events.PlayerSessionSummarized{
+		TableID:        t.ID,
+		PlayerID:       playerID,
+		Reason:         reason,
+		StartedAt:      session.StartedAt,
+		Duration:       now.Sub(session.StartedAt),
+		HandsPlayed:    session.HandsPlayed,
+		NetResult:      session.NetResult,
+		FinalStack:     t.GetPlayerBuyIn(playerID),
+		BiggestPotWon:  session.BiggestPotWon,
+		BiggestPotLost: session.BiggestPotLost,
+		At:             now,
+	})
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerSessions(t *testing.T) {
+	setup := func() *Table {
+		table := NewTable("Test Table", TableRules{})
+		for _, id := range []string{"player-1", "player-2"} {
+			require.NoError(t, table.SeatPlayer(&Player{ID: id}))
+			table.BuyIns[id] = 1000
+		}
+
+		// player-1 wins 30 net in the first hand, player-2 wins 50 net in the second
+		table.recordSessionHand(&Hand{
+			Players:          table.Players,
+			AntesPaid:        map[string]int{"player-1": 10, "player-2": 10},
+			ContinuationBets: map[string]int{"player-1": 20, "player-2": 20},
+			Events: []events.Event{
+				events.PotAmountAwarded{PlayerID: "player-1", Amount: 60},
+			},
+		})
+		table.recordSessionHand(&Hand{
+			Players:          table.Players,
+			AntesPaid:        map[string]int{"player-1": 10, "player-2": 10},
+			ContinuationBets: map[string]int{"player-1": 40, "player-2": 40},
+			Events: []events.Event{
+				events.PotAmountAwarded{PlayerID: "player-2", Amount: 100},
+			},
+		})
+
+		return table
+	}
+
+	t.Run("Summarizes the session when the player leaves", func(t *testing.T) {
+		// Setup
+		table := setup()
+		table.BuyIns["player-1"] = 980
+
+		// Act
+		err := table.PlayerLeaves("player-1")
+
+		// Assert
+		require.NoError(t, err)
+		event, found := findEventOfType(table.Events, events.PlayerSessionSummarized{}.Name())
+		require.True(t, found)
+		summary := event.(events.PlayerSessionSummarized)
+		assert.Equal(t, "player-1", summary.PlayerID)
+		assert.Equal(t, 2, summary.HandsPlayed)
+		assert.Equal(t, -20, summary.NetResult)
+		assert.Equal(t, 980, summary.FinalStack)
+		assert.Equal(t, 30, summary.BiggestPotWon)
+		assert.Equal(t, 50, summary.BiggestPotLost)
+		assert.False(t, summary.StartedAt.IsZero())
+	})
+
+	t.Run("Summarizes every seated player when the table closes", func(t *testing.T) {
+		// Setup
+		table := setup()
+
+		// Act
+		table.Close("test")
+
+		// Assert
+		summaries := map[string]events.PlayerSessionSummarized{}
+		for _, event := range table.Events {
+			if summary, ok := event.(events.PlayerSessionSummarized); ok {
+				summaries[summary.PlayerID] = summary
+			}
+		}
+		require.Len(t, summaries, 2)
+		assert.Equal(t, 20, summaries["player-2"].NetResult)
+		assert.Equal(t, "table closed", summaries["player-2"].Reason)
+	})
+
+	t.Run("A session is only summarized once", func(t *testing.T) {
+		// Setup
+		table := setup()
+		require.NoError(t, table.PlayerLeaves("player-1"))
+
+		// Act
+		table.Close("test")
+
+		// Assert
+		count := 0
+		for _, event := range table.Events {
+			if _, ok := event.(events.PlayerSessionSummarized); ok {
+				count++
+			}
+		}
+		assert.Equal(t, 2, count)
+	})
+}
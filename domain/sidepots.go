@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// SidePot is a share of the pot that only the players who matched it can win
+type SidePot struct {
+	Amount   int
+	Eligible []string // Players still in the hand who put in at least the pot's level
+}
+
+// SidePots splits the pot by what each player put in: the main pot is what every player still in
+// the hand matched, then one side pot per higher contribution. Money put in by folded players above
+// the highest contribution still in the hand stays in the last pot.
+func (h *Hand) SidePots() []SidePot {
+	contributions := h.playerContributions()
+
+	// Levels are the distinct contributions of the players still in the hand
+	levels := []int{}
+	for playerID, amount := range contributions {
+		if h.IsPlayerActive(playerID) && !slices.Contains(levels, amount) {
+			levels = append(levels, amount)
+		}
+	}
+	slices.Sort(levels)
+
+	pots := []SidePot{}
+	previous := 0
+	for _, level := range levels {
+		pot := SidePot{}
+		for _, amount := range contributions {
+			pot.Amount += min(max(amount-previous, 0), level-previous)
+		}
+		for _, player := range h.Players {
+			if h.IsPlayerActive(player.ID) && contributions[player.ID] >= level {
+				pot.Eligible = append(pot.Eligible, player.ID)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	if len(pots) == 0 {
+		return pots
+	}
+
+	// Dead money above the last level, and anything added to the pot outside of bets
+	contributed := 0
+	for _, pot := range pots {
+		contributed += pot.Amount
+	}
+	pots[len(pots)-1].Amount += h.Pot - contributed
+
+	return pots
+}
+
+// potShare is the part of a pot that goes to the high or the low hand
+type potShare struct {
+	side    string
+	winners []string
+	amount  int
+}
+
+// payoutSidePots awards each pot to the best hand among its eligible players, splitting it
+// between the high and low hands on hi-lo tables
+func (h *Hand) payoutSidePots(pots []SidePot) error {
+	breakdown := make(map[string]int)
+	details := make([]events.SidePot, 0, len(pots))
+
+	for i, pot := range pots {
+		name := "main pot"
+		if i > 0 {
+			name = fmt.Sprintf("side pot %d", i)
+		}
+
+		detail := events.SidePot{
+			Amount:   pot.Amount,
+			Eligible: pot.Eligible,
+			Winners:  make(map[string]int),
+		}
+
+		playerCards := make(map[string]cards.Stack)
+		for _, playerID := range pot.Eligible {
+			playerCards[playerID] = h.combinePlayerHoleAndSelectedCommunityCards(playerID)
+		}
+
+		var highWinners, lowWinners []string
+		for _, result := range h.comparePlayerHands(playerCards) {
+			if result.IsWinner {
+				highWinners = append(highWinners, result.PlayerID)
+			}
+			if result.IsLowWinner {
+				lowWinners = append(lowWinners, result.PlayerID)
+			}
+		}
+
+		shares := []potShare{{"", highWinners, pot.Amount}}
+		if h.TableRules.HiLoSplit && len(lowWinners) > 0 {
+			lowHalf := pot.Amount / 2
+			shares = []potShare{{"high", highWinners, pot.Amount - lowHalf}, {"low", lowWinners, lowHalf}}
+		}
+
+		for _, share := range shares {
+			if len(share.winners) == 0 {
+				continue
+			}
+
+			// The odd chips go to the first winner, as in a single pot
+			amount := share.amount / len(share.winners)
+			remainder := share.amount % len(share.winners)
+			for j, winnerID := range share.winners {
+				won := amount
+				if j == 0 {
+					won += remainder
+				}
+
+				if err := h.awardSidePayout(winnerID, won, name, share.side); err != nil {
+					return err
+				}
+				breakdown[winnerID] += won
+				detail.Winners[winnerID] += won
+			}
+		}
+
+		details = append(details, detail)
+	}
+
+	h.emitEvent(events.PotBrokenDown{
+		TableID:   h.TableID,
+		HandID:    h.ID,
+		Breakdown: breakdown,
+		Pots:      details,
+		At:        h.clock().Now(),
+	})
+
+	return nil
+}
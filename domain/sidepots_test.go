@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSidePotHand creates a hand in the payout phase where player-1 is short stacked and holds the
+// best hand, player-2 the second best and player-3 the worst
+func setupSidePotHand() (*Hand, *Table) {
+	hand, table := setupContinuationPhaseHand(3)
+	hand.Phase = HandPhase_Payout
+
+	contributions := map[string][2]int{
+		"player-1": {10, 30},
+		"player-2": {10, 90},
+		"player-3": {10, 90},
+	}
+	for playerID, bets := range contributions {
+		hand.AntesPaid[playerID] = bets[0]
+		hand.ContinuationBets[playerID] = bets[1]
+		hand.Pot += bets[0] + bets[1]
+	}
+
+	hand.HoleCards["player-1"] = cards.Stack{{Suit: cards.Spades, Value: cards.Ace}, {Suit: cards.Hearts, Value: cards.Ace}}
+	hand.CommunitySelections["player-1"] = cards.Stack{{Suit: cards.Diamonds, Value: cards.Ace}, {Suit: cards.Spades, Value: cards.King}, {Suit: cards.Hearts, Value: cards.King}}
+	hand.HoleCards["player-2"] = cards.Stack{{Suit: cards.Spades, Value: cards.Queen}, {Suit: cards.Hearts, Value: cards.Queen}}
+	hand.CommunitySelections["player-2"] = cards.Stack{{Suit: cards.Diamonds, Value: cards.Queen}, {Suit: cards.Spades, Value: cards.Two}, {Suit: cards.Hearts, Value: cards.Three}}
+	hand.HoleCards["player-3"] = cards.Stack{{Suit: cards.Spades, Value: cards.Four}, {Suit: cards.Hearts, Value: cards.Seven}}
+	hand.CommunitySelections["player-3"] = cards.Stack{{Suit: cards.Diamonds, Value: cards.Nine}, {Suit: cards.Clubs, Value: cards.Jack}, {Suit: cards.Spades, Value: cards.Five}}
+
+	return hand, table
+}
+
+func TestSidePots(t *testing.T) {
+	t.Run("Equal contributions make a single pot", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(3)
+		for _, player := range hand.Players {
+			hand.AntesPaid[player.ID] = 10
+			hand.ContinuationBets[player.ID] = 30
+			hand.Pot += 40
+		}
+
+		// Act
+		pots := hand.SidePots()
+
+		// Assert
+		assert.Equal(t, []SidePot{{Amount: 120, Eligible: []string{"player-1", "player-2", "player-3"}}}, pots)
+	})
+
+	t.Run("A short stack only contests the main pot", func(t *testing.T) {
+		// Setup
+		hand, _ := setupSidePotHand()
+
+		// Act
+		pots := hand.SidePots()
+
+		// Assert
+		assert.Equal(t, []SidePot{
+			{Amount: 120, Eligible: []string{"player-1", "player-2", "player-3"}},
+			{Amount: 120, Eligible: []string{"player-2", "player-3"}},
+		}, pots)
+	})
+
+	t.Run("Folded players' bets stay in the pots they reached", func(t *testing.T) {
+		// Setup
+		hand, _ := setupSidePotHand()
+		hand.setPlayerAsInactive("player-3")
+
+		// Act
+		pots := hand.SidePots()
+
+		// Assert
+		assert.Equal(t, []SidePot{
+			{Amount: 120, Eligible: []string{"player-1", "player-2"}},
+			{Amount: 120, Eligible: []string{"player-2"}},
+		}, pots)
+	})
+
+	t.Run("Each pot goes to the best hand among its eligible players", func(t *testing.T) {
+		// Setup
+		hand, table := setupSidePotHand()
+		hand.Results = hand.comparePlayerHands(hand.combineAllPlayerHoleAndSelectedCommunityCards())
+
+		// Act
+		err := hand.Payout()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1000+120, table.GetPlayerBuyIn("player-1"), "main pot")
+		assert.Equal(t, 1000+120, table.GetPlayerBuyIn("player-2"), "side pot")
+		assert.Equal(t, 1000, table.GetPlayerBuyIn("player-3"))
+		assert.Equal(t, 0, hand.Pot)
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+
+		event, found := findEventOfType(hand.Events, events.PotBrokenDown{}.Name())
+		require.True(t, found)
+		brokenDown := event.(events.PotBrokenDown)
+		assert.Equal(t, map[string]int{"player-1": 120, "player-2": 120}, brokenDown.Breakdown)
+		require.Len(t, brokenDown.Pots, 2)
+		assert.Equal(t, map[string]int{"player-1": 120}, brokenDown.Pots[0].Winners)
+		assert.Equal(t, map[string]int{"player-2": 120}, brokenDown.Pots[1].Winners)
+	})
+}
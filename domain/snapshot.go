@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"maps"
+	"time"
+)
+
+// TableSnapshot is a point-in-time view of a table for debugging.
+// It is redacted: hole cards, community selections, the deck and the shuffle seed are only counted.
+type TableSnapshot struct {
+	ID          string
+	Name        string
+	Status      TableStatus
+	Rules       TableRules
+	HostID      string
+	StartsAt    time.Time
+	Players     []PlayerSnapshot
+	HandsPlayed int
+	EventCount  int
+	ActiveHand  *HandSnapshot
+	TakenAt     time.Time
+}
+
+// PlayerSnapshot is a seated player in a table snapshot
+type PlayerSnapshot struct {
+	ID           string
+	Name         string
+	Status       string
+	Stack        int
+	Away         bool
+	NextHandOnly bool // Sat down during the hand in progress, dealt in from the next one
+}
+
+// HandSnapshot is the active hand in a table snapshot
+type HandSnapshot struct {
+	ID               string
+	Number           int
+	Phase            HandPhase
+	StartedAt        time.Time
+	Pot              int
+	CurrentBettor    string
+	ButtonPosition   int
+	ActivePlayers    []string
+	AntesPaid        map[string]int
+	ContinuationBets map[string]int
+	CommunityCards   []string
+	HoleCardCounts   map[string]int // Number of hole cards dealt, by player ID
+	SelectionCounts  map[string]int // Number of community cards selected, by player ID
+	DeckRemaining    int
+	CardsBurned      int
+	SeedCommitment   string
+	EventCount       int
+}
+
+// Snapshot copies the table state into a redacted snapshot, safe to serialize and hand out.
+// It is taken in the game loop, as the stacks and the hand's bets are only written there.
+func (t *Table) Snapshot() TableSnapshot {
+	var snapshot TableSnapshot
+	t.guard("snapshot", func() error {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		snapshot = t.snapshot()
+		return nil
+	})
+	return snapshot
+}
+
+func (t *Table) snapshot() TableSnapshot {
+	snapshot := TableSnapshot{
+		ID:          t.ID,
+		Name:        t.Name,
+		Status:      t.Status,
+		Rules:       t.Rules,
+		HostID:      t.HostID,
+		StartsAt:    t.StartsAt,
+		Players:     make([]PlayerSnapshot, 0, len(t.Players)),
+		HandsPlayed: len(t.Hands),
+		EventCount:  len(t.Events),
+		TakenAt:     t.clock().Now(),
+	}
+
+	for _, p := range t.Players {
+		snapshot.Players = append(snapshot.Players, PlayerSnapshot{
+			ID:           p.ID,
+			Name:         p.Name,
+			Status:       p.Status,
+			Stack:        t.BuyIns[p.ID],
+			Away:         t.Away[p.ID],
+			NextHandOnly: t.NextHand[p.ID],
+		})
+	}
+
+	if t.ActiveHand != nil {
+		snapshot.ActiveHand = t.ActiveHand.snapshot()
+	}
+
+	return snapshot
+}
+
+// snapshot copies the hand state, keeping private cards out
+func (h *Hand) snapshot() *HandSnapshot {
+	snapshot := &HandSnapshot{
+		ID:               h.ID,
+		Number:           h.Number,
+		Phase:            h.Phase,
+		StartedAt:        h.StartedAt,
+		Pot:              h.Pot,
+		CurrentBettor:    h.CurrentBettor,
+		ButtonPosition:   h.ButtonPosition,
+		ActivePlayers:    []string{},
+		AntesPaid:        maps.Clone(h.AntesPaid),
+		ContinuationBets: maps.Clone(h.ContinuationBets),
+		CommunityCards:   make([]string, 0, len(h.CommunityCards)),
+		HoleCardCounts:   make(map[string]int),
+		SelectionCounts:  make(map[string]int),
+		DeckRemaining:    h.DeckRemaining(),
+		CardsBurned:      len(h.BurnedCards),
+		SeedCommitment:   h.SeedCommitment,
+		EventCount:       len(h.Events),
+	}
+
+	// Keep seat order so snapshots are easy to compare
+	for _, p := range h.Players {
+		if h.ActivePlayers[p.ID] {
+			snapshot.ActivePlayers = append(snapshot.ActivePlayers, p.ID)
+		}
+	}
+
+	for _, card := range h.CommunityCards {
+		snapshot.CommunityCards = append(snapshot.CommunityCards, card.String())
+	}
+
+	for playerID, holeCards := range h.HoleCards {
+		snapshot.HoleCardCounts[playerID] = len(holeCards)
+	}
+
+	for playerID, selection := range h.CommunitySelections {
+		snapshot.SelectionCounts[playerID] = len(selection)
+	}
+
+	return snapshot
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableSnapshot(t *testing.T) {
+	t.Run("Redacts private cards", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(2)
+		table.Players = hand.Players
+		table.ActiveHand = hand
+		hand.HoleCards["player-1"] = cards.Stack{{Suit: cards.Spades, Value: cards.Ace}, {Suit: cards.Hearts, Value: cards.Ace}}
+		hand.CommunityCards = cards.Stack{{Suit: cards.Clubs, Value: cards.King}}
+		hand.CommunitySelections["player-1"] = cards.Stack{{Suit: cards.Clubs, Value: cards.King}}
+
+		// Act
+		snapshot := table.Snapshot()
+
+		// Assert
+		require.NotNil(t, snapshot.ActiveHand)
+		assert.Len(t, snapshot.Players, 2)
+		assert.Equal(t, 1000, snapshot.Players[0].Stack)
+		assert.Equal(t, 2, snapshot.ActiveHand.HoleCardCounts["player-1"])
+		assert.Equal(t, 1, snapshot.ActiveHand.SelectionCounts["player-1"])
+		assert.Equal(t, []string{cards.Card{Suit: cards.Clubs, Value: cards.King}.String()}, snapshot.ActiveHand.CommunityCards)
+		assert.Equal(t, len(hand.Deck), snapshot.ActiveHand.DeckRemaining)
+	})
+
+	t.Run("Changing the snapshot does not affect the table", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(2)
+		table.ActiveHand = hand
+		hand.AntesPaid["player-1"] = 10
+
+		// Act
+		snapshot := table.Snapshot()
+		snapshot.ActiveHand.AntesPaid["player-1"] = 500
+
+		// Assert
+		assert.Equal(t, 10, hand.AntesPaid["player-1"])
+	})
+
+	t.Run("Can be taken while the table plays", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		table.Players = hand.Players
+		table.ActiveHand = hand
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range hand.Players {
+				table.Do("ante", func() error {
+					return hand.PlayerPlacesAnte(hand.CurrentBettor, 10)
+				})
+			}
+		}()
+
+		// Act
+		snapshots := []TableSnapshot{}
+		for range 20 {
+			snapshots = append(snapshots, table.Snapshot())
+		}
+		<-done
+
+		// Assert
+		assert.Len(t, snapshots, 20)
+		assert.Equal(t, 990, table.Snapshot().Players[0].Stack)
+	})
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// ErrStaleAction is returned for an action sent against a hand or phase that is already over,
+// e.g. a fold that crossed the start of the next hand on the wire
+var ErrStaleAction = errors.New("stale action: the hand has moved on")
+
+// GetHandForAction returns the hand a player action targets, as long as the player acted on its
+// current state: the active hand, in the phase the player saw. lastEventID is optional, when given
+// the player must have seen the last phase change and the last turn prompt they were sent.
+func (t *Table) GetHandForAction(playerID string, handID string, phase string, lastEventID string) (*Hand, error) {
+	if handID == "" || phase == "" {
+		return nil, errors.New("hand ID and phase are required")
+	}
+
+	t.mu.RLock()
+	hand := t.ActiveHand
+	t.mu.RUnlock()
+
+	if hand == nil || hand.ID != handID {
+		if _, err := t.GetHandByID(handID); err != nil {
+			return nil, err
+		}
+		return nil, ErrStaleAction
+	}
+
+	if string(hand.Phase) != phase {
+		return nil, ErrStaleAction
+	}
+
+	// Event IDs are monotonic ULIDs, so they compare in emission order
+	if lastEventID != "" && lastEventID < hand.lastPromptID(playerID) {
+		return nil, ErrStaleAction
+	}
+
+	return hand, nil
+}
+
+// lastPromptID returns the ID of the last event asking the player to act: a phase change or their turn
+func (h *Hand) lastPromptID(playerID string) string {
+	for i := len(h.Events) - 1; i >= 0; i-- {
+		switch e := h.Events[i].(type) {
+		case events.PhaseChanged:
+			return e.ID
+		case events.PlayerTurnStarted:
+			if e.PlayerID == playerID {
+				return e.ID
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHandForAction(t *testing.T) {
+	setup := func() (*Table, *Hand) {
+		hand, table := setupContinuationPhaseHand(3)
+		table.ActiveHand = hand
+		hand.Events = []events.Event{
+			events.PhaseChanged{ID: "01A", HandID: hand.ID, NewPhase: string(HandPhase_Continuation)},
+			events.PlayerTurnStarted{ID: "01B", HandID: hand.ID, PlayerID: "player-2"},
+			events.PlayerTurnStarted{ID: "01C", HandID: hand.ID, PlayerID: "player-3"},
+		}
+		return table, hand
+	}
+
+	t.Run("Returns the active hand when the player acted on its current state", func(t *testing.T) {
+		// Setup
+		table, hand := setup()
+
+		// Act
+		found, err := table.GetHandForAction("player-2", hand.ID, string(HandPhase_Continuation), "01B")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Same(t, hand, found)
+	})
+
+	t.Run("Rejects actions for a previous hand", func(t *testing.T) {
+		// Setup
+		table, _ := setup()
+		previous := &Hand{ID: "previous-hand-id", Phase: HandPhase_Ended}
+		table.Hands = append(table.Hands, previous)
+
+		// Act
+		_, err := table.GetHandForAction("player-2", previous.ID, string(HandPhase_Continuation), "")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrStaleAction)
+	})
+
+	t.Run("Rejects actions for a previous phase", func(t *testing.T) {
+		// Setup
+		table, hand := setup()
+
+		// Act
+		_, err := table.GetHandForAction("player-2", hand.ID, string(HandPhase_Antes), "")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrStaleAction)
+	})
+
+	t.Run("Rejects actions from a client that missed its latest prompt", func(t *testing.T) {
+		// Setup
+		table, hand := setup()
+
+		// Act
+		_, err := table.GetHandForAction("player-2", hand.ID, string(HandPhase_Continuation), "01A")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrStaleAction)
+	})
+
+	t.Run("Requires the hand and the phase", func(t *testing.T) {
+		// Setup
+		table, hand := setup()
+
+		// Act
+		_, err := table.GetHandForAction("player-2", hand.ID, "", "")
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrStaleAction)
+	})
+
+	t.Run("Unknown hands are not stale", func(t *testing.T) {
+		// Setup
+		table, _ := setup()
+
+		// Act
+		_, err := table.GetHandForAction("player-2", "unknown-hand-id", string(HandPhase_Continuation), "")
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrStaleAction)
+	})
+}
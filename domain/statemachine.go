@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PhaseTransition is an edge of the hand state machine
+type PhaseTransition struct {
+	From  HandPhase
+	To    HandPhase
+	Guard string // What has to be true for the hand to move on
+}
+
+// HandTransitions lists every phase change a hand can make. The TransitionTo* methods check it before moving on.
+var HandTransitions = []PhaseTransition{
+	{From: HandPhase_Start, To: HandPhase_Antes, Guard: "hand initialized, and at least 2 players ready if a ready-check ran"},
+	{From: HandPhase_Start, To: HandPhase_Ended, Guard: "ready-check with fewer than 2 players ready"},
+	{From: HandPhase_Antes, To: HandPhase_Hole, Guard: "all antes paid, or ante timeout with players left"},
+	{From: HandPhase_Antes, To: HandPhase_Ended, Guard: "ante timeout with no player left"},
+	{From: HandPhase_Hole, To: HandPhase_Continuation, Guard: "hole cards dealt"},
+	{From: HandPhase_Hole, To: HandPhase_CommunityDeal, Guard: "hole cards dealt in a bomb pot"},
+	{From: HandPhase_Continuation, To: HandPhase_CommunityDeal, Guard: "all active players bet or folded"},
+	{From: HandPhase_Continuation, To: HandPhase_Payout, Guard: "one player left after folds"},
+	{From: HandPhase_CommunityDeal, To: HandPhase_CommunitySelection, Guard: "8 community cards dealt"},
+	{From: HandPhase_CommunityDeal, To: HandPhase_Discard, Guard: "8 community cards dealt, and the table plays discards"},
+	{From: HandPhase_CommunityDeal, To: HandPhase_Payout, Guard: "one player left after folds"},
+	{From: HandPhase_Discard, To: HandPhase_CommunitySelection, Guard: "all active players discarded or passed"},
+	{From: HandPhase_Discard, To: HandPhase_Payout, Guard: "one player left after folds"},
+	{From: HandPhase_CommunitySelection, To: HandPhase_Decision, Guard: "all active players selected 3 cards"},
+	{From: HandPhase_CommunitySelection, To: HandPhase_Payout, Guard: "one player left after folds"},
+	{From: HandPhase_Decision, To: HandPhase_Payout, Guard: "hands evaluated"},
+	{From: HandPhase_Decision, To: HandPhase_Ended, Guard: "no hand to evaluate"},
+	{From: HandPhase_Payout, To: HandPhase_Ended, Guard: "pot paid out"},
+}
+
+// CanTransition checks if the state machine has an edge between two phases
+func CanTransition(from HandPhase, to HandPhase) bool {
+	for _, transition := range HandTransitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// canTransitionTo checks if the hand may move from its current phase to the given one
+func (h *Hand) canTransitionTo(phase HandPhase) bool {
+	return CanTransition(h.Phase, phase)
+}
+
+// handPhases returns the phases that appear in the state machine, in the order they are first reached
+func handPhases() []HandPhase {
+	phases := []HandPhase{}
+	seen := make(map[HandPhase]bool)
+	for _, transition := range HandTransitions {
+		for _, phase := range []HandPhase{transition.From, transition.To} {
+			if !seen[phase] {
+				seen[phase] = true
+				phases = append(phases, phase)
+			}
+		}
+	}
+	return phases
+}
+
+// HandStateMachineDOT renders the state machine as a Graphviz graph, with the current phase highlighted if not empty
+func HandStateMachineDOT(current HandPhase) string {
+	var b strings.Builder
+	b.WriteString("digraph hand {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, phase := range handPhases() {
+		if phase == current {
+			fmt.Fprintf(&b, "  %q [style=\"rounded,filled\", fillcolor=orange];\n", phase)
+		} else {
+			fmt.Fprintf(&b, "  %q;\n", phase)
+		}
+	}
+
+	for _, transition := range HandTransitions {
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", transition.From, transition.To, transition.Guard)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// HandStateMachineMermaid renders the state machine as a Mermaid state diagram, with the current phase highlighted if not empty
+func HandStateMachineMermaid(current HandPhase) string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+
+	// Mermaid state IDs can't contain dots, so phases are aliased
+	for _, phase := range handPhases() {
+		fmt.Fprintf(&b, "  state \"%s\" as %s\n", phase, mermaidStateID(phase))
+	}
+
+	fmt.Fprintf(&b, "  [*] --> %s\n", mermaidStateID(HandPhase_Start))
+	for _, transition := range HandTransitions {
+		fmt.Fprintf(&b, "  %s --> %s : %s\n", mermaidStateID(transition.From), mermaidStateID(transition.To), transition.Guard)
+	}
+	fmt.Fprintf(&b, "  %s --> [*]\n", mermaidStateID(HandPhase_Ended))
+
+	if current != "" {
+		b.WriteString("  classDef current fill:orange\n")
+		fmt.Fprintf(&b, "  class %s current\n", mermaidStateID(current))
+	}
+
+	return b.String()
+}
+
+func mermaidStateID(phase HandPhase) string {
+	return strings.ReplaceAll(string(phase), ".", "_")
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandStateMachine(t *testing.T) {
+	t.Run("Only allows listed transitions", func(t *testing.T) {
+		assert.True(t, CanTransition(HandPhase_Start, HandPhase_Antes))
+		assert.True(t, CanTransition(HandPhase_Continuation, HandPhase_Payout))
+		assert.False(t, CanTransition(HandPhase_Start, HandPhase_Hole))
+		assert.False(t, CanTransition(HandPhase_Ended, HandPhase_Start))
+	})
+
+	t.Run("Every phase but the last one can move on", func(t *testing.T) {
+		for _, phase := range handPhases() {
+			if phase == HandPhase_Ended {
+				continue
+			}
+			assert.True(t, hasOutgoingTransition(phase), "phase %s is a dead end", phase)
+		}
+	})
+
+	t.Run("Ignores transitions the table does not list", func(t *testing.T) {
+		// Setup
+		hand, _ := setupContinuationPhaseHand(2)
+
+		// Act
+		hand.TransitionToAntesPhase()
+
+		// Assert
+		assert.Equal(t, HandPhase_Continuation, hand.Phase)
+	})
+
+	t.Run("Renders DOT with the current phase highlighted", func(t *testing.T) {
+		// Act
+		dot := HandStateMachineDOT(HandPhase_Continuation)
+
+		// Assert
+		assert.Contains(t, dot, `"continuation" [style="rounded,filled", fillcolor=orange];`)
+		assert.Contains(t, dot, `"continuation" -> "community.deal" [label="all active players bet or folded"];`)
+	})
+
+	t.Run("Renders Mermaid with aliased phase IDs", func(t *testing.T) {
+		// Act
+		mermaid := HandStateMachineMermaid(HandPhase_CommunityDeal)
+
+		// Assert
+		assert.Contains(t, mermaid, `state "community.deal" as community_deal`)
+		assert.Contains(t, mermaid, "community_deal --> community_selection : 8 community cards dealt")
+		assert.Contains(t, mermaid, "class community_deal current")
+	})
+}
+
+func hasOutgoingTransition(phase HandPhase) bool {
+	for _, transition := range HandTransitions {
+		if transition.From == phase {
+			return true
+		}
+	}
+	return false
+}
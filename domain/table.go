@@ -3,28 +3,38 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
 	"github.com/lazharichir/poker/domain/events"
 	"github.com/lazharichir/poker/domain/hands"
-	"github.com/sanity-io/litter"
+	"github.com/lazharichir/poker/domain/wallet"
 )
 
 func NewTable(name string, rules TableRules) *Table {
-	return &Table{
+	table := &Table{
 		ID:            uuid.NewString(),
 		Name:          name,
 		Status:        TableStatusWaiting,
 		BuyIns:        make(map[string]int),
 		Events:        []events.Event{},
 		eventHandlers: []events.EventHandler{},
+		eventIDs:      events.NewIDGenerator(),
 		Rules:         rules,
 		Players:       []*Player{},
-		Hands:         []Hand{},
+		Hands:         []*Hand{},
 		ActiveHand:    nil,
+		runClock:      RunClock{OpenedAt: time.Now()},
+		openingAnte:   rules.AnteValue,
 	}
+
+	table.scheduleClose()
+
+	return table
 }
 
 // Table represents a poker table
@@ -33,14 +43,68 @@ type Table struct {
 	Name       string
 	Rules      TableRules
 	Players    []*Player
-	Hands      []Hand
+	Hands      []*Hand // Every hand played at the table, the active one included
 	ActiveHand *Hand
 	Status     TableStatus
 	BuyIns     map[string]int
+	StartsAt   time.Time              // When the first hand is due to start, zero if no countdown is running
+	Away       map[string]bool        // Players flagged as disconnected
+	Left       map[string]bool        // Players who left a blind-off table, their stack stays until it is blinded off
+	NextHand   map[string]bool        // Players who sat down during a hand, dealt in from the next one
+	Bots       map[string]BotStrategy // Players the table plays for, see SeatBot
+	HostID     string                 // The first player to sit, manages the blocklist
+	Blocklist  map[string]Ban         // Players the host keeps off the table, by player ID
+
+	sessions map[string]*PlayerSession // Seated players' running session summaries
+
+	pendingTopUps map[string]int // Chips to add to players' stacks once the current hand is over
+
+	recentPots   []int // Final pots of the last hands, for ante scaling
+	anteCooldown int   // Hands left before the ante may go up again
+	openingAnte  int   // Ante the table opened with, rematches go back to it
+
+	rematch *rematch // Proposed rematch waiting for the players to accept, see ProposeRematch
+
+	runClock RunClock
+
+	backPressure bool // The table's events are stored slower than they are emitted, see SetBackPressure
+	handHeld     bool // The next hand waits for the back-pressure to be released
+	draining     bool // No new hand is dealt, see Drain
+
+	// BotFillAllowed is the site-wide switch for Rules.BotFill, set by the lobby
+	BotFillAllowed bool
+	fillBots       map[string]bool // Bots seated by bot fill, true once sent away at the end of the hand
+
+	startTimer Timer
+	closeTimer Timer
+	turnTimer  Timer // Runs out the current bettor's turn
+	fillTimer  Timer // Seats bots for a player waiting alone, see BotFill
+
+	// Clock stamps the table's events and schedules its timers, the system clock when nil
+	Clock Clock
+
+	// loop serializes the table's game loop: player commands, timers and bots take turns, see Do
+	loop sync.Mutex
+
+	// mu guards Players, Hands, ActiveHand and Events for readers outside the table's own flow (e.g. HTTP handlers)
+	mu sync.RWMutex
+
+	// SeedEscrow seals each hand's shuffle seed for dispute resolution, nil disables escrow
+	SeedEscrow escrow.Sealer
+
+	// Shuffler picks each hand's shuffle seed, nil uses the secure random source
+	Shuffler cards.Shuffler
+
+	// Wallet holds the players' bankrolls: buy-ins are debited from it and stacks cashed out to it.
+	// Nil keeps bankrolls in Player.Balance.
+	Wallet    wallet.Wallet
+	walletOps int // Numbers the table's wallet calls, for their idempotency keys
 
 	// events
 	Events        []events.Event
 	eventHandlers []events.EventHandler
+	eventIDs      *events.IDGenerator
+	lastSeq       atomic.Uint64 // Sequence of the table's latest event
 }
 
 type TableStatus string
@@ -51,17 +115,98 @@ const (
 	TableStatusEnded   TableStatus = "ended"
 )
 
-// TableRules defines the rules for a poker table
+// TableRules defines the rules for a poker table, see Validate and PresetTableRules
 type TableRules struct {
 	AnteValue                 int
 	ContinuationBetMultiplier int
-	DiscardPhaseDuration      int
-	DiscardCostType           string
-	DiscardCostValue          int
+	DiscardPhaseDuration      int    // Seconds each player has to decide on a discard, zero for the default 3
+	DiscardCostType           string // How discards are priced, one of the DiscardCost constants; empty skips the discard phase
+	DiscardCostValue          int    // Chips for fixed costs, percent of the ante, player bet or pot otherwise
 	PlayerTimeout             time.Duration
 	MaxPlayers                int
+	MaxBuyIn                  int                    // Most chips a player may have in front of them after a top-up, zero for no cap
+	StartCountdown            time.Duration          // Waiting-room delay before the first hand, zero disables the automatic start
+	AutoPlayWhenAway          bool                   // Act for disconnected players instead of folding them (tournament tables)
+	BlindOff                  bool                   // Tournament tables: absent players keep their stack in play, posting antes and folding until eliminated
+	TournamentChips           bool                   // Tournament tables: stacks aren't money, and are never cashed out to the players' bankrolls
+	CloseWhenEmptyAfter       time.Duration          // How long a table may stay without seated players before it is closed, zero keeps it open
+	HiLoSplit                 bool                   // Split each pot between the best high and the best 8-or-better low
+	ConfirmBetsAbove          int                    // Percentage of the player's stack above which a bet must be confirmed, zero disables confirmation
+	FoldWinPolicy             FoldWinPolicy          // What the last player standing wins when everyone else folds before the community cards
+	SelectionTimeoutPolicy    SelectionTimeoutPolicy // What happens to players still missing community cards when the selection window closes
+	BurnPolicy                BurnPolicy             // When a card is burnt before dealing community cards
+	FeedPrivacy               FeedPrivacy            // Whether the table's big pots show in the site-wide feed, and how
+	Private                   bool                   // Played by a group of friends, who may agree on a rematch once the table is finished
+
+	// Community cards dealt each hand, and how many of them each player selects to make a hand with their hole cards.
+	// Zero deals 8 and selects 3, see ValidateCommunity for the variants tables may play.
+	CommunityCards int
+	SelectedCards  int
+
+	// SelectionWindow is how long players have to select their community cards, 5s when zero.
+	// A PhaseTimeouts entry for the selection phase takes precedence.
+	SelectionWindow time.Duration
+
+	// CommunityWaves deals the community cards in several waves, e.g. 4 then 4, each wave being the number
+	// of cards it deals. Waves must add up to all the community cards, empty deals them in a single wave.
+	CommunityWaves []int
+
+	// PhaseTimeouts puts pressure on late-phase play (turbo tables): the time to act in each phase,
+	// usually shorter as the hand progresses. Phases not listed use PlayerTimeout.
+	PhaseTimeouts map[HandPhase]time.Duration
+
+	// AnteScaling raises the ante when pots stay small across several hands
+	AnteScaling AnteScaling
+
+	// BombPots makes every Nth hand a bomb pot, where everyone posts a multiple of the ante and sees the community cards
+	BombPots BombPots
+
+	// ReadyCheck is how long seated players have to confirm they are ready before each hand is dealt (private games).
+	// Players who don't confirm in time sit the hand out. Zero disables the ready-check.
+	ReadyCheck time.Duration
+
+	// BotFill seats bots for a player waiting alone, when the lobby allows it
+	BotFill BotFill
 }
 
+// FoldWinPolicy decides what happens to the pot when all players but one fold before any community card is dealt
+type FoldWinPolicy string
+
+const (
+	FoldWinPolicyAwardPot     FoldWinPolicy = "award_pot" // The last player takes the whole pot (default)
+	FoldWinPolicyRefund       FoldWinPolicy = "refund"    // The hand is void and every player gets their bets back
+	FoldWinPolicyAwardNetBets FoldWinPolicy = "net_bets"  // The last player only wins what they matched, the rest is returned
+)
+
+// SelectionTimeoutPolicy decides what happens to players who haven't picked all their community cards when the selection window closes
+type SelectionTimeoutPolicy string
+
+const (
+	SelectionTimeoutAutoComplete SelectionTimeoutPolicy = "auto_complete" // The best cards left are picked for them (default)
+	SelectionTimeoutFold         SelectionTimeoutPolicy = "fold"          // They fold
+)
+
+// BurnPolicy decides when cards are burnt face down during the community deal
+type BurnPolicy string
+
+const (
+	BurnBeforeCommunity BurnPolicy = "before_community" // One card before the first community card (default)
+	BurnBeforeEachWave  BurnPolicy = "before_each_wave" // One card before each wave of community cards
+	BurnNone            BurnPolicy = "none"             // Community cards come straight off the top of the deck
+)
+
+// FeedPrivacy decides whether a table's notable hands appear in the site-wide big pots feed
+type FeedPrivacy string
+
+const (
+	FeedPrivate   FeedPrivacy = ""          // Never shown (default)
+	FeedAnonymous FeedPrivacy = "anonymous" // Shown without the table or the players
+	FeedPublic    FeedPrivacy = "public"    // Shown with the table name and the winners
+)
+
+// ErrTableFull is returned when a player sits at a table with every seat taken
+var ErrTableFull = errors.New("table is full")
+
 // SeatPlayer adds a player to the table
 func (t *Table) SeatPlayer(player *Player) error {
 	if player == nil {
@@ -79,14 +224,38 @@ func (t *Table) SeatPlayer(player *Player) error {
 		}
 	}
 
+	if t.IsBlocked(player.ID) {
+		return errors.New("player is blocked from this table")
+	}
+
+	if t.Rules.MaxPlayers > 0 && len(t.Players) >= t.Rules.MaxPlayers {
+		return ErrTableFull
+	}
+
+	t.mu.Lock()
 	t.Players = append(t.Players, player)
+	t.mu.Unlock()
+
+	if t.HostID == "" {
+		t.HostID = player.ID
+	}
+
+	nextHandOnly := t.waitForNextHand(player.ID)
+
+	now := t.clock().Now()
+	t.startSession(player.ID, now)
 
 	t.emitEvent(events.PlayerJoinedTable{
-		TableID: t.ID,
-		UserID:  player.ID,
-		At:      time.Now(),
+		TableID:      t.ID,
+		UserID:       player.ID,
+		NextHandOnly: nextHandOnly,
+		At:           now,
 	})
 
+	t.cancelClose()
+	t.scheduleFirstHand()
+	t.handleBotFillSeat(player.ID)
+
 	return nil
 }
 
@@ -108,11 +277,9 @@ func (t *Table) PlayerBuysIn(playerID string, chips int) error {
 		return errors.New("player not found")
 	}
 
-	if t.Players[playerIndex].Balance < chips {
-		return errors.New("player does not have enough balance")
+	if err := t.Players[playerIndex].Withdraw(t.Wallet, chips, t.walletKey("buy-in", playerID), "buy-in"); err != nil {
+		return err
 	}
-
-	t.Players[playerIndex].RemoveFromBalance(chips)
 	t.IncreasePlayerBuyIn(playerID, chips)
 
 	return nil
@@ -125,6 +292,17 @@ func (t *Table) GetPlayerBuyIn(playerID string) int {
 	return t.BuyIns[playerID]
 }
 
+// RequiresBetConfirmation checks if a bet is large enough, relative to the player's stack,
+// that the client should confirm it before it is applied
+func (t *Table) RequiresBetConfirmation(playerID string, amount int) bool {
+	if t.Rules.ConfirmBetsAbove <= 0 {
+		return false
+	}
+
+	stack := t.GetPlayerBuyIn(playerID)
+	return amount*100 > stack*t.Rules.ConfirmBetsAbove
+}
+
 func (t *Table) IncreasePlayerBuyIn(playerID string, amount int) {
 	if _, exists := t.BuyIns[playerID]; !exists {
 		t.BuyIns[playerID] = 0
@@ -137,7 +315,7 @@ func (t *Table) IncreasePlayerBuyIn(playerID string, amount int) {
 	t.emitEvent(events.PlayerChipsChanged{
 		TableID: t.ID,
 		UserID:  playerID,
-		At:      time.Now(),
+		At:      t.clock().Now(),
 		Before:  before,
 		After:   after,
 		Change:  after - before,
@@ -156,7 +334,7 @@ func (t *Table) DecreasePlayerBuyIn(playerID string, amount int) {
 	t.emitEvent(events.PlayerChipsChanged{
 		TableID: t.ID,
 		UserID:  playerID,
-		At:      time.Now(),
+		At:      t.clock().Now(),
 		Before:  before,
 		After:   after,
 		Change:  after - before,
@@ -167,32 +345,264 @@ func (t *Table) removePlayerFromBuyIns(playerID string) {
 	delete(t.BuyIns, playerID)
 }
 
-// PlayerLeaves removes a player from the table
+// ErrLeavingDuringHand is returned when a player still in the hand tries to leave the table
+var ErrLeavingDuringHand = errors.New("can't leave while still in the hand, fold first")
+
+// PlayerLeaves removes a player from the table, cashing their stack out to their bankroll
 func (t *Table) PlayerLeaves(playerID string) error {
-	playerIndex := -1
+	playerIndex := t.seatIndex(playerID)
+	if playerIndex == -1 {
+		return errors.New("player not found")
+	}
+
+	// Tournament stacks stay in play after their owner leaves
+	if t.Rules.BlindOff && t.Status == TableStatusPlaying && t.BuyIns[playerID] > 0 {
+		return t.startBlindOff(playerID)
+	}
+
+	// Chips in the pot can't be cashed out, players still in the hand have to fold first
+	if hand := t.ActiveHand; hand != nil && !hand.HasEnded() && hand.IsPlayerActive(playerID) {
+		return ErrLeavingDuringHand
+	}
+
+	// A stack the wallet didn't take stays on the table, the player can leave again once it does
+	if err := t.cashOut(t.Players[playerIndex]); err != nil {
+		return err
+	}
+	t.removePlayer(playerIndex)
+	return nil
+}
+
+// UnseatPlayer takes a player off the table with their stack, for them to sit at another table: the stack is
+// neither cashed out nor blinded off. It returns the stack, and whether the player had left and was blinding off.
+func (t *Table) UnseatPlayer(playerID string) (int, bool, error) {
+	playerIndex := t.seatIndex(playerID)
+	if playerIndex == -1 {
+		return 0, false, errors.New("player not found")
+	}
+
+	if hand := t.ActiveHand; hand != nil && !hand.HasEnded() && hand.IsPlayerActive(playerID) {
+		return 0, false, ErrLeavingDuringHand
+	}
+
+	stack := t.GetPlayerBuyIn(playerID)
+	left := t.Left[playerID]
+	t.removePlayer(playerIndex)
+	return stack, left, nil
+}
+
+// seatIndex returns the index of a player in Players, -1 if they are not seated
+func (t *Table) seatIndex(playerID string) int {
 	for i, p := range t.Players {
 		if p.ID == playerID {
-			playerIndex = i
-			break
+			return i
 		}
 	}
+	return -1
+}
 
-	if playerIndex == -1 {
-		return errors.New("player not found")
+// removePlayer takes the player at the given seat off the table, with what's left of their stack.
+// Callers cash the stack out first if it goes back to the player's bankroll.
+func (t *Table) removePlayer(playerIndex int) {
+	leaving := t.Players[playerIndex]
+	playerID := leaving.ID
+	wasBot := t.IsBot(playerID)
+
+	// Build a new slice, the active hand may still share the old one
+	remaining := make([]*Player, 0, len(t.Players)-1)
+	remaining = append(remaining, t.Players[:playerIndex]...)
+	remaining = append(remaining, t.Players[playerIndex+1:]...)
+
+	t.mu.Lock()
+	t.Players = remaining
+	t.mu.Unlock()
+
+	// Hand the blocklist over to the next player in seat order
+	if t.HostID == playerID {
+		t.HostID = ""
+		if len(remaining) > 0 {
+			t.HostID = remaining[0].ID
+		}
 	}
 
-	t.Players = append(t.Players[:playerIndex], t.Players[playerIndex+1:]...)
+	// Summarize before the stack is removed
+	t.endSession(playerID, "left the table")
+
 	t.removePlayerFromBuyIns(playerID)
+	delete(t.Away, playerID)
+	delete(t.Left, playerID)
+	delete(t.NextHand, playerID)
+	delete(t.Bots, playerID)
+	delete(t.pendingTopUps, playerID)
 
 	t.emitEvent(events.PlayerLeftTable{
 		TableID: t.ID,
 		UserID:  playerID,
-		At:      time.Now(),
+		At:      t.clock().Now(),
 	})
 
+	if len(t.Players) < 2 {
+		t.cancelFirstHand("not enough players")
+	}
+
+	if len(t.Players) == 0 {
+		t.scheduleClose()
+	}
+
+	t.handleBotFillLeave(playerID, wasBot)
+	t.handleRematchLeave(playerID)
+}
+
+// cashOut returns a leaving player's stack to their bankroll. Tournament chips stay behind as they aren't money,
+// and so do the stacks of bots, which have no bankroll. It fails if the wallet does, the stack must then stay
+// on the table rather than be lost.
+func (t *Table) cashOut(player *Player) error {
+	stack := t.GetPlayerBuyIn(player.ID)
+	if stack <= 0 || t.Rules.TournamentChips || t.IsBot(player.ID) {
+		return nil
+	}
+
+	if err := player.Deposit(t.Wallet, stack, t.walletKey("cash-out", player.ID), "cash-out"); err != nil {
+		return fmt.Errorf("could not cash out %d chips: %w", stack, err)
+	}
+
+	t.emitEvent(events.PlayerCashedOut{
+		TableID:  t.ID,
+		PlayerID: player.ID,
+		Amount:   stack,
+		Balance:  player.Bankroll(t.Wallet),
+		At:       t.clock().Now(),
+	})
 	return nil
 }
 
+// scheduleFirstHand starts the waiting-room countdown once at least two players are seated
+func (t *Table) scheduleFirstHand() {
+	if t.Rules.StartCountdown <= 0 || t.Status != TableStatusWaiting {
+		return
+	}
+
+	if len(t.Players) < 2 || t.startTimer != nil {
+		return
+	}
+
+	t.StartsAt = t.clock().Now().Add(t.Rules.StartCountdown)
+	t.startTimer = t.clock().AfterFunc(t.Rules.StartCountdown, func() {
+		t.guard("first hand", func() error {
+			t.startFirstHand()
+			return nil
+		})
+	})
+
+	t.emitEvent(events.TableStartingSoon{
+		TableID:     t.ID,
+		PlayerCount: len(t.Players),
+		StartsAt:    t.StartsAt,
+		At:          t.clock().Now(),
+	})
+}
+
+// cancelFirstHand stops a running waiting-room countdown
+func (t *Table) cancelFirstHand(reason string) {
+	if t.startTimer == nil {
+		return
+	}
+
+	t.startTimer.Stop()
+	t.startTimer = nil
+	t.StartsAt = time.Time{}
+
+	t.emitEvent(events.TableStartCancelled{
+		TableID: t.ID,
+		Reason:  reason,
+		At:      t.clock().Now(),
+	})
+}
+
+// startFirstHand is called when the waiting-room countdown expires
+func (t *Table) startFirstHand() {
+	t.startTimer = nil
+	t.StartsAt = time.Time{}
+
+	if err := t.AllowPlaying(); err != nil {
+		fmt.Println("Could not start table", t.ID, ":", err)
+		return
+	}
+
+	hand, err := t.StartNewHand()
+	if err != nil {
+		fmt.Println("Could not start first hand at table", t.ID, ":", err)
+		return
+	}
+
+	if err := hand.InitializeHand(); err != nil {
+		fmt.Println("Could not deal first hand at table", t.ID, ":", err)
+		return
+	}
+	if t.Rules.ReadyCheck > 0 {
+		hand.StartReadyCheck()
+		return
+	}
+	hand.TransitionToAntesPhase()
+}
+
+// scheduleClose starts the countdown to close the table while nobody is seated
+func (t *Table) scheduleClose() {
+	if t.Rules.CloseWhenEmptyAfter <= 0 || t.Status == TableStatusEnded {
+		return
+	}
+
+	if len(t.Players) > 0 || t.closeTimer != nil {
+		return
+	}
+
+	t.closeTimer = t.clock().AfterFunc(t.Rules.CloseWhenEmptyAfter, func() {
+		t.guard("empty table close", func() error {
+			t.closeTimer = nil
+			if len(t.Players) == 0 {
+				t.Close("empty for " + t.Rules.CloseWhenEmptyAfter.String())
+			}
+			return nil
+		})
+	})
+}
+
+// cancelClose stops the empty-table countdown once someone sits down
+func (t *Table) cancelClose() {
+	if t.closeTimer == nil {
+		return
+	}
+
+	t.closeTimer.Stop()
+	t.closeTimer = nil
+}
+
+// Close ends the table for good and stops its timers. The lobby drops the table when it sees TableClosed.
+func (t *Table) Close(reason string) {
+	if t.Status == TableStatusEnded {
+		return
+	}
+
+	t.cancelFirstHand(reason)
+	t.cancelClose()
+	t.cancelBotFill()
+
+	for _, player := range t.GetPlayers() {
+		t.endSession(player.ID, "table closed")
+	}
+
+	t.mu.Lock()
+	t.Status = TableStatusEnded
+	t.ActiveHand = nil
+	t.mu.Unlock()
+
+	t.emitEvent(events.TableClosed{
+		TableID: t.ID,
+		Reason:  reason,
+		At:      t.clock().Now(),
+	})
+}
+
 // AllowPlaying starts the table if there are enough players
 func (t *Table) AllowPlaying() error {
 	if len(t.Players) < 2 {
@@ -208,11 +618,18 @@ func (t *Table) AllowPlaying() error {
 	return nil
 }
 
-// GetHandByID returns a hand by its ID
+// GetHandByID returns the active or a completed hand by its ID
 func (t *Table) GetHandByID(handID string) (*Hand, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.ActiveHand != nil && t.ActiveHand.ID == handID {
+		return t.ActiveHand, nil
+	}
+
 	for _, h := range t.Hands {
 		if h.ID == handID {
-			return &h, nil
+			return h, nil
 		}
 	}
 
@@ -225,14 +642,21 @@ func (t *Table) StartNewHand() (*Hand, error) {
 		return nil, errors.New("table must be in playing status to start a new hand")
 	}
 
+	if t.IsDraining() {
+		return nil, ErrTableDraining
+	}
+
 	// Check if there is an active hand
 	if t.ActiveHand != nil {
 		return nil, errors.New("there is already an active hand: " + t.ActiveHand.ID)
 	}
 
 	// Create the first hand
+	number := t.RunClock().HandNumber + 1
 	hand := &Hand{
 		ID:                          uuid.NewString(),
+		Number:                      number,
+		BombPot:                     t.Rules.BombPots.isBombPot(number),
 		Table:                       t,
 		TableID:                     t.ID,
 		Players:                     t.Players,
@@ -244,6 +668,7 @@ func (t *Table) StartNewHand() (*Hand, error) {
 		eventHandlers:               []events.EventHandler{},
 		TableRules:                  t.Rules,
 		Deck:                        cards.NewDeck52(),
+		Shuffler:                    t.Shuffler,
 		Results:                     []hands.HandComparisonResult{},
 		CurrentBettor:               "",
 		CommunitySelections:         make(map[string]cards.Stack),
@@ -264,17 +689,24 @@ func (t *Table) StartNewHand() (*Hand, error) {
 }
 
 func (t *Table) handleHandEvent(event events.Event) {
-	fmt.Println("---")
-	fmt.Println("Table received event:", event.Name())
-	litter.D(event)
-
 	t.emitEvent(event)
+	t.handleAutoPlayEvent(event)
+	t.handleBotEvent(event)
+	t.handleBlindOffEvent(event)
+	t.handleTurnTimerEvent(event)
 
 	switch ev := event.(type) {
 	case events.HandEnded:
 		fmt.Println("Hand ended with pot = ", ev.FinalPot)
+		t.recordSessionHand(t.ActiveHand)
+		t.mu.Lock()
 		t.ActiveHand = nil
-		t.StartNewHand()
+		t.mu.Unlock()
+		t.applyPendingTopUps()
+		t.eliminateBlindedOffPlayers()
+		t.removeLeavingFillBots()
+		t.scaleAnte(ev.FinalPot)
+		t.startNextHand()
 	}
 }
 
@@ -293,8 +725,11 @@ func (t *Table) findButtonPosition() int {
 }
 
 func (t *Table) setActiveHand(hand *Hand) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.ActiveHand = hand
-	t.Hands = append(t.Hands, *hand)
+	t.Hands = append(t.Hands, hand)
 }
 
 // RegisterEventHandler registers a callback function that will be called when events occur
@@ -302,10 +737,30 @@ func (t *Table) RegisterEventHandler(handler events.EventHandler) {
 	t.eventHandlers = append(t.eventHandlers, handler)
 }
 
+// stampEvent assigns the next table-scoped ID and sequence to an event that doesn't have them yet
+func (t *Table) stampEvent(event events.Event) events.Event {
+	if t.eventIDs == nil {
+		t.eventIDs = events.NewIDGenerator()
+	}
+	event = t.eventIDs.Stamp(event)
+
+	seq := events.ExtractSequence(event)
+	if seq.Table != 0 {
+		return event
+	}
+	seq.Table = t.lastSeq.Add(1)
+	return events.WithSequence(event, seq)
+}
+
 // emitEvent notifies all registered handlers of a new event
 func (t *Table) emitEvent(event events.Event) {
+	event = t.stampEvent(event)
+
 	// Add event to hand's event log
+	t.mu.Lock()
 	t.Events = append(t.Events, event)
+	t.runClock.countEvent(event)
+	t.mu.Unlock()
 
 	// Notify all handlers
 	for _, handler := range t.eventHandlers {
@@ -313,15 +768,41 @@ func (t *Table) emitEvent(event events.Event) {
 	}
 }
 
-// GetPlayers returns all players at the table
+// GetPlayers returns copies of the players at the table, in seat order.
+// Changing them does not affect the table.
 func (t *Table) GetPlayers() []*Player {
-	return t.Players
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	players := make([]*Player, 0, len(t.Players))
+	for _, p := range t.Players {
+		player := *p
+		players = append(players, &player)
+	}
+	return players
+}
+
+// GetEvents returns the events the table kept in memory, oldest first. Older ones may have been pruned.
+func (t *Table) GetEvents() []events.Event {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]events.Event{}, t.Events...)
 }
 
 // GetCurrentHandID returns the ID of the current active hand, if any
 func (t *Table) GetCurrentHandID() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	if t.ActiveHand != nil {
 		return t.ActiveHand.ID
 	}
 	return ""
 }
+
+// walletKey returns the idempotency key of the table's next wallet call
+func (t *Table) walletKey(operation string, playerID string) string {
+	t.walletOps++
+	return fmt.Sprintf("table/%s/%s/%s/%d", t.ID, operation, playerID, t.walletOps)
+}
@@ -1,10 +1,15 @@
 package domain
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPlayerSeats(t *testing.T) {
@@ -40,6 +45,13 @@ func TestPlayerSeats(t *testing.T) {
 	err = table.SeatPlayer(newPlayer)
 	assert.Error(t, err)
 	assert.Equal(t, "can only add players when table is waiting or playing", err.Error())
+
+	// Test error when every seat is taken
+	table.Status = TableStatusWaiting
+	table.Rules.MaxPlayers = 1
+	err = table.SeatPlayer(newPlayer)
+	assert.ErrorIs(t, err, ErrTableFull)
+	assert.Len(t, table.Players, 1)
 }
 
 func TestPlayerBuysIn(t *testing.T) {
@@ -111,6 +123,206 @@ func TestPlayerLeaves(t *testing.T) {
 	assert.Equal(t, "player not found", err.Error())
 }
 
+// unavailableWallet is a wallet whose credits fail while it is down
+type unavailableWallet struct {
+	*wallet.Ledger
+	down bool
+}
+
+func (w *unavailableWallet) Credit(playerID string, amount int, key string, reason string) (wallet.Transaction, error) {
+	if w.down {
+		return wallet.Transaction{}, errors.New("wallet unavailable")
+	}
+	return w.Ledger.Credit(playerID, amount, key, reason)
+}
+
+func TestTableWallet(t *testing.T) {
+	setup := func() (*Table, *Player, *wallet.Ledger) {
+		ledger := wallet.NewLedger()
+		ledger.Credit("player-1", 1000, "welcome/player-1", "starting balance")
+		player := &Player{ID: "player-1", Name: "Player 1", Balance: 1000}
+
+		table := NewTable("Test Table", TableRules{})
+		table.Wallet = ledger
+		require.NoError(t, table.SeatPlayer(player))
+		return table, player, ledger
+	}
+
+	t.Run("Buy-ins are debited from the wallet", func(t *testing.T) {
+		// Setup
+		table, player, ledger := setup()
+
+		// Act
+		err := table.PlayerBuysIn(player.ID, 400)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 600, ledger.Balance(player.ID))
+		assert.Equal(t, 600, player.Bankroll(ledger))
+		assert.Equal(t, 400, table.GetPlayerBuyIn(player.ID))
+
+		transactions := ledger.Transactions(player.ID)
+		require.Len(t, transactions, 2)
+		assert.Equal(t, wallet.KindDebit, transactions[1].Kind)
+		assert.Equal(t, "buy-in", transactions[1].Reason)
+	})
+
+	t.Run("A buy-in the wallet can't cover gets no chips", func(t *testing.T) {
+		// Setup
+		table, player, ledger := setup()
+
+		// Act
+		err := table.PlayerBuysIn(player.ID, 1500)
+
+		// Assert
+		assert.ErrorIs(t, err, wallet.ErrInsufficientFunds)
+		assert.Equal(t, 0, table.GetPlayerBuyIn(player.ID))
+		assert.Equal(t, 1000, ledger.Balance(player.ID))
+	})
+
+	t.Run("Leaving cashes the stack out to the wallet", func(t *testing.T) {
+		// Setup
+		table, player, ledger := setup()
+		require.NoError(t, table.PlayerBuysIn(player.ID, 400))
+		table.IncreasePlayerBuyIn(player.ID, 150) // Won a pot
+
+		// Act
+		err := table.PlayerLeaves(player.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1150, ledger.Balance(player.ID))
+		assert.Equal(t, 1150, player.Bankroll(ledger))
+		transactions := ledger.Transactions(player.ID)
+		assert.Equal(t, "cash-out", transactions[len(transactions)-1].Reason)
+	})
+
+	t.Run("A stack the wallet can't take stays on the table until it can", func(t *testing.T) {
+		// Setup
+		table, player, ledger := setup()
+		require.NoError(t, table.PlayerBuysIn(player.ID, 400))
+		unavailable := &unavailableWallet{Ledger: ledger, down: true}
+		table.Wallet = unavailable
+
+		// Act
+		err := table.PlayerLeaves(player.ID)
+
+		// Assert
+		assert.ErrorContains(t, err, "wallet unavailable")
+		assert.True(t, table.IsSeated(player.ID))
+		assert.Equal(t, 400, table.GetPlayerBuyIn(player.ID))
+		assert.Equal(t, 600, ledger.Balance(player.ID))
+
+		// Act
+		unavailable.down = false
+		err = table.PlayerLeaves(player.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, table.IsSeated(player.ID))
+		assert.Equal(t, 1000, ledger.Balance(player.ID))
+	})
+
+	t.Run("A player can cash out of two tables at once", func(t *testing.T) {
+		// Setup
+		table, player, ledger := setup()
+		other := NewTable("Other Table", TableRules{})
+		other.Wallet = ledger
+		require.NoError(t, other.SeatPlayer(player))
+		require.NoError(t, table.PlayerBuysIn(player.ID, 400))
+		require.NoError(t, other.PlayerBuysIn(player.ID, 300))
+
+		// Act
+		left := make(chan error)
+		for _, tbl := range []*Table{table, other} {
+			go func() {
+				left <- tbl.Do("leave", func() error { return tbl.PlayerLeaves(player.ID) })
+			}()
+		}
+
+		// Assert
+		require.NoError(t, <-left)
+		require.NoError(t, <-left)
+		assert.Equal(t, 1000, player.Bankroll(ledger))
+	})
+}
+
+func TestCashOut(t *testing.T) {
+	setupHand := func() (*Hand, *Table) {
+		hand, table := setupContinuationPhaseHand(3)
+		table.Players = hand.Players
+		table.Status = TableStatusPlaying
+		table.ActiveHand = hand
+		return hand, table
+	}
+
+	t.Run("The stack goes back to the balance", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "player-1", Balance: 200}
+		table := NewTable("Test Table", TableRules{})
+		require.NoError(t, table.SeatPlayer(player))
+		require.NoError(t, table.PlayerBuysIn(player.ID, 150))
+		table.IncreasePlayerBuyIn(player.ID, 100) // Won a pot
+
+		// Act
+		err := table.PlayerLeaves(player.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 300, player.Balance)
+		event, found := findEventOfType(table.Events, events.PlayerCashedOut{}.Name())
+		require.True(t, found)
+		cashedOut := event.(events.PlayerCashedOut)
+		assert.Equal(t, 250, cashedOut.Amount)
+		assert.Equal(t, 300, cashedOut.Balance)
+	})
+
+	t.Run("Players still in the hand can't leave", func(t *testing.T) {
+		// Setup
+		_, table := setupHand()
+
+		// Act
+		err := table.PlayerLeaves("player-2")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrLeavingDuringHand)
+		assert.True(t, table.IsSeated("player-2"))
+		assert.Equal(t, 1000, table.GetPlayerBuyIn("player-2"))
+	})
+
+	t.Run("Players who folded may leave with their stack", func(t *testing.T) {
+		// Setup
+		hand, table := setupHand()
+		hand.setPlayerAsInactive("player-3")
+		player := table.Players[2]
+
+		// Act
+		err := table.PlayerLeaves("player-3")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, table.IsSeated("player-3"))
+		assert.Equal(t, 1000, player.Balance)
+	})
+
+	t.Run("Tournament chips are not cashed out", func(t *testing.T) {
+		// Setup
+		player := &Player{ID: "player-1"}
+		table := NewTable("Test Table", TableRules{TournamentChips: true})
+		require.NoError(t, table.SeatPlayer(player))
+		table.IncreasePlayerBuyIn(player.ID, 1500)
+
+		// Act
+		err := table.PlayerLeaves(player.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, player.Balance)
+		_, found := findEventOfType(table.Events, events.PlayerCashedOut{}.Name())
+		assert.False(t, found)
+	})
+}
+
 func TestAllowPlaying(t *testing.T) {
 	// Setup
 	table := &Table{
@@ -204,3 +416,211 @@ func TestFindButtonPosition(t *testing.T) {
 	position = table.findButtonPosition()
 	assert.Equal(t, 0, position)
 }
+
+func TestTableEventIDs(t *testing.T) {
+	table := NewTable("Test Table", TableRules{})
+
+	table.SeatPlayer(&Player{ID: "player-1", Name: "Player 1"})
+	table.SeatPlayer(&Player{ID: "player-2", Name: "Player 2"})
+	table.IncreasePlayerBuyIn("player-1", 100)
+
+	assert.Len(t, table.Events, 3)
+
+	previous := ""
+	for _, event := range table.Events {
+		id := events.ExtractEventID(event)
+		assert.NotEmpty(t, id)
+		assert.Greater(t, id, previous)
+		previous = id
+	}
+}
+
+func TestTableEventSequences(t *testing.T) {
+	setup := func() (*Table, *Hand) {
+		table := NewTestTable()
+		for _, id := range []string{"player-1", "player-2"} {
+			table.SeatPlayer(&Player{ID: id, Balance: 1000})
+			table.IncreasePlayerBuyIn(id, 500)
+		}
+		table.Status = TableStatusPlaying
+		hand, err := table.StartNewHand()
+		require.NoError(t, err)
+		require.NoError(t, hand.InitializeHand())
+		return table, hand
+	}
+
+	t.Run("Table events are numbered one after the other", func(t *testing.T) {
+		// Setup
+		table, _ := setup()
+
+		// Assert
+		require.NotEmpty(t, table.Events)
+		for i, event := range table.Events {
+			assert.Equal(t, uint64(i+1), events.ExtractSequence(event).Table, event.Name())
+		}
+	})
+
+	t.Run("Hand events are also numbered within their hand", func(t *testing.T) {
+		// Setup
+		table, hand := setup()
+
+		// Act
+		seated := table.Events[0]
+
+		// Assert
+		assert.Zero(t, events.ExtractSequence(seated).Hand)
+		require.NotEmpty(t, hand.Events)
+		for i, event := range hand.Events {
+			assert.Equal(t, uint64(i+1), events.ExtractSequence(event).Hand, event.Name())
+		}
+	})
+}
+
+func TestWaitingRoomCountdown(t *testing.T) {
+	t.Run("Second player starts the countdown and the first hand", func(t *testing.T) {
+		table := NewTable("Test Table", TableRules{StartCountdown: 20 * time.Millisecond})
+
+		table.SeatPlayer(&Player{ID: "player-1", Name: "Player 1"})
+		assert.True(t, table.StartsAt.IsZero())
+
+		table.SeatPlayer(&Player{ID: "player-2", Name: "Player 2"})
+		assert.False(t, table.StartsAt.IsZero())
+
+		event, found := findEventOfType(table.GetEvents(), events.TableStartingSoon{}.Name())
+		assert.True(t, found)
+		assert.Equal(t, 2, event.(events.TableStartingSoon).PlayerCount)
+
+		assert.Eventually(t, func() bool {
+			return inLoop(table, func() bool { return table.Status == TableStatusPlaying && table.ActiveHand != nil })
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Countdown is cancelled when players drop below two", func(t *testing.T) {
+		table := NewTable("Test Table", TableRules{StartCountdown: 20 * time.Millisecond})
+
+		table.SeatPlayer(&Player{ID: "player-1", Name: "Player 1"})
+		table.SeatPlayer(&Player{ID: "player-2", Name: "Player 2"})
+		table.PlayerLeaves("player-2")
+
+		_, found := findEventOfType(table.GetEvents(), events.TableStartCancelled{}.Name())
+		assert.True(t, found)
+		assert.True(t, table.StartsAt.IsZero())
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, TableStatusWaiting, inLoop(table, func() TableStatus { return table.Status }))
+		assert.Nil(t, inLoop(table, func() *Hand { return table.ActiveHand }))
+	})
+}
+
+func TestTableAccessors(t *testing.T) {
+	t.Run("GetHandByID returns the live hand, before and after it ends", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}}
+		table.Status = TableStatusPlaying
+
+		hand, err := table.StartNewHand()
+		assert.NoError(t, err)
+		require.NoError(t, hand.InitializeHand())
+
+		// Act
+		active, err := table.GetHandByID(hand.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Same(t, hand, active)
+		assert.NotEmpty(t, active.SeedCommitment)
+		assert.Equal(t, hand.ID, table.GetCurrentHandID())
+
+		// Completed hands stay reachable
+		table.ActiveHand = nil
+		completed, err := table.GetHandByID(hand.ID)
+		assert.NoError(t, err)
+		assert.Same(t, hand, completed)
+		assert.Empty(t, table.GetCurrentHandID())
+
+		_, err = table.GetHandByID("unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetPlayers returns defensive copies", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.SeatPlayer(&Player{ID: "player-1", Name: "Player 1", Balance: 100})
+
+		// Act
+		players := table.GetPlayers()
+		players[0].Balance = 0
+
+		// Assert
+		assert.Len(t, table.Players, 1)
+		assert.Equal(t, 100, table.Players[0].Balance)
+	})
+
+	t.Run("Leaving does not reorder the active hand's players", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{})
+		table.Players = []*Player{{ID: "player-1"}, {ID: "player-2"}, {ID: "player-3"}}
+		table.Status = TableStatusPlaying
+		hand, _ := table.StartNewHand()
+
+		// Act
+		err := table.PlayerLeaves("player-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, table.GetPlayers(), 2)
+		assert.Equal(t, "player-1", hand.Players[0].ID)
+		assert.Len(t, hand.Players, 3)
+	})
+}
+
+func TestRequiresBetConfirmation(t *testing.T) {
+	// Setup
+	table := NewTable("Test Table", TableRules{ConfirmBetsAbove: 50})
+	table.BuyIns["player-1"] = 1000
+
+	// Assert
+	assert.False(t, table.RequiresBetConfirmation("player-1", 500), "exactly half the stack")
+	assert.True(t, table.RequiresBetConfirmation("player-1", 501))
+	assert.True(t, table.RequiresBetConfirmation("player-2", 10), "player without chips")
+
+	table.Rules.ConfirmBetsAbove = 0
+	assert.False(t, table.RequiresBetConfirmation("player-1", 1000), "confirmation disabled")
+}
+
+func TestCloseWhenEmpty(t *testing.T) {
+	t.Run("Empty table is closed and removed from the lobby", func(t *testing.T) {
+		// Setup
+		lobby := &Lobby{}
+		table, _ := lobby.NewTable("Test Table", TableRules{CloseWhenEmptyAfter: 20 * time.Millisecond})
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			_, found := findEventOfType(table.GetEvents(), events.TableClosed{}.Name())
+			return found
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, TableStatusEnded, inLoop(table, func() TableStatus { return table.Status }))
+
+		_, err := lobby.GetTable(table.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Seating a player keeps the table open", func(t *testing.T) {
+		// Setup
+		table := NewTable("Test Table", TableRules{CloseWhenEmptyAfter: 20 * time.Millisecond})
+
+		// Act
+		table.SeatPlayer(&Player{ID: "player-1"})
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert
+		assert.Equal(t, TableStatusWaiting, inLoop(table, func() TableStatus { return table.Status }))
+
+		// Leaving restarts the countdown
+		table.PlayerLeaves("player-1")
+		assert.Eventually(t, func() bool {
+			return inLoop(table, func() bool { return table.Status == TableStatusEnded })
+		}, time.Second, 5*time.Millisecond)
+	})
+}
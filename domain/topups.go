@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// TopUp adds chips from the player's balance to their stack. Stacks only change between hands:
+// during a hand the top-up is queued, and applied once the hand has ended. Returns true if it was queued.
+func (t *Table) TopUp(playerID string, amount int) (bool, error) {
+	if amount <= 0 {
+		return false, errors.New("top-up amount must be positive")
+	}
+
+	player := t.seatedPlayer(playerID)
+	if player == nil {
+		return false, errors.New("player not found")
+	}
+	if t.Left[playerID] {
+		return false, errors.New("player has left the table")
+	}
+
+	pending := t.pendingTopUps[playerID]
+	if player.Bankroll(t.Wallet) < pending+amount {
+		return false, errors.New("player does not have enough balance")
+	}
+	if t.Rules.MaxBuyIn > 0 && t.GetPlayerBuyIn(playerID)+pending+amount > t.Rules.MaxBuyIn {
+		return false, errors.New("top-up would exceed the maximum buy-in")
+	}
+
+	if t.ActiveHand != nil {
+		if t.pendingTopUps == nil {
+			t.pendingTopUps = make(map[string]int)
+		}
+		t.pendingTopUps[playerID] += amount
+		return true, nil
+	}
+
+	return false, t.applyTopUp(player, amount, false)
+}
+
+// PendingTopUp returns the chips a player asked to add once the current hand is over
+func (t *Table) PendingTopUp(playerID string) int {
+	return t.pendingTopUps[playerID]
+}
+
+// applyPendingTopUps adds the top-ups queued during the hand that just ended, in seat order.
+// A top-up is cut down to what the maximum buy-in still allows after the hand, and dropped if the
+// player no longer has the balance for it.
+func (t *Table) applyPendingTopUps() {
+	if len(t.pendingTopUps) == 0 {
+		return
+	}
+	pending := t.pendingTopUps
+	t.pendingTopUps = nil
+
+	playerIDs := make([]string, 0, len(pending))
+	for playerID := range pending {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	for _, playerID := range playerIDs {
+		player := t.seatedPlayer(playerID)
+		if player == nil || t.Left[playerID] {
+			continue
+		}
+
+		amount := pending[playerID]
+		if t.Rules.MaxBuyIn > 0 {
+			amount = min(amount, t.Rules.MaxBuyIn-t.GetPlayerBuyIn(playerID))
+		}
+		if amount <= 0 || player.Bankroll(t.Wallet) < amount {
+			continue
+		}
+
+		if err := t.applyTopUp(player, amount, true); err != nil {
+			fmt.Println("Could not top up player", playerID, "at table", t.ID, ":", err)
+		}
+	}
+}
+
+func (t *Table) applyTopUp(player *Player, amount int, queued bool) error {
+	if err := player.Withdraw(t.Wallet, amount, t.walletKey("top-up", player.ID), "top-up"); err != nil {
+		return err
+	}
+	t.IncreasePlayerBuyIn(player.ID, amount)
+
+	if session, ok := t.sessions[player.ID]; ok {
+		session.ToppedUp += amount
+	}
+
+	t.emitEvent(events.PlayerToppedUp{
+		TableID:  t.ID,
+		PlayerID: player.ID,
+		Amount:   amount,
+		Stack:    t.GetPlayerBuyIn(player.ID),
+		Queued:   queued,
+		At:       t.clock().Now(),
+	})
+	return nil
+}
+
+// seatedPlayer returns a player seated at the table, nil if they are not
+func (t *Table) seatedPlayer(playerID string) *Player {
+	for _, p := range t.Players {
+		if p.ID == playerID {
+			return p
+		}
+	}
+	return nil
+}
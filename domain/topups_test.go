@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopUp(t *testing.T) {
+	setup := func(maxBuyIn int) (*Table, *Player) {
+		table := NewTable("Test Table", TableRules{AnteValue: 10, MaxBuyIn: maxBuyIn})
+		player := &Player{ID: "player-1", Balance: 1000}
+		require.NoError(t, table.SeatPlayer(player))
+		require.NoError(t, table.PlayerBuysIn(player.ID, 200))
+		return table, player
+	}
+
+	t.Run("Adds chips right away between hands", func(t *testing.T) {
+		// Setup
+		table, player := setup(0)
+
+		// Act
+		queued, err := table.TopUp(player.ID, 300)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, queued)
+		assert.Equal(t, 500, table.GetPlayerBuyIn(player.ID))
+		assert.Equal(t, 500, player.Balance)
+		assert.Equal(t, 300, table.sessions[player.ID].ToppedUp)
+		event, found := findEventOfType(table.Events, events.PlayerToppedUp{}.Name())
+		require.True(t, found)
+		assert.Equal(t, events.PlayerToppedUp{
+			ID:       event.(events.PlayerToppedUp).ID,
+			Seq:      event.(events.PlayerToppedUp).Seq,
+			TableID:  table.ID,
+			PlayerID: player.ID,
+			Amount:   300,
+			Stack:    500,
+			At:       event.(events.PlayerToppedUp).At,
+		}, event)
+	})
+
+	t.Run("Queues top-ups during a hand until it ends", func(t *testing.T) {
+		// Setup
+		table, player := setup(0)
+		table.ActiveHand = &Hand{}
+
+		// Act
+		queued, err := table.TopUp(player.ID, 100)
+		require.NoError(t, err)
+		stackDuringHand := table.GetPlayerBuyIn(player.ID)
+		table.ActiveHand = nil
+		table.applyPendingTopUps()
+
+		// Assert
+		assert.True(t, queued)
+		assert.Equal(t, 200, stackDuringHand)
+		assert.Equal(t, 300, table.GetPlayerBuyIn(player.ID))
+		assert.Equal(t, 0, table.PendingTopUp(player.ID))
+		event, found := findEventOfType(table.Events, events.PlayerToppedUp{}.Name())
+		require.True(t, found)
+		assert.True(t, event.(events.PlayerToppedUp).Queued)
+	})
+
+	t.Run("Refuses top-ups above the maximum buy-in", func(t *testing.T) {
+		// Setup
+		table, player := setup(400)
+
+		// Act
+		_, err := table.TopUp(player.ID, 300)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 200, table.GetPlayerBuyIn(player.ID))
+	})
+
+	t.Run("Cuts queued top-ups down to the maximum buy-in after the hand", func(t *testing.T) {
+		// Setup
+		table, player := setup(400)
+		table.ActiveHand = &Hand{}
+		_, err := table.TopUp(player.ID, 200)
+		require.NoError(t, err)
+
+		// Act
+		table.BuyIns[player.ID] = 350 // Won a pot
+		table.ActiveHand = nil
+		table.applyPendingTopUps()
+
+		// Assert
+		assert.Equal(t, 400, table.BuyIns[player.ID])
+		assert.Equal(t, 750, player.Balance)
+	})
+
+	t.Run("Refuses top-ups beyond the player's balance", func(t *testing.T) {
+		// Setup
+		table, player := setup(0)
+
+		// Act
+		_, err := table.TopUp(player.ID, 900)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
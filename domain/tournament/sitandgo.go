@@ -0,0 +1,36 @@
+package tournament
+
+import (
+	"errors"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// NewSitAndGo creates a single-table tournament that starts as soon as its seats are taken, registration
+// closing with it. Config.TableSize is the number of seats, Config.MaxEntrants follows it.
+// The prize structure defaults to SitAndGoPayouts when Config.Payouts is empty.
+func NewSitAndGo(lobby *domain.Lobby, config Config) (*Tournament, error) {
+	if config.TableSize < 2 {
+		return nil, errors.New("tables need at least 2 seats")
+	}
+
+	config.MaxEntrants = config.TableSize
+	if len(config.Payouts) == 0 {
+		config.Payouts = SitAndGoPayouts(config.TableSize)
+	}
+
+	return newTournament(lobby, config, true)
+}
+
+// SitAndGoPayouts returns the usual prize structure of a sit-and-go with the given number of seats:
+// winner takes all heads-up, the top two are paid up to five seats, the top three beyond
+func SitAndGoPayouts(seats int) []int {
+	switch {
+	case seats <= 2:
+		return []int{100}
+	case seats <= 5:
+		return []int{65, 35}
+	default:
+		return []int{50, 30, 20}
+	}
+}
@@ -0,0 +1,111 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sitAndGoConfig(seats int) Config {
+	config := testConfig()
+	config.TableSize = seats
+	config.Payouts = nil
+	return config
+}
+
+func TestSitAndGo(t *testing.T) {
+	t.Run("It starts once every seat is taken", func(t *testing.T) {
+		// Setup
+		sng, err := NewSitAndGo(&domain.Lobby{}, sitAndGoConfig(3))
+		require.NoError(t, err)
+		players := testPlayers(3)
+		require.NoError(t, sng.Register(players[0]))
+		require.NoError(t, sng.Register(players[1]))
+		assert.Equal(t, StatusRegistering, sng.Status())
+
+		// Act
+		err = sng.Register(players[2])
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, StatusRunning, sng.Status())
+		require.Len(t, sng.tables, 1)
+		assert.Len(t, sng.tables[0].Players, 3)
+
+		created := findEvents[events.TournamentCreated](sng.Events)
+		require.Len(t, created, 1)
+		assert.True(t, created[0].SitAndGo)
+		assert.Equal(t, 3, created[0].MaxEntrants)
+	})
+
+	t.Run("Registration is locked once it started", func(t *testing.T) {
+		// Setup
+		sng, err := NewSitAndGo(&domain.Lobby{}, sitAndGoConfig(2))
+		require.NoError(t, err)
+		players := testPlayers(3)
+		require.NoError(t, sng.Register(players[0]))
+		require.NoError(t, sng.Register(players[1]))
+
+		// Act
+		late := sng.Register(players[2])
+		leaving := sng.Unregister(players[0].ID)
+
+		// Assert
+		assert.Error(t, late)
+		assert.Error(t, leaving)
+		assert.Equal(t, 1000, players[2].Balance)
+	})
+
+	t.Run("The prize structure depends on the number of seats", func(t *testing.T) {
+		assert.Equal(t, []int{100}, SitAndGoPayouts(2))
+		assert.Equal(t, []int{65, 35}, SitAndGoPayouts(4))
+		assert.Equal(t, []int{50, 30, 20}, SitAndGoPayouts(9))
+	})
+
+	t.Run("A configured prize structure is kept", func(t *testing.T) {
+		// Setup
+		config := sitAndGoConfig(6)
+		config.Payouts = []int{100}
+
+		// Act
+		sng, err := NewSitAndGo(&domain.Lobby{}, config)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int{100}, sng.Config.Payouts)
+	})
+
+	t.Run("It pays out once a player has all the chips", func(t *testing.T) {
+		// Setup
+		sng, err := NewSitAndGo(&domain.Lobby{}, sitAndGoConfig(3))
+		require.NoError(t, err)
+		for _, player := range testPlayers(3) {
+			require.NoError(t, sng.Register(player))
+		}
+
+		// Act
+		bust(sng, "player-1")
+		bust(sng, "player-2")
+
+		// Assert
+		assert.Equal(t, StatusFinished, sng.Status())
+		finished := findEvents[events.TournamentFinished](sng.Events)
+		require.Len(t, finished, 1)
+		assert.Equal(t, 300, finished[0].PrizePool)
+		assert.Equal(t, []events.TournamentPayout{
+			{Place: 1, PlayerID: "player-3", Amount: 195},
+			{Place: 2, PlayerID: "player-2", Amount: 105},
+		}, finished[0].Payouts)
+	})
+
+	t.Run("A sit-and-go needs at least two seats", func(t *testing.T) {
+		// Act
+		_, err := NewSitAndGo(&domain.Lobby{}, sitAndGoConfig(1))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,745 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// defaultStartCountdown is how long tournament tables wait for their players before the first hand,
+// when the table rules don't say
+const defaultStartCountdown = 10 * time.Second
+
+// Status is where a tournament stands
+type Status string
+
+const (
+	StatusRegistering Status = "registering"
+	StatusRunning     Status = "running"
+	StatusFinished    Status = "finished"
+)
+
+// Level is a step of the ante schedule
+type Level struct {
+	Ante     int
+	Duration time.Duration // How long the level lasts, the last level lasts until the end
+}
+
+// Config describes a tournament
+type Config struct {
+	Name          string
+	BuyIn         int // Taken from each entrant's balance, the buy-ins make up the prize pool
+	StartingStack int // Tournament chips each entrant starts with
+	TableSize     int // Players seated at each table
+	MaxEntrants   int // Zero for no cap
+	Levels        []Level
+	Payouts       []int // Percentage of the prize pool paid to each place, first place first, adding up to 100
+
+	// TableRules are the rules of the tournament's tables, their ante and size come from the tournament
+	TableRules domain.TableRules
+}
+
+// validate checks that a tournament can run with the config
+func (c Config) validate() error {
+	if c.Name == "" {
+		return errors.New("tournament name is required")
+	}
+	if c.BuyIn < 0 {
+		return errors.New("buy-in can't be negative")
+	}
+	if c.StartingStack <= 0 {
+		return errors.New("starting stack must be positive")
+	}
+	if c.TableSize < 2 {
+		return errors.New("tables need at least 2 seats")
+	}
+	if len(c.Levels) == 0 {
+		return errors.New("at least one level is required")
+	}
+	for _, level := range c.Levels {
+		if level.Ante <= 0 {
+			return errors.New("level antes must be positive")
+		}
+	}
+	if len(c.Payouts) == 0 {
+		return errors.New("at least one paid place is required")
+	}
+	total := 0
+	for _, share := range c.Payouts {
+		if share <= 0 {
+			return errors.New("payout shares must be positive")
+		}
+		total += share
+	}
+	if total != 100 {
+		return errors.New("payout shares must add up to 100")
+	}
+	return nil
+}
+
+// Tournament seats its entrants at several tables of the lobby, raises the ante on a schedule,
+// moves players to keep the tables balanced as they bust, and pays the prize pool out once one
+// player has all the chips.
+//
+// Tournament chips are table stacks: they are not taken from or returned to balances, only the
+// buy-ins and the payouts are.
+type Tournament struct {
+	ID     string
+	Config Config
+
+	// Clock stamps the tournament's events and schedules the level raises, the lobby's clock when nil
+	Clock domain.Clock
+
+	lobby    *domain.Lobby
+	sitAndGo bool // Starts as soon as the last seat is taken
+
+	mu         sync.Mutex // guards everything below, tournament events are emitted outside of it
+	status     Status
+	entrants   []*domain.Player         // In registration order
+	tables     []*domain.Table          // Tables still in play, in creation order
+	seats      map[string]*domain.Table // Table of each player still in, by player ID
+	busted     []string                 // Players out, first one out first
+	prizePool  int
+	level      int
+	levelTimer domain.Timer
+	walletOps  int // Numbers the tournament's wallet calls, for their idempotency keys
+
+	moves sync.WaitGroup // Changes handed to the game loop of other tables, see onTable
+
+	// Events
+	eventsMu      sync.Mutex
+	Events        []events.Event
+	eventHandlers []events.EventHandler
+	eventIDs      *events.IDGenerator
+}
+
+// New creates a tournament open for registration, its tables will be created in the lobby
+func New(lobby *domain.Lobby, config Config) (*Tournament, error) {
+	return newTournament(lobby, config, false)
+}
+
+func newTournament(lobby *domain.Lobby, config Config, sitAndGo bool) (*Tournament, error) {
+	if lobby == nil {
+		return nil, errors.New("lobby is required")
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	t := &Tournament{
+		ID:       uuid.NewString(),
+		Config:   config,
+		lobby:    lobby,
+		sitAndGo: sitAndGo,
+		status:   StatusRegistering,
+		seats:    make(map[string]*domain.Table),
+		eventIDs: events.NewIDGenerator(),
+	}
+
+	t.emitEvent(events.TournamentCreated{
+		TournamentID:   t.ID,
+		TournamentName: config.Name,
+		BuyIn:          config.BuyIn,
+		StartingStack:  config.StartingStack,
+		MaxEntrants:    config.MaxEntrants,
+		SitAndGo:       sitAndGo,
+		At:             t.clock().Now(),
+	})
+
+	return t, nil
+}
+
+// Status returns where the tournament stands
+func (t *Tournament) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status
+}
+
+// Level returns the current level, as an index in the schedule
+func (t *Tournament) Level() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.level
+}
+
+// TableIDs returns the tables still in play
+func (t *Tournament) TableIDs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := make([]string, 0, len(t.tables))
+	for _, table := range t.tables {
+		ids = append(ids, table.ID)
+	}
+	return ids
+}
+
+// PlayersLeft returns how many players are still in
+func (t *Tournament) PlayersLeft() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.status == StatusRegistering {
+		return len(t.entrants)
+	}
+	return len(t.seats)
+}
+
+// Register enters a player, paying the buy-in from their balance
+func (t *Tournament) Register(player *domain.Player) error {
+	if player == nil {
+		return errors.New("player is nil")
+	}
+
+	t.mu.Lock()
+	if t.status != StatusRegistering {
+		t.mu.Unlock()
+		return errors.New("registration is closed")
+	}
+	if t.entrantIndex(player.ID) >= 0 {
+		t.mu.Unlock()
+		return errors.New("player is already registered")
+	}
+	if t.Config.MaxEntrants > 0 && len(t.entrants) >= t.Config.MaxEntrants {
+		t.mu.Unlock()
+		return errors.New("tournament is full")
+	}
+	if t.Config.BuyIn > 0 {
+		if err := player.Withdraw(t.lobby.Wallet, t.Config.BuyIn, t.walletKey("buy-in", player.ID), "tournament buy-in"); err != nil {
+			t.mu.Unlock()
+			return err
+		}
+	}
+	t.entrants = append(t.entrants, player)
+	entrants := len(t.entrants)
+	full := t.sitAndGo && entrants == t.Config.MaxEntrants
+	t.mu.Unlock()
+
+	t.emitEvent(events.TournamentPlayerRegistered{
+		TournamentID: t.ID,
+		PlayerID:     player.ID,
+		Entrants:     entrants,
+		At:           t.clock().Now(),
+	})
+
+	if full {
+		return t.Start()
+	}
+	return nil
+}
+
+// Unregister takes a player out before the start, refunding their buy-in
+func (t *Tournament) Unregister(playerID string) error {
+	t.mu.Lock()
+	if t.status != StatusRegistering {
+		t.mu.Unlock()
+		return errors.New("tournament has already started")
+	}
+	i := t.entrantIndex(playerID)
+	if i < 0 {
+		t.mu.Unlock()
+		return errors.New("player not found")
+	}
+
+	if t.Config.BuyIn > 0 {
+		if err := t.entrants[i].Deposit(t.lobby.Wallet, t.Config.BuyIn, t.walletKey("refund", playerID), "tournament refund"); err != nil {
+			t.mu.Unlock()
+			return err
+		}
+	}
+	t.entrants = append(t.entrants[:i], t.entrants[i+1:]...)
+	entrants := len(t.entrants)
+	t.mu.Unlock()
+
+	t.emitEvent(events.TournamentPlayerUnregistered{
+		TournamentID: t.ID,
+		PlayerID:     playerID,
+		Entrants:     entrants,
+		At:           t.clock().Now(),
+	})
+
+	return nil
+}
+
+// Start closes registration and seats the entrants, spread evenly over as few tables as possible.
+// The tables deal their first hand once their start countdown runs out.
+func (t *Tournament) Start() error {
+	t.mu.Lock()
+	if t.status != StatusRegistering {
+		t.mu.Unlock()
+		return errors.New("tournament has already started")
+	}
+	if len(t.entrants) < 2 {
+		t.mu.Unlock()
+		return errors.New("need at least 2 entrants to start")
+	}
+
+	rules := t.Config.TableRules
+	rules.AnteValue = t.Config.Levels[0].Ante
+	rules.MaxPlayers = t.Config.TableSize
+	rules.BlindOff = true // Players who leave keep their stack in play until it is blinded off
+	rules.TournamentChips = true
+	if rules.StartCountdown <= 0 {
+		rules.StartCountdown = defaultStartCountdown
+	}
+
+	tableCount := t.tablesNeeded(len(t.entrants))
+	tableIDs := make([]string, 0, tableCount)
+	for i := range tableCount {
+		table, err := t.lobby.NewTable(fmt.Sprintf("%s #%d", t.Config.Name, i+1), rules)
+		if err != nil {
+			t.mu.Unlock()
+			return err
+		}
+		table.RegisterEventHandler(t.handleTableEvent)
+		t.tables = append(t.tables, table)
+		tableIDs = append(tableIDs, table.ID)
+	}
+
+	for i, player := range t.entrants {
+		table := t.tables[i%tableCount]
+		if err := table.Do("tournament seating", func() error {
+			if err := table.SeatPlayer(player); err != nil {
+				return err
+			}
+			table.IncreasePlayerBuyIn(player.ID, t.Config.StartingStack)
+			return nil
+		}); err != nil {
+			t.mu.Unlock()
+			return fmt.Errorf("could not seat %s: %w", player.ID, err)
+		}
+		t.seats[player.ID] = table
+	}
+
+	t.status = StatusRunning
+	t.prizePool = len(t.entrants) * t.Config.BuyIn
+	t.scheduleNextLevel()
+	started := events.TournamentStarted{
+		TournamentID: t.ID,
+		TableIDs:     tableIDs,
+		Entrants:     len(t.entrants),
+		PrizePool:    t.prizePool,
+		At:           t.clock().Now(),
+	}
+	t.mu.Unlock()
+
+	t.emitEvent(started)
+
+	return nil
+}
+
+// AdvanceLevel moves to the next level of the schedule, which the tables pick up from their next hand.
+// Levels advance on their own as their duration runs out.
+func (t *Tournament) AdvanceLevel() error {
+	t.mu.Lock()
+	if t.status != StatusRunning {
+		t.mu.Unlock()
+		return errors.New("tournament is not running")
+	}
+	if t.level >= len(t.Config.Levels)-1 {
+		t.mu.Unlock()
+		return errors.New("already at the last level")
+	}
+
+	if t.levelTimer != nil {
+		t.levelTimer.Stop()
+		t.levelTimer = nil
+	}
+
+	t.level++
+	ante := t.Config.Levels[t.level].Ante
+	tables := append([]*domain.Table{}, t.tables...)
+	t.scheduleNextLevel()
+	raised := events.TournamentLevelRaised{
+		TournamentID: t.ID,
+		Level:        t.level,
+		Ante:         ante,
+		At:           t.clock().Now(),
+	}
+	t.mu.Unlock()
+
+	// Outside of the tournament's lock, a table's loop may be waiting for it at the end of a hand
+	for _, table := range tables {
+		table.Do("tournament level raise", func() error {
+			// Hands copy the table rules when they start, as with ante scaling
+			table.Rules.AnteValue = ante
+			return nil
+		})
+	}
+
+	t.emitEvent(raised)
+
+	return nil
+}
+
+// scheduleNextLevel starts the timer of the current level, the last one never runs out
+func (t *Tournament) scheduleNextLevel() {
+	level := t.Config.Levels[t.level]
+	if t.level >= len(t.Config.Levels)-1 || level.Duration <= 0 {
+		return
+	}
+
+	t.levelTimer = t.clock().AfterFunc(level.Duration, func() {
+		if err := t.AdvanceLevel(); err != nil {
+			fmt.Println("Could not advance level of tournament", t.ID, ":", err)
+		}
+	})
+}
+
+// handleTableEvent follows the tournament's tables, players bust and move between hands
+func (t *Tournament) handleTableEvent(event events.Event) {
+	switch ev := event.(type) {
+	case events.HandEnded:
+		t.afterHand(ev.TableID)
+	case events.PlayerLeftTable:
+		t.playerLeft(ev.TableID, ev.UserID)
+	}
+}
+
+// afterHand busts the players of a table left without chips, then balances the tables or pays out
+// the prize pool. The table's hand is over, so its players may be moved to another table.
+// It runs in the table's game loop, the other tables are changed through onTable. The table is changed
+// once the tournament's lock is released, as players leaving it come back to the tournament.
+func (t *Tournament) afterHand(tableID string) {
+	t.mu.Lock()
+	if t.status != StatusRunning {
+		t.mu.Unlock()
+		return
+	}
+	table := t.table(tableID)
+	if table == nil {
+		t.mu.Unlock()
+		return
+	}
+
+	now := t.clock().Now()
+	var emitted []events.Event
+	var changes []func()
+
+	// Players busting on the same hand are placed in seat order
+	for _, playerID := range t.playersAt(table) {
+		if table.GetPlayerBuyIn(playerID) > 0 {
+			continue
+		}
+
+		place := len(t.seats)
+		delete(t.seats, playerID)
+		t.busted = append(t.busted, playerID)
+		changes = append(changes, func() { table.PlayerLeaves(playerID) })
+
+		emitted = append(emitted, events.TournamentPlayerBusted{
+			TournamentID: t.ID,
+			TableID:      table.ID,
+			PlayerID:     playerID,
+			Place:        place,
+			At:           now,
+		})
+	}
+
+	if len(t.seats) <= 1 {
+		finished, closeLast := t.finish(table, now)
+		emitted = append(emitted, finished)
+		changes = append(changes, closeLast)
+	} else {
+		moved, moves := t.balance(table, now)
+		emitted = append(emitted, moved...)
+		changes = append(changes, moves...)
+	}
+	t.mu.Unlock()
+
+	for _, change := range changes {
+		change()
+	}
+	for _, event := range emitted {
+		t.emitEvent(event)
+	}
+}
+
+// playerLeft busts a player who left their table for good. Players who leave during play are
+// blinded off and bust after a hand, this is for those leaving before their table's first hand.
+// Players the tournament busts or moves are no longer seated at the table when they leave it.
+func (t *Tournament) playerLeft(tableID string, playerID string) {
+	t.mu.Lock()
+	table, seated := t.seats[playerID]
+	if t.status != StatusRunning || !seated || table.ID != tableID {
+		t.mu.Unlock()
+		return
+	}
+
+	now := t.clock().Now()
+	place := len(t.seats)
+	delete(t.seats, playerID)
+	t.busted = append(t.busted, playerID)
+	emitted := []events.Event{events.TournamentPlayerBusted{
+		TournamentID: t.ID,
+		TableID:      tableID,
+		PlayerID:     playerID,
+		Place:        place,
+		At:           now,
+	}}
+	var closeLast func()
+	if len(t.seats) <= 1 {
+		var finished events.Event
+		finished, closeLast = t.finish(table, now)
+		emitted = append(emitted, finished)
+	}
+	t.mu.Unlock()
+
+	if closeLast != nil {
+		closeLast()
+	}
+	for _, event := range emitted {
+		t.emitEvent(event)
+	}
+}
+
+// balance breaks the table when the players left fit at the other tables, or else moves a player
+// to the shortest table when this one has two players more. It returns the moves' events, and the
+// changes to make to the table once the tournament's lock is released.
+func (t *Tournament) balance(table *domain.Table, now time.Time) ([]events.Event, []func()) {
+	var moved []events.Event
+	var changes []func()
+
+	players := t.playersAt(table)
+	if len(t.tables) > t.tablesNeeded(len(t.seats)) {
+		for _, playerID := range players {
+			event, change := t.move(playerID, table, t.shortestTable(table), now)
+			moved = append(moved, event)
+			changes = append(changes, change)
+		}
+		t.removeTable(table)
+		changes = append(changes, func() { t.lobby.CloseTable(table.ID, "tournament table broken") })
+		return moved, changes
+	}
+
+	shortest := t.shortestTable(table)
+	if shortest != nil && len(players)-t.seated(shortest) >= 2 {
+		// The last seat goes, the button keeps moving around the others
+		event, change := t.move(players[len(players)-1], table, shortest, now)
+		moved = append(moved, event)
+		changes = append(changes, change)
+	}
+	return moved, changes
+}
+
+// move gives the player a seat at another table, and returns the change taking them and their stack
+// over from the table they leave. A player who had left is blinded off at their new table.
+func (t *Tournament) move(playerID string, from *domain.Table, to *domain.Table, now time.Time) (events.Event, func()) {
+	player := t.entrants[t.entrantIndex(playerID)]
+	chips := from.GetPlayerBuyIn(playerID)
+	t.seats[playerID] = to
+
+	change := func() {
+		stack, left, err := from.UnseatPlayer(playerID)
+		if err != nil {
+			fmt.Println("Tournament", t.ID, "could not move", playerID, "from table", from.ID, ":", err)
+			return
+		}
+		t.onTable(to, "tournament move", func() error {
+			if err := to.SeatPlayer(player); err != nil {
+				return fmt.Errorf("could not move %s: %w", playerID, err)
+			}
+			to.IncreasePlayerBuyIn(playerID, stack)
+			if left {
+				return to.PlayerLeaves(playerID)
+			}
+			return nil
+		})
+	}
+
+	return events.TournamentPlayerMoved{
+		TournamentID: t.ID,
+		PlayerID:     playerID,
+		FromTableID:  from.ID,
+		ToTableID:    to.ID,
+		Chips:        chips,
+		At:           now,
+	}, change
+}
+
+// finish pays the prize pool out and closes the last tables. It returns the change closing the table
+// being handled, to make once the tournament's lock is released.
+// What the paid places don't take, rounding included, goes to the winner.
+func (t *Tournament) finish(last *domain.Table, now time.Time) (events.Event, func()) {
+	for playerID := range t.seats {
+		t.busted = append(t.busted, playerID)
+	}
+	t.seats = make(map[string]*domain.Table)
+
+	payouts := []events.TournamentPayout{}
+	paid := 0
+	for i, share := range t.Config.Payouts {
+		if i >= len(t.busted) {
+			break
+		}
+		playerID := t.busted[len(t.busted)-1-i]
+		amount := t.prizePool * share / 100
+		payouts = append(payouts, events.TournamentPayout{Place: i + 1, PlayerID: playerID, Amount: amount})
+		paid += amount
+	}
+	if len(payouts) > 0 {
+		payouts[0].Amount += t.prizePool - paid
+	}
+
+	for _, payout := range payouts {
+		if payout.Amount <= 0 {
+			continue
+		}
+		key := fmt.Sprintf("tournament/%s/prize/%d", t.ID, payout.Place)
+		if err := t.entrants[t.entrantIndex(payout.PlayerID)].Deposit(t.lobby.Wallet, payout.Amount, key, "tournament prize"); err != nil {
+			fmt.Println("Could not pay", payout.PlayerID, "their prize in tournament", t.ID, ":", err)
+		}
+	}
+
+	if t.levelTimer != nil {
+		t.levelTimer.Stop()
+		t.levelTimer = nil
+	}
+	t.status = StatusFinished
+
+	for _, table := range t.tables {
+		if table == last {
+			continue
+		}
+		t.onTable(table, "tournament finish", func() error {
+			return t.lobby.CloseTable(table.ID, "tournament finished")
+		})
+	}
+	t.tables = nil
+
+	return events.TournamentFinished{
+		TournamentID: t.ID,
+		PrizePool:    t.prizePool,
+		Payouts:      payouts,
+		At:           now,
+	}, func() {
+		t.lobby.CloseTable(last.ID, "tournament finished")
+	}
+}
+
+// onTable runs f in the game loop of another table than the one being handled, without waiting for it:
+// that table's loop may itself be waiting for the tournament
+func (t *Tournament) onTable(table *domain.Table, where string, f func() error) {
+	t.moves.Add(1)
+	go func() {
+		defer t.moves.Done()
+		if err := table.Do(where, f); err != nil {
+			fmt.Println("Tournament", t.ID, "could not change table", table.ID, ":", err)
+		}
+	}()
+}
+
+// tablesNeeded returns how many tables seat the players
+func (t *Tournament) tablesNeeded(players int) int {
+	return (players + t.Config.TableSize - 1) / t.Config.TableSize
+}
+
+// shortestTable returns the table with the fewest players other than the given one, nil if there is none
+func (t *Tournament) shortestTable(except *domain.Table) *domain.Table {
+	var shortest *domain.Table
+	for _, table := range t.tables {
+		if table == except {
+			continue
+		}
+		if shortest == nil || t.seated(table) < t.seated(shortest) {
+			shortest = table
+		}
+	}
+	return shortest
+}
+
+// seated counts the players still in at a table, those on their way to it included. The table's own
+// count lags behind while moves are pending, and can only be read in its game loop.
+func (t *Tournament) seated(table *domain.Table) int {
+	count := 0
+	for _, seat := range t.seats {
+		if seat == table {
+			count++
+		}
+	}
+	return count
+}
+
+// playersAt returns the players still in at the table whose hand just ended, in seat order
+func (t *Tournament) playersAt(table *domain.Table) []string {
+	playerIDs := []string{}
+	for _, player := range table.GetPlayers() {
+		if t.seats[player.ID] == table {
+			playerIDs = append(playerIDs, player.ID)
+		}
+	}
+	return playerIDs
+}
+
+func (t *Tournament) table(tableID string) *domain.Table {
+	for _, table := range t.tables {
+		if table.ID == tableID {
+			return table
+		}
+	}
+	return nil
+}
+
+func (t *Tournament) removeTable(removed *domain.Table) {
+	for i, table := range t.tables {
+		if table == removed {
+			t.tables = append(t.tables[:i], t.tables[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *Tournament) entrantIndex(playerID string) int {
+	for i, player := range t.entrants {
+		if player.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// clock returns the tournament's clock, else the lobby's, else the system clock
+func (t *Tournament) clock() domain.Clock {
+	if t.Clock != nil {
+		return t.Clock
+	}
+	if t.lobby != nil && t.lobby.Clock != nil {
+		return t.lobby.Clock
+	}
+	return domain.SystemClock{}
+}
+
+// AddEventHandler adds an event handler to the tournament
+func (t *Tournament) AddEventHandler(handler events.EventHandler) {
+	t.eventsMu.Lock()
+	defer t.eventsMu.Unlock()
+
+	t.eventHandlers = append(t.eventHandlers, handler)
+}
+
+// emitEvent notifies all registered handlers of a new event
+func (t *Tournament) emitEvent(event events.Event) {
+	t.eventsMu.Lock()
+	event = t.eventIDs.Stamp(event)
+	t.Events = append(t.Events, event)
+	handlers := t.eventHandlers
+	t.eventsMu.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// walletKey returns the idempotency key of the tournament's next wallet call
+func (t *Tournament) walletKey(operation string, playerID string) string {
+	t.walletOps++
+	return fmt.Sprintf("tournament/%s/%s/%s/%d", t.ID, operation, playerID, t.walletOps)
+}
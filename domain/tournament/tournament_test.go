@@ -0,0 +1,475 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		Name:          "Sunday Special",
+		BuyIn:         100,
+		StartingStack: 1500,
+		TableSize:     2,
+		Levels: []Level{
+			{Ante: 10, Duration: time.Hour},
+			{Ante: 20, Duration: time.Hour},
+			{Ante: 40},
+		},
+		Payouts: []int{70, 30},
+	}
+}
+
+func testPlayers(n int) []*domain.Player {
+	players := make([]*domain.Player, n)
+	for i := range players {
+		players[i] = &domain.Player{ID: fmt.Sprintf("player-%d", i+1), Balance: 1000}
+	}
+	return players
+}
+
+// startedTournament registers n players and starts the tournament
+func startedTournament(t *testing.T, n int) (*Tournament, []*domain.Player, *domain.Lobby) {
+	lobby := &domain.Lobby{}
+	tournament, err := New(lobby, testConfig())
+	require.NoError(t, err)
+
+	players := testPlayers(n)
+	for _, player := range players {
+		require.NoError(t, tournament.Register(player))
+	}
+	require.NoError(t, tournament.Start())
+	return tournament, players, lobby
+}
+
+// bust empties a player's stack and ends the hand at their table
+func bust(tournament *Tournament, playerID string) {
+	table := tournament.seats[playerID]
+	table.BuyIns[playerID] = 0
+	endHand(tournament, table.ID)
+}
+
+// endHand ends the hand at a table, and waits for the players moved to other tables to be seated
+func endHand(tournament *Tournament, tableID string) {
+	tournament.handleTableEvent(events.HandEnded{TableID: tableID})
+	tournament.moves.Wait()
+}
+
+func findEvents[E events.Event](all []events.Event) []E {
+	found := []E{}
+	for _, event := range all {
+		if e, ok := event.(E); ok {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func TestRegistration(t *testing.T) {
+	t.Run("Registering pays the buy-in", func(t *testing.T) {
+		// Setup
+		tournament, err := New(&domain.Lobby{}, testConfig())
+		require.NoError(t, err)
+		player := testPlayers(1)[0]
+
+		// Act
+		err = tournament.Register(player)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 900, player.Balance)
+		assert.Equal(t, 1, tournament.PlayersLeft())
+		assert.Len(t, findEvents[events.TournamentPlayerRegistered](tournament.Events), 1)
+	})
+
+	t.Run("A player can't register twice, or without the buy-in", func(t *testing.T) {
+		// Setup
+		tournament, err := New(&domain.Lobby{}, testConfig())
+		require.NoError(t, err)
+		player := testPlayers(1)[0]
+		require.NoError(t, tournament.Register(player))
+		broke := &domain.Player{ID: "broke", Balance: 50}
+
+		// Act
+		twice := tournament.Register(player)
+		poor := tournament.Register(broke)
+
+		// Assert
+		assert.Error(t, twice)
+		assert.Error(t, poor)
+		assert.Equal(t, 50, broke.Balance)
+	})
+
+	t.Run("Unregistering refunds the buy-in", func(t *testing.T) {
+		// Setup
+		tournament, err := New(&domain.Lobby{}, testConfig())
+		require.NoError(t, err)
+		player := testPlayers(1)[0]
+		require.NoError(t, tournament.Register(player))
+
+		// Act
+		err = tournament.Unregister(player.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1000, player.Balance)
+		assert.Equal(t, 0, tournament.PlayersLeft())
+	})
+
+	t.Run("Registration closes when the tournament starts", func(t *testing.T) {
+		// Setup
+		tournament, players, _ := startedTournament(t, 2)
+
+		// Act
+		late := tournament.Register(&domain.Player{ID: "late", Balance: 1000})
+		leaving := tournament.Unregister(players[0].ID)
+
+		// Assert
+		assert.Error(t, late)
+		assert.Error(t, leaving)
+	})
+
+	t.Run("Invalid configs are rejected", func(t *testing.T) {
+		// Setup
+		config := testConfig()
+		config.Payouts = []int{60, 30}
+
+		// Act
+		_, err := New(&domain.Lobby{}, config)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestStart(t *testing.T) {
+	t.Run("Entrants are spread evenly with the starting stack", func(t *testing.T) {
+		// Setup
+		tournament, err := New(&domain.Lobby{}, testConfig())
+		require.NoError(t, err)
+		for _, player := range testPlayers(5) {
+			require.NoError(t, tournament.Register(player))
+		}
+
+		// Act
+		err = tournament.Start()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, StatusRunning, tournament.Status())
+		require.Len(t, tournament.tables, 3)
+		for _, table := range tournament.tables {
+			assert.LessOrEqual(t, len(table.Players), 2)
+			assert.Equal(t, 10, table.Rules.AnteValue)
+			for _, player := range table.Players {
+				assert.Equal(t, 1500, table.GetPlayerBuyIn(player.ID))
+			}
+		}
+		started := findEvents[events.TournamentStarted](tournament.Events)
+		require.Len(t, started, 1)
+		assert.Equal(t, 500, started[0].PrizePool)
+	})
+
+	t.Run("A tournament needs two entrants", func(t *testing.T) {
+		// Setup
+		tournament, err := New(&domain.Lobby{}, testConfig())
+		require.NoError(t, err)
+		require.NoError(t, tournament.Register(testPlayers(1)[0]))
+
+		// Act
+		err = tournament.Start()
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, StatusRegistering, tournament.Status())
+	})
+}
+
+func TestLevels(t *testing.T) {
+	t.Run("Advancing a level raises the ante of every table", func(t *testing.T) {
+		// Setup
+		tournament, _, _ := startedTournament(t, 4)
+
+		// Act
+		err := tournament.AdvanceLevel()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, tournament.Level())
+		for _, table := range tournament.tables {
+			assert.Equal(t, 20, table.Rules.AnteValue)
+		}
+		raised := findEvents[events.TournamentLevelRaised](tournament.Events)
+		require.Len(t, raised, 1)
+		assert.Equal(t, 20, raised[0].Ante)
+	})
+
+	t.Run("The last level holds until the end", func(t *testing.T) {
+		// Setup
+		tournament, _, _ := startedTournament(t, 2)
+		require.NoError(t, tournament.AdvanceLevel())
+		require.NoError(t, tournament.AdvanceLevel())
+
+		// Act
+		err := tournament.AdvanceLevel()
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 2, tournament.Level())
+	})
+}
+
+func TestBustingAndBalancing(t *testing.T) {
+	t.Run("A busted player gets their place", func(t *testing.T) {
+		// Setup
+		tournament, _, _ := startedTournament(t, 4)
+
+		// Act
+		bust(tournament, "player-1")
+
+		// Assert
+		busted := findEvents[events.TournamentPlayerBusted](tournament.Events)
+		require.Len(t, busted, 1)
+		assert.Equal(t, "player-1", busted[0].PlayerID)
+		assert.Equal(t, 4, busted[0].Place)
+		assert.Equal(t, 3, tournament.PlayersLeft())
+		assert.Len(t, tournament.TableIDs(), 2, "three players still need two tables")
+	})
+
+	t.Run("A table is broken once the players fit at fewer tables", func(t *testing.T) {
+		// Setup
+		tournament, _, lobby := startedTournament(t, 4)
+		bust(tournament, "player-1")
+		broken := tournament.seats["player-2"]
+		chips := broken.GetPlayerBuyIn("player-4")
+
+		// Act
+		bust(tournament, "player-2")
+
+		// Assert
+		require.Len(t, tournament.tables, 1)
+		remaining := tournament.tables[0]
+		assert.Len(t, remaining.Players, 2)
+		assert.Equal(t, chips, remaining.GetPlayerBuyIn("player-4"))
+		assert.Equal(t, domain.TableStatusEnded, broken.Status)
+		_, err := lobby.GetTable(broken.ID)
+		assert.Error(t, err, "broken tables leave the lobby")
+
+		moved := findEvents[events.TournamentPlayerMoved](tournament.Events)
+		require.Len(t, moved, 1)
+		assert.Equal(t, "player-4", moved[0].PlayerID)
+		assert.Equal(t, remaining.ID, moved[0].ToTableID)
+	})
+
+	t.Run("A player moves to the shortest table when tables get uneven", func(t *testing.T) {
+		// Setup
+		config := testConfig()
+		config.TableSize = 3
+		lobby := &domain.Lobby{}
+		tournament, err := New(lobby, config)
+		require.NoError(t, err)
+		for _, player := range testPlayers(6) {
+			require.NoError(t, tournament.Register(player))
+		}
+		require.NoError(t, tournament.Start())
+		short := tournament.seats["player-1"]
+		long := tournament.seats["player-2"]
+
+		// Act
+		short.BuyIns["player-1"] = 0
+		short.BuyIns["player-3"] = 0
+		endHand(tournament, long.ID)
+		endHand(tournament, short.ID)
+		endHand(tournament, long.ID)
+
+		// Assert
+		assert.Len(t, short.Players, 2)
+		assert.Len(t, long.Players, 2)
+		assert.Len(t, findEvents[events.TournamentPlayerMoved](tournament.Events), 1)
+	})
+
+	t.Run("Players of a broken table are spread over the others without overfilling them", func(t *testing.T) {
+		// Setup
+		config := testConfig()
+		config.TableSize = 3
+		lobby := &domain.Lobby{}
+		tournament, err := New(lobby, config)
+		require.NoError(t, err)
+		for _, player := range testPlayers(7) {
+			require.NoError(t, tournament.Register(player))
+		}
+		require.NoError(t, tournament.Start())
+		broken := tournament.seats["player-1"]
+
+		// Act
+		bust(tournament, "player-1")
+
+		// Assert
+		require.Len(t, tournament.tables, 2)
+		assert.Equal(t, domain.TableStatusEnded, broken.Status)
+		for _, table := range tournament.tables {
+			assert.Len(t, table.Players, 3)
+		}
+		assert.Len(t, findEvents[events.TournamentPlayerMoved](tournament.Events), 2)
+	})
+}
+
+func TestLeaving(t *testing.T) {
+	t.Run("A player leaving before their table's first hand is out, and the tournament still finishes", func(t *testing.T) {
+		// Setup
+		tournament, _, _ := startedTournament(t, 3)
+		table := tournament.seats["player-3"]
+
+		// Act
+		require.NoError(t, table.PlayerLeaves("player-3"))
+		bust(tournament, "player-1")
+
+		// Assert
+		busted := findEvents[events.TournamentPlayerBusted](tournament.Events)
+		require.Len(t, busted, 2)
+		assert.Equal(t, "player-3", busted[0].PlayerID)
+		assert.Equal(t, 3, busted[0].Place)
+		assert.Equal(t, "player-1", busted[1].PlayerID)
+		assert.Equal(t, 2, busted[1].Place)
+		assert.Equal(t, StatusFinished, tournament.Status())
+		assert.Equal(t, 0, tournament.PlayersLeft())
+	})
+}
+
+func TestBlindOff(t *testing.T) {
+	t.Run("A player leaving mid-tournament is blinded off, then out with their place", func(t *testing.T) {
+		// Setup
+		config := testConfig()
+		config.TableSize = 3
+		tournament, err := New(&domain.Lobby{}, config)
+		require.NoError(t, err)
+		for _, player := range testPlayers(3) {
+			require.NoError(t, tournament.Register(player))
+		}
+		require.NoError(t, tournament.Start())
+		table := tournament.seats["player-1"]
+		table.Status = domain.TableStatusPlaying
+
+		// Act
+		require.NoError(t, table.PlayerLeaves("player-1"))
+		stillIn := tournament.PlayersLeft()
+		bust(tournament, "player-1")
+
+		// Assert
+		blindingOff := findEvents[events.PlayerBlindingOff](table.GetEvents())
+		require.Len(t, blindingOff, 1)
+		assert.Equal(t, "player-1", blindingOff[0].PlayerID)
+		assert.Equal(t, 1500, blindingOff[0].Stack)
+		assert.Equal(t, 3, stillIn, "the stack stays in play after its owner leaves")
+
+		busted := findEvents[events.TournamentPlayerBusted](tournament.Events)
+		require.Len(t, busted, 1)
+		assert.Equal(t, "player-1", busted[0].PlayerID)
+		assert.Equal(t, 3, busted[0].Place)
+		assert.False(t, table.IsSeated("player-1"))
+		assert.Equal(t, 2, tournament.PlayersLeft())
+	})
+
+	t.Run("A player blinding off keeps blinding off at the table they are moved to", func(t *testing.T) {
+		// Setup
+		tournament, _, _ := startedTournament(t, 4)
+		bust(tournament, "player-1")
+		broken := tournament.seats["player-4"]
+		broken.Status = domain.TableStatusPlaying
+		require.NoError(t, broken.PlayerLeaves("player-4"))
+		remaining := tournament.seats["player-3"]
+		remaining.Status = domain.TableStatusPlaying
+
+		// Act
+		bust(tournament, "player-2")
+
+		// Assert
+		assert.Same(t, remaining, tournament.seats["player-4"])
+		assert.True(t, remaining.IsSeated("player-4"))
+		assert.Equal(t, 1500, remaining.GetPlayerBuyIn("player-4"))
+		assert.True(t, remaining.Left["player-4"])
+		assert.Len(t, findEvents[events.PlayerBlindingOff](remaining.GetEvents()), 1)
+	})
+}
+
+func TestPayouts(t *testing.T) {
+	t.Run("The prize pool goes to the paid places once a player has all the chips", func(t *testing.T) {
+		// Setup
+		tournament, players, lobby := startedTournament(t, 4)
+		bust(tournament, "player-1")
+		bust(tournament, "player-2")
+
+		// Act
+		bust(tournament, "player-3")
+
+		// Assert
+		assert.Equal(t, StatusFinished, tournament.Status())
+		finished := findEvents[events.TournamentFinished](tournament.Events)
+		require.Len(t, finished, 1)
+		assert.Equal(t, []events.TournamentPayout{
+			{Place: 1, PlayerID: "player-4", Amount: 280},
+			{Place: 2, PlayerID: "player-3", Amount: 120},
+		}, finished[0].Payouts)
+		assert.Equal(t, 900+280, players[3].Balance)
+		assert.Equal(t, 900+120, players[2].Balance)
+		assert.Equal(t, 900, players[0].Balance)
+		assert.Empty(t, lobby.GetTables())
+	})
+
+	t.Run("Unpaid places and rounding go to the winner", func(t *testing.T) {
+		// Setup
+		config := testConfig()
+		config.BuyIn = 33
+		config.Payouts = []int{50, 30, 20}
+		lobby := &domain.Lobby{}
+		tournament, err := New(lobby, config)
+		require.NoError(t, err)
+		players := testPlayers(2)
+		for _, player := range players {
+			require.NoError(t, tournament.Register(player))
+		}
+		require.NoError(t, tournament.Start())
+
+		// Act
+		bust(tournament, "player-1")
+
+		// Assert
+		finished := findEvents[events.TournamentFinished](tournament.Events)
+		require.Len(t, finished, 1)
+		assert.Equal(t, []events.TournamentPayout{
+			{Place: 1, PlayerID: "player-2", Amount: 47},
+			{Place: 2, PlayerID: "player-1", Amount: 19},
+		}, finished[0].Payouts)
+	})
+	t.Run("Buy-ins and prizes go through the lobby's wallet", func(t *testing.T) {
+		// Setup
+		ledger := wallet.NewLedger()
+		lobby := &domain.Lobby{Wallet: ledger}
+		tournament, err := New(lobby, testConfig())
+		require.NoError(t, err)
+		players := testPlayers(2)
+		for _, player := range players {
+			ledger.Credit(player.ID, 1000, "welcome/"+player.ID, "starting balance")
+			require.NoError(t, tournament.Register(player))
+		}
+		require.NoError(t, tournament.Start())
+		for _, table := range tournament.tables {
+			assert.True(t, table.Rules.TournamentChips, "tournament stacks are never cashed out")
+		}
+
+		// Act
+		bust(tournament, "player-1")
+
+		// Assert
+		assert.Equal(t, 900+140, ledger.Balance("player-2"))
+		assert.Equal(t, 900+60, ledger.Balance("player-1"))
+		assert.Equal(t, 900+140, players[1].Bankroll(ledger))
+	})
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// handleTurnTimerEvent runs the current bettor's turn timer, or the community selection window:
+// each prompt replaces the previous timer, and the timer is dropped once the hand is over
+func (t *Table) handleTurnTimerEvent(event events.Event) {
+	switch ev := event.(type) {
+	case events.PlayerTurnStarted:
+		t.stopTurnTimer()
+		if ev.Timeout <= 0 || t.ActiveHand == nil {
+			return
+		}
+
+		hand := t.ActiveHand
+		t.turnTimer = t.clock().AfterFunc(ev.Timeout, func() {
+			t.guard("turn timeout", func() error {
+				t.expireTurn(hand, ev)
+				return nil
+			})
+		})
+
+	case events.CommunitySelectionStarted:
+		// Everyone picks at once, the window closes for all players together
+		t.stopTurnTimer()
+		if ev.TimeLimit <= 0 || t.ActiveHand == nil {
+			return
+		}
+
+		hand := t.ActiveHand
+		t.turnTimer = t.clock().AfterFunc(ev.TimeLimit, func() {
+			t.guard("community selection timeout", func() error {
+				t.expireCommunitySelection(hand)
+				return nil
+			})
+		})
+
+	case events.CommunitySelectionEnded, events.HandEnded:
+		t.stopTurnTimer()
+	}
+}
+
+func (t *Table) stopTurnTimer() {
+	if t.turnTimer != nil {
+		t.turnTimer.Stop()
+		t.turnTimer = nil
+	}
+}
+
+// expireTurn applies the default action if the player is still on the turn the timer was set for
+func (t *Table) expireTurn(hand *Hand, turn events.PlayerTurnStarted) {
+	if t.ActiveHand != hand || string(hand.Phase) != turn.Phase || !hand.IsPlayerTheCurrentBettor(turn.PlayerID) {
+		return
+	}
+
+	if err := hand.HandleTurnTimeout(turn.PlayerID); err != nil {
+		fmt.Println("Turn timeout failed for player", turn.PlayerID, ":", err)
+	}
+}
+
+// expireCommunitySelection closes the selection window if the hand is still waiting on picks
+func (t *Table) expireCommunitySelection(hand *Hand) {
+	if t.ActiveHand != hand || !hand.IsInPhase(HandPhase_CommunitySelection) {
+		return
+	}
+
+	if err := hand.CloseCommunitySelection(); err != nil {
+		fmt.Println("Closing the community selection failed:", err)
+	}
+}
+
+// HandleTurnTimeout applies the default action of a player who let their turn run out:
+// they sit the hand out if their ante is missing, fold to continuation bets and skip their discard
+func (h *Hand) HandleTurnTimeout(playerID string) error {
+	if !h.IsPlayerTheCurrentBettor(playerID) {
+		return ErrNotYourTurn
+	}
+
+	switch h.Phase {
+	case HandPhase_Antes:
+		h.emitPlayerTimedOut(playerID, "fold")
+		h.setPlayerAsInactive(playerID)
+
+		if h.countActivePlayers() == 1 {
+			lastActivePlayer, err := h.getLastActivePlayer()
+			if err != nil {
+				return err
+			}
+			h.sweepBetsIntoPot()
+			return h.handleSinglePlayerWin(lastActivePlayer.ID)
+		}
+
+		h.advanceAntes(playerID)
+		return nil
+
+	case HandPhase_Continuation:
+		h.emitPlayerTimedOut(playerID, "fold")
+		return h.PlayerFolds(playerID)
+
+	case HandPhase_Discard:
+		h.handleDiscardTimeout(playerID)
+		return nil
+	}
+
+	return errors.New("no turn to time out in current phase")
+}
+
+func (h *Hand) emitPlayerTimedOut(playerID string, defaultAction string) {
+	h.emitEvent(events.PlayerTimedOut{
+		TableID:       h.TableID,
+		HandID:        h.ID,
+		PlayerID:      playerID,
+		Phase:         string(h.Phase),
+		DefaultAction: defaultAction,
+		At:            h.clock().Now(),
+	})
+}
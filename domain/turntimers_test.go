@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock keeps the timers it is asked for, tests fire them instead of waiting
+type fakeClock struct {
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	duration time.Duration
+	f        func()
+	stopped  bool
+}
+
+// Now is the wall clock, fake timers only run when fired
+func (c *fakeClock) Now() time.Time { return time.Now() }
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	timer := &fakeTimer{duration: d, f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (t *fakeTimer) Stop() bool {
+	wasPending := !t.stopped
+	t.stopped = true
+	return wasPending
+}
+
+// pending returns the timers that were neither stopped nor fired
+func (c *fakeClock) pending() []*fakeTimer {
+	timers := []*fakeTimer{}
+	for _, timer := range c.timers {
+		if !timer.stopped {
+			timers = append(timers, timer)
+		}
+	}
+	return timers
+}
+
+// fire runs a timer as if its duration had elapsed
+func (t *fakeTimer) fire() {
+	t.stopped = true
+	t.f()
+}
+
+// promptCurrentBettor wires the hand to its table with a fake clock and starts the current bettor's turn
+func promptCurrentBettor(hand *Hand, table *Table) *fakeClock {
+	clock := &fakeClock{}
+	table.Clock = clock
+	table.Players = hand.Players
+	table.ActiveHand = hand
+	hand.RegisterEventHandler(table.handleHandEvent)
+
+	hand.emitEvent(events.PlayerTurnStarted{
+		TableID:   hand.TableID,
+		HandID:    hand.ID,
+		PlayerID:  hand.CurrentBettor,
+		Phase:     string(hand.Phase),
+		TimeoutAt: time.Now().Add(hand.turnTimeout()),
+		Timeout:   hand.turnTimeout(),
+		At:        time.Now(),
+	})
+	return clock
+}
+
+func TestTurnTimers(t *testing.T) {
+	t.Run("Turn prompts start a timer for the player timeout", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+
+		// Act
+		clock := promptCurrentBettor(hand, table)
+
+		// Assert
+		require.Len(t, clock.pending(), 1)
+		assert.Equal(t, 30*time.Second, clock.pending()[0].duration)
+	})
+
+	t.Run("Player missing the ante sits the hand out", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(3)
+		clock := promptCurrentBettor(hand, table)
+		timedOutID := hand.CurrentBettor
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		event, found := findEventOfType(hand.Events, events.PlayerTimedOut{}.Name())
+		require.True(t, found)
+		assert.Equal(t, timedOutID, event.(events.PlayerTimedOut).PlayerID)
+		assert.Equal(t, "fold", event.(events.PlayerTimedOut).DefaultAction)
+		assert.False(t, hand.IsPlayerActive(timedOutID))
+		assert.NotEqual(t, timedOutID, hand.CurrentBettor)
+		assert.Len(t, clock.pending(), 1, "the next player's turn is timed")
+	})
+
+	t.Run("Player timing out on the continuation bet folds", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		clock := promptCurrentBettor(hand, table)
+		timedOutID := hand.CurrentBettor
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		event, found := findEventOfType(hand.Events, events.PlayerFolded{}.Name())
+		require.True(t, found)
+		assert.Equal(t, timedOutID, event.(events.PlayerFolded).PlayerID)
+		assert.False(t, hand.IsPlayerActive(timedOutID))
+	})
+
+	t.Run("Acting in time stops the timer", func(t *testing.T) {
+		// Setup
+		hand, table := setupContinuationPhaseHand(3)
+		clock := promptCurrentBettor(hand, table)
+		first := clock.timers[0]
+		playerID := hand.CurrentBettor
+
+		// Act
+		err := hand.PlayerPlacesContinuationBet(playerID, 30)
+		first.f()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, first.stopped)
+		assert.True(t, hand.IsPlayerActive(playerID))
+		_, found := findEventOfType(hand.Events, events.PlayerTimedOut{}.Name())
+		assert.False(t, found, "a late timer does not time out the player")
+	})
+
+	t.Run("Hand ends when a timeout leaves a single player", func(t *testing.T) {
+		// Setup
+		hand, table := setupAntesPhaseHand(2)
+		clock := promptCurrentBettor(hand, table)
+
+		// Act
+		clock.pending()[0].fire()
+
+		// Assert
+		assert.Equal(t, HandPhase_Ended, hand.Phase)
+	})
+}
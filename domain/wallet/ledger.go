@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ledger is an in-memory Wallet
+type Ledger struct {
+	mu           sync.Mutex
+	balances     map[string]int
+	transactions map[string][]Transaction // By player ID
+	byKey        map[string]Transaction
+}
+
+// NewLedger creates an empty ledger, every player starts with a zero balance
+func NewLedger() *Ledger {
+	return &Ledger{
+		balances:     make(map[string]int),
+		transactions: make(map[string][]Transaction),
+		byKey:        make(map[string]Transaction),
+	}
+}
+
+// Balance returns the player's balance
+func (l *Ledger) Balance(playerID string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.balances[playerID]
+}
+
+// Credit adds amount to the player's balance
+func (l *Ledger) Credit(playerID string, amount int, key string, reason string) (Transaction, error) {
+	return l.record(playerID, KindCredit, amount, key, reason)
+}
+
+// Debit takes amount from the player's balance, it fails with ErrInsufficientFunds if the balance is too low
+func (l *Ledger) Debit(playerID string, amount int, key string, reason string) (Transaction, error) {
+	return l.record(playerID, KindDebit, amount, key, reason)
+}
+
+// Transactions returns the player's transactions, oldest first
+func (l *Ledger) Transactions(playerID string) []Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return slices.Clone(l.transactions[playerID])
+}
+
+func (l *Ledger) record(playerID string, kind Kind, amount int, key string, reason string) (Transaction, error) {
+	if amount <= 0 {
+		return Transaction{}, ErrInvalidAmount
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if key != "" {
+		if done, exists := l.byKey[key]; exists {
+			if done.PlayerID != playerID || done.Kind != kind || done.Amount != amount {
+				return Transaction{}, ErrKeyReused
+			}
+			return done, nil
+		}
+	}
+
+	balance := l.balances[playerID]
+	if kind == KindDebit {
+		if balance < amount {
+			return Transaction{}, ErrInsufficientFunds
+		}
+		balance -= amount
+	} else {
+		balance += amount
+	}
+
+	transaction := Transaction{
+		ID:           uuid.NewString(),
+		Key:          key,
+		PlayerID:     playerID,
+		Kind:         kind,
+		Amount:       amount,
+		BalanceAfter: balance,
+		Reason:       reason,
+		At:           time.Now(),
+	}
+
+	l.balances[playerID] = balance
+	l.transactions[playerID] = append(l.transactions[playerID], transaction)
+	if key != "" {
+		l.byKey[key] = transaction
+	}
+	return transaction, nil
+}
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	t.Run("Credits and debits move the balance", func(t *testing.T) {
+		// Setup
+		ledger := NewLedger()
+
+		// Act
+		_, creditErr := ledger.Credit("player-1", 1000, "welcome/player-1", "starting balance")
+		debit, debitErr := ledger.Debit("player-1", 300, "buy-in/1", "buy-in")
+
+		// Assert
+		require.NoError(t, creditErr)
+		require.NoError(t, debitErr)
+		assert.Equal(t, 700, ledger.Balance("player-1"))
+		assert.Equal(t, 700, debit.BalanceAfter)
+		assert.Equal(t, KindDebit, debit.Kind)
+
+		transactions := ledger.Transactions("player-1")
+		require.Len(t, transactions, 2)
+		assert.Equal(t, "starting balance", transactions[0].Reason)
+		assert.Equal(t, "buy-in", transactions[1].Reason)
+	})
+
+	t.Run("A debit can't overdraw the balance", func(t *testing.T) {
+		// Setup
+		ledger := NewLedger()
+		ledger.Credit("player-1", 100, "", "deposit")
+
+		// Act
+		_, err := ledger.Debit("player-1", 101, "", "buy-in")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+		assert.Equal(t, 100, ledger.Balance("player-1"))
+		assert.Len(t, ledger.Transactions("player-1"), 1)
+	})
+
+	t.Run("A retried call with the same key moves the money once", func(t *testing.T) {
+		// Setup
+		ledger := NewLedger()
+		first, err := ledger.Credit("player-1", 500, "prize/1", "tournament prize")
+		require.NoError(t, err)
+
+		// Act
+		retried, err := ledger.Credit("player-1", 500, "prize/1", "tournament prize")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, first, retried)
+		assert.Equal(t, 500, ledger.Balance("player-1"))
+		assert.Len(t, ledger.Transactions("player-1"), 1)
+	})
+
+	t.Run("A key can't be reused for another movement", func(t *testing.T) {
+		// Setup
+		ledger := NewLedger()
+		ledger.Credit("player-1", 500, "key-1", "deposit")
+
+		// Act
+		_, otherAmount := ledger.Credit("player-1", 600, "key-1", "deposit")
+		_, otherPlayer := ledger.Credit("player-2", 500, "key-1", "deposit")
+
+		// Assert
+		assert.ErrorIs(t, otherAmount, ErrKeyReused)
+		assert.ErrorIs(t, otherPlayer, ErrKeyReused)
+		assert.Equal(t, 0, ledger.Balance("player-2"))
+	})
+
+	t.Run("Amounts must be positive", func(t *testing.T) {
+		// Setup
+		ledger := NewLedger()
+
+		// Act
+		_, err := ledger.Debit("player-1", -50, "", "buy-in")
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+}
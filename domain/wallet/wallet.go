@@ -0,0 +1,47 @@
+// Package wallet keeps players' bankrolls: what they can buy in with, outside of any table.
+// Every movement is a transaction in a ledger, made once per idempotency key so retried calls
+// can't move the money twice.
+package wallet
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInsufficientFunds is returned when a debit is larger than the player's balance
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrInvalidAmount is returned for debits and credits that aren't positive
+var ErrInvalidAmount = errors.New("amount must be positive")
+
+// ErrKeyReused is returned when an idempotency key comes back with another movement than the one it made
+var ErrKeyReused = errors.New("idempotency key already used for another transaction")
+
+// Kind is the direction of a transaction
+type Kind string
+
+const (
+	KindCredit Kind = "credit" // Money in, e.g. a cash-out or a tournament prize
+	KindDebit  Kind = "debit"  // Money out, e.g. a buy-in
+)
+
+// Transaction is a movement of a player's balance
+type Transaction struct {
+	ID           string
+	Key          string // Idempotency key of the call that made it
+	PlayerID     string
+	Kind         Kind
+	Amount       int // Always positive, Kind gives the direction
+	BalanceAfter int
+	Reason       string // e.g. "buy-in", "cash-out", "tournament prize"
+	At           time.Time
+}
+
+// Wallet moves players' balances. Calls with a key that already made a transaction return that
+// transaction and change nothing, so callers can retry them safely.
+type Wallet interface {
+	Balance(playerID string) int
+	Credit(playerID string, amount int, key string, reason string) (Transaction, error)
+	Debit(playerID string, amount int, key string, reason string) (Transaction, error)
+	Transactions(playerID string) []Transaction // Oldest first
+}
@@ -1,19 +1,50 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Players' time zones are found even on hosts without a zoneinfo database
 
 	"github.com/lazharichir/poker/server"
+	"github.com/lazharichir/poker/server/tracing"
 )
 
+// shutdownTimeout is how long the hands in progress have to end once the server is told to stop
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	fmt.Println("Starting Unique Poker Game Backend...")
 
-	s := server.NewServer()
-	err := s.Start("7777")
+	// Timestamps go out in UTC whatever the host's time zone, players see them in their own
+	time.Local = time.UTC
 
+	shutdownTracing, err := tracing.Setup()
 	if err != nil {
+		log.Fatalf("Tracing setup failed: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	s := server.NewServer()
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Start("7777") }()
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case err := <-stopped:
 		log.Fatalf("Server failed: %v", err)
+	case <-signals.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown: %v", err)
 	}
 }
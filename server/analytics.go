@@ -0,0 +1,29 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// handleSelectionHeatmap returns how often community cards get selected, by layout position and rank.
+// With ?handId= it returns the ranked selections of that hand instead.
+func (s *Server) handleSelectionHeatmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var response any = s.heatmaps.Heatmap()
+	if handID := r.URL.Query().Get("handId"); handID != "" {
+		hand, found := s.heatmaps.Hand(handID)
+		if !found {
+			http.Error(w, "Hand not found", http.StatusNotFound)
+			return
+		}
+		response = hand
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// BanRequest is the body of an operator ban
+type BanRequest struct {
+	PlayerID        string `json:"playerId"`
+	Reason          string `json:"reason"`
+	Note            string `json:"note"`
+	By              string `json:"by"`
+	DurationSeconds int    `json:"durationSeconds"` // zero for a permanent ban
+}
+
+// BanResponse represents a ban or a table block in API responses
+type BanResponse struct {
+	PlayerID  string     `json:"playerId"`
+	Reason    string     `json:"reason"`
+	Note      string     `json:"note,omitempty"`
+	By        string     `json:"by,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func newBanResponses(bans []domain.Ban) []BanResponse {
+	responses := make([]BanResponse, 0, len(bans))
+	for _, ban := range bans {
+		response := BanResponse{
+			PlayerID:  ban.PlayerID,
+			Reason:    string(ban.Reason),
+			Note:      ban.Note,
+			By:        ban.By,
+			CreatedAt: ban.CreatedAt,
+		}
+		if !ban.ExpiresAt.IsZero() {
+			expiresAt := ban.ExpiresAt
+			response.ExpiresAt = &expiresAt
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// handleBans lists (GET), issues (POST) and lifts (DELETE ?playerId=&by=) operator bans
+func (s *Server) handleBans(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newBanResponses(s.lobby.GetBans()))
+
+	case http.MethodPost:
+		var req BanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		ban := domain.Ban{
+			PlayerID: req.PlayerID,
+			Reason:   domain.BanReason(req.Reason),
+			Note:     req.Note,
+			By:       req.By,
+		}
+		if req.DurationSeconds > 0 {
+			ban.ExpiresAt = time.Now().Add(time.Duration(req.DurationSeconds) * time.Second)
+		}
+
+		if err := s.lobby.BanPlayer(ban); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodDelete:
+		query := r.URL.Query()
+		if err := s.lobby.UnbanPlayer(query.Get("playerId"), query.Get("by")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleTableBlocklist lists a table's blocklist (?tableId=). The host manages it over the WebSocket.
+func (s *Server) handleTableBlocklist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	table, err := s.lobby.GetTable(r.URL.Query().Get("tableId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newBanResponses(table.GetBlocklist()))
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// Number of hands returned by the big pots feed
+const (
+	defaultBigPotsLimit = 20
+	maxBigPotsLimit     = 50
+)
+
+// defaultBigPotThreshold is the smallest pot shown in the big pots feed, unless POKER_BIG_POT_MIN says otherwise
+const defaultBigPotThreshold = 1_000
+
+// bigPotThresholdFromEnv reads POKER_BIG_POT_MIN, the smallest pot shown in the big pots feed.
+// Zero only shows hands won with a strong hand.
+func bigPotThresholdFromEnv() int {
+	value := os.Getenv("POKER_BIG_POT_MIN")
+	if value == "" {
+		return defaultBigPotThreshold
+	}
+
+	threshold, err := strconv.Atoi(value)
+	if err != nil || threshold < 0 {
+		log.Fatalf("Invalid POKER_BIG_POT_MIN: %q", value)
+	}
+	return threshold
+}
+
+// handleBigPots returns the recent notable hands of the tables that opted in, most recent first (?limit=)
+func (s *Server) handleBigPots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := defaultBigPotsLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxBigPotsLimit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=10")
+	json.NewEncoder(w).Encode(s.bigPots.Recent(limit))
+}
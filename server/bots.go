@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// BotRequest seats a bot (POST) or changes the strategy of a seated one (PUT, with PlayerID)
+type BotRequest struct {
+	TableID  string `json:"tableId"`
+	PlayerID string `json:"playerId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Strategy string `json:"strategy"`
+	Stack    int    `json:"stack,omitempty"`
+}
+
+// BotResponse is a bot seated at a table
+type BotResponse struct {
+	PlayerID string `json:"playerId"`
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"`
+	Stack    int    `json:"stack"`
+	Fill     bool   `json:"fill,omitempty"` // Seated by bot fill, leaves as players join
+}
+
+// BotsResponse lists the bots of a table with the strategies they may play
+type BotsResponse struct {
+	Strategies []string      `json:"strategies"`
+	Bots       []BotResponse `json:"bots"`
+}
+
+func newBotResponse(table *domain.Table, player *domain.Player) BotResponse {
+	return BotResponse{
+		PlayerID: player.ID,
+		Name:     player.Name,
+		Strategy: table.Bots[player.ID].Name(),
+		Stack:    table.GetPlayerBuyIn(player.ID),
+		Fill:     table.IsFillBot(player.ID),
+	}
+}
+
+// botFillFromEnv reads POKER_BOT_FILL, the site-wide switch letting tables seat bots for players waiting alone
+func botFillFromEnv() bool {
+	value := os.Getenv("POKER_BOT_FILL")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid POKER_BOT_FILL: %v", err)
+	}
+	return enabled
+}
+
+// handleBots lists (GET ?tableId=), seats (POST), re-assigns (PUT) and removes (DELETE ?tableId=&playerId=) a table's bots
+func (s *Server) handleBots(w http.ResponseWriter, r *http.Request) {
+	var req BotRequest
+	switch r.Method {
+	case http.MethodGet, http.MethodDelete:
+		req.TableID = r.URL.Query().Get("tableId")
+		req.PlayerID = r.URL.Query().Get("playerId")
+	case http.MethodPost, http.MethodPut:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	table, err := s.lobby.GetTable(req.TableID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		response := BotsResponse{Strategies: domain.BotStrategyNames(), Bots: []BotResponse{}}
+		for _, player := range table.GetPlayers() {
+			if table.IsBot(player.ID) {
+				response.Bots = append(response.Bots, newBotResponse(table, player))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(response)
+
+	case http.MethodPost:
+		if req.Stack <= 0 {
+			http.Error(w, "stack must be positive", http.StatusBadRequest)
+			return
+		}
+
+		var bot *domain.Player
+		err := table.Do("bot seat", func() (err error) {
+			bot, err = table.SeatBot(req.Name, req.Strategy, req.Stack)
+			return err
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(newBotResponse(table, bot))
+
+	case http.MethodPut:
+		if err := table.Do("bot strategy", func() error { return table.SetBotStrategy(req.PlayerID, req.Strategy) }); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, domain.ErrNotABot) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodDelete:
+		if !table.IsBot(req.PlayerID) {
+			http.Error(w, domain.ErrNotABot.Error(), http.StatusNotFound)
+			return
+		}
+		if err := table.Do("bot leave", func() error { return table.PlayerLeaves(req.PlayerID) }); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,144 @@
+package broadcast
+
+import (
+	"bytes"
+	"sync"
+)
+
+// viewerBuffer is how many frames a viewer may lag behind before it is dropped
+const viewerBuffer = 64
+
+// Viewer receives a table's event stream as ready-to-write Server-Sent Events frames.
+// Frames is closed when the viewer falls too far behind or the table closes.
+type Viewer struct {
+	Frames chan []byte
+	closed bool
+}
+
+// Hub fans out one serialized event stream per table to any number of viewers.
+// Each event is formatted once and the same bytes are handed to every viewer.
+type Hub struct {
+	mu     sync.RWMutex
+	tables map[string]map[*Viewer]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{
+		tables: make(map[string]map[*Viewer]struct{}),
+	}
+}
+
+// Subscribe adds a viewer to a table's stream
+func (h *Hub) Subscribe(tableID string) *Viewer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	viewer := &Viewer{Frames: make(chan []byte, viewerBuffer)}
+
+	if h.tables[tableID] == nil {
+		h.tables[tableID] = make(map[*Viewer]struct{})
+	}
+	h.tables[tableID][viewer] = struct{}{}
+
+	return viewer
+}
+
+// Unsubscribe removes a viewer from a table's stream
+func (h *Hub) Unsubscribe(tableID string, viewer *Viewer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(tableID, viewer)
+}
+
+// Publish sends an event envelope to every viewer of the table. Viewers that can't keep up
+// are disconnected rather than slowing the table down, they can reconnect and resume.
+func (h *Hub) Publish(tableID string, eventID string, eventName string, data []byte) {
+	h.mu.RLock()
+	viewers := h.tables[tableID]
+	if len(viewers) == 0 {
+		h.mu.RUnlock()
+		return
+	}
+
+	frame := FormatFrame(eventID, eventName, data)
+
+	var lagging []*Viewer
+	for viewer := range viewers {
+		select {
+		case viewer.Frames <- frame:
+		default:
+			lagging = append(lagging, viewer)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(lagging) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	for _, viewer := range lagging {
+		h.removeLocked(tableID, viewer)
+	}
+	h.mu.Unlock()
+}
+
+// CloseTable disconnects every viewer of a table
+func (h *Hub) CloseTable(tableID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for viewer := range h.tables[tableID] {
+		h.removeLocked(tableID, viewer)
+	}
+}
+
+// ViewerCount returns how many viewers are watching a table
+func (h *Hub) ViewerCount(tableID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.tables[tableID])
+}
+
+func (h *Hub) removeLocked(tableID string, viewer *Viewer) {
+	viewers, ok := h.tables[tableID]
+	if !ok {
+		return
+	}
+
+	if _, ok := viewers[viewer]; !ok {
+		return
+	}
+
+	delete(viewers, viewer)
+	if !viewer.closed {
+		viewer.closed = true
+		close(viewer.Frames)
+	}
+
+	if len(viewers) == 0 {
+		delete(h.tables, tableID)
+	}
+}
+
+// FormatFrame encodes an event as a Server-Sent Events frame
+func FormatFrame(eventID string, eventName string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(eventID) + len(eventName) + len(data) + 24)
+
+	if eventID != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(eventID)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("event: ")
+	buf.WriteString(eventName)
+	buf.WriteString("\ndata: ")
+	buf.Write(data)
+	buf.WriteString("\n\n")
+
+	return buf.Bytes()
+}
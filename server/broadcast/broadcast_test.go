@@ -0,0 +1,176 @@
+package broadcast
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type benchmarkEvent struct {
+	ID       string
+	TableID  string
+	HandID   string
+	PlayerID string
+	Amount   int
+	At       time.Time
+}
+
+func benchmarkPayload(b *testing.B) []byte {
+	data, err := json.Marshal(benchmarkEvent{
+		ID:       "01J00000000000000000000000",
+		TableID:  "table",
+		HandID:   "hand",
+		PlayerID: "player",
+		Amount:   100,
+		At:       time.Now(),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// drain keeps viewers reading so the benchmark measures fan-out, not backpressure
+func drain(viewers []*Viewer) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	for _, viewer := range viewers {
+		wg.Add(1)
+		go func(v *Viewer) {
+			defer wg.Done()
+			for range v.Frames {
+			}
+		}(viewer)
+	}
+	return &wg
+}
+
+func BenchmarkHubPublish(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("%d viewers", count), func(b *testing.B) {
+			hub := NewHub()
+			viewers := make([]*Viewer, count)
+			for i := range viewers {
+				viewers[i] = hub.Subscribe("table")
+			}
+			wg := drain(viewers)
+			data := benchmarkPayload(b)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.Publish("table", "id", "ANTE_PLACED", data)
+			}
+			b.StopTimer()
+
+			hub.CloseTable("table")
+			wg.Wait()
+		})
+	}
+}
+
+// BenchmarkPerViewerMarshal is the baseline the hub avoids: serializing the event for each viewer
+func BenchmarkPerViewerMarshal(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("%d viewers", count), func(b *testing.B) {
+			event := benchmarkEvent{TableID: "table", HandID: "hand", PlayerID: "player", Amount: 100, At: time.Now()}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for v := 0; v < count; v++ {
+					data, _ := json.Marshal(event)
+					_ = FormatFrame("id", "ANTE_PLACED", data)
+				}
+			}
+		})
+	}
+}
+
+// closed checks if the hub disconnected the viewer, once the frames it was sent are read
+func closed(viewer *Viewer) bool {
+	for {
+		select {
+		case _, open := <-viewer.Frames:
+			if !open {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Run("Every viewer of the table gets the same frame", func(t *testing.T) {
+		// Setup
+		hub := NewHub()
+		first, second := hub.Subscribe("table-1"), hub.Subscribe("table-1")
+		other := hub.Subscribe("table-2")
+
+		// Act
+		hub.Publish("table-1", "event-1", "POT_CHANGED", []byte(`{"Amount":10}`))
+
+		// Assert
+		expected := "id: event-1\nevent: POT_CHANGED\ndata: {\"Amount\":10}\n\n"
+		require.Len(t, first.Frames, 1)
+		require.Len(t, second.Frames, 1)
+		assert.Equal(t, expected, string(<-first.Frames))
+		assert.Equal(t, expected, string(<-second.Frames))
+		assert.Empty(t, other.Frames)
+	})
+
+	t.Run("A viewer that falls behind is dropped, the others keep up", func(t *testing.T) {
+		// Setup
+		hub := NewHub()
+		lagging, reading := hub.Subscribe("table-1"), hub.Subscribe("table-1")
+		for i := range viewerBuffer {
+			hub.Publish("table-1", fmt.Sprint(i), "POT_CHANGED", []byte(`{}`))
+			<-reading.Frames
+		}
+
+		// Act
+		hub.Publish("table-1", "one-too-many", "POT_CHANGED", []byte(`{}`))
+
+		// Assert
+		assert.True(t, closed(lagging))
+		assert.False(t, closed(reading))
+		assert.Equal(t, 1, hub.ViewerCount("table-1"))
+	})
+
+	t.Run("Closing the table disconnects its viewers only", func(t *testing.T) {
+		// Setup
+		hub := NewHub()
+		viewers := []*Viewer{hub.Subscribe("table-1"), hub.Subscribe("table-1")}
+		other := hub.Subscribe("table-2")
+
+		// Act
+		hub.CloseTable("table-1")
+		hub.Publish("table-1", "event-1", "POT_CHANGED", []byte(`{}`))
+
+		// Assert
+		for _, viewer := range viewers {
+			assert.True(t, closed(viewer))
+		}
+		assert.Zero(t, hub.ViewerCount("table-1"))
+		assert.False(t, closed(other))
+	})
+
+	t.Run("An unsubscribed viewer is disconnected once", func(t *testing.T) {
+		// Setup
+		hub := NewHub()
+		viewer := hub.Subscribe("table-1")
+
+		// Act
+		hub.Unsubscribe("table-1", viewer)
+		hub.CloseTable("table-1")
+
+		// Assert
+		assert.True(t, closed(viewer))
+		assert.Zero(t, hub.ViewerCount("table-1"))
+	})
+}
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"context"
+	"time"
+)
+
+// Instance identifies a server instance and where its peers can reach it
+type Instance struct {
+	ID   string `json:"id"`
+	Addr string `json:"addr"` // Base URL for calls between instances, e.g. http://10.0.0.5:7777
+}
+
+// Registry keeps one lease per table, held by the instance that runs the table
+type Registry interface {
+	// Acquire takes the lease if nobody holds it
+	Acquire(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error)
+	// Renew extends the lease, it fails if the lease expired and was taken by another instance
+	Renew(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error)
+	// Release gives the lease up, if still held by the owner
+	Release(ctx context.Context, tableID string, owner Instance) error
+	// Owner returns the instance holding the lease, if any
+	Owner(ctx context.Context, tableID string) (Instance, bool, error)
+}
+
+// Relay carries deliveries between instances
+type Relay interface {
+	Publish(ctx context.Context, delivery Delivery) error
+	// Subscribe calls the handler for every delivery published by any instance, until ctx is done
+	Subscribe(ctx context.Context, handler func(Delivery)) error
+}
+
+// DeliveryKind tells the receiving instance who to hand a delivery to
+type DeliveryKind string
+
+const (
+	DeliverToTable      DeliveryKind = "table"      // Players seated at the table
+	DeliverToPlayer     DeliveryKind = "player"     // A single player
+	DeliverToSpectators DeliveryKind = "spectators" // Spectator streams of the table
+	DropTable           DeliveryKind = "drop_table" // The table closed, forget it and disconnect its spectators
+)
+
+// Delivery is an event envelope on its way to the clients connected to other instances
+type Delivery struct {
+	Origin    string       `json:"origin"` // ID of the instance that published it
+	Kind      DeliveryKind `json:"kind"`
+	TableID   string       `json:"tableId,omitempty"`
+	PlayerID  string       `json:"playerId,omitempty"`
+	EventID   string       `json:"eventId,omitempty"`
+	EventName string       `json:"eventName,omitempty"`
+	Data      []byte       `json:"data,omitempty"`
+}
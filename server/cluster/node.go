@@ -0,0 +1,264 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/events"
+)
+
+// ForwardPath is where an instance accepts commands for the tables it owns
+const ForwardPath = "/internal/cluster/commands"
+
+// SecretHeader carries the shared cluster secret on calls between instances
+const SecretHeader = "X-Poker-Cluster-Secret"
+
+// Lease timings: a table whose owner stops renewing is free again after leaseTTL
+const (
+	leaseTTL           = 30 * time.Second
+	leaseRenewInterval = 10 * time.Second
+	resubscribeDelay   = time.Second
+)
+
+// ForwardedCommand is a command sent to the instance that owns its table
+type ForwardedCommand struct {
+	PlayerID   string          `json:"playerId"`
+	PlayerName string          `json:"playerName"`
+	Message    json.RawMessage `json:"message"`
+}
+
+// ForwardResult is what the owning instance answers to a forwarded command
+type ForwardResult struct {
+	Error     string   `json:"error,omitempty"`
+	Responses [][]byte `json:"responses,omitempty"` // Envelopes to write to the client that sent the command
+}
+
+// Node connects this instance to the cluster. It holds the leases of the tables created here,
+// relays the events they produce to the other instances and delivers the events relayed by
+// other instances to its own clients and spectators.
+//
+// A table lives in the memory of its owner, so a lease that can't be renewed is only logged:
+// the table keeps running here and the operator has to drain the instance.
+type Node struct {
+	Self Instance
+
+	registry   Registry
+	relay      Relay
+	clients    *connection.Manager
+	spectators events.Spectators
+	secret     string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	owned map[string]bool
+}
+
+// NewNode creates a node for this instance
+func NewNode(self Instance, registry Registry, relay Relay, clients *connection.Manager, spectators events.Spectators, secret string) *Node {
+	return &Node{
+		Self:       self,
+		registry:   registry,
+		relay:      relay,
+		clients:    clients,
+		spectators: spectators,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		owned:      make(map[string]bool),
+	}
+}
+
+// Start listens to the other instances and keeps the leases alive until ctx is done
+func (n *Node) Start(ctx context.Context) {
+	go n.subscribe(ctx)
+
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.renewLeases(ctx)
+		}
+	}
+}
+
+func (n *Node) subscribe(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := n.relay.Subscribe(ctx, n.deliver); err != nil && ctx.Err() == nil {
+			log.Printf("Cluster relay subscription failed, retrying: %v", err)
+			time.Sleep(resubscribeDelay)
+		}
+	}
+}
+
+func (n *Node) renewLeases(ctx context.Context) {
+	n.mu.Lock()
+	tableIDs := make([]string, 0, len(n.owned))
+	for tableID := range n.owned {
+		tableIDs = append(tableIDs, tableID)
+	}
+	n.mu.Unlock()
+
+	for _, tableID := range tableIDs {
+		ok, err := n.registry.Renew(ctx, tableID, n.Self, leaseTTL)
+		if err != nil {
+			log.Printf("Could not renew the lease of table %s: %v", tableID, err)
+		} else if !ok {
+			log.Printf("Lost the lease of table %s, another instance may own it", tableID)
+		}
+	}
+}
+
+// Claim takes the lease of a table created on this instance
+func (n *Node) Claim(ctx context.Context, tableID string) error {
+	ok, err := n.registry.Acquire(ctx, tableID, n.Self, leaseTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("table %s is already owned by another instance", tableID)
+	}
+
+	n.mu.Lock()
+	n.owned[tableID] = true
+	n.mu.Unlock()
+
+	return nil
+}
+
+// Owner returns the instance running a table
+func (n *Node) Owner(ctx context.Context, tableID string) (Instance, bool, error) {
+	return n.registry.Owner(ctx, tableID)
+}
+
+// Forward sends a command to the instance that owns its table
+func (n *Node) Forward(ctx context.Context, owner Instance, cmd ForwardedCommand) (ForwardResult, error) {
+	var result ForwardResult
+
+	body, err := json.Marshal(cmd)
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, owner.Addr+ForwardPath, bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SecretHeader, n.secret)
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("instance %s answered with status %d", owner.ID, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Authenticate checks that a call comes from another instance of the cluster
+func (n *Node) Authenticate(r *http.Request) error {
+	if n.secret == "" || r.Header.Get(SecretHeader) != n.secret {
+		return errors.New("invalid cluster secret")
+	}
+	return nil
+}
+
+// SendToPlayer delivers to the player here if connected, otherwise through the other instances
+func (n *Node) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
+	if n.clients.SendToPlayer(ctx, playerID, message) {
+		return true
+	}
+
+	return n.publish(ctx, Delivery{Kind: DeliverToPlayer, PlayerID: playerID, Data: message})
+}
+
+// SendToTable delivers to the players at the table, on every instance
+func (n *Node) SendToTable(ctx context.Context, tableID string, message []byte) {
+	n.clients.SendToTable(ctx, tableID, message)
+	n.publish(ctx, Delivery{Kind: DeliverToTable, TableID: tableID, Data: message})
+}
+
+// RemoveTable forgets a closed table on every instance and gives its lease up
+func (n *Node) RemoveTable(tableID string) {
+	n.clients.RemoveTable(tableID)
+
+	ctx := context.Background()
+	n.publish(ctx, Delivery{Kind: DropTable, TableID: tableID})
+
+	n.mu.Lock()
+	owned := n.owned[tableID]
+	delete(n.owned, tableID)
+	n.mu.Unlock()
+
+	if owned {
+		if err := n.registry.Release(ctx, tableID, n.Self); err != nil {
+			log.Printf("Could not release the lease of table %s: %v", tableID, err)
+		}
+	}
+}
+
+// Publish delivers to the table's spectators, on every instance
+func (n *Node) Publish(tableID string, eventID string, eventName string, data []byte) {
+	n.spectators.Publish(tableID, eventID, eventName, data)
+	n.publish(context.Background(), Delivery{
+		Kind:      DeliverToSpectators,
+		TableID:   tableID,
+		EventID:   eventID,
+		EventName: eventName,
+		Data:      data,
+	})
+}
+
+// CloseTable disconnects the table's spectators here, RemoveTable takes care of the other instances
+func (n *Node) CloseTable(tableID string) {
+	n.spectators.CloseTable(tableID)
+}
+
+func (n *Node) publish(ctx context.Context, delivery Delivery) bool {
+	delivery.Origin = n.Self.ID
+	if err := n.relay.Publish(ctx, delivery); err != nil {
+		log.Printf("Could not relay %s delivery: %v", delivery.Kind, err)
+		return false
+	}
+	return true
+}
+
+// deliver hands a delivery relayed by another instance to the local clients and spectators
+func (n *Node) deliver(delivery Delivery) {
+	if delivery.Origin == n.Self.ID {
+		return
+	}
+
+	ctx := context.Background()
+
+	switch delivery.Kind {
+	case DeliverToTable:
+		n.clients.SendToTable(ctx, delivery.TableID, delivery.Data)
+	case DeliverToPlayer:
+		n.clients.SendToPlayer(ctx, delivery.PlayerID, delivery.Data)
+	case DeliverToSpectators:
+		n.spectators.Publish(delivery.TableID, delivery.EventID, delivery.EventName, delivery.Data)
+	case DropTable:
+		n.clients.RemoveTable(delivery.TableID)
+		n.spectators.CloseTable(delivery.TableID)
+	}
+}
@@ -0,0 +1,340 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRegistry is a Registry in memory, shared by the nodes of a test
+type memoryRegistry struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease // By table ID
+}
+
+type memoryLease struct {
+	owner   Instance
+	expires time.Time
+}
+
+func newMemoryRegistry() *memoryRegistry {
+	return &memoryRegistry{leases: make(map[string]memoryLease)}
+}
+
+func (r *memoryRegistry) Acquire(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lease, exists := r.leases[tableID]; exists && time.Now().Before(lease.expires) {
+		return false, nil
+	}
+	r.leases[tableID] = memoryLease{owner: owner, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (r *memoryRegistry) Renew(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lease, exists := r.leases[tableID]
+	if !exists || lease.owner != owner || time.Now().After(lease.expires) {
+		return false, nil
+	}
+	r.leases[tableID] = memoryLease{owner: owner, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (r *memoryRegistry) Release(ctx context.Context, tableID string, owner Instance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.leases[tableID].owner == owner {
+		delete(r.leases, tableID)
+	}
+	return nil
+}
+
+func (r *memoryRegistry) Owner(ctx context.Context, tableID string) (Instance, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lease, exists := r.leases[tableID]
+	if !exists || time.Now().After(lease.expires) {
+		return Instance{}, false, nil
+	}
+	return lease.owner, true, nil
+}
+
+// memoryRelay is a Relay in memory, handing every delivery to every subscriber as soon as it is published
+type memoryRelay struct {
+	mu       sync.Mutex
+	handlers []func(Delivery)
+}
+
+func (r *memoryRelay) Publish(ctx context.Context, delivery Delivery) error {
+	r.mu.Lock()
+	handlers := append([]func(Delivery){}, r.handlers...)
+	r.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(delivery)
+	}
+	return nil
+}
+
+func (r *memoryRelay) Subscribe(ctx context.Context, handler func(Delivery)) error {
+	r.mu.Lock()
+	r.handlers = append(r.handlers, handler)
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (r *memoryRelay) subscribers() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.handlers)
+}
+
+// recordingSpectators remembers what was published to the spectators of each table
+type recordingSpectators struct {
+	mu        sync.Mutex
+	published map[string][]string // Event IDs by table ID
+	closed    []string
+}
+
+func (s *recordingSpectators) Publish(tableID string, eventID string, eventName string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.published == nil {
+		s.published = make(map[string][]string)
+	}
+	s.published[tableID] = append(s.published[tableID], eventID)
+}
+
+func (s *recordingSpectators) CloseTable(tableID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = append(s.closed, tableID)
+}
+
+func (s *recordingSpectators) eventIDs(tableID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.published[tableID]...)
+}
+
+func (s *recordingSpectators) closedTables() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.closed...)
+}
+
+// testNode is a node of a test cluster, with its clients and spectators
+type testNode struct {
+	*Node
+	clients    *connection.Manager
+	spectators *recordingSpectators
+}
+
+// newTestCluster starts nodes sharing a registry and a relay, until the test ends
+func newTestCluster(t *testing.T, ids ...string) (*memoryRegistry, []*testNode) {
+	registry, relay := newMemoryRegistry(), &memoryRelay{}
+
+	nodes := []*testNode{}
+	for _, id := range ids {
+		clients := connection.NewManager()
+		go clients.Start()
+		spectators := &recordingSpectators{}
+		node := NewNode(Instance{ID: id, Addr: "http://" + id}, registry, relay, clients, spectators, "secret")
+		go node.Start(t.Context())
+		nodes = append(nodes, &testNode{Node: node, clients: clients, spectators: spectators})
+	}
+
+	require.Eventually(t, func() bool { return relay.subscribers() == len(ids) }, time.Second, time.Millisecond)
+	return registry, nodes
+}
+
+// connect connects a player seated at a table to the node
+func (n *testNode) connect(t *testing.T, playerID string, tableID string) *connection.Client {
+	client := &connection.Client{ID: "conn-" + playerID, Send: make(chan connection.Message, 8), Player: &domain.Player{ID: playerID}}
+	n.clients.Register <- client
+	require.Eventually(t, func() bool { return n.clients.AddTableToClient(client.ID, tableID) }, time.Second, time.Millisecond)
+	return client
+}
+
+func TestNodeLeases(t *testing.T) {
+	t.Run("A table is claimed by one instance only", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a", "node-b")
+
+		// Act
+		errA := nodes[0].Claim(t.Context(), "table-1")
+		errB := nodes[1].Claim(t.Context(), "table-1")
+
+		// Assert
+		assert.NoError(t, errA)
+		assert.Error(t, errB)
+		owner, owned, err := nodes[1].Owner(t.Context(), "table-1")
+		require.NoError(t, err)
+		assert.True(t, owned)
+		assert.Equal(t, nodes[0].Self, owner)
+	})
+
+	t.Run("Removing a table gives its lease up and drops it on every instance", func(t *testing.T) {
+		// Setup
+		registry, nodes := newTestCluster(t, "node-a", "node-b")
+		require.NoError(t, nodes[0].Claim(t.Context(), "table-1"))
+		remote := nodes[1].connect(t, "player-1", "table-1")
+
+		// Act
+		nodes[0].RemoveTable("table-1")
+
+		// Assert
+		_, owned, err := registry.Owner(t.Context(), "table-1")
+		require.NoError(t, err)
+		assert.False(t, owned)
+		assert.False(t, nodes[1].clients.IsClientAtTable(remote.ID, "table-1"))
+		assert.Equal(t, []string{"table-1"}, nodes[1].spectators.closedTables())
+		assert.NoError(t, nodes[1].Claim(t.Context(), "table-1"), "another instance may run the table now")
+	})
+
+	t.Run("Removing a table owned elsewhere keeps its lease", func(t *testing.T) {
+		// Setup
+		registry, nodes := newTestCluster(t, "node-a", "node-b")
+		require.NoError(t, nodes[0].Claim(t.Context(), "table-1"))
+
+		// Act
+		nodes[1].RemoveTable("table-1")
+
+		// Assert
+		owner, owned, err := registry.Owner(t.Context(), "table-1")
+		require.NoError(t, err)
+		assert.True(t, owned)
+		assert.Equal(t, nodes[0].Self, owner)
+	})
+}
+
+func TestNodeDeliveries(t *testing.T) {
+	t.Run("Table messages reach the players of every instance once", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a", "node-b")
+		local := nodes[0].connect(t, "player-1", "table-1")
+		remote := nodes[1].connect(t, "player-2", "table-1")
+
+		// Act
+		nodes[0].SendToTable(t.Context(), "table-1", []byte(`{"name":"POT_CHANGED","payload":{}}`))
+
+		// Assert
+		assert.Len(t, local.Send, 1, "the instance skips what it relayed itself")
+		assert.Len(t, remote.Send, 1)
+	})
+
+	t.Run("Player messages go through the relay only for players connected elsewhere", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a", "node-b")
+		local := nodes[0].connect(t, "player-1", "table-1")
+		remote := nodes[1].connect(t, "player-2", "table-1")
+
+		// Act
+		toLocal := nodes[0].SendToPlayer(t.Context(), "player-1", []byte(`{"name":"HOLE_CARDS_DEALT","payload":{}}`))
+		toRemote := nodes[0].SendToPlayer(t.Context(), "player-2", []byte(`{"name":"HOLE_CARDS_DEALT","payload":{}}`))
+
+		// Assert
+		assert.True(t, toLocal)
+		assert.True(t, toRemote)
+		assert.Len(t, local.Send, 1)
+		assert.Len(t, remote.Send, 1)
+	})
+
+	t.Run("Spectators of every instance get the table's events", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a", "node-b")
+
+		// Act
+		nodes[0].Publish("table-1", "event-1", "POT_CHANGED", []byte(`{}`))
+
+		// Assert
+		assert.Equal(t, []string{"event-1"}, nodes[0].spectators.eventIDs("table-1"))
+		assert.Equal(t, []string{"event-1"}, nodes[1].spectators.eventIDs("table-1"))
+	})
+
+	t.Run("Deliveries from the instance itself are skipped", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a")
+
+		// Act
+		nodes[0].deliver(Delivery{Origin: "node-a", Kind: DeliverToSpectators, TableID: "table-1", EventID: "event-1"})
+		nodes[0].deliver(Delivery{Origin: "node-b", Kind: DeliverToSpectators, TableID: "table-1", EventID: "event-2"})
+
+		// Assert
+		assert.Equal(t, []string{"event-2"}, nodes[0].spectators.eventIDs("table-1"))
+	})
+}
+
+func TestNodeForward(t *testing.T) {
+	t.Run("Commands reach the owner with the cluster secret and get its answer", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a", "node-b")
+		var received ForwardedCommand
+		owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != ForwardPath || nodes[1].Authenticate(r) != nil {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&received)
+			json.NewEncoder(w).Encode(ForwardResult{Responses: [][]byte{[]byte(`{"name":"COMMAND_ACK"}`)}})
+		}))
+		defer owner.Close()
+
+		// Act
+		result, err := nodes[0].Forward(t.Context(), Instance{ID: "node-b", Addr: owner.URL}, ForwardedCommand{
+			PlayerID: "player-1",
+			Message:  json.RawMessage(`{"type":"PLAYER_FOLDS"}`),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "player-1", received.PlayerID)
+		assert.JSONEq(t, `{"type":"PLAYER_FOLDS"}`, string(received.Message))
+		assert.Equal(t, [][]byte{[]byte(`{"name":"COMMAND_ACK"}`)}, result.Responses)
+	})
+
+	t.Run("An owner refusing the command fails it", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a")
+		owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}))
+		defer owner.Close()
+
+		// Act
+		_, err := nodes[0].Forward(t.Context(), Instance{ID: "node-b", Addr: owner.URL}, ForwardedCommand{PlayerID: "player-1"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Calls without the cluster secret are refused", func(t *testing.T) {
+		// Setup
+		_, nodes := newTestCluster(t, "node-a")
+		request := httptest.NewRequest(http.MethodPost, ForwardPath, nil)
+
+		// Act & Assert
+		assert.Error(t, nodes[0].Authenticate(request))
+		request.Header.Set(SecretHeader, "secret")
+		assert.NoError(t, nodes[0].Authenticate(request))
+	})
+}
@@ -0,0 +1,131 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys and channels used by the cluster
+const (
+	redisLeasePrefix  = "poker:cluster:table:"
+	redisRelayChannel = "poker:cluster:relay"
+)
+
+// Only the owner may renew or release a lease
+var (
+	renewLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseLeaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// RedisRegistry keeps table leases in Redis, as keys that expire unless renewed
+type RedisRegistry struct {
+	client *redis.Client
+}
+
+// NewRedisRegistry creates a registry on the given Redis client
+func NewRedisRegistry(client *redis.Client) *RedisRegistry {
+	return &RedisRegistry{client: client}
+}
+
+func (r *RedisRegistry) Acquire(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error) {
+	value, err := json.Marshal(owner)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, redisLeasePrefix+tableID, value, ttl).Result()
+}
+
+func (r *RedisRegistry) Renew(ctx context.Context, tableID string, owner Instance, ttl time.Duration) (bool, error) {
+	value, err := json.Marshal(owner)
+	if err != nil {
+		return false, err
+	}
+	renewed, err := renewLeaseScript.Run(ctx, r.client, []string{redisLeasePrefix + tableID}, string(value), ttl.Milliseconds()).Int()
+	return renewed == 1, err
+}
+
+func (r *RedisRegistry) Release(ctx context.Context, tableID string, owner Instance) error {
+	value, err := json.Marshal(owner)
+	if err != nil {
+		return err
+	}
+	return releaseLeaseScript.Run(ctx, r.client, []string{redisLeasePrefix + tableID}, string(value)).Err()
+}
+
+func (r *RedisRegistry) Owner(ctx context.Context, tableID string) (Instance, bool, error) {
+	var owner Instance
+
+	value, err := r.client.Get(ctx, redisLeasePrefix+tableID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return owner, false, nil
+	}
+	if err != nil {
+		return owner, false, err
+	}
+
+	if err := json.Unmarshal(value, &owner); err != nil {
+		return owner, false, err
+	}
+	return owner, true, nil
+}
+
+// RedisRelay relays deliveries between instances over a Redis pub/sub channel
+type RedisRelay struct {
+	client *redis.Client
+}
+
+// NewRedisRelay creates a relay on the given Redis client
+func NewRedisRelay(client *redis.Client) *RedisRelay {
+	return &RedisRelay{client: client}
+}
+
+func (r *RedisRelay) Publish(ctx context.Context, delivery Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, redisRelayChannel, data).Err()
+}
+
+func (r *RedisRelay) Subscribe(ctx context.Context, handler func(Delivery)) error {
+	pubsub := r.client.Subscribe(ctx, redisRelayChannel)
+	defer pubsub.Close()
+
+	// Wait for the subscription to be confirmed, so errors surface here
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return errors.New("relay channel closed")
+			}
+
+			var delivery Delivery
+			if err := json.Unmarshal([]byte(message.Payload), &delivery); err != nil {
+				log.Printf("Ignoring malformed cluster delivery: %v", err)
+				continue
+			}
+			handler(delivery)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package cluster
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redisClient connects to the Redis server of POKER_CLUSTER_REDIS_URL, the test is skipped without one
+func redisClient(t *testing.T) *redis.Client {
+	url := os.Getenv("POKER_CLUSTER_REDIS_URL")
+	if url == "" {
+		t.Skip("POKER_CLUSTER_REDIS_URL is not set")
+	}
+
+	options, err := redis.ParseURL(url)
+	require.NoError(t, err)
+	client := redis.NewClient(options)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRedisRegistry(t *testing.T) {
+	client := redisClient(t)
+	registry := NewRedisRegistry(client)
+	owner := Instance{ID: "node-a", Addr: "http://node-a"}
+	other := Instance{ID: "node-b", Addr: "http://node-b"}
+	tableID := "test-" + time.Now().Format("20060102150405.000000000")
+	t.Cleanup(func() { client.Del(context.Background(), redisLeasePrefix+tableID) })
+
+	t.Run("Only the owner holds, renews and releases the lease", func(t *testing.T) {
+		// Act & Assert
+		acquired, err := registry.Acquire(t.Context(), tableID, owner, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = registry.Acquire(t.Context(), tableID, other, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+
+		renewed, err := registry.Renew(t.Context(), tableID, other, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, renewed)
+
+		renewed, err = registry.Renew(t.Context(), tableID, owner, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, renewed)
+
+		require.NoError(t, registry.Release(t.Context(), tableID, other))
+		current, held, err := registry.Owner(t.Context(), tableID)
+		require.NoError(t, err)
+		assert.True(t, held)
+		assert.Equal(t, owner, current)
+
+		require.NoError(t, registry.Release(t.Context(), tableID, owner))
+		_, held, err = registry.Owner(t.Context(), tableID)
+		require.NoError(t, err)
+		assert.False(t, held)
+	})
+}
+
+func TestRedisRelay(t *testing.T) {
+	t.Run("Subscribers get the deliveries published by any instance", func(t *testing.T) {
+		// Setup
+		relay := NewRedisRelay(redisClient(t))
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		received := make(chan Delivery, 1)
+		go relay.Subscribe(ctx, func(delivery Delivery) { received <- delivery })
+		time.Sleep(100 * time.Millisecond)
+
+		// Act
+		sent := Delivery{Origin: "node-a", Kind: DeliverToTable, TableID: "table-1", Data: []byte(`{"name":"POT_CHANGED"}`)}
+		require.NoError(t, relay.Publish(t.Context(), sent))
+
+		// Assert
+		select {
+		case delivery := <-received:
+			assert.Equal(t, sent, delivery)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the delivery was not relayed")
+		}
+	})
+}
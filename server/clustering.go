@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/lazharichir/poker/server/cluster"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/events"
+	"github.com/redis/go-redis/v9"
+)
+
+// clusterFromEnv joins the cluster when POKER_CLUSTER_REDIS_URL is set, returning nil otherwise.
+// POKER_CLUSTER_ADVERTISE_ADDR is the base URL other instances use to reach this one, and
+// POKER_CLUSTER_SECRET the secret shared by all instances.
+func clusterFromEnv(connMgr *connection.Manager, spectators events.Spectators) *cluster.Node {
+	redisURL := os.Getenv("POKER_CLUSTER_REDIS_URL")
+	if redisURL == "" {
+		return nil
+	}
+
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("Invalid POKER_CLUSTER_REDIS_URL: %v", err)
+	}
+
+	addr := os.Getenv("POKER_CLUSTER_ADVERTISE_ADDR")
+	secret := os.Getenv("POKER_CLUSTER_SECRET")
+	if addr == "" || secret == "" {
+		log.Fatalf("POKER_CLUSTER_ADVERTISE_ADDR and POKER_CLUSTER_SECRET are required to join a cluster")
+	}
+
+	client := redis.NewClient(options)
+	self := cluster.Instance{ID: uuid.NewString(), Addr: addr}
+
+	log.Printf("Joining cluster as instance %s (%s)", self.ID, self.Addr)
+
+	return cluster.NewNode(self, cluster.NewRedisRegistry(client), cluster.NewRedisRelay(client), connMgr, spectators, secret)
+}
+
+// handleForwardedCommand runs a command another instance received for a table owned here
+func (s *Server) handleForwardedCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.cluster.Authenticate(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var cmd cluster.ForwardedCommand
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.cmdRouter.HandleForwardedCommand(r.Context(), cmd))
+}
+
+// tableExists checks if a table runs on this instance or, in a cluster, on any instance
+func (s *Server) tableExists(r *http.Request, tableID string) bool {
+	if _, err := s.lobby.GetTable(tableID); err == nil {
+		return true
+	}
+
+	if s.cluster == nil {
+		return false
+	}
+
+	_, ok, err := s.cluster.Owner(r.Context(), tableID)
+	if err != nil {
+		log.Printf("Could not look up the owner of table %s: %v", tableID, err)
+	}
+	return ok
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lazharichir/poker/server/handlers"
+)
+
+// commandMiddlewareFromEnv returns the command middleware configured on top of the router's own.
+// POKER_COMMAND_RATE_LIMIT caps the commands a connection may send per second, unlimited by default,
+// and POKER_LOG_COMMANDS logs every command.
+func commandMiddlewareFromEnv() []handlers.Middleware {
+	var middleware []handlers.Middleware
+
+	if value := os.Getenv("POKER_LOG_COMMANDS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Fatalf("Invalid POKER_LOG_COMMANDS: %v", err)
+		}
+		if enabled {
+			middleware = append(middleware, handlers.Logging())
+		}
+	}
+
+	if value := os.Getenv("POKER_COMMAND_RATE_LIMIT"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			log.Fatalf("Invalid POKER_COMMAND_RATE_LIMIT: %q", value)
+		}
+		middleware = append(middleware, handlers.RateLimit(limit, time.Second))
+	}
+
+	return middleware
+}
+
+// handleCommandStats returns how many commands were handled, how long they took and how they failed, by command name
+func (s *Server) handleCommandStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(s.commandStats.Snapshot())
+}
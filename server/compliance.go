@@ -0,0 +1,95 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/server/compliance"
+	"github.com/lazharichir/poker/server/store"
+)
+
+// complianceExporterFromEnv signs the action exports of the compliance API with the Ed25519 key whose seed
+// POKER_COMPLIANCE_SIGNING_KEY holds (32 bytes, base64 encoded). Without it there are no exports.
+func complianceExporterFromEnv(lobby *domain.Lobby, eventStore *store.PostgresStore) *compliance.Exporter {
+	value := os.Getenv("POKER_COMPLIANCE_SIGNING_KEY")
+	if value == "" {
+		return nil
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		log.Fatalf("POKER_COMPLIANCE_SIGNING_KEY must be a base64 encoded Ed25519 seed of %d bytes", ed25519.SeedSize)
+	}
+
+	exporter := compliance.NewExporter(ed25519.NewKeyFromSeed(seed), lobby.Wallet, nil, func() []events.Event {
+		tableEvents := []events.Event{}
+		for _, table := range lobby.GetTables() {
+			tableEvents = append(tableEvents, table.GetEvents()...)
+		}
+		return tableEvents
+	})
+	if eventStore != nil {
+		exporter.Store = eventStore
+	}
+	return exporter
+}
+
+// handleComplianceExport serves the signed export of a player's monetary actions, for regulators: a zip archive
+// of the CSV file and its manifest. Served at GET /api/compliance/players/{id}/actions?from=&to=&tableId= to
+// holders of POKER_COMPLIANCE_TOKEN, with RFC 3339 times; to defaults to now, and no tableId exports every table.
+func (s *Server) handleComplianceExport(w http.ResponseWriter, r *http.Request) {
+	if s.compliance == nil {
+		http.Error(w, "compliance exports are disabled, set POKER_COMPLIANCE_SIGNING_KEY", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	req := compliance.Request{
+		PlayerID: r.PathValue("id"),
+		TableID:  query.Get("tableId"),
+		To:       time.Now(),
+	}
+
+	from, err := time.Parse(time.RFC3339, query.Get("from"))
+	if err != nil {
+		http.Error(w, "from must be an RFC 3339 time", http.StatusBadRequest)
+		return
+	}
+	req.From = from
+
+	if value := query.Get("to"); value != "" {
+		if req.To, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "to must be an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+
+	export, err := s.compliance.Export(r.Context(), req)
+	if errors.Is(err, compliance.ErrInvalidRange) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("Compliance export for player %s failed: %v", req.PlayerID, err)
+		http.Error(w, "Export failed", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Compliance export of %d actions for player %s (%s to %s)", export.Manifest.Rows, req.PlayerID,
+		req.From.Format(time.RFC3339), req.To.Format(time.RFC3339))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="actions-%s.zip"`, req.PlayerID))
+	w.Header().Set("Cache-Control", "no-store")
+	if err := export.WriteZip(w); err != nil {
+		log.Printf("Writing the compliance export for player %s failed: %v", req.PlayerID, err)
+	}
+}
@@ -0,0 +1,180 @@
+// Package compliance exports a player's monetary actions for regulators: the movements of their bankroll,
+// from the wallet ledger, and the chips they put in and took out of hands, from the table events. Exports are
+// a CSV file with a manifest that holds its hash and is signed with the operator's key.
+package compliance
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
+)
+
+// ErrInvalidRange is returned for exports whose time range ends before it starts
+var ErrInvalidRange = errors.New("export range ends before it starts")
+
+// Actions of export rows. Ledger rows carry the transaction's reason instead, e.g. "tournament prize".
+// The server takes no rake, so no row is ever a rake.
+const (
+	ActionBuyIn   = "buy-in"
+	ActionTopUp   = "top-up"
+	ActionCashOut = "cash-out"
+	ActionBet     = "bet"    // Antes, continuation bets and every other chip committed to a hand
+	ActionPayout  = "payout" // Winnings
+	ActionRefund  = "refund" // Chips a hand gave back, e.g. an uncalled bet
+)
+
+// Sources of export rows
+const (
+	SourceLedger = "ledger"
+	SourceEvents = "events"
+)
+
+// Request is what to export: a player's actions from From (inclusive) to To (exclusive), at one table
+// or, with no TableID, everywhere
+type Request struct {
+	PlayerID string
+	TableID  string
+	From     time.Time
+	To       time.Time
+}
+
+// Row is one monetary action
+type Row struct {
+	At        time.Time
+	Source    string // SourceLedger or SourceEvents
+	Reference string // ID of the ledger transaction or of the event
+	TableID   string
+	HandID    string
+	Action    string
+	Amount    int    // Positive when chips come to the player, negative when they leave
+	Balance   int    // The player's bankroll after a ledger transaction, zero for events
+	Detail    string // The reason given by the ledger or the hand
+}
+
+// csvHeader names the columns of the CSV file, in the order of Row's fields
+var csvHeader = []string{"at", "source", "reference", "table_id", "hand_id", "action", "amount", "balance", "detail"}
+
+// Rows picks the player's monetary actions in the requested range from the ledger transactions and the table
+// events, oldest first. Events are deduplicated by ID, so overlapping sources can be passed together.
+func Rows(req Request, transactions []wallet.Transaction, tableEvents []events.Event) []Row {
+	rows := []Row{}
+
+	for _, transaction := range transactions {
+		tableID := tableOfWalletKey(transaction.Key)
+		if transaction.PlayerID != req.PlayerID || !req.covers(tableID, transaction.At) {
+			continue
+		}
+
+		amount := transaction.Amount
+		if transaction.Kind == wallet.KindDebit {
+			amount = -amount
+		}
+		rows = append(rows, Row{
+			At:        transaction.At,
+			Source:    SourceLedger,
+			Reference: transaction.ID,
+			TableID:   tableID,
+			Action:    transaction.Reason,
+			Amount:    amount,
+			Balance:   transaction.BalanceAfter,
+			Detail:    transaction.Reason,
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, event := range tableEvents {
+		row, ok := eventRow(event)
+		if !ok || seen[row.Reference] {
+			continue
+		}
+		seen[row.Reference] = true
+
+		if playerOf(event) != req.PlayerID || !req.covers(row.TableID, row.At) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	slices.SortStableFunc(rows, func(a, b Row) int { return a.At.Compare(b.At) })
+	return rows
+}
+
+func (req Request) covers(tableID string, at time.Time) bool {
+	if req.TableID != "" && tableID != req.TableID {
+		return false
+	}
+	return !at.Before(req.From) && at.Before(req.To)
+}
+
+// eventRow turns the events that move a player's chips in or out of a hand into rows
+func eventRow(event events.Event) (Row, bool) {
+	switch e := event.(type) {
+	case events.EscrowFunded:
+		return Row{At: e.At, Source: SourceEvents, Reference: e.ID, TableID: e.TableID, HandID: e.HandID,
+			Action: ActionBet, Amount: -e.Amount, Detail: e.Reason}, true
+
+	case events.EscrowReleased:
+		action := ActionPayout
+		if strings.Contains(e.Reason, "refund") || strings.Contains(e.Reason, "returned") {
+			action = ActionRefund
+		}
+		return Row{At: e.At, Source: SourceEvents, Reference: e.ID, TableID: e.TableID, HandID: e.HandID,
+			Action: action, Amount: e.Amount, Detail: e.Reason}, true
+	}
+	return Row{}, false
+}
+
+func playerOf(event events.Event) string {
+	switch e := event.(type) {
+	case events.EscrowFunded:
+		return e.PlayerID
+	case events.EscrowReleased:
+		return e.PlayerID
+	}
+	return ""
+}
+
+// tableOfWalletKey returns the table that made a ledger transaction, from the idempotency key tables
+// give their wallet calls (table/<table ID>/<operation>/...), empty for other transactions
+func tableOfWalletKey(key string) string {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 || parts[0] != "table" {
+		return ""
+	}
+	return parts[1]
+}
+
+// WriteCSV writes the rows as CSV, with a header line. Times are RFC 3339 in UTC.
+func WriteCSV(w io.Writer, rows []Row) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{
+			row.At.UTC().Format(time.RFC3339Nano),
+			row.Source,
+			row.Reference,
+			row.TableID,
+			row.HandID,
+			row.Action,
+			strconv.Itoa(row.Amount),
+			strconv.Itoa(row.Balance),
+			row.Detail,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
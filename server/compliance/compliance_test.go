@@ -0,0 +1,181 @@
+package compliance
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// playedSession is alice buying in at table-1, betting in a hand and winning it, then cashing out,
+// with bob's actions at the same table mixed in
+func playedSession(t *testing.T) (*wallet.Ledger, []events.Event) {
+	ledger := wallet.NewLedger()
+	_, err := ledger.Credit("alice", 5000, "deposit-1", "deposit")
+	require.NoError(t, err)
+	_, err = ledger.Debit("alice", 1000, "table/table-1/buy-in/alice/1", "buy-in")
+	require.NoError(t, err)
+	_, err = ledger.Credit("alice", 1150, "table/table-1/cash-out/alice/2", "cash-out")
+	require.NoError(t, err)
+
+	tableEvents := []events.Event{
+		events.EscrowFunded{ID: "e1", TableID: "table-1", HandID: "hand-1", PlayerID: "alice", Amount: 50, Reason: "ante", At: start.Add(time.Minute)},
+		events.EscrowFunded{ID: "e2", TableID: "table-1", HandID: "hand-1", PlayerID: "bob", Amount: 50, Reason: "ante", At: start.Add(time.Minute)},
+		events.EscrowFunded{ID: "e3", TableID: "table-1", HandID: "hand-1", PlayerID: "alice", Amount: 100, Reason: "continuation bet", At: start.Add(2 * time.Minute)},
+		events.EscrowReleased{ID: "e4", TableID: "table-1", HandID: "hand-1", PlayerID: "alice", Amount: 50, Reason: "uncalled bet returned", At: start.Add(3 * time.Minute)},
+		events.EscrowReleased{ID: "e5", TableID: "table-1", HandID: "hand-1", PlayerID: "alice", Amount: 150, Reason: "last player standing", At: start.Add(3 * time.Minute)},
+		events.PotChanged{ID: "e6", TableID: "table-1", HandID: "hand-1", At: start.Add(3 * time.Minute)},
+	}
+	return ledger, tableEvents
+}
+
+func TestRows(t *testing.T) {
+	t.Run("Picks the player's monetary actions from both sources", func(t *testing.T) {
+		// Setup
+		ledger, tableEvents := playedSession(t)
+		req := Request{PlayerID: "alice", From: time.Time{}, To: time.Now().Add(time.Hour)}
+
+		// Act
+		rows := Rows(req, ledger.Transactions("alice"), tableEvents)
+
+		// Assert
+		actions := map[string]int{}
+		for _, row := range rows {
+			actions[row.Action] += row.Amount
+		}
+		assert.Equal(t, map[string]int{"deposit": 5000, ActionBuyIn: -1000, ActionCashOut: 1150, ActionBet: -150, ActionRefund: 50, ActionPayout: 150}, actions)
+		for i := 1; i < len(rows); i++ {
+			assert.False(t, rows[i].At.Before(rows[i-1].At), "rows are in time order")
+		}
+	})
+
+	t.Run("Keeps to the table and time range", func(t *testing.T) {
+		// Setup
+		ledger, tableEvents := playedSession(t)
+		req := Request{PlayerID: "alice", TableID: "table-1", From: start.Add(2 * time.Minute), To: start.Add(3 * time.Minute)}
+
+		// Act
+		rows := Rows(req, ledger.Transactions("alice"), tableEvents)
+
+		// Assert
+		require.Len(t, rows, 1)
+		assert.Equal(t, "e3", rows[0].Reference)
+		assert.Equal(t, "hand-1", rows[0].HandID)
+	})
+
+	t.Run("Events from the store and from memory are counted once", func(t *testing.T) {
+		// Setup
+		_, tableEvents := playedSession(t)
+		req := Request{PlayerID: "alice", From: start, To: start.Add(time.Hour)}
+
+		// Act
+		rows := Rows(req, nil, append(tableEvents, tableEvents...))
+
+		// Assert
+		assert.Len(t, rows, 4)
+	})
+}
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Signs a manifest holding the hash of the CSV file", func(t *testing.T) {
+		// Setup
+		publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		ledger, tableEvents := playedSession(t)
+		store := events.NewMemoryStore()
+		_, err = store.Append(ctx, "table-1", tableEvents[:3]...)
+		require.NoError(t, err)
+		exporter := NewExporter(key, ledger, store, func() []events.Event { return tableEvents })
+
+		// Act
+		export, err := exporter.Export(ctx, Request{PlayerID: "alice", From: start, To: time.Now().Add(time.Hour)})
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, export.Manifest.Verify(publicKey))
+		sum := sha256.Sum256(export.CSV)
+		assert.Equal(t, hex.EncodeToString(sum[:]), export.Manifest.SHA256)
+		assert.Equal(t, 7, export.Manifest.Rows)
+
+		records, err := csv.NewReader(bytes.NewReader(export.CSV)).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, csvHeader, records[0])
+		assert.Len(t, records, 8)
+	})
+
+	t.Run("Altered manifests don't verify", func(t *testing.T) {
+		// Setup
+		publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		ledger, tableEvents := playedSession(t)
+		export, err := NewExporter(key, ledger, nil, func() []events.Event { return tableEvents }).
+			Export(ctx, Request{PlayerID: "alice", From: start, To: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+
+		// Act
+		altered := export.Manifest
+		altered.Totals = map[string]int{ActionPayout: 1}
+
+		// Assert
+		assert.ErrorIs(t, altered.Verify(publicKey), ErrBadSignature)
+		assert.ErrorIs(t, export.Manifest.Verify(otherKey), ErrBadSignature)
+	})
+
+	t.Run("Archives the CSV file with its manifest", func(t *testing.T) {
+		// Setup
+		publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		ledger, _ := playedSession(t)
+		export, err := NewExporter(key, ledger, nil, nil).Export(ctx, Request{PlayerID: "alice", From: start, To: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+
+		// Act
+		var archive bytes.Buffer
+		require.NoError(t, export.WriteZip(&archive))
+
+		// Assert
+		reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		require.NoError(t, err)
+		require.Len(t, reader.File, 2)
+
+		file, err := reader.Open("manifest.json")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+
+		var manifest Manifest
+		require.NoError(t, json.Unmarshal(data, &manifest))
+		assert.NoError(t, manifest.Verify(publicKey), "the manifest still verifies once decoded")
+	})
+
+	t.Run("Rejects ranges that end before they start", func(t *testing.T) {
+		// Setup
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		// Act
+		_, err = NewExporter(key, nil, nil, nil).Export(ctx, Request{PlayerID: "alice", From: start, To: start.Add(-time.Hour)})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidRange)
+	})
+}
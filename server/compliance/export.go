@@ -0,0 +1,192 @@
+package compliance
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/domain/wallet"
+)
+
+// ErrBadSignature is returned when a manifest wasn't signed with the given key, or was changed since
+var ErrBadSignature = errors.New("manifest signature does not match")
+
+// Manifest describes an export. It holds the hash of the CSV file and is signed with the operator's key,
+// so neither can be altered without it showing.
+type Manifest struct {
+	PlayerID    string         `json:"playerId"`
+	TableID     string         `json:"tableId,omitempty"` // Empty when the export covers every table
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Rows        int            `json:"rows"`
+	Totals      map[string]int `json:"totals"`    // Sum of the amounts by action
+	File        string         `json:"file"`      // Name of the CSV file in the archive
+	SHA256      string         `json:"sha256"`    // Of the CSV file, hex encoded
+	PublicKey   string         `json:"publicKey"` // Ed25519 key the manifest is signed with, base64 encoded
+	Signature   string         `json:"signature,omitempty"`
+}
+
+// signedBytes is what the signature covers: the manifest's JSON without the signature
+func (m Manifest) signedBytes() ([]byte, error) {
+	m.Signature = ""
+	return json.Marshal(m)
+}
+
+// Verify checks that the manifest was signed with the key, which regulators get from the operator rather
+// than trusting the one the manifest names
+func (m Manifest) Verify(key ed25519.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return ErrBadSignature
+	}
+	data, err := m.signedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, data, signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Export is a CSV file of monetary actions with its signed manifest
+type Export struct {
+	CSV      []byte
+	Manifest Manifest
+}
+
+// WriteZip writes the export as a zip archive holding the CSV file and manifest.json
+func (e Export) WriteZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	file, err := archive.Create(e.Manifest.File)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(e.CSV); err != nil {
+		return err
+	}
+
+	manifest, err := archive.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(manifest)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e.Manifest); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// Exporter generates signed exports from the wallet ledger and the table events
+type Exporter struct {
+	Wallet     wallet.Wallet         // nil when bankrolls aren't kept in a wallet, exports then only hold hands
+	Store      events.EventStore     // nil without an event store
+	LiveEvents func() []events.Event // Events of the tables in memory, including those the store has yet to get
+	Key        ed25519.PrivateKey    // Signs the manifests
+	Now        func() time.Time      // time.Now when nil
+}
+
+// NewExporter creates an exporter signing with the key
+func NewExporter(key ed25519.PrivateKey, w wallet.Wallet, store events.EventStore, liveEvents func() []events.Event) *Exporter {
+	return &Exporter{Wallet: w, Store: store, LiveEvents: liveEvents, Key: key}
+}
+
+// Export generates the signed export of the request
+func (x *Exporter) Export(ctx context.Context, req Request) (Export, error) {
+	if req.To.Before(req.From) {
+		return Export{}, ErrInvalidRange
+	}
+
+	tableEvents, err := x.events(ctx, req.TableID)
+	if err != nil {
+		return Export{}, err
+	}
+
+	var transactions []wallet.Transaction
+	if x.Wallet != nil {
+		transactions = x.Wallet.Transactions(req.PlayerID)
+	}
+
+	rows := Rows(req, transactions, tableEvents)
+
+	var file bytes.Buffer
+	if err := WriteCSV(&file, rows); err != nil {
+		return Export{}, err
+	}
+
+	now := time.Now
+	if x.Now != nil {
+		now = x.Now
+	}
+
+	totals := make(map[string]int)
+	for _, row := range rows {
+		totals[row.Action] += row.Amount
+	}
+	sum := sha256.Sum256(file.Bytes())
+
+	manifest := Manifest{
+		PlayerID:    req.PlayerID,
+		TableID:     req.TableID,
+		From:        req.From.UTC(),
+		To:          req.To.UTC(),
+		GeneratedAt: now().UTC(),
+		Rows:        len(rows),
+		Totals:      totals,
+		File:        fmt.Sprintf("actions-%s.csv", req.PlayerID),
+		SHA256:      hex.EncodeToString(sum[:]),
+		PublicKey:   base64.StdEncoding.EncodeToString(x.Key.Public().(ed25519.PublicKey)),
+	}
+
+	data, err := manifest.signedBytes()
+	if err != nil {
+		return Export{}, err
+	}
+	manifest.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(x.Key, data))
+
+	return Export{CSV: file.Bytes(), Manifest: manifest}, nil
+}
+
+// events loads the events of the table, or of every table, from the store and from memory
+func (x *Exporter) events(ctx context.Context, tableID string) ([]events.Event, error) {
+	loaded := []events.Event{}
+
+	if x.Store != nil {
+		tableIDs := []string{tableID}
+		if tableID == "" {
+			var err error
+			if tableIDs, err = x.Store.Tables(ctx); err != nil {
+				return nil, fmt.Errorf("could not list the stored tables: %w", err)
+			}
+		}
+
+		for _, id := range tableIDs {
+			stored, err := x.Store.LoadEvents(ctx, id, 1)
+			if err != nil {
+				return nil, fmt.Errorf("could not load the events of table %s: %w", id, err)
+			}
+			for _, event := range stored {
+				loaded = append(loaded, event.Event)
+			}
+		}
+	}
+
+	if x.LiveEvents != nil {
+		loaded = append(loaded, x.LiveEvents()...)
+	}
+	return loaded, nil
+}
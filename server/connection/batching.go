@@ -0,0 +1,71 @@
+package connection
+
+import (
+	"bytes"
+	"time"
+)
+
+// DefaultBatchWindow is how long a batching client's writer waits for more frames after the first one
+const DefaultBatchWindow = 5 * time.Millisecond
+
+// Batcher coalesces the frames queued for a client in quick succession, such as the events of a phase change,
+// into one frame holding a JSON array of their envelopes. It serves a single client's writer.
+type Batcher struct {
+	Window   time.Duration // How long to wait for more frames after the first one
+	MaxBytes int           // Size above which no more frames join a batch, zero for no limit
+
+	next *Message // A frame left out of the previous batch for lack of room
+}
+
+// Next waits for a frame on the queue and returns it batched with those queued within the window, along with
+// the number of envelopes in the batch. It returns false once the queue is closed and every frame taken from
+// it was returned.
+func (b *Batcher) Next(queue <-chan Message) (Message, int, bool) {
+	first, ok := b.take(queue)
+	if !ok {
+		return Message{}, 0, false
+	}
+
+	frames := [][]byte{first.Data}
+	size := len(first.Data) + 2 // The brackets of the array
+
+	timer := time.NewTimer(b.Window)
+	defer timer.Stop()
+
+collect:
+	for {
+		select {
+		case message, ok := <-queue:
+			if !ok {
+				break collect
+			}
+			if b.MaxBytes > 0 && size+len(message.Data)+1 > b.MaxBytes {
+				b.next = &message
+				break collect
+			}
+			frames = append(frames, message.Data)
+			size += len(message.Data) + 1
+		case <-timer.C:
+			break collect
+		}
+	}
+
+	batch := make([]byte, 0, size)
+	batch = append(batch, '[')
+	batch = append(batch, bytes.Join(frames, []byte{','})...)
+	batch = append(batch, ']')
+
+	return Message{Data: batch, Ctx: first.Ctx, Queued: first.Queued}, len(frames), true
+}
+
+// take returns the frame left over from the previous batch, or the next one on the queue
+func (b *Batcher) take(queue <-chan Message) (Message, bool) {
+	if b.next != nil {
+		message := *b.next
+		b.next = nil
+		return message, true
+	}
+
+	message, ok := <-queue
+	return message, ok
+}
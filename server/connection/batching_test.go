@@ -0,0 +1,80 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcher(t *testing.T) {
+	queue := func(frames ...string) chan Message {
+		q := make(chan Message, len(frames))
+		for _, frame := range frames {
+			q <- Message{Data: []byte(frame)}
+		}
+		return q
+	}
+
+	t.Run("Frames queued together are sent as one array", func(t *testing.T) {
+		// Setup
+		q := queue(`{"name":"PHASE_CHANGED"}`, `{"name":"BETTING_ROUND_STARTED"}`, `{"name":"PLAYER_TURN_STARTED"}`)
+		batcher := &Batcher{Window: 10 * time.Millisecond}
+
+		// Act
+		batch, envelopes, ok := batcher.Next(q)
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, 3, envelopes)
+		assert.JSONEq(t, `[{"name":"PHASE_CHANGED"},{"name":"BETTING_ROUND_STARTED"},{"name":"PLAYER_TURN_STARTED"}]`, string(batch.Data))
+	})
+
+	t.Run("Frames queued after the window go in the next batch", func(t *testing.T) {
+		// Setup
+		q := queue(`{"name":"PLAYER_FOLDED"}`)
+		batcher := &Batcher{Window: time.Millisecond}
+
+		// Act
+		first, _, _ := batcher.Next(q)
+		q <- Message{Data: []byte(`{"name":"POT_CHANGED"}`)}
+		second, _, _ := batcher.Next(q)
+
+		// Assert
+		assert.Equal(t, `[{"name":"PLAYER_FOLDED"}]`, string(first.Data))
+		assert.Equal(t, `[{"name":"POT_CHANGED"}]`, string(second.Data))
+	})
+
+	t.Run("Batches stay under the size limit", func(t *testing.T) {
+		// Setup
+		q := queue(`{"n":1}`, `{"n":2}`, `{"n":3}`)
+		batcher := &Batcher{Window: 10 * time.Millisecond, MaxBytes: len(`[{"n":1},{"n":2}]`)}
+
+		// Act
+		first, _, _ := batcher.Next(q)
+		second, envelopes, ok := batcher.Next(q)
+
+		// Assert
+		assert.Equal(t, `[{"n":1},{"n":2}]`, string(first.Data))
+		require.True(t, ok)
+		assert.Equal(t, 1, envelopes)
+		assert.Equal(t, `[{"n":3}]`, string(second.Data))
+	})
+
+	t.Run("Frames queued before the client left are still sent", func(t *testing.T) {
+		// Setup
+		q := queue(`{"n":1}`, `{"n":2}`)
+		close(q)
+		batcher := &Batcher{Window: time.Hour}
+
+		// Act
+		batch, _, ok := batcher.Next(q)
+		_, _, open := batcher.Next(q)
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, `[{"n":1},{"n":2}]`, string(batch.Data))
+		assert.False(t, open)
+	})
+}
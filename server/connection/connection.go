@@ -1,20 +1,42 @@
 package connection
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
 )
 
+// Message is an outbound frame queued for a client
+type Message struct {
+	Data   []byte
+	Ctx    context.Context // Trace context of the dispatch that produced the frame
+	Queued time.Time       // When the frame was handed to the client's writer, zero if unknown
+}
+
+// Identity is who an ID token of the identity provider says the client is
+type Identity struct {
+	PlayerID  string
+	Name      string    // May be empty
+	ExpiresAt time.Time // The client must send a fresh token before then
+}
+
 // Client represents a connected player
 type Client struct {
 	ID       string
-	Conn     *websocket.Conn
-	Send     chan []byte
+	Conn     *websocket.Conn // nil for clients of the gRPC API
+	Send     chan Message
 	Player   *domain.Player // Links to domain.Player.ID
 	TableIDs []string       // Tables the player is currently on
+	Identity *Identity      // Proven by an ID token, nil when the client didn't send one
+
+	Spectating []string // Tables watched without a seat, they only get public events
+
+	Excluded []events.Category // Optional event categories the client opted out of
 }
 
 // Manager handles all client connections
@@ -24,6 +46,11 @@ type Manager struct {
 	Register   chan *Client
 	Unregister chan *Client
 	mutex      sync.RWMutex
+
+	// Sessions outlive connections for a grace period, so dropped players can resume them
+	sessions       map[string]*session // By token
+	playerSessions map[string]string   // Player IDs to tokens
+	grace          time.Duration
 }
 
 // NewManager creates a new connection manager
@@ -33,6 +60,10 @@ func NewManager() *Manager {
 		playerMap:  make(map[string]string),
 		Register:   make(chan *Client), // Updated to match the capitalized field
 		Unregister: make(chan *Client), // Updated to match the capitalized field
+
+		sessions:       make(map[string]*session),
+		playerSessions: make(map[string]string),
+		grace:          DefaultReconnectGrace,
 	}
 }
 
@@ -50,7 +81,8 @@ func (m *Manager) Start() {
 		case client := <-m.Unregister:
 			m.mutex.Lock()
 			if _, ok := m.clients[client.ID]; ok {
-				if client.Player != nil {
+				// The player may already be back on another connection
+				if client.Player != nil && m.playerMap[client.Player.ID] == client.ID {
 					delete(m.playerMap, client.Player.ID)
 				}
 				delete(m.clients, client.ID)
@@ -61,16 +93,31 @@ func (m *Manager) Start() {
 	}
 }
 
+// CloseAll unregisters every client, their connections close once the frames already queued are sent
+func (m *Manager) CloseAll() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for id, client := range m.clients {
+		delete(m.clients, id)
+		close(client.Send)
+	}
+	m.playerMap = make(map[string]string)
+}
+
 // SendToPlayer sends a message to a specific player
-func (m *Manager) SendToPlayer(playerID string, message []byte) bool {
+func (m *Manager) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
 	if connID, exists := m.playerMap[playerID]; exists {
 		fmt.Println("found", playerID)
 		if client, ok := m.clients[connID]; ok {
+			if !newEnvelopeFilter(message).wants(client) {
+				return true // Delivered as far as the client is concerned
+			}
 			fmt.Println("sending message to player", playerID)
-			client.Send <- message
+			client.Send <- Message{Data: message, Ctx: ctx, Queued: time.Now()}
 			fmt.Println("message sent to player", playerID)
 			return true
 		}
@@ -81,14 +128,17 @@ func (m *Manager) SendToPlayer(playerID string, message []byte) bool {
 }
 
 // SendToTable sends a message to all players at a table
-func (m *Manager) SendToTable(tableID string, message []byte) {
+func (m *Manager) SendToTable(ctx context.Context, tableID string, message []byte) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	filter := newEnvelopeFilter(message)
 	for _, client := range m.clients {
 		for _, id := range client.TableIDs {
 			if id == tableID {
-				client.Send <- message
+				if filter.wants(client) {
+					client.Send <- Message{Data: message, Ctx: ctx, Queued: time.Now()}
+				}
 				break // Send only once even if the client is at the table multiple times
 			}
 		}
@@ -130,6 +180,21 @@ func (m *Manager) RemoveTableFromClient(clientID string, tableID string) bool {
 	return false
 }
 
+// RemoveTable removes a table ID from every client, once the table is gone
+func (m *Manager) RemoveTable(tableID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, client := range m.clients {
+		for i, id := range client.TableIDs {
+			if id == tableID {
+				client.TableIDs = append(client.TableIDs[:i], client.TableIDs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
 // IsClientAtTable checks if a client is at a specific table
 func (m *Manager) IsClientAtTable(clientID string, tableID string) bool {
 	m.mutex.RLock()
@@ -0,0 +1,168 @@
+package connection
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// DefaultReconnectGrace is how long a dropped player may take to reconnect before their tables act for them
+const DefaultReconnectGrace = 30 * time.Second
+
+var (
+	ErrSessionNotFound = errors.New("session not found or expired")
+	ErrSessionInUse    = errors.New("session is still connected")
+)
+
+// session keeps a player's identity and tables across connections
+type session struct {
+	token    string
+	player   *domain.Player
+	clientID string // Connection currently holding the session, empty while the player is disconnected
+	tableIDs []string
+	expiry   *time.Timer // Runs out the grace period while the player is disconnected
+}
+
+// SetReconnectGrace sets how long sessions survive a disconnection, zero ends them with the connection
+func (m *Manager) SetReconnectGrace(grace time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.grace = grace
+}
+
+// ReconnectGrace returns how long sessions survive a disconnection
+func (m *Manager) ReconnectGrace() time.Duration {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.grace
+}
+
+// StartSession issues a token the client can resume its player's session with, replacing any previous one
+func (m *Manager) StartSession(client *Client) (string, error) {
+	if client.Player == nil {
+		return "", errors.New("client has no player")
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.endSession(client.Player.ID)
+	m.sessions[token] = &session{token: token, player: client.Player, clientID: client.ID}
+	m.playerSessions[client.Player.ID] = token
+
+	return token, nil
+}
+
+// HoldSession keeps the session of a disconnecting client for the grace period. onExpire runs if the player
+// doesn't resume it in time. Returns false when there is no session to hold, the player is gone for good.
+func (m *Manager) HoldSession(client *Client, onExpire func()) bool {
+	if client.Player == nil {
+		return false
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	token, exists := m.playerSessions[client.Player.ID]
+	if !exists {
+		return false
+	}
+
+	s := m.sessions[token]
+	if s.clientID != client.ID {
+		return false
+	}
+	if m.grace <= 0 {
+		m.endSession(client.Player.ID)
+		return false
+	}
+
+	s.clientID = ""
+	s.tableIDs = slices.Clone(client.TableIDs)
+	s.expiry = time.AfterFunc(m.grace, func() {
+		m.mutex.Lock()
+		expired := m.sessions[token] == s && s.clientID == ""
+		if expired {
+			m.endSession(s.player.ID)
+		}
+		m.mutex.Unlock()
+
+		if expired {
+			onExpire()
+		}
+	})
+
+	return true
+}
+
+// ResumeSession binds a held session to a new connection: the client gets its player and tables back.
+// The token is used up, the returned one resumes the session next time.
+func (m *Manager) ResumeSession(client *Client, token string) (string, error) {
+	newToken, err := newSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, exists := m.sessions[token]
+	if !exists {
+		return "", ErrSessionNotFound
+	}
+	if s.clientID != "" {
+		return "", ErrSessionInUse
+	}
+	// Signed-in clients may only resume their own player's session
+	if client.Identity != nil && client.Identity.PlayerID != s.player.ID {
+		return "", ErrSessionNotFound
+	}
+
+	s.expiry.Stop()
+	delete(m.sessions, token)
+
+	s.token = newToken
+	s.clientID = client.ID
+	s.expiry = nil
+	m.sessions[newToken] = s
+	m.playerSessions[s.player.ID] = newToken
+
+	client.Player = s.player
+	client.TableIDs = s.tableIDs
+	m.playerMap[s.player.ID] = client.ID
+
+	return newToken, nil
+}
+
+// endSession forgets a player's session, the caller holds the lock
+func (m *Manager) endSession(playerID string) {
+	token, exists := m.playerSessions[playerID]
+	if !exists {
+		return
+	}
+
+	if s := m.sessions[token]; s.expiry != nil {
+		s.expiry.Stop()
+	}
+	delete(m.sessions, token)
+	delete(m.playerSessions, playerID)
+}
+
+func newSessionToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
@@ -0,0 +1,100 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions(t *testing.T) {
+	setup := func(grace time.Duration) (*Manager, *Client) {
+		m := NewManager()
+		m.SetReconnectGrace(grace)
+		client := &Client{ID: "conn-1", Player: &domain.Player{ID: "player-1"}, TableIDs: []string{"table-1"}}
+		m.clients[client.ID] = client
+		m.playerMap["player-1"] = client.ID
+		return m, client
+	}
+
+	t.Run("A reconnecting client gets its player and tables back", func(t *testing.T) {
+		// Setup
+		m, client := setup(time.Minute)
+		token, err := m.StartSession(client)
+		require.NoError(t, err)
+		require.True(t, m.HoldSession(client, func() { t.Error("session expired") }))
+
+		// Act
+		reconnected := &Client{ID: "conn-2"}
+		newToken, err := m.ResumeSession(reconnected, token)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, token, newToken)
+		assert.Equal(t, "player-1", reconnected.Player.ID)
+		assert.Equal(t, []string{"table-1"}, reconnected.TableIDs)
+		assert.Equal(t, "conn-2", m.playerMap["player-1"])
+	})
+
+	t.Run("Tokens can only be used once", func(t *testing.T) {
+		// Setup
+		m, client := setup(time.Minute)
+		token, _ := m.StartSession(client)
+		m.HoldSession(client, func() {})
+		_, err := m.ResumeSession(&Client{ID: "conn-2"}, token)
+		require.NoError(t, err)
+
+		// Act
+		_, err = m.ResumeSession(&Client{ID: "conn-3"}, token)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("A connected session can't be taken over", func(t *testing.T) {
+		// Setup
+		m, client := setup(time.Minute)
+		token, _ := m.StartSession(client)
+
+		// Act
+		_, err := m.ResumeSession(&Client{ID: "conn-2"}, token)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSessionInUse)
+	})
+
+	t.Run("Sessions expire after the grace period", func(t *testing.T) {
+		// Setup
+		m, client := setup(10 * time.Millisecond)
+		token, _ := m.StartSession(client)
+		expired := make(chan struct{})
+
+		// Act
+		m.HoldSession(client, func() { close(expired) })
+
+		// Assert
+		select {
+		case <-expired:
+		case <-time.After(time.Second):
+			t.Fatal("session did not expire")
+		}
+		_, err := m.ResumeSession(&Client{ID: "conn-2"}, token)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+
+	t.Run("Sessions end with the connection without a grace period", func(t *testing.T) {
+		// Setup
+		m, client := setup(0)
+		token, _ := m.StartSession(client)
+
+		// Act
+		held := m.HoldSession(client, func() {})
+
+		// Assert
+		assert.False(t, held)
+		_, err := m.ResumeSession(&Client{ID: "conn-2"}, token)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
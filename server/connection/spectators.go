@@ -0,0 +1,74 @@
+package connection
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// AddSpectator makes a client watch a table without a seat, it reports whether the client is connected
+func (m *Manager) AddSpectator(clientID string, tableID string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	client, ok := m.clients[clientID]
+	if !ok {
+		return false
+	}
+	if !slices.Contains(client.Spectating, tableID) {
+		client.Spectating = append(client.Spectating, tableID)
+	}
+	return true
+}
+
+// RemoveSpectator stops a client watching a table, it reports whether the client was watching it
+func (m *Manager) RemoveSpectator(clientID string, tableID string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	client, ok := m.clients[clientID]
+	if !ok {
+		return false
+	}
+	i := slices.Index(client.Spectating, tableID)
+	if i < 0 {
+		return false
+	}
+	client.Spectating = slices.Delete(client.Spectating, i, i+1)
+	return true
+}
+
+// IsSpectating checks if a client watches a table without a seat
+func (m *Manager) IsSpectating(clientID string, tableID string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	client, ok := m.clients[clientID]
+	return ok && slices.Contains(client.Spectating, tableID)
+}
+
+// Publish sends a public envelope to the clients spectating the table, so the manager can stand
+// next to the Server-Sent Events hub as a spectator stream
+func (m *Manager) Publish(tableID string, eventID string, eventName string, data []byte) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	filter := eventFilter(eventName)
+	for _, client := range m.clients {
+		if slices.Contains(client.Spectating, tableID) && filter.wants(client) {
+			client.Send <- Message{Data: data, Ctx: context.Background(), Queued: time.Now()}
+		}
+	}
+}
+
+// CloseTable stops every client spectating a table, once the table is gone
+func (m *Manager) CloseTable(tableID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, client := range m.clients {
+		if i := slices.Index(client.Spectating, tableID); i >= 0 {
+			client.Spectating = slices.Delete(client.Spectating, i, i+1)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpectators(t *testing.T) {
+	setup := func() (*Manager, *Client, *Client) {
+		m := NewManager()
+		seated := &Client{ID: "conn-1", Send: make(chan Message, 4), Player: &domain.Player{ID: "player-1"}, TableIDs: []string{"table-1"}}
+		spectator := &Client{ID: "conn-2", Send: make(chan Message, 4)}
+		m.clients[seated.ID] = seated
+		m.clients[spectator.ID] = spectator
+		m.playerMap["player-1"] = seated.ID
+		return m, seated, spectator
+	}
+
+	t.Run("Spectators get public envelopes but not table ones", func(t *testing.T) {
+		// Setup
+		m, seated, spectator := setup()
+		assert.True(t, m.AddSpectator(spectator.ID, "table-1"))
+
+		// Act
+		m.Publish("table-1", "evt-1", "HAND_STARTED", []byte("public"))
+		m.SendToTable(t.Context(), "table-1", []byte("seated"))
+
+		// Assert
+		assert.True(t, m.IsSpectating(spectator.ID, "table-1"))
+		assert.Len(t, spectator.Send, 1)
+		assert.Equal(t, []byte("public"), (<-spectator.Send).Data)
+		assert.Len(t, seated.Send, 1)
+		assert.Equal(t, []byte("seated"), (<-seated.Send).Data)
+	})
+
+	t.Run("A client stops receiving once it stops spectating", func(t *testing.T) {
+		// Setup
+		m, _, spectator := setup()
+		m.AddSpectator(spectator.ID, "table-1")
+
+		// Act
+		removed := m.RemoveSpectator(spectator.ID, "table-1")
+		m.Publish("table-1", "evt-1", "HAND_STARTED", []byte("public"))
+
+		// Assert
+		assert.True(t, removed)
+		assert.False(t, m.RemoveSpectator(spectator.ID, "table-1"))
+		assert.Empty(t, spectator.Send)
+	})
+
+	t.Run("Closing a table drops its spectators", func(t *testing.T) {
+		// Setup
+		m, _, spectator := setup()
+		m.AddSpectator(spectator.ID, "table-1")
+		m.AddSpectator(spectator.ID, "table-2")
+
+		// Act
+		m.CloseTable("table-1")
+
+		// Assert
+		assert.Equal(t, []string{"table-2"}, spectator.Spectating)
+	})
+
+	t.Run("Unknown clients can't spectate", func(t *testing.T) {
+		// Setup
+		m, _, _ := setup()
+
+		// Act
+		added := m.AddSpectator("conn-unknown", "table-1")
+
+		// Assert
+		assert.False(t, added)
+	})
+}
@@ -0,0 +1,57 @@
+package connection
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// SetExcludedCategories sets the optional event categories a client doesn't want, replacing the previous
+// ones. It reports whether the client is connected.
+func (m *Manager) SetExcludedCategories(clientID string, categories []events.Category) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	client, ok := m.clients[clientID]
+	if !ok {
+		return false
+	}
+	client.Excluded = slices.Clone(categories)
+	return true
+}
+
+// envelopeFilter decides which clients get an envelope, the envelope is only decoded if a client filters
+type envelopeFilter struct {
+	message  []byte
+	category events.Category
+	decoded  bool
+}
+
+func newEnvelopeFilter(message []byte) *envelopeFilter {
+	return &envelopeFilter{message: message}
+}
+
+// eventFilter filters an envelope whose event name is already known
+func eventFilter(eventName string) *envelopeFilter {
+	return &envelopeFilter{category: events.CategoryOf(eventName), decoded: true}
+}
+
+// wants reports whether the client accepts the envelope
+func (f *envelopeFilter) wants(client *Client) bool {
+	if len(client.Excluded) == 0 {
+		return true
+	}
+
+	if !f.decoded {
+		var envelope struct {
+			Name string `json:"name"`
+		}
+		// Frames that aren't envelopes fall in CategoryGame, which is always sent
+		_ = json.Unmarshal(f.message, &envelope)
+		f.category = events.CategoryOf(envelope.Name)
+		f.decoded = true
+	}
+
+	return !slices.Contains(client.Excluded, f.category)
+}
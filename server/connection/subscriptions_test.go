@@ -0,0 +1,78 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludedCategories(t *testing.T) {
+	setup := func() (*Manager, *Client, *Client) {
+		m := NewManager()
+		full := &Client{ID: "conn-1", Send: make(chan Message, 4), Player: &domain.Player{ID: "player-1"}, TableIDs: []string{"table-1"}}
+		light := &Client{ID: "conn-2", Send: make(chan Message, 4), Player: &domain.Player{ID: "player-2"}, TableIDs: []string{"table-1"}}
+		m.clients[full.ID] = full
+		m.clients[light.ID] = light
+		m.playerMap["player-1"] = full.ID
+		m.playerMap["player-2"] = light.ID
+		return m, full, light
+	}
+
+	t.Run("Excluded categories are not sent to the client", func(t *testing.T) {
+		// Setup
+		m, full, light := setup()
+		assert.True(t, m.SetExcludedCategories(light.ID, []events.Category{events.CategoryPot}))
+
+		// Act
+		m.SendToTable(t.Context(), "table-1", []byte(`{"name":"POT_CHANGED","payload":{}}`))
+		delivered := m.SendToPlayer(t.Context(), "player-2", []byte(`{"name":"BETS_SWEPT_INTO_POT","payload":{}}`))
+
+		// Assert
+		assert.True(t, delivered)
+		assert.Len(t, full.Send, 1)
+		assert.Empty(t, light.Send)
+	})
+
+	t.Run("Other events still reach the client", func(t *testing.T) {
+		// Setup
+		m, _, light := setup()
+		m.SetExcludedCategories(light.ID, []events.Category{events.CategoryPot, events.CategoryDealer})
+
+		// Act
+		m.SendToTable(t.Context(), "table-1", []byte(`{"name":"PLAYER_FOLDED","payload":{}}`))
+		m.SendToTable(t.Context(), "table-1", []byte(`{"name":"ENVELOPE_CHUNK","id":"evt-1"}`))
+
+		// Assert
+		assert.Len(t, light.Send, 2)
+	})
+
+	t.Run("Spectators can opt out too", func(t *testing.T) {
+		// Setup
+		m, _, _ := setup()
+		spectator := &Client{ID: "conn-3", Send: make(chan Message, 4)}
+		m.clients[spectator.ID] = spectator
+		m.AddSpectator(spectator.ID, "table-1")
+		m.SetExcludedCategories(spectator.ID, []events.Category{events.CategoryDealer})
+
+		// Act
+		m.Publish("table-1", "evt-1", "DECK_SHUFFLED", []byte("shuffled"))
+		m.Publish("table-1", "evt-2", "HAND_STARTED", []byte("started"))
+
+		// Assert
+		assert.Len(t, spectator.Send, 1)
+		assert.Equal(t, []byte("started"), (<-spectator.Send).Data)
+	})
+
+	t.Run("Unknown clients are reported", func(t *testing.T) {
+		// Setup
+		m, _, _ := setup()
+
+		// Act
+		ok := m.SetExcludedCategories("conn-9", []events.Category{events.CategoryPot})
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// unsafeCardLoggingFromEnv reads POKER_LOG_UNSAFE_CARDS, which logs card values instead of masking them.
+// Never set it on a server real players use.
+func unsafeCardLoggingFromEnv() bool {
+	value := os.Getenv("POKER_LOG_UNSAFE_CARDS")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid POKER_LOG_UNSAFE_CARDS: %v", err)
+	}
+	if enabled {
+		log.Printf("POKER_LOG_UNSAFE_CARDS is set, hole cards are written to the logs")
+	}
+	return enabled
+}
+
+// handleTableSnapshot returns a redacted snapshot of a table's state, for debugging live tables
+func (s *Server) handleTableSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	engine, err := s.lobby.GetEngine(r.URL.Query().Get("tableId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(engine.Snapshot())
+}
+
+// handleTableStateMachine renders the hand state machine with the table's active hand highlighted.
+// Use ?format=dot for Graphviz, Mermaid is the default.
+func (s *Server) handleTableStateMachine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	table, err := s.lobby.GetTable(r.URL.Query().Get("tableId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var current domain.HandPhase
+	if hand := table.Snapshot().ActiveHand; hand != nil {
+		current = hand.Phase
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	switch r.URL.Query().Get("format") {
+	case "", "mermaid":
+		w.Write([]byte(domain.HandStateMachineMermaid(current)))
+	case "dot":
+		w.Write([]byte(domain.HandStateMachineDOT(current)))
+	default:
+		http.Error(w, "Unknown format, use mermaid or dot", http.StatusBadRequest)
+	}
+}
@@ -1,45 +1,175 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/lazharichir/poker/domain/events"
-	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/domain/logging"
+	"github.com/lazharichir/poker/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EventEnvelope wraps an event with its name for client consumption
 type EventEnvelope struct {
-	Name    string          `json:"name"`
-	Payload json.RawMessage `json:"payload"`
+	ID         string          `json:"id,omitempty"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload"`
+	ServerTime int64           `json:"serverTime,omitempty"` // Unix milliseconds when the envelope was built
+	Deadline   *DeadlineHint   `json:"deadline,omitempty"`
+
+	// Positions in the table's and the hand's event streams, numbered by the table as it emits them.
+	// Clients apply a table's events in this order. Events only some players see are numbered too, so the
+	// numbers skip: PrevTableSeq is the TableSeq of the previous envelope sent to the whole table, and a
+	// client whose last one from the table differs has missed events and should resync from a snapshot.
+	TableSeq     uint64 `json:"tableSeq,omitempty"`
+	HandSeq      uint64 `json:"handSeq,omitempty"`
+	PrevTableSeq uint64 `json:"prevTableSeq,omitempty"` // Zero for the first envelope sent to the table, or not sent to it
+}
+
+// DeadlineHint lets clients run countdowns that don't depend on their own clock being right.
+// Clients should count down RemainingMs from when they received the envelope, or convert At
+// to their clock with the offset measured by TIME_SYNC.
+type DeadlineHint struct {
+	At          int64 `json:"at"`          // Unix milliseconds, server clock
+	RemainingMs int64 `json:"remainingMs"` // Time left when the envelope was built
+}
+
+// newDeadlineHint returns a hint for deadline-bearing events, nil otherwise
+func newDeadlineHint(event events.Event, now time.Time) *DeadlineHint {
+	deadline, ok := events.ExtractDeadline(event)
+	if !ok {
+		return nil
+	}
+
+	return &DeadlineHint{
+		At:          deadline.UnixMilli(),
+		RemainingMs: max(deadline.Sub(now).Milliseconds(), 0),
+	}
+}
+
+// Clients delivers envelopes to connected players. The connection manager reaches the clients of this
+// instance, a cluster node also reaches those connected to the other instances.
+type Clients interface {
+	SendToPlayer(ctx context.Context, playerID string, message []byte) bool
+	SendToTable(ctx context.Context, tableID string, message []byte)
+	RemoveTable(tableID string)
+}
+
+// Spectators delivers public envelopes to the spectator streams of a table
+type Spectators interface {
+	Publish(tableID string, eventID string, eventName string, data []byte)
+	CloseTable(tableID string)
+}
+
+// SpectatorFanOut delivers to several kinds of spectator streams, e.g. Server-Sent Events and websockets
+type SpectatorFanOut []Spectators
+
+// Publish delivers to every stream
+func (f SpectatorFanOut) Publish(tableID string, eventID string, eventName string, data []byte) {
+	for _, spectators := range f {
+		spectators.Publish(tableID, eventID, eventName, data)
+	}
+}
+
+// CloseTable closes the table on every stream
+func (f SpectatorFanOut) CloseTable(tableID string) {
+	for _, spectators := range f {
+		spectators.CloseTable(tableID)
+	}
 }
 
 // Dispatcher handles routing events to clients
 type Dispatcher struct {
-	connMgr *connection.Manager
+	connMgr     Clients
+	scopes      *tracing.Scopes
+	broadcaster Spectators
+	policies    RoutingPolicies
+	payloads    *PayloadStats
+	maxEnvelope int       // Envelopes above this many bytes reach players in chunks, zero never chunks
+	handViews   HandViews // nil pushes no hand views
+
+	seqMu        sync.Mutex
+	lastTableSeq map[string]uint64 // By table, the TableSeq of the latest envelope sent to the whole table
 }
 
 // NewDispatcher creates a new event dispatcher
-func NewDispatcher(connMgr *connection.Manager) *Dispatcher {
+func NewDispatcher(connMgr Clients, scopes *tracing.Scopes, broadcaster Spectators) *Dispatcher {
 	return &Dispatcher{
-		connMgr: connMgr,
+		connMgr:     connMgr,
+		scopes:      scopes,
+		broadcaster: broadcaster,
+		policies:    DefaultRoutingPolicies(),
+		payloads:    NewPayloadStats(),
+
+		lastTableSeq: map[string]uint64{},
 	}
 }
 
+// SetMaxEnvelopeSize sets the size in bytes above which envelopes are sent to players in chunks, zero never chunks
+func (d *Dispatcher) SetMaxEnvelopeSize(limit int) {
+	d.maxEnvelope = limit
+}
+
+// MaxEnvelopeSize returns the size in bytes above which envelopes are chunked, zero if they never are
+func (d *Dispatcher) MaxEnvelopeSize() int {
+	return d.maxEnvelope
+}
+
+// PayloadStats returns the sizes of the envelopes dispatched so far
+func (d *Dispatcher) PayloadStats() *PayloadStats {
+	return d.payloads
+}
+
+// Route sets the routing policy of an event, e.g. for events added by extensions
+func (d *Dispatcher) Route(event events.Event, policy Policy) {
+	d.policies.Register(event, policy)
+}
+
 // HandleEvent processes domain events and sends them to clients
 func (d *Dispatcher) HandleEvent(event events.Event) {
-	// Convert event to JSON for the payload
-	eventPayload, err := json.Marshal(event)
+	// Attribute the dispatch to the command that caused the event, if any
+	tableID := events.ExtractTableID(event)
+	ctx := d.scopes.Lookup(tracing.TableKey(tableID), tracing.PlayerKey(lobbyEventPlayerID(event)))
+	ctx, span := tracing.Tracer().Start(ctx, "dispatch "+event.Name(), trace.WithAttributes(
+		attribute.String("poker.event", event.Name()),
+		attribute.String("poker.event_id", events.ExtractEventID(event)),
+		attribute.String("poker.table_id", tableID),
+	))
+	defer span.End()
+
+	// Convert event to JSON for the payload, without the fields only the server may read
+	eventPayload, err := json.Marshal(events.Redact(event))
 	if err != nil {
 		log.Println("Failed to marshal event payload:", err)
 		return
 	}
 
+	audience, ok := d.policies.Resolve(event)
+	if !ok {
+		log.Println("No routing policy for event, not delivered:", logging.Event(event))
+		return
+	}
+	audience = restrictAudience(event, audience)
+
 	// Create the envelope with name and payload
+	now := time.Now()
+	seq := events.ExtractSequence(event)
 	envelope := EventEnvelope{
-		Name:    event.Name(),
-		Payload: eventPayload,
+		ID:         events.ExtractEventID(event),
+		Name:       event.Name(),
+		Payload:    eventPayload,
+		ServerTime: now.UnixMilli(),
+		Deadline:   newDeadlineHint(event, now),
+		TableSeq:   seq.Table,
+		HandSeq:    seq.Hand,
+	}
+	if audience.TableID != "" && seq.Table != 0 {
+		envelope.PrevTableSeq = d.advanceTableSeq(audience.TableID, seq.Table)
 	}
 
 	// Marshal the complete envelope
@@ -51,107 +181,67 @@ func (d *Dispatcher) HandleEvent(event events.Event) {
 
 	log.Println("Dispatching event:", event.Name())
 
-	// Route event based on type
-	switch e := event.(type) {
-	case events.PlayerEnteredLobby:
-		fmt.Println("Dispatching PlayerEnteredLobby event for player:", e.PlayerID)
-		sent := d.connMgr.SendToPlayer(e.PlayerID, envelopeData)
-		fmt.Println("PlayerEnteredLobby event sent:", sent)
-
-	case events.PlayerLeftLobby:
-		d.connMgr.SendToPlayer(e.PlayerID, envelopeData)
-
-	case events.PlayerJoinedTable:
-		// Send to all players at the table
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.HandStarted:
-		// Send to all players at the table
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.HoleCardDealt:
-		// Only send to specific player
-		d.connMgr.SendToPlayer(e.PlayerID, envelopeData)
-
-	case events.PlayerFolded:
-		// Send to all players at the table
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.PlayerLeftTable:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.PlayerChipsChanged:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.PhaseChanged:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.HandEnded:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.AntePlaced:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.ContinuationBetPlaced:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.CommunityCardSelected:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.PlayerTimedOut:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.HoleCardsDealt:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.CardBurned:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.CommunityCardDealt:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.PlayerTurnStarted:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.BettingRoundStarted:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.BettingRoundEnded:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.CommunitySelectionStarted:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
-
-	case events.CommunitySelectionEnded:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	// Oversized envelopes go to players in several frames, spectator streams have no frame limit
+	frames := [][]byte{envelopeData}
+	chunked := d.maxEnvelope > 0 && len(envelopeData) > d.maxEnvelope
+	if chunked {
+		if frames, err = chunkEnvelope(envelope.ID, envelopeData, d.maxEnvelope); err != nil {
+			log.Println("Failed to chunk event envelope:", err)
+			return
+		}
+	}
+	d.payloads.Record(envelope.Name, len(envelopeData), chunked)
 
-	case events.HandsEvaluated:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	// Spectators get the same bytes, fanned out once per table
+	if audience.Spectators && audience.TableID != "" {
+		d.broadcaster.Publish(audience.TableID, envelope.ID, envelope.Name, envelopeData)
+	}
 
-	case events.ShowdownStarted:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	for _, frame := range frames {
+		if audience.TableID != "" {
+			d.connMgr.SendToTable(ctx, audience.TableID, frame)
+		}
 
-	case events.PlayerShowedHand:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+		for _, playerID := range audience.PlayerIDs {
+			d.connMgr.SendToPlayer(ctx, playerID, frame)
+		}
+	}
 
-	case events.PotChanged:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	// The players dealt in get their view of the hand as the event left it
+	if d.handViews != nil && seq.Hand != 0 && (audience.TableID != "" || len(audience.PlayerIDs) > 0) {
+		d.pushHandViews(ctx, event, seq)
+	}
 
-	case events.PotBrokenDown:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	if audience.CloseTable {
+		d.connMgr.RemoveTable(audience.TableID)
+		d.broadcaster.CloseTable(audience.TableID)
+		d.seqMu.Lock()
+		delete(d.lastTableSeq, audience.TableID)
+		d.seqMu.Unlock()
+	}
+}
 
-	case events.PotAmountAwarded:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+// advanceTableSeq records the TableSeq of an envelope sent to a whole table, and returns the previous one
+func (d *Dispatcher) advanceTableSeq(tableID string, seq uint64) uint64 {
+	d.seqMu.Lock()
+	defer d.seqMu.Unlock()
 
-	case events.SingleWinnerDetermined:
-		d.connMgr.SendToTable(e.TableID, envelopeData)
+	prev := d.lastTableSeq[tableID]
+	d.lastTableSeq[tableID] = seq
+	return prev
+}
 
-	// Add cases for all event types, determining who should receive each event
-	default:
-		// For events without special handling, send to all players at the table
-		// if we can determine the table ID
-		if tableID := events.ExtractTableID(event); tableID != "" {
-			d.connMgr.SendToTable(tableID, envelopeData)
-		}
+// lobbyEventPlayerID returns the player a lobby event is about, as lobby events have no table
+func lobbyEventPlayerID(event events.Event) string {
+	switch e := event.(type) {
+	case events.PlayerEnteredLobby:
+		return e.PlayerID
+	case events.PlayerLeftLobby:
+		return e.PlayerID
+	case events.PlayerBanned:
+		return e.PlayerID
+	case events.PlayerUnbanned:
+		return e.PlayerID
 	}
+	return ""
 }
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// HandViewUpdate is a player's view of the hand in progress at a table. It is pushed to the players dealt in
+// after every event that changes the hand, and sent in answer to GET_HAND_VIEW.
+type HandViewUpdate struct {
+	TableID string
+	Hand    *domain.HandView `json:",omitempty"` // nil between hands
+}
+
+func (h HandViewUpdate) Name() string { return "HAND_VIEW" }
+
+// HandViews builds the views the players dealt in a hand have of it, none if the hand isn't played here.
+// It is called while the table dispatches the event, from the table's game loop.
+type HandViews func(tableID string, handID string) []domain.HandView
+
+// SetHandViews makes the dispatcher push the players' views of a hand after each of its events
+func (d *Dispatcher) SetHandViews(views HandViews) {
+	d.handViews = views
+}
+
+// pushHandViews sends each player dealt in the event's hand their view of it, as it is after the event
+func (d *Dispatcher) pushHandViews(ctx context.Context, event events.Event, seq events.Sequence) {
+	tableID := events.ExtractTableID(event)
+	views := d.handViews(tableID, events.ExtractHandID(event))
+
+	now := time.Now()
+	for _, view := range views {
+		payload, err := json.Marshal(HandViewUpdate{TableID: tableID, Hand: &view})
+		if err != nil {
+			log.Println("Failed to marshal hand view:", err)
+			return
+		}
+
+		envelope := EventEnvelope{
+			Name:       HandViewUpdate{}.Name(),
+			Payload:    payload,
+			ServerTime: now.UnixMilli(),
+			TableSeq:   seq.Table,
+			HandSeq:    seq.Hand,
+		}
+		data, err := json.Marshal(envelope)
+		if err != nil {
+			log.Println("Failed to marshal hand view envelope:", err)
+			return
+		}
+
+		frames := [][]byte{data}
+		chunked := d.maxEnvelope > 0 && len(data) > d.maxEnvelope
+		if chunked {
+			if frames, err = chunkEnvelope("view-"+events.ExtractEventID(event), data, d.maxEnvelope); err != nil {
+				log.Println("Failed to chunk hand view envelope:", err)
+				return
+			}
+		}
+		d.payloads.Record(envelope.Name, len(data), chunked)
+
+		for _, frame := range frames {
+			d.connMgr.SendToPlayer(ctx, view.PlayerID, frame)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// PayloadSize sums up the envelopes sent for one event name
+type PayloadSize struct {
+	Name       string `json:"name"`
+	Count      int    `json:"count"`
+	TotalBytes int64  `json:"totalBytes"`
+	MaxBytes   int    `json:"maxBytes"`
+	Chunked    int    `json:"chunked"` // Envelopes over the size limit, sent in chunks
+}
+
+// PayloadStats tracks the size of the envelopes built by the dispatcher, by event name
+type PayloadStats struct {
+	mu    sync.Mutex
+	sizes map[string]*PayloadSize
+}
+
+// NewPayloadStats creates empty payload statistics
+func NewPayloadStats() *PayloadStats {
+	return &PayloadStats{sizes: make(map[string]*PayloadSize)}
+}
+
+// Record counts an envelope of the given size
+func (s *PayloadStats) Record(name string, size int, chunked bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, exists := s.sizes[name]
+	if !exists {
+		stats = &PayloadSize{Name: name}
+		s.sizes[name] = stats
+	}
+
+	stats.Count++
+	stats.TotalBytes += int64(size)
+	stats.MaxBytes = max(stats.MaxBytes, size)
+	if chunked {
+		stats.Chunked++
+	}
+}
+
+// Snapshot returns the statistics of every event name, largest envelopes first
+func (s *PayloadStats) Snapshot() []PayloadSize {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make([]PayloadSize, 0, len(s.sizes))
+	for _, stats := range s.sizes {
+		snapshot = append(snapshot, *stats)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].MaxBytes != snapshot[j].MaxBytes {
+			return snapshot[i].MaxBytes > snapshot[j].MaxBytes
+		}
+		return snapshot[i].Name < snapshot[j].Name
+	})
+	return snapshot
+}
+
+// EnvelopeChunk carries a part of an envelope too large for a single frame. Clients concatenate
+// the Data of chunks 0 to Count-1 sharing the same ID, and parse the result as the envelope.
+type EnvelopeChunk struct {
+	Name  string `json:"name"` // Always ENVELOPE_CHUNK
+	ID    string `json:"id"`   // ID of the chunked event
+	Index int    `json:"index"`
+	Count int    `json:"count"`
+	Data  string `json:"data"`
+}
+
+// chunkEnvelope splits an envelope in frames of at most limit bytes of envelope data each.
+// Chunks end on character boundaries so that every Data field stays valid UTF-8.
+func chunkEnvelope(eventID string, envelope []byte, limit int) ([][]byte, error) {
+	parts := []string{}
+	for len(envelope) > 0 {
+		end := min(limit, len(envelope))
+		for end < len(envelope) && end > 0 && !utf8.RuneStart(envelope[end]) {
+			end--
+		}
+		if end == 0 {
+			end = min(limit, len(envelope))
+		}
+
+		parts = append(parts, string(envelope[:end]))
+		envelope = envelope[end:]
+	}
+
+	frames := make([][]byte, 0, len(parts))
+	for i, part := range parts {
+		frame, err := json.Marshal(EnvelopeChunk{
+			Name:  "ENVELOPE_CHUNK",
+			ID:    eventID,
+			Index: i,
+			Count: len(parts),
+			Data:  part,
+		})
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
@@ -0,0 +1,109 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/server/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadStats(t *testing.T) {
+	t.Run("Sizes are summed by event name, largest first", func(t *testing.T) {
+		// Setup
+		stats := NewPayloadStats()
+
+		// Act
+		stats.Record("ANTE_PLACED", 100, false)
+		stats.Record("ANTE_PLACED", 300, false)
+		stats.Record("HANDS_EVALUATED", 5000, true)
+
+		// Assert
+		assert.Equal(t, []PayloadSize{
+			{Name: "HANDS_EVALUATED", Count: 1, TotalBytes: 5000, MaxBytes: 5000, Chunked: 1},
+			{Name: "ANTE_PLACED", Count: 2, TotalBytes: 400, MaxBytes: 300},
+		}, stats.Snapshot())
+	})
+}
+
+func TestChunkEnvelope(t *testing.T) {
+	reassemble := func(t *testing.T, frames [][]byte) string {
+		var data strings.Builder
+		for i, frame := range frames {
+			var chunk EnvelopeChunk
+			require.NoError(t, json.Unmarshal(frame, &chunk))
+			assert.Equal(t, "ENVELOPE_CHUNK", chunk.Name)
+			assert.Equal(t, "event-1", chunk.ID)
+			assert.Equal(t, i, chunk.Index)
+			assert.Equal(t, len(frames), chunk.Count)
+			assert.True(t, utf8.ValidString(chunk.Data))
+			data.WriteString(chunk.Data)
+		}
+		return data.String()
+	}
+
+	t.Run("Chunks reassemble to the envelope", func(t *testing.T) {
+		// Setup
+		envelope := []byte(`{"name":"HANDS_EVALUATED","payload":{"players":["a","b","c"]}}`)
+
+		// Act
+		frames, err := chunkEnvelope("event-1", envelope, 16)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, frames, 4)
+		assert.Equal(t, string(envelope), reassemble(t, frames))
+	})
+
+	t.Run("Chunks never split a character", func(t *testing.T) {
+		// Setup
+		envelope := []byte(`{"name":"PLAYER_JOINED_TABLE","payload":{"playerName":"Zoë ♠♥♦♣"}}`)
+
+		// Act
+		frames, err := chunkEnvelope("event-1", envelope, 5)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, string(envelope), reassemble(t, frames))
+	})
+}
+
+func TestDispatcherPayloadLimit(t *testing.T) {
+	t.Run("Oversized envelopes reach players in chunks and spectators whole", func(t *testing.T) {
+		// Setup
+		rec := &recorder{}
+		dispatcher := NewDispatcher(rec, tracing.NewScopes(), rec)
+		dispatcher.SetMaxEnvelopeSize(64)
+
+		// Act
+		dispatcher.HandleEvent(events.AntePlaced{ID: "event-1", TableID: "table-1", PlayerID: "player-1", Amount: 10, At: time.Now()})
+
+		// Assert
+		require.Greater(t, len(rec.deliveries), 2)
+		assert.Equal(t, "spectators:table-1", rec.deliveries[0])
+		for _, delivery := range rec.deliveries[1:] {
+			assert.Equal(t, "table:table-1", delivery)
+		}
+		stats := dispatcher.PayloadStats().Snapshot()
+		require.Len(t, stats, 1)
+		assert.Equal(t, 1, stats[0].Chunked)
+	})
+
+	t.Run("Envelopes are sent whole without a limit", func(t *testing.T) {
+		// Setup
+		rec := &recorder{}
+		dispatcher := NewDispatcher(rec, tracing.NewScopes(), rec)
+
+		// Act
+		dispatcher.HandleEvent(events.AntePlaced{ID: "event-1", TableID: "table-1", PlayerID: "player-1", Amount: 10, At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"spectators:table-1", "table:table-1"}, rec.deliveries)
+		assert.Equal(t, 0, dispatcher.PayloadStats().Snapshot()[0].Chunked)
+	})
+}
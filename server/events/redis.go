@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisPublishTimeout bounds how long publishing one envelope may wait on Redis
+const redisPublishTimeout = time.Second
+
+// redisPublishQueue is how many envelopes may wait for Redis, those dispatched once it is full are dropped
+const redisPublishQueue = 4096
+
+// RedisPublisher is a dispatcher backend that publishes envelopes to Redis channels, one per table
+// and one per player, so that other services (analytics, notification workers, other nodes)
+// can follow the live stream without a websocket. Channels are <prefix>:table:<id> and <prefix>:player:<id>.
+//
+// Dispatching only queues the envelope, Start publishes them in order: a slow or unreachable Redis
+// never holds the tables up, it loses envelopes instead.
+type RedisPublisher struct {
+	client  *redis.Client
+	prefix  string
+	queue   chan redisMessage
+	dropped atomic.Int64
+}
+
+// redisMessage is an envelope waiting to be published
+type redisMessage struct {
+	channel string
+	data    []byte
+}
+
+// NewRedisPublisher creates a publisher on the given Redis client, Start publishes what it is given
+func NewRedisPublisher(client *redis.Client, prefix string) *RedisPublisher {
+	return &RedisPublisher{
+		client: client,
+		prefix: prefix,
+		queue:  make(chan redisMessage, redisPublishQueue),
+	}
+}
+
+// Start publishes the queued envelopes until ctx is done
+func (p *RedisPublisher) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-p.queue:
+			p.publish(ctx, message)
+		}
+	}
+}
+
+// TableChannel returns the channel a table's events are published to
+func (p *RedisPublisher) TableChannel(tableID string) string {
+	return p.prefix + ":table:" + tableID
+}
+
+// PlayerChannel returns the channel a player's private events are published to
+func (p *RedisPublisher) PlayerChannel(playerID string) string {
+	return p.prefix + ":player:" + playerID
+}
+
+// SendToPlayer queues the envelope for the player's channel, it reports whether there was room for it
+func (p *RedisPublisher) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
+	return p.enqueue(p.PlayerChannel(playerID), message)
+}
+
+// SendToTable queues the envelope for the table's channel
+func (p *RedisPublisher) SendToTable(ctx context.Context, tableID string, message []byte) {
+	p.enqueue(p.TableChannel(tableID), message)
+}
+
+// RemoveTable does nothing, subscribers learn the table closed from the TABLE_CLOSED envelope
+func (p *RedisPublisher) RemoveTable(tableID string) {}
+
+func (p *RedisPublisher) enqueue(channel string, message []byte) bool {
+	select {
+	case p.queue <- redisMessage{channel: channel, data: message}:
+		return true
+	default:
+		// Logged now and then, a full queue drops envelopes by the thousand
+		if dropped := p.dropped.Add(1); dropped%1000 == 1 {
+			log.Printf("Redis publishing is falling behind, dropped %d envelopes so far", dropped)
+		}
+		return false
+	}
+}
+
+func (p *RedisPublisher) publish(ctx context.Context, message redisMessage) {
+	ctx, cancel := context.WithTimeout(ctx, redisPublishTimeout)
+	defer cancel()
+
+	if err := p.client.Publish(ctx, message.channel, message.data).Err(); err != nil {
+		log.Printf("Failed to publish to Redis channel %s: %v", message.channel, err)
+	}
+}
+
+// FanOut delivers every envelope to several backends, e.g. websockets and Redis
+type FanOut []Clients
+
+// SendToPlayer delivers to every backend, it reports whether any of them reached the player
+func (f FanOut) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
+	sent := false
+	for _, clients := range f {
+		if clients.SendToPlayer(ctx, playerID, message) {
+			sent = true
+		}
+	}
+	return sent
+}
+
+// SendToTable delivers to every backend
+func (f FanOut) SendToTable(ctx context.Context, tableID string, message []byte) {
+	for _, clients := range f {
+		clients.SendToTable(ctx, tableID, message)
+	}
+}
+
+// RemoveTable tells every backend the table is gone
+func (f FanOut) RemoveTable(tableID string) {
+	for _, clients := range f {
+		clients.RemoveTable(tableID)
+	}
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPublisher(t *testing.T) {
+	t.Run("Tables and players have their own channels", func(t *testing.T) {
+		// Setup
+		publisher := NewRedisPublisher(nil, "poker:events")
+
+		// Assert
+		assert.Equal(t, "poker:events:table:table-1", publisher.TableChannel("table-1"))
+		assert.Equal(t, "poker:events:player:player-1", publisher.PlayerChannel("player-1"))
+	})
+
+	t.Run("Envelopes are queued for their channel in order", func(t *testing.T) {
+		// Setup
+		publisher := NewRedisPublisher(nil, "poker:events")
+
+		// Act
+		publisher.SendToTable(t.Context(), "table-1", []byte(`{"name":"POT_CHANGED"}`))
+		queued := publisher.SendToPlayer(t.Context(), "player-1", []byte(`{"name":"HOLE_CARDS_DEALT"}`))
+
+		// Assert
+		assert.True(t, queued)
+		assert.Equal(t, redisMessage{channel: "poker:events:table:table-1", data: []byte(`{"name":"POT_CHANGED"}`)}, <-publisher.queue)
+		assert.Equal(t, redisMessage{channel: "poker:events:player:player-1", data: []byte(`{"name":"HOLE_CARDS_DEALT"}`)}, <-publisher.queue)
+	})
+
+	t.Run("Envelopes are dropped once the queue is full, the tables don't wait", func(t *testing.T) {
+		// Setup
+		publisher := NewRedisPublisher(nil, "poker:events")
+		for range redisPublishQueue {
+			publisher.SendToTable(t.Context(), "table-1", []byte(`{}`))
+		}
+
+		// Act
+		queued := publisher.SendToPlayer(t.Context(), "player-1", []byte(`{}`))
+
+		// Assert
+		assert.False(t, queued)
+		assert.Equal(t, int64(1), publisher.dropped.Load())
+		assert.Len(t, publisher.queue, redisPublishQueue)
+	})
+}
+
+// recordingClients remembers what a backend was given
+type recordingClients struct {
+	reaches      bool // Whether the player is connected to the backend
+	toPlayers    []string
+	toTables     []string
+	removedTable string
+}
+
+func (c *recordingClients) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
+	c.toPlayers = append(c.toPlayers, playerID)
+	return c.reaches
+}
+
+func (c *recordingClients) SendToTable(ctx context.Context, tableID string, message []byte) {
+	c.toTables = append(c.toTables, tableID)
+}
+
+func (c *recordingClients) RemoveTable(tableID string) {
+	c.removedTable = tableID
+}
+
+func TestFanOut(t *testing.T) {
+	t.Run("Every backend gets every envelope", func(t *testing.T) {
+		// Setup
+		websockets, redis := &recordingClients{}, &recordingClients{}
+		fanOut := FanOut{websockets, redis}
+
+		// Act
+		fanOut.SendToTable(t.Context(), "table-1", []byte(`{}`))
+		fanOut.SendToPlayer(t.Context(), "player-1", []byte(`{}`))
+		fanOut.RemoveTable("table-1")
+
+		// Assert
+		for _, backend := range []*recordingClients{websockets, redis} {
+			assert.Equal(t, []string{"table-1"}, backend.toTables)
+			assert.Equal(t, []string{"player-1"}, backend.toPlayers)
+			assert.Equal(t, "table-1", backend.removedTable)
+		}
+	})
+
+	t.Run("A player is reached if any backend reaches them", func(t *testing.T) {
+		// Act & Assert
+		assert.True(t, FanOut{&recordingClients{}, &recordingClients{reaches: true}}.SendToPlayer(t.Context(), "player-1", []byte(`{}`)))
+		assert.False(t, FanOut{&recordingClients{}, &recordingClients{}}.SendToPlayer(t.Context(), "player-1", []byte(`{}`)))
+	})
+}
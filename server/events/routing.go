@@ -0,0 +1,183 @@
+package events
+
+import (
+	"slices"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Audience is who receives an event
+type Audience struct {
+	TableID    string   // Every player seated at the table, empty for none
+	PlayerIDs  []string // Individual players, on top of the table
+	Spectators bool     // Whether the table's spectator streams get the event
+	CloseTable bool     // The table is gone: once delivered, nobody should receive anything else from it
+}
+
+// Policy resolves the audience of an event
+type Policy func(event events.Event) Audience
+
+// RoutingPolicies maps event names to the policy that routes them.
+// Events without a policy are not delivered, so a new private event can't leak by default.
+type RoutingPolicies map[string]Policy
+
+// Register sets the policy of an event, replacing any previous one
+func (p RoutingPolicies) Register(event events.Event, policy Policy) {
+	p[event.Name()] = policy
+}
+
+// Resolve returns the audience of an event, false if no policy routes it
+func (p RoutingPolicies) Resolve(event events.Event) (Audience, bool) {
+	policy, ok := p[event.Name()]
+	if !ok {
+		return Audience{}, false
+	}
+	return policy(event), true
+}
+
+// toTable sends a public event to the players at the table and its spectators
+func toTable(event events.Event) Audience {
+	return Audience{TableID: events.ExtractTableID(event), Spectators: true}
+}
+
+// toNobody keeps an event on the server, e.g. audit entries
+func toNobody(event events.Event) Audience {
+	return Audience{}
+}
+
+// toPlayer sends a private event to a single player
+func toPlayer[E events.Event](playerID func(E) string) Policy {
+	return func(event events.Event) Audience {
+		return Audience{PlayerIDs: []string{playerID(event.(E))}}
+	}
+}
+
+// toTableAndPlayer sends a public event to the table, and to a player who may not be seated there
+func toTableAndPlayer[E events.Event](playerID func(E) string) Policy {
+	return func(event events.Event) Audience {
+		return Audience{
+			TableID:    events.ExtractTableID(event),
+			PlayerIDs:  []string{playerID(event.(E))},
+			Spectators: true,
+		}
+	}
+}
+
+// closingTable sends the last event of a table, then forgets its clients and spectators
+func closingTable(event events.Event) Audience {
+	audience := toTable(event)
+	audience.CloseTable = true
+	return audience
+}
+
+// restrictAudience narrows an audience to what the event's visibility allows,
+// so a misrouted owner-only event still can't reach the table or other players
+func restrictAudience(event events.Event, audience Audience) Audience {
+	visibility, owner := events.Classify(event)
+	if visibility != events.VisibilityOwner {
+		return audience
+	}
+
+	// A seated owner would have been reached through the table
+	if audience.TableID != "" || slices.Contains(audience.PlayerIDs, owner) {
+		return Audience{PlayerIDs: []string{owner}}
+	}
+	return Audience{}
+}
+
+// DefaultRoutingPolicies returns the policies of every domain event
+func DefaultRoutingPolicies() RoutingPolicies {
+	p := RoutingPolicies{}
+
+	// Lobby
+	p.Register(events.PlayerEnteredLobby{}, toPlayer(func(e events.PlayerEnteredLobby) string { return e.PlayerID }))
+	p.Register(events.PlayerLeftLobby{}, toPlayer(func(e events.PlayerLeftLobby) string { return e.PlayerID }))
+	p.Register(events.PlayerBanned{}, toPlayer(func(e events.PlayerBanned) string { return e.PlayerID }))
+	p.Register(events.PlayerUnbanned{}, toPlayer(func(e events.PlayerUnbanned) string { return e.PlayerID }))
+	p.Register(events.PlayerPrivacyChanged{}, toPlayer(func(e events.PlayerPrivacyChanged) string { return e.PlayerID }))
+
+	// Table, clients list new tables over HTTP so TableCreated only reaches the table's own audience
+	p.Register(events.TableCreated{}, toTable)
+	p.Register(events.PlayerJoinedTable{}, toTable)
+	p.Register(events.PlayerLeftTable{}, toTable)
+	p.Register(events.PlayerCashedOut{}, toPlayer(func(e events.PlayerCashedOut) string { return e.PlayerID }))
+	p.Register(events.PlayerChipsChanged{}, toTable)
+	p.Register(events.TableStartingSoon{}, toTable)
+	p.Register(events.TableStartCancelled{}, toTable)
+	p.Register(events.FillBotsSeated{}, toTable)
+	p.Register(events.TableClosed{}, closingTable)
+	p.Register(events.PlayerAutoPlayToggled{}, toTable)
+	p.Register(events.PlayerBlockedFromTable{}, toTableAndPlayer(func(e events.PlayerBlockedFromTable) string { return e.PlayerID }))
+	p.Register(events.PlayerUnblockedFromTable{}, toTableAndPlayer(func(e events.PlayerUnblockedFromTable) string { return e.PlayerID }))
+	p.Register(events.PlayerBlindingOff{}, toTable)
+	p.Register(events.AbsentStackBlindedOff{}, toTable)
+	p.Register(events.PlayerEliminated{}, toTableAndPlayer(func(e events.PlayerEliminated) string { return e.PlayerID }))
+	p.Register(events.BombPotStarted{}, toTable)
+	p.Register(events.AnteScaled{}, toTable)
+	p.Register(events.PlayerToppedUp{}, toTable)
+	p.Register(events.RematchProposed{}, toTable)
+	p.Register(events.RematchAccepted{}, toTable)
+	p.Register(events.RematchStarted{}, toTable)
+	p.Register(events.RematchCancelled{}, toTable)
+
+	// Only the player sees their own results
+	p.Register(events.PlayerSessionSummarized{}, toPlayer(func(e events.PlayerSessionSummarized) string { return e.PlayerID }))
+
+	// Hand
+	p.Register(events.HandStarted{}, toTable)
+	p.Register(events.PhaseChanged{}, toTable)
+	p.Register(events.HandEnded{}, toTable)
+	p.Register(events.HandVoided{}, toTable)
+	p.Register(events.ReadyCheckStarted{}, toTable)
+	p.Register(events.PlayerReady{}, toTable)
+	p.Register(events.ReadyCheckCompleted{}, toTable)
+
+	// Player actions
+	p.Register(events.AntePlaced{}, toTable)
+	p.Register(events.PlayerFolded{}, toTable)
+	p.Register(events.ContinuationBetPlaced{}, toTable)
+	p.Register(events.DiscardCostPaid{}, toTable)
+	p.Register(events.CommunityCardDiscarded{}, toTable)
+	p.Register(events.DiscardSkipped{}, toTable)
+	p.Register(events.CommunityCardSelected{}, toTable)
+	p.Register(events.PlayerTimedOut{}, toTable)
+
+	// Dealing, hole cards only go to their owner and the sealed seed is audit only
+	p.Register(events.HoleCardDealt{}, toPlayer(func(e events.HoleCardDealt) string { return e.PlayerID }))
+	p.Register(events.HoleCardsDealt{}, toTable)
+	p.Register(events.DeckShuffled{}, toNobody)
+	p.Register(events.CardBurned{}, toTable)
+	p.Register(events.CommunityCardDealt{}, toTable)
+
+	// Turns and rounds
+	p.Register(events.PlayerTurnStarted{}, toTable)
+	p.Register(events.BettingRoundStarted{}, toTable)
+	p.Register(events.BettingRoundEnded{}, toTable)
+	p.Register(events.CommunitySelectionStarted{}, toTable)
+	p.Register(events.CommunitySelectionEnded{}, toTable)
+
+	// Showdown and payout
+	p.Register(events.HandsEvaluated{}, toTable)
+	p.Register(events.ShowdownStarted{}, toTable)
+	p.Register(events.PlayerShowedHand{}, toTable)
+	p.Register(events.BetsSweptIntoPot{}, toTable)
+	p.Register(events.PotChanged{}, toTable)
+	p.Register(events.PotBrokenDown{}, toTable)
+	p.Register(events.PotAmountAwarded{}, toTable)
+	p.Register(events.EscrowFunded{}, toNobody)
+	p.Register(events.EscrowReleased{}, toNobody)
+	p.Register(events.SingleWinnerDetermined{}, toTable)
+
+	// Tournaments, players learn about their own registration, moves and bust-out.
+	// Tournament-wide news has no table to go to, clients poll the tournament instead.
+	p.Register(events.TournamentCreated{}, toNobody)
+	p.Register(events.TournamentPlayerRegistered{}, toPlayer(func(e events.TournamentPlayerRegistered) string { return e.PlayerID }))
+	p.Register(events.TournamentPlayerUnregistered{}, toPlayer(func(e events.TournamentPlayerUnregistered) string { return e.PlayerID }))
+	p.Register(events.TournamentStarted{}, toNobody)
+	p.Register(events.TournamentLevelRaised{}, toNobody)
+	p.Register(events.TournamentPlayerMoved{}, toPlayer(func(e events.TournamentPlayerMoved) string { return e.PlayerID }))
+	p.Register(events.TournamentPlayerBusted{}, toTableAndPlayer(func(e events.TournamentPlayerBusted) string { return e.PlayerID }))
+	p.Register(events.TournamentFinished{}, toNobody)
+
+	return p
+}
@@ -0,0 +1,282 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/server/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// declaredEventNames returns the names of the events declared in the domain events package
+func declaredEventNames(t *testing.T) []string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../../domain/events", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || fn.Name.Name != "Name" || len(fn.Body.List) != 1 {
+					continue
+				}
+				ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+				if !ok || len(ret.Results) != 1 {
+					continue
+				}
+				if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					name, err := strconv.Unquote(lit.Value)
+					require.NoError(t, err)
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recorder stands in for both the clients and the spectators, it records who each delivery went to
+type recorder struct {
+	deliveries []string // "table:<id>", "player:<id>", "spectators:<id>", "remove:<id>" or "close:<id>"
+	envelopes  []EventEnvelope
+}
+
+func (r *recorder) SendToPlayer(ctx context.Context, playerID string, message []byte) bool {
+	r.deliveries = append(r.deliveries, "player:"+playerID)
+	r.record(message)
+	return true
+}
+
+func (r *recorder) SendToTable(ctx context.Context, tableID string, message []byte) {
+	r.deliveries = append(r.deliveries, "table:"+tableID)
+	r.record(message)
+}
+
+func (r *recorder) record(message []byte) {
+	var envelope EventEnvelope
+	if err := json.Unmarshal(message, &envelope); err == nil {
+		r.envelopes = append(r.envelopes, envelope)
+	}
+}
+
+func (r *recorder) RemoveTable(tableID string) {
+	r.deliveries = append(r.deliveries, "remove:"+tableID)
+}
+
+func (r *recorder) Publish(tableID string, eventID string, eventName string, data []byte) {
+	r.deliveries = append(r.deliveries, "spectators:"+tableID)
+}
+
+func (r *recorder) CloseTable(tableID string) {
+	r.deliveries = append(r.deliveries, "close:"+tableID)
+}
+
+func TestRoutingPolicies(t *testing.T) {
+	t.Run("Every event has a routing policy", func(t *testing.T) {
+		// Setup
+		policies := DefaultRoutingPolicies()
+
+		// Act
+		missing := []string{}
+		for _, name := range declaredEventNames(t) {
+			if _, ok := policies[name]; !ok {
+				missing = append(missing, name)
+			}
+		}
+
+		// Assert
+		assert.Empty(t, missing)
+	})
+
+	t.Run("Every policy routes a declared event", func(t *testing.T) {
+		// Setup
+		declared := declaredEventNames(t)
+
+		// Act
+		unknown := []string{}
+		for name := range DefaultRoutingPolicies() {
+			if !slices.Contains(declared, name) {
+				unknown = append(unknown, name)
+			}
+		}
+
+		// Assert
+		assert.Empty(t, unknown)
+	})
+
+	t.Run("Private events only reach their player", func(t *testing.T) {
+		// Setup
+		policies := DefaultRoutingPolicies()
+
+		// Act
+		holeCard, _ := policies.Resolve(events.HoleCardDealt{TableID: "table-1", PlayerID: "player-1", Card: cards.Card{Suit: cards.Spades, Value: cards.Ace}})
+		summary, _ := policies.Resolve(events.PlayerSessionSummarized{TableID: "table-1", PlayerID: "player-1"})
+		shuffle, _ := policies.Resolve(events.DeckShuffled{TableID: "table-1"})
+
+		// Assert
+		assert.Equal(t, Audience{PlayerIDs: []string{"player-1"}}, holeCard)
+		assert.Equal(t, Audience{PlayerIDs: []string{"player-1"}}, summary)
+		assert.Equal(t, Audience{}, shuffle)
+	})
+
+	t.Run("Owner-only events are never routed to the table", func(t *testing.T) {
+		// Setup
+		policies := DefaultRoutingPolicies()
+		owned := []events.Event{
+			events.HoleCardDealt{TableID: "table-1", PlayerID: "player-1"},
+			events.PlayerSessionSummarized{TableID: "table-1", PlayerID: "player-1"},
+			events.PlayerEnteredLobby{PlayerID: "player-1"},
+			events.PlayerLeftLobby{PlayerID: "player-1"},
+			events.PlayerBanned{PlayerID: "player-1"},
+			events.PlayerUnbanned{PlayerID: "player-1"},
+		}
+
+		for _, event := range owned {
+			// Act
+			visibility, owner := events.Classify(event)
+			audience, _ := policies.Resolve(event)
+
+			// Assert
+			assert.Equal(t, events.VisibilityOwner, visibility, event.Name())
+			assert.Equal(t, Audience{PlayerIDs: []string{owner}}, audience, event.Name())
+		}
+	})
+
+	t.Run("Events without a policy are not resolved", func(t *testing.T) {
+		// Setup
+		policies := RoutingPolicies{}
+
+		// Act
+		_, ok := policies.Resolve(events.HandStarted{TableID: "table-1"})
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
+
+func TestDispatcherRouting(t *testing.T) {
+	setup := func() (*Dispatcher, *recorder) {
+		rec := &recorder{}
+		return NewDispatcher(rec, tracing.NewScopes(), rec), rec
+	}
+
+	t.Run("Public table events reach spectators and the table", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+
+		// Act
+		dispatcher.HandleEvent(events.AntePlaced{TableID: "table-1", PlayerID: "player-1", Amount: 10, At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"spectators:table-1", "table:table-1"}, rec.deliveries)
+	})
+
+	t.Run("Hole cards only reach their player", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+
+		// Act
+		dispatcher.HandleEvent(events.HoleCardDealt{TableID: "table-1", PlayerID: "player-1", At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"player:player-1"}, rec.deliveries)
+	})
+
+	t.Run("Misrouted owner-only events still only reach their owner", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+		dispatcher.Route(events.HoleCardDealt{}, toTable)
+
+		// Act
+		dispatcher.HandleEvent(events.HoleCardDealt{TableID: "table-1", PlayerID: "player-1", At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"player:player-1"}, rec.deliveries)
+	})
+
+	t.Run("Closing a table forgets its clients after the last delivery", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+
+		// Act
+		dispatcher.HandleEvent(events.TableClosed{TableID: "table-1", At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"spectators:table-1", "table:table-1", "remove:table-1", "close:table-1"}, rec.deliveries)
+	})
+
+	t.Run("Envelopes carry the sequence of the previous one sent to the table", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+		at := time.Now()
+
+		// Act
+		dispatcher.HandleEvent(events.HandStarted{Seq: events.Sequence{Table: 7, Hand: 1}, TableID: "table-1", At: at})
+		dispatcher.HandleEvent(events.HoleCardDealt{Seq: events.Sequence{Table: 8, Hand: 2}, TableID: "table-1", PlayerID: "player-1", At: at})
+		dispatcher.HandleEvent(events.HoleCardsDealt{Seq: events.Sequence{Table: 9, Hand: 3}, TableID: "table-1", At: at})
+
+		// Assert
+		require.Len(t, rec.envelopes, 3)
+		assert.Equal(t, []uint64{7, 8, 9}, []uint64{rec.envelopes[0].TableSeq, rec.envelopes[1].TableSeq, rec.envelopes[2].TableSeq})
+		assert.Equal(t, uint64(3), rec.envelopes[2].HandSeq)
+		assert.Zero(t, rec.envelopes[0].PrevTableSeq)
+		assert.Zero(t, rec.envelopes[1].PrevTableSeq, "private events aren't part of the table's chain")
+		assert.Equal(t, uint64(7), rec.envelopes[2].PrevTableSeq)
+	})
+
+	t.Run("Players dealt in get their view of the hand after its events", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+		dispatcher.SetHandViews(func(tableID string, handID string) []domain.HandView {
+			return []domain.HandView{{ID: handID, TableID: tableID, PlayerID: "player-1"}, {ID: handID, TableID: tableID, PlayerID: "player-2"}}
+		})
+
+		// Act
+		dispatcher.HandleEvent(events.PlayerFolded{Seq: events.Sequence{Table: 4, Hand: 2}, TableID: "table-1", HandID: "hand-1", PlayerID: "player-2", At: time.Now()})
+		dispatcher.HandleEvent(events.EscrowFunded{Seq: events.Sequence{Table: 5, Hand: 3}, TableID: "table-1", HandID: "hand-1", At: time.Now()})
+		dispatcher.HandleEvent(events.PlayerJoinedTable{Seq: events.Sequence{Table: 6}, TableID: "table-1", At: time.Now()})
+
+		// Assert
+		assert.Equal(t, []string{"spectators:table-1", "table:table-1", "player:player-1", "player:player-2", "spectators:table-1", "table:table-1"}, rec.deliveries)
+		view := rec.envelopes[1]
+		assert.Equal(t, "HAND_VIEW", view.Name)
+		assert.Equal(t, uint64(2), view.HandSeq)
+		var update HandViewUpdate
+		require.NoError(t, json.Unmarshal(view.Payload, &update))
+		assert.Equal(t, "player-1", update.Hand.PlayerID)
+		assert.Equal(t, "hand-1", update.Hand.ID)
+	})
+
+	t.Run("Extensions can route their own events", func(t *testing.T) {
+		// Setup
+		dispatcher, rec := setup()
+		dispatcher.Route(events.HandStarted{}, toNobody)
+
+		// Act
+		dispatcher.HandleEvent(events.HandStarted{TableID: "table-1", At: time.Now()})
+
+		// Assert
+		assert.Empty(t, rec.deliveries)
+	})
+}
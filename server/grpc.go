@@ -0,0 +1,466 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/events"
+	"github.com/lazharichir/poker/server/handlers"
+	"github.com/lazharichir/poker/server/pokerpb"
+	"github.com/lazharichir/poker/server/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcPortFromEnv reads POKER_GRPC_PORT, the port of the gRPC API. The API is off when it isn't set.
+func grpcPortFromEnv() string {
+	port := os.Getenv("POKER_GRPC_PORT")
+	if port == "" {
+		return ""
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		log.Fatalf("Invalid POKER_GRPC_PORT: %q", port)
+	}
+	return port
+}
+
+// serveGRPC serves the gRPC API on the given port, next to the WebSocket server, over TLS unless tlsConfig is nil
+func (s *Server) serveGRPC(port string, tlsConfig *tls.Config) {
+	listener, err := net.Listen("tcp", "0.0.0.0:"+port)
+	if err != nil {
+		log.Fatalf("Could not listen for gRPC on port %s: %v", port, err)
+	}
+
+	var options []grpc.ServerOption
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(options...)
+	pokerpb.RegisterPokerServer(grpcServer, &grpcService{server: s, adminToken: os.Getenv("POKER_ADMIN_TOKEN")})
+	s.mu.Lock()
+	s.grpcServer = grpcServer
+	s.mu.Unlock()
+
+	log.Printf("Starting gRPC server on port %s", port)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("gRPC server stopped: %v", err)
+	}
+}
+
+// grpcService serves the gRPC API with the same lobby and command router as the WebSocket
+type grpcService struct {
+	pokerpb.UnimplementedPokerServer
+	server     *Server
+	adminToken string // POKER_ADMIN_TOKEN, operators see every table with it
+}
+
+// bearerToken returns the bearer token of the call's authorization metadata, empty if there is none
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return ""
+	}
+	token, _ := strings.CutPrefix(md.Get("authorization")[0], "Bearer ")
+	return token
+}
+
+// Play runs a session like a WebSocket connection does: the stream gets a client of its own,
+// its commands go through the command router and whatever reaches the client is streamed back
+func (g *grpcService) Play(stream pokerpb.Poker_PlayServer) error {
+	// Clients signing in send their ID token in the authorization metadata, as a bearer token
+	identity, err := g.server.authenticateRequest(stream.Context(), bearerToken(stream.Context()))
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	client := &connection.Client{
+		ID:       uuid.NewString(),
+		Send:     make(chan connection.Message, 256),
+		Identity: identity,
+	}
+	g.server.connMgr.Register <- client
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		writeEnvelopes(stream, client)
+	}()
+
+	// The stream must not be written to once Play returns
+	defer func() {
+		g.server.disconnect(client)
+		<-written
+	}()
+
+	for {
+		cmd, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		message, err := commandMessage(cmd)
+		if err != nil {
+			log.Printf("Invalid gRPC command from client %s: %v", client.ID, err)
+			continue
+		}
+
+		if err := g.server.cmdRouter.HandleCommand(stream.Context(), client, message); err != nil {
+			log.Printf("Error handling command: %v", err)
+		}
+	}
+}
+
+// writeEnvelopes streams the frames sent to a client until it is unregistered
+func writeEnvelopes(stream pokerpb.Poker_PlayServer, client *connection.Client) {
+	chunks := map[string][]string{} // Data of chunked envelopes, by event ID
+
+	for message := range client.Send {
+		data, complete := joinChunks(chunks, message.Data)
+		if !complete {
+			continue
+		}
+
+		envelope, err := protoEnvelope(data)
+		if err != nil {
+			log.Printf("Could not convert envelope for gRPC client %s: %v", client.ID, err)
+			continue
+		}
+
+		if message.Ctx == nil {
+			message.Ctx = context.Background()
+		}
+		_, span := tracing.Tracer().Start(message.Ctx, "grpc.write", writeAttributes(client, message))
+
+		err = stream.Send(envelope)
+		span.End()
+		if err != nil {
+			log.Printf("Error writing message: %v", err)
+			break
+		}
+	}
+
+	// Keep draining so senders never block on a client that is going away
+	for range client.Send {
+	}
+}
+
+// joinChunks collects ENVELOPE_CHUNK frames, and returns the envelope once all its chunks are in.
+// Other frames are returned as is.
+func joinChunks(chunks map[string][]string, frame []byte) ([]byte, bool) {
+	var chunk events.EnvelopeChunk
+	if err := json.Unmarshal(frame, &chunk); err != nil || chunk.Name != "ENVELOPE_CHUNK" {
+		return frame, true
+	}
+	if chunk.Index < 0 || chunk.Index >= chunk.Count {
+		return nil, false
+	}
+
+	parts, exists := chunks[chunk.ID]
+	if !exists {
+		parts = make([]string, chunk.Count)
+		chunks[chunk.ID] = parts
+	}
+	parts[chunk.Index] = chunk.Data
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, false
+		}
+	}
+	delete(chunks, chunk.ID)
+	return []byte(strings.Join(parts, "")), true
+}
+
+// protoEnvelope converts an envelope as sent over the WebSocket
+func protoEnvelope(data []byte) (*pokerpb.Envelope, error) {
+	var envelope events.EventEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+
+	payload := &structpb.Struct{}
+	if err := payload.UnmarshalJSON(envelope.Payload); err != nil {
+		return nil, err
+	}
+
+	converted := &pokerpb.Envelope{
+		Id:           envelope.ID,
+		Name:         envelope.Name,
+		Payload:      payload,
+		ServerTime:   envelope.ServerTime,
+		TableSeq:     envelope.TableSeq,
+		HandSeq:      envelope.HandSeq,
+		PrevTableSeq: envelope.PrevTableSeq,
+	}
+	if envelope.Deadline != nil {
+		converted.Deadline = &pokerpb.Deadline{
+			At:          envelope.Deadline.At,
+			RemainingMs: envelope.Deadline.RemainingMs,
+		}
+	}
+	return converted, nil
+}
+
+// commandMessage converts a gRPC command into the message the WebSocket would have received
+func commandMessage(cmd *pokerpb.Command) ([]byte, error) {
+	var command commands.Command
+
+	switch c := cmd.GetCommand().(type) {
+	case *pokerpb.Command_EnterLobby:
+		command = commands.EnterLobby{
+			PlayerID:   c.EnterLobby.GetPlayerId(),
+			PlayerName: c.EnterLobby.GetPlayerName(),
+			Country:    c.EnterLobby.GetCountry(),
+			Locale:     c.EnterLobby.GetLocale(),
+			TimeZone:   c.EnterLobby.GetTimeZone(),
+		}
+	case *pokerpb.Command_LeaveLobby:
+		command = commands.LeaveLobby{PlayerID: c.LeaveLobby.GetPlayerId()}
+	case *pokerpb.Command_UpdatePrivacy:
+		command = commands.UpdatePrivacy{
+			PlayerID:             c.UpdatePrivacy.GetPlayerId(),
+			HideStats:            c.UpdatePrivacy.GetHideStats(),
+			HideFromLeaderboards: c.UpdatePrivacy.GetHideFromLeaderboards(),
+			HideCountry:          c.UpdatePrivacy.GetHideCountry(),
+		}
+	case *pokerpb.Command_ResumeSession:
+		command = commands.ResumeSession{Token: c.ResumeSession.GetToken()}
+	case *pokerpb.Command_PlayerSeats:
+		command = commands.PlayerSeats{PlayerID: c.PlayerSeats.GetPlayerId(), TableID: c.PlayerSeats.GetTableId()}
+	case *pokerpb.Command_PlayerLeavesTable:
+		command = commands.PlayerLeavesTable{PlayerID: c.PlayerLeavesTable.GetPlayerId(), TableID: c.PlayerLeavesTable.GetTableId()}
+	case *pokerpb.Command_PlayerBuysIn:
+		command = commands.PlayerBuysIn{PlayerID: c.PlayerBuysIn.GetPlayerId(), TableID: c.PlayerBuysIn.GetTableId(), Amount: int(c.PlayerBuysIn.GetAmount())}
+	case *pokerpb.Command_TopUp:
+		command = commands.TopUp{PlayerID: c.TopUp.GetPlayerId(), TableID: c.TopUp.GetTableId(), Amount: int(c.TopUp.GetAmount())}
+	case *pokerpb.Command_ProposeRematch:
+		command = commands.ProposeRematch{PlayerID: c.ProposeRematch.GetPlayerId(), TableID: c.ProposeRematch.GetTableId(), BuyIn: int(c.ProposeRematch.GetBuyIn())}
+	case *pokerpb.Command_AcceptRematch:
+		command = commands.AcceptRematch{PlayerID: c.AcceptRematch.GetPlayerId(), TableID: c.AcceptRematch.GetTableId()}
+	case *pokerpb.Command_PlayerReady:
+		command = commands.PlayerReady{PlayerID: c.PlayerReady.GetPlayerId(), TableID: c.PlayerReady.GetTableId(), HandID: c.PlayerReady.GetHandId()}
+	case *pokerpb.Command_PlayerPlacesAnte:
+		action := c.PlayerPlacesAnte.GetAction()
+		command = commands.PlayerPlacesAnte{
+			PlayerID:    action.GetPlayerId(),
+			TableID:     action.GetTableId(),
+			HandID:      action.GetHandId(),
+			Phase:       action.GetPhase(),
+			LastEventID: action.GetLastEventId(),
+			Amount:      int(c.PlayerPlacesAnte.GetAmount()),
+		}
+	case *pokerpb.Command_PlayerFolds:
+		action := c.PlayerFolds.GetAction()
+		command = commands.PlayerFolds{
+			PlayerID:    action.GetPlayerId(),
+			TableID:     action.GetTableId(),
+			HandID:      action.GetHandId(),
+			Phase:       action.GetPhase(),
+			LastEventID: action.GetLastEventId(),
+		}
+	case *pokerpb.Command_PlayerPlacesContinuationBet:
+		action := c.PlayerPlacesContinuationBet.GetAction()
+		command = commands.PlayerPlacesContinuationBet{
+			PlayerID:    action.GetPlayerId(),
+			TableID:     action.GetTableId(),
+			HandID:      action.GetHandId(),
+			Phase:       action.GetPhase(),
+			LastEventID: action.GetLastEventId(),
+			Amount:      int(c.PlayerPlacesContinuationBet.GetAmount()),
+		}
+	case *pokerpb.Command_PlayerSelectsCommunityCard:
+		action := c.PlayerSelectsCommunityCard.GetAction()
+		card, err := cards.CardFromString(c.PlayerSelectsCommunityCard.GetCard())
+		if err != nil {
+			return nil, err
+		}
+		command = commands.PlayerSelectsCommunityCard{
+			PlayerID:    action.GetPlayerId(),
+			TableID:     action.GetTableId(),
+			HandID:      action.GetHandId(),
+			Phase:       action.GetPhase(),
+			LastEventID: action.GetLastEventId(),
+			Card:        card,
+		}
+	case *pokerpb.Command_ConfirmAction:
+		command = commands.ConfirmAction{PlayerID: c.ConfirmAction.GetPlayerId(), TableID: c.ConfirmAction.GetTableId(), Token: c.ConfirmAction.GetToken()}
+	case *pokerpb.Command_TimeSync:
+		command = commands.TimeSync{ClientTime: c.TimeSync.GetClientTime()}
+	case *pokerpb.Command_BlockPlayer:
+		command = commands.BlockPlayer{
+			PlayerID:        c.BlockPlayer.GetPlayerId(),
+			TableID:         c.BlockPlayer.GetTableId(),
+			TargetPlayerID:  c.BlockPlayer.GetTargetPlayerId(),
+			Reason:          c.BlockPlayer.GetReason(),
+			Note:            c.BlockPlayer.GetNote(),
+			DurationSeconds: int(c.BlockPlayer.GetDurationSeconds()),
+		}
+	case *pokerpb.Command_UnblockPlayer:
+		command = commands.UnblockPlayer{PlayerID: c.UnblockPlayer.GetPlayerId(), TableID: c.UnblockPlayer.GetTableId(), TargetPlayerID: c.UnblockPlayer.GetTargetPlayerId()}
+	case *pokerpb.Command_SpectateTable:
+		command = commands.SpectateTable{TableID: c.SpectateTable.GetTableId()}
+	case *pokerpb.Command_StopSpectating:
+		command = commands.StopSpectating{TableID: c.StopSpectating.GetTableId()}
+	case *pokerpb.Command_SubscribeEvents:
+		command = commands.SubscribeEvents{Exclude: c.SubscribeEvents.GetExclude()}
+	case *pokerpb.Command_RefreshToken:
+		command = commands.RefreshToken{IDToken: c.RefreshToken.GetIdToken()}
+	case *pokerpb.Command_GetHandView:
+		command = commands.GetHandView{PlayerID: c.GetHandView.GetPlayerId(), TableID: c.GetHandView.GetTableId()}
+	default:
+		return nil, fmt.Errorf("unknown command %T", c)
+	}
+
+	return encodeCommand(command, cmd.GetRequestId())
+}
+
+// encodeCommand encodes a command with its name and request ID, as clients send them over the WebSocket
+func encodeCommand(command commands.Command, requestID string) ([]byte, error) {
+	data, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["name"], err = json.Marshal(command.Name()); err != nil {
+		return nil, err
+	}
+	if requestID != "" {
+		if fields["requestId"], err = json.Marshal(requestID); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// ListTables is the gRPC counterpart of GET /api/tables
+func (g *grpcService) ListTables(ctx context.Context, req *pokerpb.ListTablesRequest) (*pokerpb.ListTablesResponse, error) {
+	response := &pokerpb.ListTablesResponse{}
+	for _, table := range g.server.lobby.GetTables() {
+		// The ante and status change in the table's game loop, the snapshot is taken there
+		snapshot := table.Snapshot()
+		summary := &pokerpb.TableSummary{
+			Id:        snapshot.ID,
+			Name:      snapshot.Name,
+			Status:    string(snapshot.Status),
+			AnteValue: int64(snapshot.Rules.AnteValue),
+		}
+		if snapshot.ActiveHand != nil {
+			summary.CurrentHandId = snapshot.ActiveHand.ID
+		}
+		for _, player := range snapshot.Players {
+			summary.PlayerIds = append(summary.PlayerIds, player.ID)
+		}
+		response.Tables = append(response.Tables, summary)
+	}
+	return response, nil
+}
+
+// GetTable returns the public part of a table snapshot, to the players seated at the table and to operators
+func (g *grpcService) GetTable(ctx context.Context, req *pokerpb.GetTableRequest) (*pokerpb.Table, error) {
+	table, err := g.server.lobby.GetTable(req.GetTableId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err := g.authorizeTable(ctx, table); err != nil {
+		return nil, err
+	}
+
+	return protoTable(table.Snapshot()), nil
+}
+
+// authorizeTable lets through calls with the admin token, as the admin snapshot route does, and calls
+// from a signed-in player seated at the table
+func (g *grpcService) authorizeTable(ctx context.Context, table *domain.Table) error {
+	token := bearerToken(ctx)
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+	if g.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(g.adminToken)) == 1 {
+		return nil
+	}
+
+	identity, err := g.server.authenticateRequest(ctx, token)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if identity == nil {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if !table.IsSeated(identity.PlayerID) {
+		return status.Error(codes.PermissionDenied, handlers.ErrNotSeated.Error())
+	}
+	return nil
+}
+
+// protoTable converts a table snapshot, leaving out what only operators see
+func protoTable(snapshot domain.TableSnapshot) *pokerpb.Table {
+	table := &pokerpb.Table{
+		Id:          snapshot.ID,
+		Name:        snapshot.Name,
+		Status:      string(snapshot.Status),
+		HostId:      snapshot.HostID,
+		AnteValue:   int64(snapshot.Rules.AnteValue),
+		HandsPlayed: int64(snapshot.HandsPlayed),
+	}
+	if !snapshot.StartsAt.IsZero() {
+		table.StartsAt = timestamppb.New(snapshot.StartsAt)
+	}
+
+	for _, player := range snapshot.Players {
+		table.Seats = append(table.Seats, &pokerpb.Seat{
+			PlayerId:     player.ID,
+			Name:         player.Name,
+			Status:       player.Status,
+			Stack:        int64(player.Stack),
+			Away:         player.Away,
+			NextHandOnly: player.NextHandOnly,
+		})
+	}
+
+	if hand := snapshot.ActiveHand; hand != nil {
+		table.ActiveHand = &pokerpb.Hand{
+			Id:              hand.ID,
+			Phase:           string(hand.Phase),
+			StartedAt:       timestamppb.New(hand.StartedAt),
+			Pot:             int64(hand.Pot),
+			CurrentBettor:   hand.CurrentBettor,
+			ButtonPosition:  int64(hand.ButtonPosition),
+			ActivePlayerIds: hand.ActivePlayers,
+			CommunityCards:  hand.CommunityCards,
+			DeckRemaining:   int64(hand.DeckRemaining),
+			SeedCommitment:  hand.SeedCommitment,
+		}
+	}
+
+	return table
+}
@@ -0,0 +1,358 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/events"
+	"github.com/lazharichir/poker/server/handlers"
+	"github.com/lazharichir/poker/server/pokerpb"
+	"github.com/lazharichir/poker/server/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestServer is a server with a lobby, a command router and a running connection manager
+func newTestServer(t *testing.T) *Server {
+	lobby := &domain.Lobby{}
+	connMgr := connection.NewManager()
+	go connMgr.Start()
+
+	return &Server{
+		lobby:     lobby,
+		connMgr:   connMgr,
+		cmdRouter: handlers.NewCommandRouter(lobby, connMgr, tracing.NewScopes()),
+	}
+}
+
+// dialGRPC serves the gRPC API of the server over an in-memory connection, until the test ends
+func dialGRPC(t *testing.T, service *grpcService) pokerpb.PokerClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pokerpb.RegisterPokerServer(grpcServer, service)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pokerpb.NewPokerClient(conn)
+}
+
+// withBearer adds the token to the call's authorization metadata
+func withBearer(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestCommandMessage(t *testing.T) {
+	t.Run("Commands are encoded as the WebSocket receives them, request ID included", func(t *testing.T) {
+		// Setup
+		cmd := &pokerpb.Command{
+			RequestId: "req-1",
+			Command: &pokerpb.Command_PlayerPlacesContinuationBet{PlayerPlacesContinuationBet: &pokerpb.PlayerPlacesContinuationBet{
+				Action: &pokerpb.HandAction{PlayerId: "player-1", TableId: "table-1", HandId: "hand-1", Phase: "continuation", LastEventId: "event-1"},
+				Amount: 40,
+			}},
+		}
+
+		// Act
+		message, err := commandMessage(cmd)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"name": "PLAYER_PLACES_CONTINUATION_BET",
+			"requestId": "req-1",
+			"PlayerID": "player-1",
+			"TableID": "table-1",
+			"HandID": "hand-1",
+			"Phase": "continuation",
+			"LastEventID": "event-1",
+			"Amount": 40
+		}`, string(message))
+
+		var decoded commands.PlayerPlacesContinuationBet
+		require.NoError(t, json.Unmarshal(message, &decoded))
+		assert.Equal(t, 40, decoded.Amount)
+	})
+
+	t.Run("Commands without a request ID are sent without one", func(t *testing.T) {
+		// Setup
+		cmd := &pokerpb.Command{Command: &pokerpb.Command_TimeSync{TimeSync: &pokerpb.TimeSync{ClientTime: 1234}}}
+
+		// Act
+		message, err := commandMessage(cmd)
+
+		// Assert
+		require.NoError(t, err)
+		fields := map[string]any{}
+		require.NoError(t, json.Unmarshal(message, &fields))
+		assert.Equal(t, "TIME_SYNC", fields["name"])
+		assert.NotContains(t, fields, "requestId")
+	})
+
+	t.Run("Cards are parsed, unreadable ones fail the command", func(t *testing.T) {
+		// Setup
+		selection := func(card string) *pokerpb.Command {
+			return &pokerpb.Command{Command: &pokerpb.Command_PlayerSelectsCommunityCard{PlayerSelectsCommunityCard: &pokerpb.PlayerSelectsCommunityCard{
+				Action: &pokerpb.HandAction{PlayerId: "player-1", TableId: "table-1"},
+				Card:   card,
+			}}}
+		}
+
+		// Act
+		_, valid := commandMessage(selection("AS"))
+		_, invalid := commandMessage(selection("not a card"))
+
+		// Assert
+		assert.NoError(t, valid)
+		assert.Error(t, invalid)
+	})
+
+	t.Run("An empty command is rejected", func(t *testing.T) {
+		// Act
+		_, err := commandMessage(&pokerpb.Command{RequestId: "req-1"})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestJoinChunks(t *testing.T) {
+	chunk := func(index int, count int, data string) []byte {
+		frame, err := json.Marshal(events.EnvelopeChunk{Name: "ENVELOPE_CHUNK", ID: "event-1", Index: index, Count: count, Data: data})
+		require.NoError(t, err)
+		return frame
+	}
+
+	t.Run("Frames other than chunks are returned as is", func(t *testing.T) {
+		// Setup
+		chunks := map[string][]string{}
+		frame := []byte(`{"name":"POT_CHANGED","payload":{}}`)
+
+		// Act
+		data, complete := joinChunks(chunks, frame)
+
+		// Assert
+		assert.True(t, complete)
+		assert.Equal(t, frame, data)
+	})
+
+	t.Run("Chunks are joined once all of them are in, whatever their order", func(t *testing.T) {
+		// Setup
+		chunks := map[string][]string{}
+
+		// Act
+		_, afterLast := joinChunks(chunks, chunk(2, 3, `{}}`))
+		_, afterFirst := joinChunks(chunks, chunk(0, 3, `{"name":"HAND_VIEW",`))
+		data, complete := joinChunks(chunks, chunk(1, 3, `"payload":`))
+
+		// Assert
+		assert.False(t, afterLast)
+		assert.False(t, afterFirst)
+		assert.True(t, complete)
+		assert.JSONEq(t, `{"name":"HAND_VIEW","payload":{}}`, string(data))
+		assert.Empty(t, chunks, "joined envelopes are forgotten")
+	})
+
+	t.Run("Chunks out of range are dropped", func(t *testing.T) {
+		// Setup
+		chunks := map[string][]string{}
+
+		// Act
+		_, complete := joinChunks(chunks, chunk(3, 3, `{}`))
+
+		// Assert
+		assert.False(t, complete)
+		assert.Empty(t, chunks)
+	})
+}
+
+func TestProtoEnvelope(t *testing.T) {
+	t.Run("Envelopes keep their payload, positions and deadline", func(t *testing.T) {
+		// Setup
+		data := []byte(`{
+			"id": "event-1",
+			"name": "PLAYER_TURN_STARTED",
+			"payload": {"PlayerID": "player-1", "Amount": 20},
+			"serverTime": 1000,
+			"deadline": {"at": 6000, "remainingMs": 5000},
+			"tableSeq": 7,
+			"handSeq": 3,
+			"prevTableSeq": 6
+		}`)
+
+		// Act
+		envelope, err := protoEnvelope(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "event-1", envelope.GetId())
+		assert.Equal(t, "PLAYER_TURN_STARTED", envelope.GetName())
+		assert.Equal(t, "player-1", envelope.GetPayload().GetFields()["PlayerID"].GetStringValue())
+		assert.Equal(t, float64(20), envelope.GetPayload().GetFields()["Amount"].GetNumberValue())
+		assert.Equal(t, int64(1000), envelope.GetServerTime())
+		assert.Equal(t, uint64(7), envelope.GetTableSeq())
+		assert.Equal(t, uint64(3), envelope.GetHandSeq())
+		assert.Equal(t, uint64(6), envelope.GetPrevTableSeq())
+		require.NotNil(t, envelope.GetDeadline())
+		assert.Equal(t, int64(6000), envelope.GetDeadline().GetAt())
+		assert.Equal(t, int64(5000), envelope.GetDeadline().GetRemainingMs())
+	})
+
+	t.Run("Envelopes without a deadline have none", func(t *testing.T) {
+		// Act
+		envelope, err := protoEnvelope([]byte(`{"name":"POT_CHANGED","payload":{}}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, envelope.GetDeadline())
+	})
+
+	t.Run("Payloads that aren't objects are rejected", func(t *testing.T) {
+		// Act
+		_, err := protoEnvelope([]byte(`{"name":"POT_CHANGED","payload":[1,2]}`))
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestPlay(t *testing.T) {
+	t.Run("Commands go through the router and its answers come back on the stream", func(t *testing.T) {
+		// Setup
+		client := dialGRPC(t, &grpcService{server: newTestServer(t)})
+		stream, err := client.Play(t.Context())
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, stream.Send(&pokerpb.Command{
+			RequestId: "req-1",
+			Command:   &pokerpb.Command_TimeSync{TimeSync: &pokerpb.TimeSync{ClientTime: 1234}},
+		}))
+
+		// Assert
+		var ack *pokerpb.Envelope
+		for ack == nil {
+			envelope, err := stream.Recv()
+			require.NoError(t, err)
+			if envelope.GetName() == (handlers.CommandAcked{}).Name() {
+				ack = envelope
+			}
+		}
+		assert.Equal(t, "req-1", ack.GetPayload().GetFields()["RequestID"].GetStringValue())
+		assert.Equal(t, "TIME_SYNC", ack.GetPayload().GetFields()["Command"].GetStringValue())
+		require.NoError(t, stream.CloseSend())
+	})
+
+	t.Run("Rejected commands get an error frame", func(t *testing.T) {
+		// Setup
+		client := dialGRPC(t, &grpcService{server: newTestServer(t)})
+		stream, err := client.Play(t.Context())
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, stream.Send(&pokerpb.Command{
+			RequestId: "req-1",
+			Command:   &pokerpb.Command_PlayerFolds{PlayerFolds: &pokerpb.PlayerFolds{Action: &pokerpb.HandAction{PlayerId: "player-1", TableId: "table-1"}}},
+		}))
+
+		// Assert
+		envelope, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, handlers.CommandFailed{}.Name(), envelope.GetName())
+		assert.Equal(t, "req-1", envelope.GetPayload().GetFields()["RequestID"].GetStringValue())
+		require.NoError(t, stream.CloseSend())
+	})
+}
+
+func TestGetTable(t *testing.T) {
+	// setup serves a table where player-1 is seated, with an admin token and players signing in with their ID
+	setup := func(t *testing.T) (pokerpb.PokerClient, *domain.Table) {
+		server := newTestServer(t)
+		server.authenticate = func(ctx context.Context, idToken string) (connection.Identity, error) {
+			if idToken == "admin-token" || idToken == "" {
+				return connection.Identity{}, errors.New("invalid ID token")
+			}
+			return connection.Identity{PlayerID: idToken}, nil
+		}
+		table, err := server.lobby.NewTable("Test Table", domain.TableRules{AnteValue: 10, MaxPlayers: 6})
+		require.NoError(t, err)
+		require.NoError(t, table.Do("seat", func() error { return table.SeatPlayer(&domain.Player{ID: "player-1"}) }))
+
+		return dialGRPC(t, &grpcService{server: server, adminToken: "admin-token"}), table
+	}
+
+	t.Run("Players seated at the table see it", func(t *testing.T) {
+		// Setup
+		client, table := setup(t)
+
+		// Act
+		response, err := client.GetTable(withBearer(t.Context(), "player-1"), &pokerpb.GetTableRequest{TableId: table.ID})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, table.ID, response.GetId())
+		require.Len(t, response.GetSeats(), 1)
+		assert.Equal(t, "player-1", response.GetSeats()[0].GetPlayerId())
+	})
+
+	t.Run("Operators see it with the admin token", func(t *testing.T) {
+		// Setup
+		client, table := setup(t)
+
+		// Act
+		_, err := client.GetTable(withBearer(t.Context(), "admin-token"), &pokerpb.GetTableRequest{TableId: table.ID})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Players not seated at the table are denied", func(t *testing.T) {
+		// Setup
+		client, table := setup(t)
+
+		// Act
+		_, err := client.GetTable(withBearer(t.Context(), "player-2"), &pokerpb.GetTableRequest{TableId: table.ID})
+
+		// Assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Calls without a token are unauthenticated", func(t *testing.T) {
+		// Setup
+		client, table := setup(t)
+
+		// Act
+		_, err := client.GetTable(t.Context(), &pokerpb.GetTableRequest{TableId: table.ID})
+
+		// Assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Unknown tables are not found", func(t *testing.T) {
+		// Setup
+		client, _ := setup(t)
+
+		// Act
+		_, err := client.GetTable(withBearer(t.Context(), "player-1"), &pokerpb.GetTableRequest{TableId: "unknown"})
+
+		// Assert
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/lazharichir/poker/domain/handhistory"
+)
+
+// Number of hands listed by the table history endpoint
+const (
+	defaultHandListLimit = 50
+	maxHandListLimit     = 200
+)
+
+// handleTableHands lists the hands played at a table, most recent first (?limit=)
+func (s *Server) handleTableHands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := defaultHandListLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxHandListLimit)
+	}
+
+	summaries, err := s.hands.TableHands(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		log.Printf("Could not list the hands of table %s: %v", r.PathValue("id"), err)
+		http.Error(w, "Could not load the hand history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summaries)
+}
+
+// handleHand returns a finished hand as anyone may review it, hole cards included only when they were shown
+func (s *Server) handleHand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	hand, err := s.hands.Hand(r.Context(), r.PathValue("id"))
+	if errors.Is(err, handhistory.ErrHandNotFound) {
+		http.Error(w, "Hand not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Could not load hand %s: %v", r.PathValue("id"), err)
+		http.Error(w, "Could not load the hand history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hand.Public())
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// ErrNotInLobby is returned for commands sent before the connection entered the lobby
+var ErrNotInLobby = errors.New("client is not in the lobby")
+
+// ErrUnknownCommand is returned for commands the router doesn't know
+var ErrUnknownCommand = errors.New("unknown command type")
+
+// Error codes of COMMAND_ERROR, for clients to react without parsing messages
+const (
+	ErrorCodeInvalidCommand   = "INVALID_COMMAND"
+	ErrorCodeUnknownCommand   = "UNKNOWN_COMMAND"
+	ErrorCodeNotInLobby       = "NOT_IN_LOBBY"
+	ErrorCodeNotAuthorized    = "NOT_AUTHORIZED"
+	ErrorCodeNotAuthenticated = "NOT_AUTHENTICATED"
+	ErrorCodeTokenExpired     = "TOKEN_EXPIRED"
+	ErrorCodeNotSeated        = "NOT_SEATED"
+	ErrorCodeNotInHand        = "NOT_IN_HAND"
+	ErrorCodeNotYourTurn      = "NOT_YOUR_TURN"
+	ErrorCodeStaleAction      = "STALE_ACTION"
+	ErrorCodeSessionExpired   = "SESSION_EXPIRED"
+	ErrorCodeSessionInUse     = "SESSION_IN_USE"
+	ErrorCodeRateLimited      = "RATE_LIMITED"
+	ErrorCodeInternal         = "INTERNAL_ERROR"
+	ErrorCodeRejected         = "REJECTED" // Any other error, the message says why
+)
+
+// CommandAcked tells the client that a command carrying a request ID went through
+type CommandAcked struct {
+	RequestID string
+	Command   string
+}
+
+func (c CommandAcked) Name() string { return "COMMAND_ACK" }
+
+// CommandFailed tells the client why a command was rejected
+type CommandFailed struct {
+	RequestID string // Empty if the command had none
+	Command   string
+	Code      string
+	Message   string
+}
+
+func (c CommandFailed) Name() string { return "COMMAND_ERROR" }
+
+// errorCode classifies the error a command failed with
+func errorCode(err error) string {
+	switch {
+	case errors.As(err, new(*json.SyntaxError)), errors.As(err, new(*json.UnmarshalTypeError)):
+		return ErrorCodeInvalidCommand
+	case errors.Is(err, domain.ErrInvalidLocale), errors.Is(err, domain.ErrInvalidTimeZone):
+		return ErrorCodeInvalidCommand
+	case errors.Is(err, ErrUnknownCommand):
+		return ErrorCodeUnknownCommand
+	case errors.Is(err, ErrNotInLobby):
+		return ErrorCodeNotInLobby
+	case errors.Is(err, ErrNotAuthorized):
+		return ErrorCodeNotAuthorized
+	case errors.Is(err, ErrNotAuthenticated):
+		return ErrorCodeNotAuthenticated
+	case errors.Is(err, ErrTokenExpired):
+		return ErrorCodeTokenExpired
+	case errors.Is(err, ErrNotSeated):
+		return ErrorCodeNotSeated
+	case errors.Is(err, ErrNotInHand):
+		return ErrorCodeNotInHand
+	case errors.Is(err, domain.ErrNotYourTurn):
+		return ErrorCodeNotYourTurn
+	case errors.Is(err, domain.ErrStaleAction):
+		return ErrorCodeStaleAction
+	case errors.Is(err, connection.ErrSessionNotFound):
+		return ErrorCodeSessionExpired
+	case errors.Is(err, connection.ErrSessionInUse):
+		return ErrorCodeSessionInUse
+	case errors.Is(err, ErrRateLimited):
+		return ErrorCodeRateLimited
+	case errors.Is(err, ErrInternal), errors.Is(err, domain.ErrHandCrashed):
+		return ErrorCodeInternal
+	}
+	return ErrorCodeRejected
+}
+
+// acknowledge answers the client that sent a command: with an error frame if it failed,
+// with an ACK if it went through and the client gave a request ID to match it with
+func (r *CommandRouter) acknowledge(ctx context.Context, client *connection.Client, command string, requestID string, err error) {
+	var response Response
+	switch {
+	case err != nil:
+		response = CommandFailed{RequestID: requestID, Command: command, Code: errorCode(err), Message: err.Error()}
+	case requestID != "":
+		response = CommandAcked{RequestID: requestID, Command: command}
+	default:
+		return
+	}
+
+	if err := r.sendToClient(ctx, client, response); err != nil {
+		log.Printf("Could not answer %s command of client %s: %v", command, client.ID, err)
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// answer is the ACK or error frame the client was sent last, with the frames before it dropped
+func answer(t *testing.T, client *connection.Client) (string, CommandFailed) {
+	require.NotEmpty(t, client.Send, "the client got no answer")
+	var message connection.Message
+	for len(client.Send) > 0 {
+		message = <-client.Send
+	}
+
+	var envelope struct {
+		Name    string          `json:"name"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(message.Data, &envelope))
+	var frame CommandFailed // An ACK has the request ID and command only
+	require.NoError(t, json.Unmarshal(envelope.Payload, &frame))
+	return envelope.Name, frame
+}
+
+// handle runs the command through the router the way a connection does, answering the client
+func (f seatFixture) handle(t *testing.T, client *connection.Client, name string, fields map[string]any) error {
+	fields["name"] = name
+	message, err := json.Marshal(fields)
+	require.NoError(t, err)
+	return f.router.HandleCommand(context.Background(), client, message)
+}
+
+func TestAcknowledge(t *testing.T) {
+	// anteFixture is a seat fixture whose hand waits for the ante of its first bettor, and the other player dealt in
+	anteFixture := func(t *testing.T) (f seatFixture, ante map[string]any, bettor *connection.Client, other *connection.Client) {
+		f = newSeatFixture(t)
+		for _, player := range f.table.GetPlayers() {
+			require.NoError(t, f.router.lobby.EntersLobby(player))
+		}
+		require.NoError(t, f.table.Do("collect antes", func() error {
+			if err := f.hand.InitializeHand(); err != nil {
+				return err
+			}
+			f.hand.TransitionToAntesPhase()
+			return nil
+		}))
+		otherID := "player-2"
+		if f.hand.CurrentBettor == otherID {
+			otherID = "player-1"
+		}
+		ante = map[string]any{"TableID": f.table.ID, "HandID": f.hand.ID, "Phase": string(f.hand.Phase), "Amount": 10}
+		return f, ante, f.client(f.hand.CurrentBettor), f.client(otherID)
+	}
+	withRequestID := func(fields map[string]any, requestID string) map[string]any {
+		copied := map[string]any{"requestId": requestID}
+		for k, v := range fields {
+			copied[k] = v
+		}
+		return copied
+	}
+
+	t.Run("A command that went through is acked with its request ID", func(t *testing.T) {
+		// Setup
+		f, ante, client, _ := anteFixture(t)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1"))
+
+		// Assert
+		require.NoError(t, err)
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandAcked{}).Name(), name)
+		assert.Equal(t, "request-1", frame.RequestID)
+		assert.Equal(t, commands.PlayerPlacesAnte{}.Name(), frame.Command)
+	})
+
+	t.Run("A command without a request ID that went through isn't acked", func(t *testing.T) {
+		// Setup
+		f, ante, client, _ := anteFixture(t)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), ante)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, client.Send)
+	})
+
+	t.Run("A rejected command gets an error frame with its request ID", func(t *testing.T) {
+		// Setup
+		f, ante, _, client := anteFixture(t)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1"))
+
+		// Assert
+		require.Error(t, err)
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandFailed{}).Name(), name)
+		assert.Equal(t, "request-1", frame.RequestID)
+		assert.Equal(t, ErrorCodeNotYourTurn, frame.Code)
+	})
+
+	t.Run("A command resent under the same request ID is acked again with it", func(t *testing.T) {
+		// Setup
+		f, ante, client, _ := anteFixture(t)
+		require.NoError(t, f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1")))
+		answer(t, client)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1"))
+
+		// Assert
+		require.NoError(t, err, "the ante isn't placed twice")
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandAcked{}).Name(), name)
+		assert.Equal(t, "request-1", frame.RequestID)
+	})
+
+	t.Run("A double click is acked with the request ID of the repeat", func(t *testing.T) {
+		// Setup
+		f, ante, client, _ := anteFixture(t)
+		require.NoError(t, f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1")))
+		answer(t, client)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-2"))
+
+		// Assert
+		require.NoError(t, err, "the ante isn't placed twice")
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandAcked{}).Name(), name)
+		assert.Equal(t, "request-2", frame.RequestID)
+	})
+
+	t.Run("A resent command that was rejected gets the error again with its request ID", func(t *testing.T) {
+		// Setup
+		f, ante, _, client := anteFixture(t)
+		require.Error(t, f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1")))
+		answer(t, client)
+
+		// Act
+		err := f.handle(t, client, commands.PlayerPlacesAnte{}.Name(), withRequestID(ante, "request-1"))
+
+		// Assert
+		require.Error(t, err)
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandFailed{}).Name(), name)
+		assert.Equal(t, "request-1", frame.RequestID)
+		assert.Equal(t, ErrorCodeNotYourTurn, frame.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// maxBatchSize bounds how many commands a single batch may carry
+const maxBatchSize = 32
+
+// CommandAck tells the client how one command of a batch went
+type CommandAck struct {
+	Index   int    // Position of the command in the batch
+	Name    string // Name of the command, empty if it could not be read
+	OK      bool
+	Error   string `json:",omitempty"`
+	Skipped bool   `json:",omitempty"` // Not run, as an earlier command of the batch failed
+}
+
+// CommandBatchResult acknowledges every command of a batch, in order
+type CommandBatchResult struct {
+	Acks []CommandAck
+}
+
+func (c CommandBatchResult) Name() string { return "COMMAND_BATCH_RESULT" }
+
+// handleCommandBatch runs the commands of a batch in order, as if they had been sent one by one.
+// The batch stops at the first failure: the commands after it are skipped, the ones before it
+// stay applied as game actions can't be taken back.
+func (r *CommandRouter) handleCommandBatch(ctx context.Context, client *connection.Client, batch commands.CommandBatch) error {
+	if len(batch.Commands) == 0 {
+		return errors.New("empty command batch")
+	}
+	if len(batch.Commands) > maxBatchSize {
+		return fmt.Errorf("command batch is limited to %d commands", maxBatchSize)
+	}
+
+	result := CommandBatchResult{Acks: make([]CommandAck, len(batch.Commands))}
+	var failed error
+
+	for i, message := range batch.Commands {
+		ack := CommandAck{Index: i}
+
+		var base struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(message, &base); err == nil {
+			ack.Name = base.Name
+		}
+
+		switch {
+		case failed != nil:
+			ack.Skipped = true
+		case ack.Name == commands.CommandBatch{}.Name():
+			failed = errors.New("command batches can't be nested")
+		default:
+			failed = r.handleCommand(ctx, client, message)
+		}
+
+		if !ack.Skipped {
+			ack.OK = failed == nil
+			if failed != nil {
+				ack.Error = failed.Error()
+			}
+		}
+		result.Acks[i] = ack
+	}
+
+	if err := r.sendToClient(ctx, client, result); err != nil {
+		return err
+	}
+
+	if failed != nil {
+		return fmt.Errorf("command batch stopped: %w", failed)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/cluster"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// forwardedResponseBuffer is how many responses a forwarded command may produce
+const forwardedResponseBuffer = 16
+
+// SetCluster makes the router forward commands for tables owned by other instances
+func (r *CommandRouter) SetCluster(node *cluster.Node) {
+	r.cluster = node
+}
+
+// remoteOwner returns the instance running the table, when it isn't this one
+func (r *CommandRouter) remoteOwner(ctx context.Context, tableID string) (cluster.Instance, bool) {
+	if r.cluster == nil || tableID == "" {
+		return cluster.Instance{}, false
+	}
+
+	if _, err := r.lobby.GetTable(tableID); err == nil {
+		return cluster.Instance{}, false
+	}
+
+	owner, ok, err := r.cluster.Owner(ctx, tableID)
+	if err != nil {
+		log.Printf("Could not look up the owner of table %s: %v", tableID, err)
+		return cluster.Instance{}, false
+	}
+
+	return owner, ok && owner.ID != r.cluster.Self.ID
+}
+
+// forwardCommand runs the command on the instance that owns the table. The client stays connected
+// here, so this instance keeps track of the tables it sits at to deliver their relayed events.
+func (r *CommandRouter) forwardCommand(ctx context.Context, client *connection.Client, owner cluster.Instance, name string, tableID string, message []byte) error {
+	if client.Player == nil {
+		return ErrNotInLobby
+	}
+
+	// Join before the command runs, the relayed events may arrive before the owner answers
+	seats := name == commands.PlayerSeats{}.Name()
+	if seats {
+		r.connMgr.AddTableToClient(client.ID, tableID)
+	}
+
+	result, err := r.cluster.Forward(ctx, owner, cluster.ForwardedCommand{
+		PlayerID:   client.Player.ID,
+		PlayerName: client.Player.Name,
+		Message:    message,
+	})
+	if err == nil && result.Error != "" {
+		err = errors.New(result.Error)
+	}
+	if err != nil && seats {
+		r.connMgr.RemoveTableFromClient(client.ID, tableID)
+	}
+
+	for _, data := range result.Responses {
+		select {
+		case client.Send <- connection.Message{Data: data, Ctx: ctx, Queued: time.Now()}:
+		default:
+			return errors.New("client send buffer is full")
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if name == (commands.PlayerLeavesTable{}).Name() {
+		r.connMgr.RemoveTableFromClient(client.ID, tableID)
+	}
+
+	return nil
+}
+
+// HandleForwardedCommand runs a command that another instance received for a table owned here.
+// The player acts through a stand-in connection: its responses are returned to the forwarding
+// instance, events reach the player through the cluster relay.
+func (r *CommandRouter) HandleForwardedCommand(ctx context.Context, cmd cluster.ForwardedCommand) cluster.ForwardResult {
+	player, err := r.lobby.GetPlayer(cmd.PlayerID)
+	if err != nil {
+		if player, err = r.newPlayer(cmd.PlayerID, cmd.PlayerName); err != nil {
+			return cluster.ForwardResult{Error: err.Error()}
+		}
+		if err := r.lobby.EntersLobby(player); err != nil {
+			return cluster.ForwardResult{Error: err.Error()}
+		}
+	}
+
+	client := &connection.Client{
+		ID:     "forwarded-" + uuid.NewString(),
+		Send:   make(chan connection.Message, forwardedResponseBuffer),
+		Player: player,
+	}
+
+	result := cluster.ForwardResult{}
+	if err := r.handleCommand(ctx, client, cmd.Message); err != nil {
+		result.Error = err.Error()
+	}
+
+	for {
+		select {
+		case message := <-client.Send:
+			result.Responses = append(result.Responses, message.Data)
+		default:
+			return result
+		}
+	}
+}
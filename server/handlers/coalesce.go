@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// duplicateActionWindow is how long after an action the same action from the same player is taken for a double click
+var duplicateActionWindow = 500 * time.Millisecond
+
+// isActionCommand reports whether a command is a player's move in a hand, which a double click would repeat
+func isActionCommand(name string) bool {
+	switch name {
+	case commands.PlayerFolds{}.Name(),
+		commands.PlayerPlacesAnte{}.Name(),
+		commands.PlayerPlacesContinuationBet{}.Name(),
+		commands.PlayerSelectsCommunityCard{}.Name():
+		return true
+	}
+	return false
+}
+
+// Coalesce runs an action only once when the same player sends it again within window, as a double click does.
+// The repeat gets the answer of the original instead of an "already acted" error: an ACK once the original
+// went through. Repeats of an action that failed run again, the player may have been right to retry.
+func Coalesce(window time.Duration) Middleware {
+	coalescer := &coalescer{window: window, recent: make(map[string]*recentAction)}
+
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if client.Player == nil || !isActionCommand(cmd.Name) {
+				return next(ctx, client, cmd)
+			}
+
+			action, original := coalescer.track(client.Player.ID, fingerprint(cmd), cmd.ReceivedAt)
+			if original != nil {
+				if original.wait() == nil {
+					log.Printf("Coalesced duplicate %s command of player %s", cmd.Name, client.Player.ID)
+					return nil
+				}
+				action, _ = coalescer.track(client.Player.ID, "", cmd.ReceivedAt)
+			}
+
+			return action.run(func() error { return next(ctx, client, cmd) })
+		}
+	}
+}
+
+// recentAction is the last action a player sent
+type recentAction struct {
+	inFlight
+	fingerprint string
+	at          time.Time
+}
+
+// coalescer remembers the last action of each player
+type coalescer struct {
+	mu     sync.Mutex
+	window time.Duration
+	recent map[string]*recentAction // By player ID
+}
+
+// track returns the player's recent action with the same fingerprint, or records this one as their last action.
+// An empty fingerprint is never a duplicate.
+func (c *coalescer) track(playerID string, fingerprint string, now time.Time) (action *recentAction, original *recentAction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last, exists := c.recent[playerID]
+	if exists && fingerprint != "" && last.fingerprint == fingerprint && now.Sub(last.at) < c.window {
+		return nil, last
+	}
+
+	// Players come and go, forget the actions too old to be repeated
+	for id, a := range c.recent {
+		if now.Sub(a.at) >= c.window {
+			delete(c.recent, id)
+		}
+	}
+
+	action = &recentAction{inFlight: newInFlight(), fingerprint: fingerprint, at: now}
+	c.recent[playerID] = action
+	return action, nil
+}
+
+// fingerprint identifies what a command does, leaving out what differs between two clicks on the same button:
+// the request ID, and the last event seen which may have moved on in between
+func fingerprint(cmd Command) string {
+	var fields map[string]any
+	if err := json.Unmarshal(cmd.Message, &fields); err != nil {
+		return ""
+	}
+	delete(fields, "requestId")
+	delete(fields, "LastEventID")
+
+	// Maps are encoded with sorted keys, the same fields give the same fingerprint
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesce(t *testing.T) {
+	client := &connection.Client{ID: "client-1", Player: &domain.Player{ID: "player-1"}}
+	fold := func(requestID string, at time.Time) Command {
+		return Command{
+			Name:       commands.PlayerFolds{}.Name(),
+			Message:    []byte(`{"type":"PLAYER_FOLDS","TableID":"table-1","HandID":"hand-1","requestId":"` + requestID + `"}`),
+			RequestID:  requestID,
+			ReceivedAt: at,
+		}
+	}
+
+	t.Run("A double click runs the action once and acks the repeat", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			runs.Add(1)
+			return nil
+		})
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, fold("request-1", now))
+		repeat := handler(context.Background(), client, fold("request-2", now.Add(100*time.Millisecond)))
+
+		// Assert
+		assert.NoError(t, first)
+		assert.NoError(t, repeat)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("A repeat waits for the original to be handled", func(t *testing.T) {
+		// Setup
+		release := make(chan struct{})
+		var runs atomic.Int32
+		handler := Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			runs.Add(1)
+			<-release
+			return nil
+		})
+		now := time.Now()
+		original := make(chan error)
+		go func() { original <- handler(context.Background(), client, fold("request-1", now)) }()
+		assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		// Act
+		repeat := make(chan error)
+		go func() { repeat <- handler(context.Background(), client, fold("request-2", now)) }()
+		close(release)
+
+		// Assert
+		assert.NoError(t, <-original)
+		assert.NoError(t, <-repeat)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("A repeat of a failed action runs again", func(t *testing.T) {
+		// Setup
+		failure := errors.New("not your turn")
+		var runs atomic.Int32
+		handler := Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if runs.Add(1) == 1 {
+				return failure
+			}
+			return nil
+		})
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, fold("request-1", now))
+		retry := handler(context.Background(), client, fold("request-2", now.Add(100*time.Millisecond)))
+
+		// Assert
+		assert.ErrorIs(t, first, failure)
+		assert.NoError(t, retry)
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("A repeat of an action that panicked runs again instead of hanging", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Recover()(Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if runs.Add(1) == 1 {
+				panic("boom")
+			}
+			return nil
+		}))
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, fold("request-1", now))
+		retry := handler(context.Background(), client, fold("request-2", now.Add(100*time.Millisecond)))
+
+		// Assert
+		assert.ErrorIs(t, first, ErrInternal)
+		assert.NoError(t, retry)
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("The same action after the window runs again", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			runs.Add(1)
+			return nil
+		})
+		now := time.Now()
+
+		// Act
+		handler(context.Background(), client, fold("request-1", now))
+		handler(context.Background(), client, fold("request-2", now.Add(2*time.Second)))
+
+		// Assert
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("Commands other than actions are never coalesced", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Coalesce(time.Second)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			runs.Add(1)
+			return nil
+		})
+		leave := Command{Name: commands.PlayerLeavesTable{}.Name(), Message: []byte(`{"TableID":"table-1"}`), ReceivedAt: time.Now()}
+
+		// Act
+		handler(context.Background(), client, leave)
+		handler(context.Background(), client, leave)
+
+		// Assert
+		assert.Equal(t, int32(2), runs.Load())
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// confirmationTTL is how long a client has to confirm a large bet
+var confirmationTTL = 5 * time.Second
+
+// ConfirmationRequired is sent to the client instead of applying a large bet.
+// The bet is applied once the client sends CONFIRM_ACTION with the token before it expires.
+type ConfirmationRequired struct {
+	Token     string
+	TableID   string
+	HandID    string
+	Command   string
+	Amount    int
+	ExpiresAt time.Time
+}
+
+func (c ConfirmationRequired) Name() string { return "CONFIRMATION_REQUIRED" }
+
+type pendingConfirmation struct {
+	playerID  string
+	apply     func() error
+	expiresAt time.Time
+}
+
+// Confirmations holds the actions waiting for the client to confirm them
+type Confirmations struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+// NewConfirmations creates an empty confirmation store
+func NewConfirmations() *Confirmations {
+	return &Confirmations{
+		pending: make(map[string]pendingConfirmation),
+	}
+}
+
+// Request stores the action and returns the token the player must confirm it with
+func (c *Confirmations) Request(playerID string, apply func() error) (string, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for token, p := range c.pending {
+		if now.After(p.expiresAt) {
+			delete(c.pending, token)
+		}
+	}
+
+	token := uuid.NewString()
+	expiresAt := now.Add(confirmationTTL)
+	c.pending[token] = pendingConfirmation{
+		playerID:  playerID,
+		apply:     apply,
+		expiresAt: expiresAt,
+	}
+
+	return token, expiresAt
+}
+
+// Confirm applies the action behind the token. A token can only be used once, by the player it was issued to.
+func (c *Confirmations) Confirm(playerID string, token string) error {
+	c.mu.Lock()
+	p, exists := c.pending[token]
+	if exists && p.playerID == playerID {
+		delete(c.pending, token)
+	}
+	c.mu.Unlock()
+
+	if !exists || p.playerID != playerID {
+		return errors.New("unknown confirmation token")
+	}
+
+	if time.Now().After(p.expiresAt) {
+		return errors.New("confirmation expired")
+	}
+
+	return p.apply()
+}
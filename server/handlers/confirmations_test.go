@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmations(t *testing.T) {
+	t.Run("A token applies the action once", func(t *testing.T) {
+		// Setup
+		confirmations := NewConfirmations()
+		applied := 0
+		token, _ := confirmations.Request("player-1", func() error {
+			applied++
+			return nil
+		})
+
+		// Act
+		first := confirmations.Confirm("player-1", token)
+		second := confirmations.Confirm("player-1", token)
+
+		// Assert
+		assert.NoError(t, first)
+		assert.EqualError(t, second, "unknown confirmation token")
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("An expired token doesn't apply the action", func(t *testing.T) {
+		// Setup
+		ttl := confirmationTTL
+		confirmationTTL = time.Millisecond
+		defer func() { confirmationTTL = ttl }()
+		confirmations := NewConfirmations()
+		applied := false
+		token, expiresAt := confirmations.Request("player-1", func() error {
+			applied = true
+			return nil
+		})
+		time.Sleep(time.Until(expiresAt) + time.Millisecond)
+
+		// Act
+		err := confirmations.Confirm("player-1", token)
+
+		// Assert
+		assert.EqualError(t, err, "confirmation expired")
+		assert.False(t, applied)
+	})
+
+	t.Run("Another player can't use the token", func(t *testing.T) {
+		// Setup
+		confirmations := NewConfirmations()
+		applied := 0
+		token, _ := confirmations.Request("player-1", func() error {
+			applied++
+			return nil
+		})
+
+		// Act
+		stolen := confirmations.Confirm("player-2", token)
+		owned := confirmations.Confirm("player-1", token)
+
+		// Assert
+		assert.EqualError(t, stolen, "unknown confirmation token")
+		assert.NoError(t, owned, "the token stays usable by the player it was issued to")
+		assert.Equal(t, 1, applied)
+	})
+
+	t.Run("A confirmation arriving after the hand moved on is stale", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		confirmations := NewConfirmations()
+		action := domain.Action{Type: domain.ActionFold, PlayerID: "player-1", HandID: f.hand.ID, Phase: string(f.hand.Phase)}
+		token, _ := confirmations.Request("player-1", func() error { return f.table.SubmitAction(action) })
+		require.NoError(t, f.table.Do("move the hand on", func() error {
+			f.hand.TransitionToAntesPhase()
+			return nil
+		}))
+
+		// Act
+		err := confirmations.Confirm("player-1", token)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrStaleAction)
+		assert.EqualError(t, confirmations.Confirm("player-1", token), "unknown confirmation token", "the token is used up all the same")
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// requestIDWindow is how long the request IDs of a player's commands are remembered
+var requestIDWindow = 2 * time.Minute
+
+// isDeduplicatedCommand reports whether a command changes the game, so running it twice would apply it twice.
+// Commands answered with a response of their own are left out, their response would be lost on a repeat.
+func isDeduplicatedCommand(name string) bool {
+	if name == (commands.GetHandView{}).Name() {
+		return false
+	}
+	return seatedCommands[name] || name == commands.PlayerSeats{}.Name()
+}
+
+// Deduplicate runs a command only once when the same player sends it again with the same request ID within
+// window, as clients on flaky connections do when the answer didn't arrive. The repeat gets the answer of the
+// original, an error included: clients retry a failed command under a new request ID.
+func Deduplicate(window time.Duration) Middleware {
+	deduplicator := &deduplicator{window: window, sent: make(map[string]*sentCommand)}
+
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if client.Player == nil || cmd.RequestID == "" || !isDeduplicatedCommand(cmd.Name) {
+				return next(ctx, client, cmd)
+			}
+
+			sent, original := deduplicator.track(client.Player.ID+"/"+cmd.RequestID, cmd.ReceivedAt)
+			if original != nil {
+				err := original.wait()
+				log.Printf("Ignored %s command %s of player %s, it was already handled", cmd.Name, cmd.RequestID, client.Player.ID)
+				return err
+			}
+
+			return sent.run(func() error { return next(ctx, client, cmd) })
+		}
+	}
+}
+
+// sentCommand is a command a player sent with a request ID
+type sentCommand struct {
+	inFlight
+	at time.Time
+}
+
+// deduplicator remembers the commands players sent within the window
+type deduplicator struct {
+	mu     sync.Mutex
+	window time.Duration
+	sent   map[string]*sentCommand // By player ID and request ID
+	pruned time.Time
+}
+
+// track returns the command already sent under the key, or records this one
+func (d *deduplicator) track(key string, now time.Time) (sent *sentCommand, original *sentCommand) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if previous, exists := d.sent[key]; exists && now.Sub(previous.at) < d.window {
+		return nil, previous
+	}
+
+	// Forget the commands too old to be repeated, once per window as players send many
+	if now.Sub(d.pruned) >= d.window {
+		for k, c := range d.sent {
+			if now.Sub(c.at) >= d.window {
+				delete(d.sent, k)
+			}
+		}
+		d.pruned = now
+	}
+
+	sent = &sentCommand{inFlight: newInFlight(), at: now}
+	d.sent[key] = sent
+	return sent, nil
+}
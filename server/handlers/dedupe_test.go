@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicate(t *testing.T) {
+	client := &connection.Client{ID: "client-1", Player: &domain.Player{ID: "player-1"}}
+	ante := func(requestID string, at time.Time) Command {
+		return Command{
+			Name:       commands.PlayerPlacesAnte{}.Name(),
+			Message:    []byte(`{"type":"PLAYER_PLACES_ANTE","TableID":"table-1","HandID":"hand-1","Amount":10}`),
+			RequestID:  requestID,
+			ReceivedAt: at,
+		}
+	}
+	counting := func(runs *atomic.Int32, err error) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			runs.Add(1)
+			return err
+		}
+	}
+
+	t.Run("A command resent under the same request ID is applied once", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Deduplicate(time.Minute)(counting(&runs, nil))
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, ante("request-1", now))
+		resent := handler(context.Background(), client, ante("request-1", now.Add(time.Second)))
+
+		// Assert
+		assert.NoError(t, first)
+		assert.NoError(t, resent)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("The resent command gets the error of the original", func(t *testing.T) {
+		// Setup
+		failure := errors.New("not enough chips")
+		var runs atomic.Int32
+		handler := Deduplicate(time.Minute)(counting(&runs, failure))
+		now := time.Now()
+
+		// Act
+		handler(context.Background(), client, ante("request-1", now))
+		resent := handler(context.Background(), client, ante("request-1", now.Add(time.Second)))
+
+		// Assert
+		assert.ErrorIs(t, resent, failure)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("A command under another request ID runs", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Deduplicate(time.Minute)(counting(&runs, nil))
+		now := time.Now()
+
+		// Act
+		handler(context.Background(), client, ante("request-1", now))
+		handler(context.Background(), client, ante("request-2", now.Add(time.Second)))
+
+		// Assert
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("Request IDs are forgotten once the window is over", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Deduplicate(time.Minute)(counting(&runs, nil))
+		now := time.Now()
+
+		// Act
+		handler(context.Background(), client, ante("request-1", now))
+		handler(context.Background(), client, ante("request-1", now.Add(2*time.Minute)))
+
+		// Assert
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("A command resent while the original panics gets its failure instead of hanging", func(t *testing.T) {
+		// Setup
+		handler := Recover()(Deduplicate(time.Minute)(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			panic("boom")
+		}))
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, ante("request-1", now))
+		resent := handler(context.Background(), client, ante("request-1", now.Add(time.Second)))
+
+		// Assert
+		assert.ErrorIs(t, first, ErrInternal)
+		assert.ErrorIs(t, resent, ErrInternal)
+	})
+
+	t.Run("Commands without a request ID always run", func(t *testing.T) {
+		// Setup
+		var runs atomic.Int32
+		handler := Deduplicate(time.Minute)(counting(&runs, nil))
+		now := time.Now()
+
+		// Act
+		handler(context.Background(), client, ante("", now))
+		handler(context.Background(), client, ante("", now))
+
+		// Assert
+		assert.Equal(t, int32(2), runs.Load())
+	})
+}
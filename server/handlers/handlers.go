@@ -1,47 +1,243 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lazharichir/poker/domain"
 	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/cluster"
 	"github.com/lazharichir/poker/server/connection"
+	serverEvents "github.com/lazharichir/poker/server/events"
+	"github.com/lazharichir/poker/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrNotAuthorized is returned when a command claims to act for another player than the connection's
+var ErrNotAuthorized = errors.New("not authorized to act for this player")
+
+// ErrNotSeated is returned for table commands of players who aren't seated at the table
+var ErrNotSeated = errors.New("player is not seated at this table")
+
+// ErrNotInHand is returned for hand commands of players who weren't dealt in the hand
+var ErrNotInHand = errors.New("player is not part of this hand")
+
 // CommandRouter routes incoming commands to the appropriate handler
 type CommandRouter struct {
-	lobby   *domain.Lobby
-	connMgr *connection.Manager
+	lobby         *domain.Lobby
+	connMgr       *connection.Manager
+	scopes        *tracing.Scopes
+	confirmations *Confirmations
+	cluster       *cluster.Node // nil when running as a single instance
+	authenticate  Authenticator // nil when players aren't signed in with an identity provider
+	middleware    []Middleware
+	chain         CommandHandler // The middleware around runCommand
 }
 
 // NewCommandRouter creates a new command router
-func NewCommandRouter(lobby *domain.Lobby, connMgr *connection.Manager) *CommandRouter {
-	return &CommandRouter{
-		lobby:   lobby,
-		connMgr: connMgr,
+func NewCommandRouter(lobby *domain.Lobby, connMgr *connection.Manager, scopes *tracing.Scopes) *CommandRouter {
+	r := &CommandRouter{
+		lobby:         lobby,
+		connMgr:       connMgr,
+		scopes:        scopes,
+		confirmations: NewConfirmations(),
+	}
+	r.Use(Recover(), Authorize(lobby), Deduplicate(requestIDWindow), Coalesce(duplicateActionWindow))
+	return r
+}
+
+// HandleCommand processes an incoming command message, and answers the client with an error frame if
+// it fails, or an ACK if it carries a request ID
+func (r *CommandRouter) HandleCommand(ctx context.Context, client *connection.Client, message []byte) error {
+	// Unreadable messages fail the same way in handleCommand
+	var header struct {
+		Name      string `json:"name"`
+		RequestID string `json:"requestId"` // Chosen by the client, echoed back in the answer
 	}
+	_ = json.Unmarshal(message, &header)
+
+	err := r.handleCommand(ctx, client, message)
+	r.acknowledge(ctx, client, header.Name, header.RequestID, err)
+	return err
 }
 
-// HandleCommand processes an incoming command message
-func (r *CommandRouter) HandleCommand(client *connection.Client, message []byte) error {
+// handleCommand processes a command without answering the client
+func (r *CommandRouter) handleCommand(ctx context.Context, client *connection.Client, message []byte) error {
+	receivedAt := time.Now()
+
 	// First determine command type
 	var baseCmd struct {
-		Name string `json:"name"`
+		Name      string `json:"name"`
+		RequestID string `json:"requestId"`
+		TableID   string
+		PlayerID  string
+		HandID    string
 	}
 	if err := json.Unmarshal(message, &baseCmd); err != nil {
 		return err
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "command "+baseCmd.Name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("poker.command", baseCmd.Name),
+			attribute.String("poker.table_id", baseCmd.TableID),
+			attribute.String("poker.client_id", client.ID),
+		),
+	)
+	defer span.End()
+
+	// Events emitted while the command runs are dispatched under its span
+	playerID := baseCmd.PlayerID
+	if client.Player != nil {
+		playerID = client.Player.ID
+	}
+	unbind := r.scopes.Bind(ctx, tracing.TableKey(baseCmd.TableID), tracing.PlayerKey(playerID))
+	defer unbind()
+
+	err := r.chain(ctx, client, Command{
+		Name:       baseCmd.Name,
+		TableID:    baseCmd.TableID,
+		PlayerID:   baseCmd.PlayerID,
+		HandID:     baseCmd.HandID,
+		RequestID:  baseCmd.RequestID,
+		Message:    message,
+		ReceivedAt: receivedAt,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// authorize checks that a command acts for the player bound to the connection. Handlers always take
+// the actor from the connection, a PlayerID in the command is only accepted if it is the same player.
+func authorize(client *connection.Client, name string, claimedPlayerID string) error {
+	switch name {
+	case commands.EnterLobby{}.Name():
+		// Binds the player to the connection, but can't switch to another player once bound
+		if client.Player != nil && claimedPlayerID != client.Player.ID {
+			return ErrNotAuthorized
+		}
+		// Signed-in clients are the player of their ID token
+		if client.Identity != nil && claimedPlayerID != "" && claimedPlayerID != client.Identity.PlayerID {
+			return ErrNotAuthorized
+		}
+		return nil
+
+	case commands.ResumeSession{}.Name():
+		// Binds the session's player to the connection, which must not have one yet
+		if client.Player != nil {
+			return ErrNotAuthorized
+		}
+		return nil
+
+	case commands.TimeSync{}.Name(), commands.RefreshToken{}.Name():
+		return nil
+
+	case commands.CommandBatch{}.Name():
+		// Each command of the batch is authorized on its own
+		return nil
+
+	case commands.SpectateTable{}.Name(), commands.StopSpectating{}.Name():
+		// Watching a table needs no player
+		return nil
+
+	case commands.SubscribeEvents{}.Name():
+		// Spectators may filter their events too
+		return nil
+	}
+
+	if client.Player == nil {
+		return ErrNotInLobby
+	}
+
+	if claimedPlayerID != "" && claimedPlayerID != client.Player.ID {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
+
+// seatedCommands act at a table, only players seated there may send them
+var seatedCommands = map[string]bool{
+	commands.PlayerLeavesTable{}.Name():           true,
+	commands.PlayerBuysIn{}.Name():                true,
+	commands.TopUp{}.Name():                       true,
+	commands.ProposeRematch{}.Name():              true,
+	commands.AcceptRematch{}.Name():               true,
+	commands.PlayerFolds{}.Name():                 true,
+	commands.PlayerPlacesAnte{}.Name():            true,
+	commands.PlayerPlacesContinuationBet{}.Name(): true,
+	commands.PlayerSelectsCommunityCard{}.Name():  true,
+	commands.ConfirmAction{}.Name():               true,
+	commands.BlockPlayer{}.Name():                 true,
+	commands.UnblockPlayer{}.Name():               true,
+	commands.PlayerReady{}.Name():                 true,
+	commands.GetHandView{}.Name():                 true,
+}
+
+// authorizeSeat checks that table commands come from a player seated at the table, and hand commands from
+// a player dealt in the hand. Tables and hands this instance doesn't know are left to the handler to report,
+// or to the instance owning the table, which checks forwarded commands again.
+func authorizeSeat(lobby *domain.Lobby, client *connection.Client, cmd Command) error {
+	if !seatedCommands[cmd.Name] || cmd.TableID == "" || client.Player == nil {
+		return nil
+	}
+
+	table, err := lobby.GetTable(cmd.TableID)
+	if err != nil {
+		return nil
+	}
+	if !table.IsSeated(client.Player.ID) {
+		return ErrNotSeated
+	}
+
+	if cmd.HandID == "" {
+		return nil
+	}
+	hand, err := table.GetHandByID(cmd.HandID)
+	if err != nil {
+		return nil
+	}
+	if !hand.HasPlayer(client.Player.ID) {
+		return ErrNotInHand
+	}
+
+	return nil
+}
+
+// routeCommand decodes the message into its concrete command and calls its handler
+func (r *CommandRouter) routeCommand(ctx context.Context, client *connection.Client, name string, message []byte, receivedAt time.Time) error {
 	// Route to appropriate handler based on command type
-	switch baseCmd.Name {
+	switch name {
 	case commands.EnterLobby{}.Name():
 		var cmd commands.EnterLobby
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handleEnterLobby(client, cmd)
+		return r.handleEnterLobby(ctx, client, cmd)
+
+	case commands.ResumeSession{}.Name():
+		var cmd commands.ResumeSession
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleResumeSession(ctx, client, cmd)
+
+	case commands.RefreshToken{}.Name():
+		var cmd commands.RefreshToken
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleRefreshToken(ctx, client, cmd)
 
 	case commands.LeaveLobby{}.Name():
 		var cmd commands.LeaveLobby
@@ -50,93 +246,234 @@ func (r *CommandRouter) HandleCommand(client *connection.Client, message []byte)
 		}
 		return r.handleLeaveLobby(client, cmd)
 
+	case commands.UpdatePrivacy{}.Name():
+		var cmd commands.UpdatePrivacy
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleUpdatePrivacy(client, cmd)
+
 	case commands.PlayerSeats{}.Name():
 		var cmd commands.PlayerSeats
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerSeats(client, cmd)
+		return r.handlePlayerSeats(ctx, client, cmd)
 
 	case commands.PlayerLeavesTable{}.Name():
 		var cmd commands.PlayerLeavesTable
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerLeavesTable(client, cmd)
+		return r.handlePlayerLeavesTable(ctx, client, cmd)
 
 	case commands.PlayerBuysIn{}.Name():
 		var cmd commands.PlayerBuysIn
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerBuysIn(client, cmd)
+		return r.handlePlayerBuysIn(ctx, client, cmd)
+
+	case commands.TopUp{}.Name():
+		var cmd commands.TopUp
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleTopUp(ctx, client, cmd)
+
+	case commands.ProposeRematch{}.Name():
+		var cmd commands.ProposeRematch
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleProposeRematch(ctx, client, cmd)
+
+	case commands.AcceptRematch{}.Name():
+		var cmd commands.AcceptRematch
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleAcceptRematch(ctx, client, cmd)
 
 	case commands.PlayerFolds{}.Name():
 		var cmd commands.PlayerFolds
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerFolds(client, cmd)
+		return r.handlePlayerFolds(ctx, client, cmd)
+
+	case commands.PlayerReady{}.Name():
+		var cmd commands.PlayerReady
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handlePlayerReady(ctx, client, cmd)
 
 	case commands.PlayerPlacesAnte{}.Name():
 		var cmd commands.PlayerPlacesAnte
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerPlacesAnte(client, cmd)
+		return r.handlePlayerPlacesAnte(ctx, client, cmd)
 
 	case commands.PlayerPlacesContinuationBet{}.Name():
 		var cmd commands.PlayerPlacesContinuationBet
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerPlacesContinuationBet(client, cmd)
+		return r.handlePlayerPlacesContinuationBet(ctx, client, cmd)
 
 	case commands.PlayerSelectsCommunityCard{}.Name():
 		var cmd commands.PlayerSelectsCommunityCard
 		if err := json.Unmarshal(message, &cmd); err != nil {
 			return err
 		}
-		return r.handlePlayerSelectsCommunityCard(client, cmd)
+		return r.handlePlayerSelectsCommunityCard(ctx, client, cmd)
+
+	case commands.ConfirmAction{}.Name():
+		var cmd commands.ConfirmAction
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleConfirmAction(client, cmd)
+
+	case commands.BlockPlayer{}.Name():
+		var cmd commands.BlockPlayer
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleBlockPlayer(ctx, client, cmd)
+
+	case commands.UnblockPlayer{}.Name():
+		var cmd commands.UnblockPlayer
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleUnblockPlayer(ctx, client, cmd)
+
+	case commands.TimeSync{}.Name():
+		var cmd commands.TimeSync
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleTimeSync(ctx, client, cmd, receivedAt)
+
+	case commands.CommandBatch{}.Name():
+		var cmd commands.CommandBatch
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleCommandBatch(ctx, client, cmd)
+
+	case commands.SpectateTable{}.Name():
+		var cmd commands.SpectateTable
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleSpectateTable(ctx, client, cmd)
+
+	case commands.StopSpectating{}.Name():
+		var cmd commands.StopSpectating
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleStopSpectating(client, cmd)
+
+	case commands.GetHandView{}.Name():
+		var cmd commands.GetHandView
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleGetHandView(ctx, client, cmd)
+
+	case commands.SubscribeEvents{}.Name():
+		var cmd commands.SubscribeEvents
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			return err
+		}
+		return r.handleSubscribeEvents(ctx, client, cmd)
 
 	default:
-		fmt.Println("unknown command type", baseCmd.Name)
-		return errors.New("unknown command type")
+		fmt.Println("unknown command type", name)
+		return ErrUnknownCommand
 	}
 }
 
-func (r *CommandRouter) handleEnterLobby(client *connection.Client, cmd commands.EnterLobby) error {
+func (r *CommandRouter) handleEnterLobby(ctx context.Context, client *connection.Client, cmd commands.EnterLobby) error {
+	if err := r.requireIdentity(client); err != nil {
+		return err
+	}
+
 	// Initialize Player if not already set
 	if client.Player == nil {
+		// Signed-in players are who their ID token says, the name they chose goes before the provider's
+		playerID, name := cmd.PlayerID, cmd.PlayerName
+		if client.Identity != nil {
+			playerID = client.Identity.PlayerID
+			if name == "" {
+				name = client.Identity.Name
+			}
+		}
+
 		// Create a new player - in future we'd fetch this from a database
-		client.Player = &domain.Player{
-			ID:      cmd.PlayerID,
-			Name:    cmd.PlayerName,
-			Status:  "active",
-			Balance: 1_000, // Default starting balance
+		player, err := r.newPlayer(playerID, name)
+		if err != nil {
+			return err
+		}
+		player.Country = cmd.Country
+		if err := player.SetLocale(cmd.Locale, cmd.TimeZone); err != nil {
+			return err
 		}
+		client.Player = player
 
 		// Register the player ID with the client ID in the connection manager
-		r.connMgr.AddPlayerToClient(client.ID, cmd.PlayerID)
+		r.connMgr.AddPlayerToClient(client.ID, playerID)
 	}
 
 	if err := r.lobby.EntersLobby(client.Player); err != nil {
 		return err
 	}
-	return nil
+	return r.startSession(ctx, client)
+}
+
+// startingBalance is what new players get to play with
+const startingBalance = 1_000
+
+// newPlayer creates a player with the starting balance. With a wallet, the balance is only credited
+// the first time the player is seen, returning players get their wallet balance.
+func (r *CommandRouter) newPlayer(playerID string, name string) (*domain.Player, error) {
+	player := &domain.Player{
+		ID:     playerID,
+		Name:   name,
+		Status: "active",
+	}
+
+	if err := player.Deposit(r.lobby.Wallet, startingBalance, "welcome/"+playerID, "starting balance"); err != nil {
+		return nil, err
+	}
+	return player, nil
 }
 
 func (r *CommandRouter) handleLeaveLobby(client *connection.Client, cmd commands.LeaveLobby) error {
-	if err := r.lobby.LeavesLobby(cmd.PlayerID); err != nil {
+	if err := r.lobby.LeavesLobby(client.Player.ID); err != nil {
 		return err
 	}
 	return nil
 }
 
+// handleUpdatePrivacy replaces the player's privacy settings, at every table they sit at too
+func (r *CommandRouter) handleUpdatePrivacy(client *connection.Client, cmd commands.UpdatePrivacy) error {
+	return r.lobby.SetPlayerPrivacy(client.Player.ID, domain.Privacy{
+		HideStats:            cmd.HideStats,
+		HideFromLeaderboards: cmd.HideFromLeaderboards,
+		HideCountry:          cmd.HideCountry,
+	})
+}
+
 // Command handler implementations
-func (r *CommandRouter) handlePlayerSeats(client *connection.Client, cmd commands.PlayerSeats) error {
+func (r *CommandRouter) handlePlayerSeats(ctx context.Context, client *connection.Client, cmd commands.PlayerSeats) error {
 	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
+		return ErrNotInLobby
 	}
 
 	table, err := r.lobby.GetTable(cmd.TableID)
@@ -146,7 +483,7 @@ func (r *CommandRouter) handlePlayerSeats(client *connection.Client, cmd command
 
 	player := client.Player
 
-	if err := table.SeatPlayer(player); err != nil {
+	if err := inGameLoop(ctx, table, "seat", func() error { return table.SeatPlayer(player) }); err != nil {
 		return err
 	}
 
@@ -155,13 +492,13 @@ func (r *CommandRouter) handlePlayerSeats(client *connection.Client, cmd command
 	return nil
 }
 
-func (r *CommandRouter) handlePlayerLeavesTable(client *connection.Client, cmd commands.PlayerLeavesTable) error {
+func (r *CommandRouter) handlePlayerLeavesTable(ctx context.Context, client *connection.Client, cmd commands.PlayerLeavesTable) error {
 	table, err := r.lobby.GetTable(cmd.TableID)
 	if err != nil {
 		return err
 	}
 
-	if err := table.PlayerLeaves(client.Player.ID); err != nil {
+	if err := inGameLoop(ctx, table, "leave", func() error { return table.PlayerLeaves(client.Player.ID) }); err != nil {
 		return err
 	}
 
@@ -175,9 +512,9 @@ func (r *CommandRouter) handlePlayerLeavesTable(client *connection.Client, cmd c
 	return nil
 }
 
-func (r *CommandRouter) handlePlayerBuysIn(client *connection.Client, cmd commands.PlayerBuysIn) error {
+func (r *CommandRouter) handlePlayerBuysIn(ctx context.Context, client *connection.Client, cmd commands.PlayerBuysIn) error {
 	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
+		return ErrNotInLobby
 	}
 
 	table, err := r.lobby.GetTable(cmd.TableID)
@@ -185,82 +522,170 @@ func (r *CommandRouter) handlePlayerBuysIn(client *connection.Client, cmd comman
 		return err
 	}
 
-	if err := table.PlayerBuysIn(client.Player.ID, cmd.Amount); err != nil {
+	if err := inGameLoop(ctx, table, "buy-in", func() error { return table.PlayerBuysIn(client.Player.ID, cmd.Amount) }); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *CommandRouter) handlePlayerFolds(client *connection.Client, cmd commands.PlayerFolds) error {
-	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
-	}
-
+func (r *CommandRouter) handleTopUp(ctx context.Context, client *connection.Client, cmd commands.TopUp) error {
 	table, err := r.lobby.GetTable(cmd.TableID)
 	if err != nil {
 		return err
 	}
 
-	hand, err := table.GetHandByID(cmd.HandID)
+	// Queued top-ups are announced by PLAYER_TOPPED_UP once the hand is over
+	return inGameLoop(ctx, table, "top-up", func() error {
+		_, err := table.TopUp(client.Player.ID, cmd.Amount)
+		return err
+	})
+}
+
+func (r *CommandRouter) handleProposeRematch(ctx context.Context, client *connection.Client, cmd commands.ProposeRematch) error {
+	table, err := r.lobby.GetTable(cmd.TableID)
 	if err != nil {
 		return err
 	}
 
-	if err := hand.PlayerFolds(client.Player.ID); err != nil {
+	return inGameLoop(ctx, table, "propose-rematch", func() error {
+		return table.ProposeRematch(client.Player.ID, cmd.BuyIn)
+	})
+}
+
+func (r *CommandRouter) handleAcceptRematch(ctx context.Context, client *connection.Client, cmd commands.AcceptRematch) error {
+	table, err := r.lobby.GetTable(cmd.TableID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return inGameLoop(ctx, table, "accept-rematch", func() error {
+		return table.AcceptRematch(client.Player.ID)
+	})
+}
+
+func (r *CommandRouter) handlePlayerFolds(ctx context.Context, client *connection.Client, cmd commands.PlayerFolds) error {
+	return r.submitAction(ctx, client, cmd.TableID, domain.Action{
+		Type:        domain.ActionFold,
+		HandID:      cmd.HandID,
+		Phase:       cmd.Phase,
+		LastEventID: cmd.LastEventID,
+	})
+}
+
+func (r *CommandRouter) handlePlayerReady(ctx context.Context, client *connection.Client, cmd commands.PlayerReady) error {
+	return r.submitAction(ctx, client, cmd.TableID, domain.Action{
+		Type:   domain.ActionReady,
+		HandID: cmd.HandID,
+	})
 }
 
-func (r *CommandRouter) handlePlayerPlacesAnte(client *connection.Client, cmd commands.PlayerPlacesAnte) error {
+func (r *CommandRouter) handlePlayerPlacesAnte(ctx context.Context, client *connection.Client, cmd commands.PlayerPlacesAnte) error {
+	return r.submitAction(ctx, client, cmd.TableID, domain.Action{
+		Type:        domain.ActionAnte,
+		HandID:      cmd.HandID,
+		Phase:       cmd.Phase,
+		LastEventID: cmd.LastEventID,
+		Amount:      cmd.Amount,
+	})
+}
+
+func (r *CommandRouter) handlePlayerPlacesContinuationBet(ctx context.Context, client *connection.Client, cmd commands.PlayerPlacesContinuationBet) error {
 	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
+		return ErrNotInLobby
 	}
 
-	table, err := r.lobby.GetTable(cmd.TableID)
+	engine, err := r.lobby.GetEngine(cmd.TableID)
 	if err != nil {
 		return err
 	}
 
-	hand, err := table.GetHandByID(cmd.HandID)
-	if err != nil {
-		return err
+	action := domain.Action{
+		Type:        domain.ActionContinuationBet,
+		PlayerID:    client.Player.ID,
+		HandID:      cmd.HandID,
+		Phase:       cmd.Phase,
+		LastEventID: cmd.LastEventID,
+		Amount:      cmd.Amount,
 	}
 
-	if err := hand.PlayerPlacesAnte(client.Player.ID, cmd.Amount); err != nil {
-		return err
+	// Large bets wait for the client to confirm them, to guard against misclicks
+	if confirmer, ok := engine.(domain.BetConfirmer); ok {
+		required, err := confirmer.RequiresConfirmation(action)
+		if err != nil {
+			return err
+		}
+		if required {
+			// The hand may move on while the player is confirming, the engine checks the bet again then
+			confirmed := action
+			confirmed.LastEventID = ""
+			token, expiresAt := r.confirmations.Request(action.PlayerID, func() error {
+				return engine.SubmitAction(confirmed)
+			})
+			return r.sendToClient(ctx, client, ConfirmationRequired{
+				Token:     token,
+				TableID:   cmd.TableID,
+				HandID:    cmd.HandID,
+				Command:   cmd.Name(),
+				Amount:    cmd.Amount,
+				ExpiresAt: expiresAt,
+			})
+		}
 	}
 
-	return nil
+	return submitToEngine(ctx, engine, cmd.TableID, action)
 }
 
-func (r *CommandRouter) handlePlayerPlacesContinuationBet(client *connection.Client, cmd commands.PlayerPlacesContinuationBet) error {
+func (r *CommandRouter) handlePlayerSelectsCommunityCard(ctx context.Context, client *connection.Client, cmd commands.PlayerSelectsCommunityCard) error {
+	return r.submitAction(ctx, client, cmd.TableID, domain.Action{
+		Type:        domain.ActionSelectCommunityCard,
+		HandID:      cmd.HandID,
+		Phase:       cmd.Phase,
+		LastEventID: cmd.LastEventID,
+		Card:        cmd.Card,
+	})
+}
+
+// submitAction hands a player action to the engine running the table, acting for the connection's player
+func (r *CommandRouter) submitAction(ctx context.Context, client *connection.Client, tableID string, action domain.Action) error {
 	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
+		return ErrNotInLobby
 	}
 
-	table, err := r.lobby.GetTable(cmd.TableID)
+	engine, err := r.lobby.GetEngine(tableID)
 	if err != nil {
 		return err
 	}
 
-	hand, err := table.GetHandByID(cmd.HandID)
+	action.PlayerID = client.Player.ID
+	return submitToEngine(ctx, engine, tableID, action)
+}
+
+func (r *CommandRouter) handleBlockPlayer(ctx context.Context, client *connection.Client, cmd commands.BlockPlayer) error {
+	if client.Player == nil {
+		return ErrNotInLobby
+	}
+
+	table, err := r.lobby.GetTable(cmd.TableID)
 	if err != nil {
 		return err
 	}
 
-	if err := hand.PlayerPlacesContinuationBet(client.Player.ID, cmd.Amount); err != nil {
-		return err
+	ban := domain.Ban{
+		PlayerID: cmd.TargetPlayerID,
+		Reason:   domain.BanReason(cmd.Reason),
+		Note:     cmd.Note,
+	}
+	if cmd.DurationSeconds > 0 {
+		ban.ExpiresAt = time.Now().Add(time.Duration(cmd.DurationSeconds) * time.Second)
 	}
 
-	return nil
+	return inGameLoop(ctx, table, "block", func() error { return table.BlockPlayer(client.Player.ID, ban) })
 }
 
-func (r *CommandRouter) handlePlayerSelectsCommunityCard(client *connection.Client, cmd commands.PlayerSelectsCommunityCard) error {
-	if !r.lobby.IsInLobby(client.Player.ID) {
-		return errors.New("client is not in the lobby")
+func (r *CommandRouter) handleUnblockPlayer(ctx context.Context, client *connection.Client, cmd commands.UnblockPlayer) error {
+	if client.Player == nil {
+		return ErrNotInLobby
 	}
 
 	table, err := r.lobby.GetTable(cmd.TableID)
@@ -268,14 +693,41 @@ func (r *CommandRouter) handlePlayerSelectsCommunityCard(client *connection.Clie
 		return err
 	}
 
-	hand, err := table.GetHandByID(cmd.HandID)
+	return inGameLoop(ctx, table, "unblock", func() error { return table.UnblockPlayer(client.Player.ID, cmd.TargetPlayerID) })
+}
+
+func (r *CommandRouter) handleConfirmAction(client *connection.Client, cmd commands.ConfirmAction) error {
+	if client.Player == nil {
+		return ErrNotInLobby
+	}
+
+	return r.confirmations.Confirm(client.Player.ID, cmd.Token)
+}
+
+// Response is a message sent back to the client that issued a command, rather than broadcast as an event
+type Response interface {
+	Name() string
+}
+
+// sendToClient writes a response directly to the client that sent the command
+func (r *CommandRouter) sendToClient(ctx context.Context, client *connection.Client, response Response) error {
+	payload, err := json.Marshal(response)
 	if err != nil {
 		return err
 	}
 
-	if err := hand.PlayerSelectsCommunityCard(client.Player.ID, cmd.Card); err != nil {
+	data, err := json.Marshal(serverEvents.EventEnvelope{
+		Name:    response.Name(),
+		Payload: payload,
+	})
+	if err != nil {
 		return err
 	}
 
-	return nil
+	select {
+	case client.Send <- connection.Message{Data: data, Ctx: ctx, Queued: time.Now()}:
+		return nil
+	default:
+		return errors.New("client send buffer is full")
+	}
 }
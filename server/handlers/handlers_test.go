@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seatFixture is a lobby with a table in the middle of a hand: player-1 and player-2 were dealt in,
+// player-3 sat down after it started, and player-4 is in the lobby only
+type seatFixture struct {
+	router *CommandRouter
+	table  *domain.Table
+	hand   *domain.Hand
+}
+
+func newSeatFixture(t *testing.T) seatFixture {
+	lobby := &domain.Lobby{}
+	table, err := lobby.NewTable("Test Table", domain.TableRules{AnteValue: 10})
+	require.NoError(t, err)
+
+	table.Players = []*domain.Player{{ID: "player-1"}, {ID: "player-2"}}
+	table.Status = domain.TableStatusPlaying
+	hand, err := table.StartNewHand()
+	require.NoError(t, err)
+	table.Players = append(table.Players, &domain.Player{ID: "player-3"})
+
+	return seatFixture{
+		router: NewCommandRouter(lobby, connection.NewManager(), tracing.NewScopes()),
+		table:  table,
+		hand:   hand,
+	}
+}
+
+// client is a connection bound to the player
+func (f seatFixture) client(playerID string) *connection.Client {
+	return &connection.Client{ID: "conn-" + playerID, Send: make(chan connection.Message, 8), Player: &domain.Player{ID: playerID}}
+}
+
+// send runs the command through the router, without answering the client
+func (f seatFixture) send(t *testing.T, client *connection.Client, name string, fields map[string]any) error {
+	fields["name"] = name
+	message, err := json.Marshal(fields)
+	require.NoError(t, err)
+	return f.router.handleCommand(context.Background(), client, message)
+}
+
+func TestAuthorizeSeat(t *testing.T) {
+	t.Run("A player dealt in the hand gets through", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		client := f.client("player-1")
+
+		// Act
+		err := f.send(t, client, commands.GetHandView{}.Name(), map[string]any{"TableID": f.table.ID, "HandID": f.hand.ID})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, client.Send, 1)
+		assert.Contains(t, string((<-client.Send).Data), `"HAND_VIEW"`)
+	})
+
+	t.Run("Acting for another player is rejected", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+
+		// Act
+		err := f.send(t, f.client("player-1"), commands.PlayerFolds{}.Name(), map[string]any{"PlayerID": "player-2", "TableID": f.table.ID, "HandID": f.hand.ID})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotAuthorized)
+	})
+
+	t.Run("A player not seated at the table is rejected", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+
+		// Act
+		err := f.send(t, f.client("player-4"), commands.PlayerFolds{}.Name(), map[string]any{"TableID": f.table.ID, "HandID": f.hand.ID})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotSeated)
+	})
+
+	t.Run("A seated player not dealt in the hand is rejected", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+
+		// Act
+		err := f.send(t, f.client("player-3"), commands.PlayerFolds{}.Name(), map[string]any{"TableID": f.table.ID, "HandID": f.hand.ID})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotInHand)
+	})
+
+	t.Run("Tables this instance doesn't know are left to the handler", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+
+		// Act
+		err := f.send(t, f.client("player-4"), commands.GetHandView{}.Name(), map[string]any{"TableID": "unknown"})
+
+		// Assert
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotSeated)
+		assert.EqualError(t, err, "table not found")
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("Entering the lobby binds a new connection to the player", func(t *testing.T) {
+		// Act & Assert
+		assert.NoError(t, authorize(&connection.Client{}, commands.EnterLobby{}.Name(), "player-1"))
+	})
+
+	t.Run("A bound connection enters the lobby again as the same player only", func(t *testing.T) {
+		// Setup
+		client := &connection.Client{Player: &domain.Player{ID: "player-1"}}
+
+		// Act & Assert
+		assert.NoError(t, authorize(client, commands.EnterLobby{}.Name(), "player-1"))
+		assert.ErrorIs(t, authorize(client, commands.EnterLobby{}.Name(), "player-2"), ErrNotAuthorized)
+	})
+
+	t.Run("Signed-in clients enter the lobby as the player of their ID token", func(t *testing.T) {
+		// Setup
+		client := &connection.Client{Identity: &connection.Identity{PlayerID: "player-1"}}
+
+		// Act & Assert
+		assert.NoError(t, authorize(client, commands.EnterLobby{}.Name(), "player-1"))
+		assert.ErrorIs(t, authorize(client, commands.EnterLobby{}.Name(), "player-2"), ErrNotAuthorized)
+	})
+
+	t.Run("A bound connection can't resume another session", func(t *testing.T) {
+		// Setup
+		client := &connection.Client{Player: &domain.Player{ID: "player-1"}}
+
+		// Act & Assert
+		assert.ErrorIs(t, authorize(client, commands.ResumeSession{}.Name(), ""), ErrNotAuthorized)
+		assert.NoError(t, authorize(&connection.Client{}, commands.ResumeSession{}.Name(), ""))
+	})
+
+	t.Run("Commands act for the connection's player only", func(t *testing.T) {
+		// Setup
+		client := &connection.Client{Player: &domain.Player{ID: "player-1"}}
+
+		// Act & Assert
+		assert.NoError(t, authorize(client, commands.BlockPlayer{}.Name(), ""))
+		assert.NoError(t, authorize(client, commands.BlockPlayer{}.Name(), "player-1"))
+		assert.ErrorIs(t, authorize(client, commands.BlockPlayer{}.Name(), "player-2"), ErrNotAuthorized)
+	})
+
+	t.Run("Commands need a player once past the lobby", func(t *testing.T) {
+		// Act & Assert
+		assert.ErrorIs(t, authorize(&connection.Client{}, commands.PlayerFolds{}.Name(), ""), ErrNotInLobby)
+		assert.NoError(t, authorize(&connection.Client{}, commands.SpectateTable{}.Name(), ""))
+	})
+
+	t.Run("Blocking a player names them in TargetPlayerID, not PlayerID", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		f.table.HostID = "player-1"
+		host := f.client("player-1")
+
+		// Act
+		oldFormat := f.send(t, host, commands.BlockPlayer{}.Name(), map[string]any{"PlayerID": "player-2", "TableID": f.table.ID, "Reason": "abuse"})
+		newFormat := f.send(t, host, commands.BlockPlayer{}.Name(), map[string]any{"TableID": f.table.ID, "TargetPlayerID": "player-2", "Reason": "abuse"})
+
+		// Assert
+		assert.ErrorIs(t, oldFormat, ErrNotAuthorized)
+		require.NoError(t, newFormat)
+		blocklist := f.table.GetBlocklist()
+		require.Len(t, blocklist, 1)
+		assert.Equal(t, "player-2", blocklist[0].PlayerID)
+		assert.Equal(t, "player-1", blocklist[0].By)
+	})
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	serverEvents "github.com/lazharichir/poker/server/events"
+)
+
+func (r *CommandRouter) handleGetHandView(ctx context.Context, client *connection.Client, cmd commands.GetHandView) error {
+	table, err := r.lobby.GetTable(cmd.TableID)
+	if err != nil {
+		return err
+	}
+
+	update := serverEvents.HandViewUpdate{TableID: cmd.TableID}
+	err = inGameLoop(ctx, table, "hand view", func() error {
+		if hand := table.ActiveHand; hand != nil && hand.HasPlayer(client.Player.ID) {
+			view := hand.BuildPlayerView(client.Player.ID)
+			update.Hand = &view
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.sendToClient(ctx, client, update)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// ErrNotAuthenticated is returned when players must sign in with the identity provider and the client
+// sent no valid ID token
+var ErrNotAuthenticated = errors.New("not signed in with the identity provider")
+
+// ErrTokenExpired is returned for commands of clients whose ID token expired, until they send a fresh one
+var ErrTokenExpired = errors.New("ID token expired, send REFRESH_TOKEN")
+
+// ErrNoIdentityProvider is returned for REFRESH_TOKEN when the server doesn't sign players in with a provider
+var ErrNoIdentityProvider = errors.New("no identity provider is configured")
+
+// Authenticator verifies an ID token and returns who it identifies. Its errors wrap ErrNotAuthenticated
+// or ErrTokenExpired.
+type Authenticator func(ctx context.Context, idToken string) (connection.Identity, error)
+
+// SetAuthenticator makes players sign in with an identity provider: clients must present an ID token,
+// on connect or with REFRESH_TOKEN, before entering the lobby or resuming a session
+func (r *CommandRouter) SetAuthenticator(authenticate Authenticator) {
+	r.authenticate = authenticate
+}
+
+// requireIdentity checks that the client signed in, when the server signs players in with a provider
+func (r *CommandRouter) requireIdentity(client *connection.Client) error {
+	if r.authenticate != nil && client.Identity == nil {
+		return ErrNotAuthenticated
+	}
+	return nil
+}
+
+// authorizeIdentity rejects the commands of clients whose ID token expired, except those that don't act for
+// the player. Commands of a batch are checked one by one.
+func authorizeIdentity(client *connection.Client, cmd Command) error {
+	if client.Identity == nil {
+		return nil
+	}
+
+	switch cmd.Name {
+	case commands.RefreshToken{}.Name(), commands.TimeSync{}.Name(), commands.CommandBatch{}.Name():
+		return nil
+	}
+
+	if !cmd.ReceivedAt.Before(client.Identity.ExpiresAt) {
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+// handleRefreshToken replaces the client's identity with the one of a fresh ID token, which must be the same player's
+func (r *CommandRouter) handleRefreshToken(ctx context.Context, client *connection.Client, cmd commands.RefreshToken) error {
+	if r.authenticate == nil {
+		return ErrNoIdentityProvider
+	}
+
+	identity, err := r.authenticate(ctx, cmd.IDToken)
+	if err != nil {
+		return err
+	}
+
+	if client.Identity != nil && identity.PlayerID != client.Identity.PlayerID {
+		return ErrNotAuthorized
+	}
+	if client.Player != nil && identity.PlayerID != client.Player.ID {
+		return ErrNotAuthorized
+	}
+
+	client.Identity = &identity
+	return nil
+}
@@ -0,0 +1,27 @@
+package handlers
+
+// inFlight is the result of a command handled once on behalf of its repeats, which wait for it
+type inFlight struct {
+	done chan struct{} // Closed once the command was handled
+	err  error
+}
+
+func newInFlight() inFlight {
+	return inFlight{done: make(chan struct{})}
+}
+
+// run handles the command. Repeats are released whatever happens to it, a panic fails it as Recover does.
+func (f *inFlight) run(handle func() error) (err error) {
+	err = ErrInternal
+	defer func() {
+		f.err = err
+		close(f.done)
+	}()
+	return handle()
+}
+
+// wait returns the answer of the command once it was handled
+func (f *inFlight) wait() error {
+	<-f.done
+	return f.err
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// ErrInternal is returned for commands whose handler panicked
+var ErrInternal = errors.New("internal error")
+
+// ErrRateLimited is returned for commands sent faster than the connection is allowed to
+var ErrRateLimited = errors.New("too many commands, slow down")
+
+// Command is a command message on its way to its handler
+type Command struct {
+	Name       string
+	TableID    string // Empty for commands that don't target a table
+	PlayerID   string // Player the command claims to act for, empty if it doesn't say
+	HandID     string // Empty for commands that don't target a hand
+	RequestID  string // Chosen by the client to match the answer, and to resend the command safely
+	Message    []byte // The complete message, decoded by the handler
+	ReceivedAt time.Time
+}
+
+// CommandHandler runs a command sent by a client
+type CommandHandler func(ctx context.Context, client *connection.Client, cmd Command) error
+
+// Middleware wraps the handling of every command, to deal with concerns shared by all handlers
+type Middleware func(next CommandHandler) CommandHandler
+
+// Use adds middleware around every command, including those of batches and those forwarded by other
+// instances. Middleware added first runs first: the router starts with Recover, Authorize, Deduplicate then Coalesce.
+func (r *CommandRouter) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+	r.chain = r.runCommand
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		r.chain = r.middleware[i](r.chain)
+	}
+}
+
+// Recover turns a panicking handler into an ErrInternal failure, so one command can't take the server down
+func Recover() Middleware {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					log.Printf("Panic handling %s command of client %s: %v\n%s", cmd.Name, client.ID, recovered, debug.Stack())
+					err = ErrInternal
+				}
+			}()
+			return next(ctx, client, cmd)
+		}
+	}
+}
+
+// Authorize rejects commands that act for another player than the connection's, see authorize,
+// commands for a table the player isn't seated at or a hand they weren't dealt in, see authorizeSeat,
+// and commands sent once the client's ID token expired, see authorizeIdentity
+func Authorize(lobby *domain.Lobby) Middleware {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if err := authorizeIdentity(client, cmd); err != nil {
+				return err
+			}
+			if err := authorize(client, cmd.Name, cmd.PlayerID); err != nil {
+				return err
+			}
+			if err := authorizeSeat(lobby, client, cmd); err != nil {
+				return err
+			}
+			return next(ctx, client, cmd)
+		}
+	}
+}
+
+// Logging logs every command with how long it took and how it went
+func Logging() Middleware {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			err := next(ctx, client, cmd)
+			if err != nil {
+				log.Printf("Command %s of client %s failed after %s: %v", cmd.Name, client.ID, time.Since(cmd.ReceivedAt), err)
+			} else {
+				log.Printf("Command %s of client %s handled in %s", cmd.Name, client.ID, time.Since(cmd.ReceivedAt))
+			}
+			return err
+		}
+	}
+}
+
+// RateLimit allows each connection up to limit commands per window, the commands of a batch count one by one
+func RateLimit(limit int, window time.Duration) Middleware {
+	limiter := &rateLimiter{limit: limit, window: window, windows: make(map[string]*rateWindow)}
+
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			if !limiter.allow(client.ID, cmd.ReceivedAt) {
+				return ErrRateLimited
+			}
+			return next(ctx, client, cmd)
+		}
+	}
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter counts commands in fixed windows, per connection
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[string]*rateWindow // By client ID
+}
+
+func (l *rateLimiter) allow(clientID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current, exists := l.windows[clientID]
+	if !exists || now.Sub(current.start) >= l.window {
+		// Connections come and go, forget those whose window is over
+		for id, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, id)
+			}
+		}
+		current = &rateWindow{start: now}
+		l.windows[clientID] = current
+	}
+
+	current.count++
+	return current.count <= l.limit
+}
+
+// Metrics records every command in stats
+func Metrics(stats *CommandStats) Middleware {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			err := next(ctx, client, cmd)
+			stats.Record(cmd.Name, time.Since(cmd.ReceivedAt), err)
+			return err
+		}
+	}
+}
+
+// CommandErrors reports every failed command to record, with its COMMAND_ERROR code. Commands the router
+// doesn't know are reported as "(unknown)", clients can't make up names to report.
+func CommandErrors(record func(command string, code string)) Middleware {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			err := next(ctx, client, cmd)
+			if err != nil {
+				name, code := cmd.Name, errorCode(err)
+				if code == ErrorCodeUnknownCommand {
+					name = "(unknown)"
+				}
+				record(name, code)
+			}
+			return err
+		}
+	}
+}
+
+// CommandMetrics sums up the commands handled for one command name
+type CommandMetrics struct {
+	Name    string         `json:"name"`
+	Count   int            `json:"count"`
+	Errors  map[string]int `json:"errors,omitempty"` // By COMMAND_ERROR code
+	TotalMs float64        `json:"totalMs"`
+	MaxMs   float64        `json:"maxMs"`
+}
+
+// CommandStats tracks the commands handled by the router, by command name
+type CommandStats struct {
+	mu       sync.Mutex
+	commands map[string]*CommandMetrics
+}
+
+// NewCommandStats creates empty command statistics
+func NewCommandStats() *CommandStats {
+	return &CommandStats{commands: make(map[string]*CommandMetrics)}
+}
+
+// Record counts a command that took the given time, and failed if err isn't nil
+func (s *CommandStats) Record(name string, took time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == "" {
+		name = "(unnamed)"
+	}
+	stats, exists := s.commands[name]
+	if !exists {
+		stats = &CommandMetrics{Name: name}
+		s.commands[name] = stats
+	}
+
+	ms := float64(took) / float64(time.Millisecond)
+	stats.Count++
+	stats.TotalMs += ms
+	stats.MaxMs = max(stats.MaxMs, ms)
+	if err != nil {
+		if stats.Errors == nil {
+			stats.Errors = make(map[string]int)
+		}
+		stats.Errors[errorCode(err)]++
+	}
+}
+
+// Snapshot returns the statistics of every command name, most used first
+func (s *CommandStats) Snapshot() []CommandMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make([]CommandMetrics, 0, len(s.commands))
+	for _, stats := range s.commands {
+		metrics := *stats
+		metrics.Errors = maps.Clone(stats.Errors)
+		snapshot = append(snapshot, metrics)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Count != snapshot[j].Count {
+			return snapshot[i].Count > snapshot[j].Count
+		}
+		return snapshot[i].Name < snapshot[j].Name
+	})
+	return snapshot
+}
+
+// runCommand is the end of the middleware chain: it runs the command here, or on the instance owning its table
+func (r *CommandRouter) runCommand(ctx context.Context, client *connection.Client, cmd Command) error {
+	if owner, remote := r.remoteOwner(ctx, cmd.TableID); remote && !isSpectatorCommand(cmd.Name) {
+		return r.forwardCommand(ctx, client, owner, cmd.Name, cmd.TableID, cmd.Message)
+	}
+	return r.routeCommand(ctx, client, cmd.Name, cmd.Message, cmd.ReceivedAt)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	// recording is middleware noting its name when a command goes through it
+	recording := func(name string, order *[]string) Middleware {
+		return func(next CommandHandler) CommandHandler {
+			return func(ctx context.Context, client *connection.Client, cmd Command) error {
+				*order = append(*order, name)
+				return next(ctx, client, cmd)
+			}
+		}
+	}
+	panicking := func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, client *connection.Client, cmd Command) error {
+			panic("boom")
+		}
+	}
+
+	t.Run("Middleware added first runs first", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		var order []string
+		f.router.Use(recording("first", &order), recording("second", &order), recording("third", &order))
+
+		// Act
+		f.send(t, f.client("player-1"), commands.TimeSync{}.Name(), map[string]any{})
+
+		// Assert
+		assert.Equal(t, []string{"first", "second", "third"}, order)
+	})
+
+	t.Run("Recover is outermost, it catches panics of the middleware added after it", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		var order []string
+		f.router.Use(recording("outer", &order), panicking, recording("inner", &order))
+
+		// Act
+		err := f.send(t, f.client("player-1"), commands.TimeSync{}.Name(), map[string]any{})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInternal)
+		assert.Equal(t, []string{"outer"}, order)
+	})
+
+	t.Run("A panicking command is answered with an internal error frame", func(t *testing.T) {
+		// Setup
+		f := newSeatFixture(t)
+		f.router.Use(panicking)
+		client := f.client("player-1")
+
+		// Act
+		err := f.handle(t, client, commands.TimeSync{}.Name(), map[string]any{"requestId": "request-1"})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInternal)
+		name, frame := answer(t, client)
+		assert.Equal(t, (CommandFailed{}).Name(), name)
+		assert.Equal(t, ErrorCodeInternal, frame.Code)
+		assert.Equal(t, "request-1", frame.RequestID)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	client := &connection.Client{ID: "client-1"}
+
+	t.Run("A panic becomes an internal error", func(t *testing.T) {
+		// Setup
+		handler := Recover()(func(ctx context.Context, client *connection.Client, cmd Command) error {
+			panic("boom")
+		})
+
+		// Act
+		err := handler(context.Background(), client, Command{Name: "PANICS"})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInternal)
+	})
+
+	t.Run("Errors and successes pass through", func(t *testing.T) {
+		// Setup
+		failure := assert.AnError
+		fails := Recover()(func(ctx context.Context, client *connection.Client, cmd Command) error { return failure })
+		succeeds := Recover()(func(ctx context.Context, client *connection.Client, cmd Command) error { return nil })
+
+		// Act & Assert
+		assert.ErrorIs(t, fails(context.Background(), client, Command{}), failure)
+		assert.NoError(t, succeeds(context.Background(), client, Command{}))
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	handled := func(ctx context.Context, client *connection.Client, cmd Command) error { return nil }
+	client := &connection.Client{ID: "client-1", Player: &domain.Player{ID: "player-1"}}
+	at := func(now time.Time) Command {
+		return Command{Name: commands.TimeSync{}.Name(), ReceivedAt: now}
+	}
+
+	t.Run("Commands beyond the limit are rejected until the window is over", func(t *testing.T) {
+		// Setup
+		handler := RateLimit(2, time.Second)(handled)
+		now := time.Now()
+
+		// Act
+		first := handler(context.Background(), client, at(now))
+		second := handler(context.Background(), client, at(now.Add(100*time.Millisecond)))
+		third := handler(context.Background(), client, at(now.Add(200*time.Millisecond)))
+		later := handler(context.Background(), client, at(now.Add(time.Second)))
+
+		// Assert
+		assert.NoError(t, first)
+		assert.NoError(t, second)
+		assert.ErrorIs(t, third, ErrRateLimited)
+		assert.NoError(t, later, "a new window started")
+	})
+
+	t.Run("Each connection has its own limit", func(t *testing.T) {
+		// Setup
+		handler := RateLimit(1, time.Second)(handled)
+		other := &connection.Client{ID: "client-2", Player: &domain.Player{ID: "player-2"}}
+		now := time.Now()
+
+		// Act
+		require.NoError(t, handler(context.Background(), client, at(now)))
+		limited := handler(context.Background(), client, at(now))
+		otherErr := handler(context.Background(), other, at(now))
+
+		// Assert
+		assert.ErrorIs(t, limited, ErrRateLimited)
+		assert.NoError(t, otherErr)
+	})
+
+	t.Run("Rate limited commands are answered with their own error code", func(t *testing.T) {
+		// Act & Assert
+		assert.Equal(t, ErrorCodeRateLimited, errorCode(ErrRateLimited))
+	})
+}
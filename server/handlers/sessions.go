@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// SessionStarted gives the client the token to resume its session with, should the connection drop
+type SessionStarted struct {
+	Token            string
+	ReconnectGraceMs int64 // How long the session survives a disconnection
+}
+
+func (s SessionStarted) Name() string { return "SESSION_STARTED" }
+
+// SessionResumed brings a reconnected client up to date with the hands played at its tables
+type SessionResumed struct {
+	PlayerID         string
+	Token            string // Replaces the token the session was resumed with
+	ReconnectGraceMs int64
+	TableIDs         []string
+	Hands            []domain.HandView // The player's view of the hands in progress at their tables
+}
+
+func (s SessionResumed) Name() string { return "SESSION_RESUMED" }
+
+// startSession sends the client a token to resume its session with
+func (r *CommandRouter) startSession(ctx context.Context, client *connection.Client) error {
+	token, err := r.connMgr.StartSession(client)
+	if err != nil {
+		return err
+	}
+
+	return r.sendToClient(ctx, client, SessionStarted{
+		Token:            token,
+		ReconnectGraceMs: r.connMgr.ReconnectGrace().Milliseconds(),
+	})
+}
+
+func (r *CommandRouter) handleResumeSession(ctx context.Context, client *connection.Client, cmd commands.ResumeSession) error {
+	if err := r.requireIdentity(client); err != nil {
+		return err
+	}
+
+	token, err := r.connMgr.ResumeSession(client, cmd.Token)
+	if err != nil {
+		return err
+	}
+
+	resumed := SessionResumed{
+		PlayerID:         client.Player.ID,
+		Token:            token,
+		ReconnectGraceMs: r.connMgr.ReconnectGrace().Milliseconds(),
+		TableIDs:         client.TableIDs,
+		Hands:            []domain.HandView{},
+	}
+
+	for _, tableID := range client.TableIDs {
+		table, err := r.lobby.GetTable(tableID)
+		if err != nil {
+			continue
+		}
+
+		inGameLoop(ctx, table, "resume", func() error {
+			// The player came back before their tables started acting for them
+			table.MarkPlayerBack(client.Player.ID)
+
+			if hand := table.ActiveHand; hand != nil {
+				resumed.Hands = append(resumed.Hands, hand.BuildPlayerView(client.Player.ID))
+			}
+			return nil
+		})
+	}
+
+	return r.sendToClient(ctx, client, resumed)
+}
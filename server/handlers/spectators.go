@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// ErrSeatedAtTable is returned when a player seated at a table asks to spectate it
+var ErrSeatedAtTable = errors.New("already seated at this table")
+
+// SpectatingTable confirms a client watches a table, with the hand in progress as a spectator sees it
+type SpectatingTable struct {
+	TableID string
+	Hand    *domain.HandView `json:",omitempty"` // nil between hands, or when the table runs on another instance
+}
+
+func (s SpectatingTable) Name() string { return "SPECTATING_TABLE" }
+
+// isSpectatorCommand reports whether a command only watches a table, which any instance can serve
+func isSpectatorCommand(name string) bool {
+	return name == commands.SpectateTable{}.Name() || name == commands.StopSpectating{}.Name()
+}
+
+func (r *CommandRouter) handleSpectateTable(ctx context.Context, client *connection.Client, cmd commands.SpectateTable) error {
+	if r.connMgr.IsClientAtTable(client.ID, cmd.TableID) {
+		return ErrSeatedAtTable
+	}
+
+	table, err := r.lobby.GetTable(cmd.TableID)
+	if err != nil {
+		// In a cluster the table's public events are relayed here, but its hands are out of reach
+		if _, remote := r.remoteOwner(ctx, cmd.TableID); !remote {
+			return err
+		}
+	}
+
+	if !r.connMgr.AddSpectator(client.ID, cmd.TableID) {
+		return errors.New("client is not connected")
+	}
+
+	spectating := SpectatingTable{TableID: cmd.TableID}
+	if table != nil {
+		inGameLoop(ctx, table, "spectate", func() error {
+			if hand := table.ActiveHand; hand != nil {
+				view := hand.BuildSpectatorView()
+				spectating.Hand = &view
+			}
+			return nil
+		})
+	}
+
+	return r.sendToClient(ctx, client, spectating)
+}
+
+func (r *CommandRouter) handleStopSpectating(client *connection.Client, cmd commands.StopSpectating) error {
+	r.connMgr.RemoveSpectator(client.ID, cmd.TableID)
+	return nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// ErrMandatoryCategory is returned when a client tries to opt out of events it can't play without
+var ErrMandatoryCategory = errors.New("event category can't be opted out")
+
+// EventSubscription confirms the event categories a connection no longer receives
+type EventSubscription struct {
+	Excluded []events.Category
+}
+
+func (e EventSubscription) Name() string { return "EVENT_SUBSCRIPTION" }
+
+func (r *CommandRouter) handleSubscribeEvents(ctx context.Context, client *connection.Client, cmd commands.SubscribeEvents) error {
+	excluded := make([]events.Category, 0, len(cmd.Exclude))
+	for _, name := range cmd.Exclude {
+		category, ok := events.ParseCategory(name)
+		if !ok {
+			return fmt.Errorf("unknown event category %q", name)
+		}
+		if !category.Optional() {
+			return fmt.Errorf("%w: %s", ErrMandatoryCategory, category)
+		}
+		excluded = append(excluded, category)
+	}
+
+	if !r.connMgr.SetExcludedCategories(client.ID, excluded) {
+		return errors.New("client is not connected")
+	}
+
+	return r.sendToClient(ctx, client, EventSubscription{Excluded: excluded})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/lazharichir/poker/domain/commands"
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// TimeSyncResult echoes the client's timestamp with the server's receive and send times.
+// With t3 the client's time when the result arrives, the client can estimate:
+//
+//	rtt    = (t3 - ClientTime) - (ServerSentAt - ServerReceivedAt)
+//	offset = ((ServerReceivedAt - ClientTime) + (ServerSentAt - t3)) / 2
+//
+// and convert server deadlines to its own clock with deadline - offset.
+type TimeSyncResult struct {
+	ClientTime       int64 // Unix milliseconds, client clock
+	ServerReceivedAt int64 // Unix milliseconds, server clock
+	ServerSentAt     int64 // Unix milliseconds, server clock
+}
+
+func (t TimeSyncResult) Name() string { return "TIME_SYNC_RESULT" }
+
+// NewTimeSyncResult builds the echo for a request received at receivedAt
+func NewTimeSyncResult(clientTime int64, receivedAt time.Time) TimeSyncResult {
+	return TimeSyncResult{
+		ClientTime:       clientTime,
+		ServerReceivedAt: receivedAt.UnixMilli(),
+		ServerSentAt:     time.Now().UnixMilli(),
+	}
+}
+
+func (r *CommandRouter) handleTimeSync(ctx context.Context, client *connection.Client, cmd commands.TimeSync, receivedAt time.Time) error {
+	return r.sendToClient(ctx, client, NewTimeSyncResult(cmd.ClientTime, receivedAt))
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// submitToEngine applies a player's action to the table under a span of its own. The events the action causes
+// are dispatched while it runs, their "dispatch" spans tell how much of it went to delivery rather than the rules.
+func submitToEngine(ctx context.Context, engine domain.GameEngine, tableID string, action domain.Action) error {
+	_, span := tracing.Tracer().Start(ctx, "engine.submit", trace.WithAttributes(
+		attribute.String("poker.action", string(action.Type)),
+		attribute.String("poker.table_id", tableID),
+		attribute.String("poker.hand_id", action.HandID),
+	))
+	defer span.End()
+
+	err := engine.SubmitAction(action)
+	recordError(span, err)
+	return err
+}
+
+// inGameLoop runs f in the table's game loop under a span. Its "game loop acquired" event splits the time
+// spent waiting behind the table's other commands and timers from the time spent running f.
+func inGameLoop(ctx context.Context, table *domain.Table, where string, f func() error) error {
+	_, span := tracing.Tracer().Start(ctx, "table "+where, trace.WithAttributes(
+		attribute.String("poker.table_id", table.ID),
+	))
+	defer span.End()
+
+	err := table.Do(where, func() error {
+		span.AddEvent("game loop acquired")
+		return f()
+	})
+	recordError(span, err)
+	return err
+}
+
+// recordError marks the span as failed with err, if any
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/handlers"
+	"github.com/lazharichir/poker/server/oidc"
+)
+
+// oidcVerifierFromEnv signs players in with an OpenID Connect provider when POKER_OIDC_ISSUER is set, returning
+// nil otherwise. POKER_OIDC_AUDIENCE lists the client IDs tokens may be issued to (comma-separated), the keys are
+// fetched from POKER_OIDC_JWKS_URL or found through discovery, and POKER_OIDC_PLAYER_ID_CLAIM names the claim
+// holding player IDs, for providers that know them.
+func oidcVerifierFromEnv() *oidc.Verifier {
+	issuer := os.Getenv("POKER_OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+
+	audience := splitList(os.Getenv("POKER_OIDC_AUDIENCE"))
+	if len(audience) == 0 {
+		log.Fatalf("POKER_OIDC_AUDIENCE is required with POKER_OIDC_ISSUER")
+	}
+
+	log.Printf("Players sign in with the identity provider %s", issuer)
+
+	return oidc.NewVerifier(oidc.Config{
+		Issuer:        issuer,
+		Audience:      audience,
+		JWKSURL:       os.Getenv("POKER_OIDC_JWKS_URL"),
+		PlayerIDClaim: os.Getenv("POKER_OIDC_PLAYER_ID_CLAIM"),
+	})
+}
+
+// authenticateWith verifies ID tokens with the verifier. Identities expire with the leeway the verifier
+// grants, so a token it accepts is never expired already.
+func authenticateWith(verifier *oidc.Verifier) handlers.Authenticator {
+	return func(ctx context.Context, idToken string) (connection.Identity, error) {
+		claims, err := verifier.Verify(ctx, idToken)
+		if errors.Is(err, oidc.ErrTokenExpired) {
+			return connection.Identity{}, handlers.ErrTokenExpired
+		}
+		if err != nil {
+			return connection.Identity{}, fmt.Errorf("%w: %v", handlers.ErrNotAuthenticated, err)
+		}
+
+		return connection.Identity{
+			PlayerID:  claims.PlayerID,
+			Name:      claims.Name,
+			ExpiresAt: claims.ExpiresAt.Add(verifier.Config().Leeway),
+		}, nil
+	}
+}
+
+// idToken returns the ID token a client connects with: in the Authorization header, or in the id_token
+// query parameter for browsers, which can't set headers on websockets
+func idToken(r *http.Request) string {
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+		return token
+	}
+	return r.URL.Query().Get("id_token")
+}
+
+// authenticateRequest returns the identity of a client connecting with an ID token, nil if it sent none
+// or players don't sign in with a provider
+func (s *Server) authenticateRequest(ctx context.Context, token string) (*connection.Identity, error) {
+	if s.authenticate == nil || token == "" {
+		return nil, nil
+	}
+
+	identity, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// OIDCConfigResponse tells web clients where players sign in
+type OIDCConfigResponse struct {
+	Issuer   string   `json:"issuer"`
+	Audience []string `json:"audience"`
+}
+
+// handleOIDCConfig serves the identity provider players sign in with, 404 when there is none
+func (s *Server) handleOIDCConfig(w http.ResponseWriter, r *http.Request) {
+	if s.oidc == nil {
+		http.Error(w, "No identity provider is configured", http.StatusNotFound)
+		return
+	}
+
+	config := s.oidc.Config()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OIDCConfigResponse{Issuer: config.Issuer, Audience: config.Audience})
+}
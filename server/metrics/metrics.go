@@ -0,0 +1,117 @@
+// Package metrics exposes the server's Prometheus metrics: tables, hands and their phases, connections, commands and event dispatch
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the server's collectors in a registry of their own, so tests can create as many as they need
+type Metrics struct {
+	registry *prometheus.Registry
+
+	handsEnded       prometheus.Counter
+	handDuration     prometheus.Histogram
+	potSize          prometheus.Histogram
+	connections      prometheus.Gauge
+	commandErrors    *prometheus.CounterVec
+	dispatchDuration *prometheus.HistogramVec
+	phases           *phaseTimeline
+}
+
+// New creates the collectors. activeTables is read on every scrape, e.g. the lobby's table count.
+func New(activeTables func() int) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		handsEnded: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "poker_hands_ended_total",
+			Help: "Hands played to the end, voided ones included. Hands per minute is rate(poker_hands_ended_total[1m]) * 60.",
+		}),
+		handDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "poker_hand_duration_seconds",
+			Help:    "How long hands took, from the first card dealt to the pot paid out.",
+			Buckets: []float64{5, 10, 20, 30, 45, 60, 90, 120, 180, 300, 600},
+		}),
+		potSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "poker_pot_size_chips",
+			Help:    "Final pot of each hand, in chips.",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 14),
+		}),
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "poker_websocket_connections",
+			Help: "Open websocket connections.",
+		}),
+		commandErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "poker_command_errors_total",
+			Help: "Commands that failed, by command and COMMAND_ERROR code.",
+		}, []string{"command", "code"}),
+		dispatchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "poker_event_dispatch_seconds",
+			Help:    "How long events took to reach the connected clients, by event.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 9),
+		}, []string{"event"}),
+		phases: newPhaseTimeline(DefaultPhaseSLAs()),
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "poker_tables_active",
+			Help: "Tables open in the lobby.",
+		}, func() float64 { return float64(activeTables()) }),
+		m.handsEnded,
+		m.handDuration,
+		m.potSize,
+		m.connections,
+		m.commandErrors,
+		m.dispatchDuration,
+		m.phases.duration,
+		m.phases.breaches,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format, for /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// HandleEvent records the hands as they move through their phases and end, it is meant to be registered as an event handler
+func (m *Metrics) HandleEvent(event events.Event) {
+	m.phases.handleEvent(event)
+
+	if ended, ok := event.(events.HandEnded); ok {
+		m.handsEnded.Inc()
+		m.handDuration.Observe((time.Duration(ended.Duration) * time.Millisecond).Seconds())
+		m.potSize.Observe(float64(ended.FinalPot))
+	}
+}
+
+// TimeDispatch wraps an event handler delivering events to clients, recording how long each event took
+func (m *Metrics) TimeDispatch(handler events.EventHandler) events.EventHandler {
+	return func(event events.Event) {
+		start := time.Now()
+		handler(event)
+		m.dispatchDuration.WithLabelValues(event.Name()).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ConnectionOpened counts a websocket connection in, ConnectionClosed counts it out
+func (m *Metrics) ConnectionOpened() { m.connections.Inc() }
+
+func (m *Metrics) ConnectionClosed() { m.connections.Dec() }
+
+// CommandFailed counts a failed command under its COMMAND_ERROR code
+func (m *Metrics) CommandFailed(command string, code string) {
+	if command == "" {
+		command = "(unnamed)"
+	}
+	m.commandErrors.WithLabelValues(command, code).Inc()
+}
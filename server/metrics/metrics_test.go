@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("Ended hands are counted with their duration and pot", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+
+		// Act
+		m.HandleEvent(events.HandStarted{HandID: "hand-1"})
+		m.HandleEvent(events.HandEnded{HandID: "hand-1", Duration: 30_000, FinalPot: 120})
+
+		// Assert
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.handsEnded))
+		assert.Equal(t, 1, testutil.CollectAndCount(m.handDuration))
+		assert.Equal(t, 1, testutil.CollectAndCount(m.potSize))
+	})
+
+	t.Run("Open connections go up and down", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+
+		// Act
+		m.ConnectionOpened()
+		m.ConnectionOpened()
+		m.ConnectionClosed()
+
+		// Assert
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.connections))
+	})
+
+	t.Run("Command errors are counted by command and code", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+
+		// Act
+		m.CommandFailed("PLAYER_FOLDS", "NOT_YOUR_TURN")
+		m.CommandFailed("PLAYER_FOLDS", "NOT_YOUR_TURN")
+		m.CommandFailed("", "INVALID_COMMAND")
+
+		// Assert
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.commandErrors.WithLabelValues("PLAYER_FOLDS", "NOT_YOUR_TURN")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.commandErrors.WithLabelValues("(unnamed)", "INVALID_COMMAND")))
+	})
+
+	t.Run("Dispatch is timed by event and still reaches the handler", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+		delivered := 0
+		dispatch := m.TimeDispatch(func(events.Event) { delivered++ })
+
+		// Act
+		dispatch(events.HandStarted{})
+
+		// Assert
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, 1, testutil.CollectAndCount(m.dispatchDuration))
+	})
+
+	t.Run("Handler serves the active tables", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 3 })
+		recorder := httptest.NewRecorder()
+
+		// Act
+		m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		// Assert
+		assert.Equal(t, 200, recorder.Code)
+		assert.True(t, strings.Contains(recorder.Body.String(), "poker_tables_active 3"))
+	})
+}
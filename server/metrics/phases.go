@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// phaseCheckInterval is how often hands still in a phase are checked against its SLA
+var phaseCheckInterval = time.Second
+
+// PhaseSLAs is the longest a hand should stay in each phase, by phase name (e.g. "antes").
+// Phases without an SLA are measured but never alerted on.
+type PhaseSLAs map[string]time.Duration
+
+// DefaultPhaseSLAs leave room for every player to use their whole turn timer, and then some
+func DefaultPhaseSLAs() PhaseSLAs {
+	return PhaseSLAs{
+		"antes":               2 * time.Minute,
+		"continuation":        5 * time.Minute,
+		"community.selection": 3 * time.Minute,
+		"payout":              30 * time.Second,
+	}
+}
+
+// openPhase is the phase a hand is in
+type openPhase struct {
+	tableID string
+	phase   string
+	since   time.Time
+	alerted bool // Its SLA breach was already reported
+}
+
+// phaseTimeline follows the phase of every hand in progress
+type phaseTimeline struct {
+	mu    sync.Mutex
+	slas  PhaseSLAs
+	hands map[string]*openPhase // By hand ID
+
+	duration *prometheus.HistogramVec
+	breaches *prometheus.CounterVec
+}
+
+func newPhaseTimeline(slas PhaseSLAs) *phaseTimeline {
+	return &phaseTimeline{
+		slas:  slas,
+		hands: make(map[string]*openPhase),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "poker_hand_phase_duration_seconds",
+			Help:    "How long hands spent in each phase.",
+			Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300},
+		}, []string{"phase"}),
+		breaches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "poker_hand_phase_sla_breaches_total",
+			Help: "Hands that stayed in a phase longer than its SLA, counted once per phase as soon as the SLA is exceeded.",
+		}, []string{"phase"}),
+	}
+}
+
+// SetPhaseSLAs replaces the phase SLAs, phases already running are checked against the new ones
+func (m *Metrics) SetPhaseSLAs(slas PhaseSLAs) {
+	m.phases.mu.Lock()
+	defer m.phases.mu.Unlock()
+	m.phases.slas = slas
+}
+
+// Start checks the hands in progress against their phase SLA every second until ctx is done,
+// so a hand stuck in a phase is reported without waiting for it to move on
+func (m *Metrics) Start(ctx context.Context) {
+	ticker := time.NewTicker(phaseCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.phases.check(now)
+		}
+	}
+}
+
+// handleEvent moves hands from phase to phase, measuring the phase they leave
+func (p *phaseTimeline) handleEvent(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch e := event.(type) {
+	case events.PhaseChanged:
+		if current, ok := p.hands[e.HandID]; ok {
+			p.close(e.HandID, current, e.At)
+		}
+		if e.NewPhase == "ended" {
+			delete(p.hands, e.HandID)
+			return
+		}
+		p.hands[e.HandID] = &openPhase{tableID: e.TableID, phase: e.NewPhase, since: e.At}
+
+	case events.HandEnded:
+		delete(p.hands, e.HandID)
+
+	case events.TableClosed:
+		// Hands cut short by the table closing never end
+		for handID, current := range p.hands {
+			if current.tableID == e.TableID {
+				delete(p.hands, handID)
+			}
+		}
+	}
+}
+
+// close measures a phase the hand left at the given time, p.mu must be held
+func (p *phaseTimeline) close(handID string, current *openPhase, at time.Time) {
+	took := at.Sub(current.since)
+	p.duration.WithLabelValues(current.phase).Observe(took.Seconds())
+	p.alertIfOver(handID, current, took)
+}
+
+// check reports the hands that have been in their phase longer than its SLA
+func (p *phaseTimeline) check(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for handID, current := range p.hands {
+		p.alertIfOver(handID, current, now.Sub(current.since))
+	}
+}
+
+// alertIfOver reports a phase over its SLA, once per phase of each hand, p.mu must be held
+func (p *phaseTimeline) alertIfOver(handID string, current *openPhase, took time.Duration) {
+	sla, ok := p.slas[current.phase]
+	if !ok || sla <= 0 || took <= sla || current.alerted {
+		return
+	}
+
+	current.alerted = true
+	p.breaches.WithLabelValues(current.phase).Inc()
+	log.Printf("Hand %s at table %s has been in phase %s for %s, over its %s SLA", handID, current.tableID, current.phase, took.Round(time.Second), sla)
+}
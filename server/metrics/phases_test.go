@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseTimeline(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	phaseChanged := func(from string, to string, at time.Duration) events.PhaseChanged {
+		return events.PhaseChanged{TableID: "table-1", HandID: "hand-1", PreviousPhase: from, NewPhase: to, At: start.Add(at)}
+	}
+
+	t.Run("Time spent in each phase is measured when the hand moves on", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+
+		// Act
+		m.HandleEvent(phaseChanged("start", "antes", 0))
+		m.HandleEvent(phaseChanged("antes", "hole", 10*time.Second))
+		m.HandleEvent(phaseChanged("hole", "ended", 11*time.Second))
+
+		// Assert
+		assert.Equal(t, 2, testutil.CollectAndCount(m.phases.duration))
+		assert.Empty(t, m.phases.hands)
+	})
+
+	t.Run("Phase left after its SLA is a breach", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+		m.SetPhaseSLAs(PhaseSLAs{"antes": 5 * time.Second})
+
+		// Act
+		m.HandleEvent(phaseChanged("start", "antes", 0))
+		m.HandleEvent(phaseChanged("antes", "hole", 6*time.Second))
+
+		// Assert
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.phases.breaches.WithLabelValues("antes")))
+	})
+
+	t.Run("Hand stuck in a phase is reported once while still in it", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+		m.SetPhaseSLAs(PhaseSLAs{"antes": 5 * time.Second})
+		m.HandleEvent(phaseChanged("start", "antes", 0))
+
+		// Act
+		m.phases.check(start.Add(4 * time.Second))
+		m.phases.check(start.Add(6 * time.Second))
+		m.phases.check(start.Add(7 * time.Second))
+		m.HandleEvent(phaseChanged("antes", "hole", 8*time.Second))
+
+		// Assert
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.phases.breaches.WithLabelValues("antes")))
+	})
+
+	t.Run("Phases without an SLA are never breached", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+		m.SetPhaseSLAs(PhaseSLAs{})
+		m.HandleEvent(phaseChanged("start", "antes", 0))
+
+		// Act
+		m.phases.check(start.Add(time.Hour))
+
+		// Assert
+		assert.Equal(t, 0, testutil.CollectAndCount(m.phases.breaches))
+	})
+
+	t.Run("Hands of a closed table are forgotten", func(t *testing.T) {
+		// Setup
+		m := New(func() int { return 0 })
+		m.HandleEvent(phaseChanged("start", "antes", 0))
+
+		// Act
+		m.HandleEvent(events.TableClosed{TableID: "table-1"})
+
+		// Assert
+		assert.Empty(t, m.phases.hands)
+	})
+}
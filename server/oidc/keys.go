@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownKey is returned for tokens signed with a key the provider doesn't publish
+var ErrUnknownKey = errors.New("ID token signed with an unknown key")
+
+// Key refresh: providers rotate their keys, tokens signed with a new one make the verifier fetch them again
+const (
+	keysMaxAge          = time.Hour
+	keysMinRefreshDelay = time.Minute // Forged key IDs mustn't make the verifier hammer the provider
+)
+
+// keySet holds the signing keys published by the provider, by key ID
+type keySet struct {
+	issuer     string
+	jwksURL    string // Found through discovery when empty
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+func newKeySet(issuer string, jwksURL string) *keySet {
+	return &keySet{
+		issuer:     issuer,
+		jwksURL:    jwksURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// key returns the provider's key with the given ID. Tokens without a key ID are accepted as long as
+// the provider publishes a single key.
+func (s *keySet) key(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, found := s.lookup(keyID)
+	stale := time.Since(s.fetched) >= keysMaxAge
+	if (!found || stale) && time.Since(s.fetched) >= keysMinRefreshDelay {
+		if err := s.fetch(ctx); err != nil {
+			// Keys that can't be refreshed still verify the tokens they signed
+			if found {
+				return key, nil
+			}
+			return nil, err
+		}
+		key, found = s.lookup(keyID)
+	}
+
+	if !found {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (s *keySet) lookup(keyID string) (crypto.PublicKey, bool) {
+	if keyID == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, found := s.keys[keyID]
+	return key, found
+}
+
+// fetch downloads the provider's keys, the caller holds the lock
+func (s *keySet) fetch(ctx context.Context) error {
+	s.fetched = time.Now()
+
+	if s.jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := s.getJSON(ctx, strings.TrimSuffix(s.issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return fmt.Errorf("could not discover the OIDC provider: %w", err)
+		}
+		if discovery.Issuer != s.issuer || discovery.JWKSURI == "" {
+			return fmt.Errorf("OIDC discovery document of %s is for issuer %q", s.issuer, discovery.Issuer)
+		}
+		s.jwksURL = discovery.JWKSURI
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := s.getJSON(ctx, s.jwksURL, &jwks); err != nil {
+		return fmt.Errorf("could not fetch the keys of the OIDC provider: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// Keys of other types are of no use to verify tokens
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+	s.keys = keys
+	return nil
+}
+
+func (s *keySet) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// jsonWebKey is a public key as published in a JWKS document
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// ECDSA
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, supported := curves[k.Curve]
+		if !supported {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
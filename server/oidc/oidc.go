@@ -0,0 +1,254 @@
+// Package oidc verifies the ID tokens of an OpenID Connect provider, so operators can sign players in
+// with their own identity provider. Tokens must be signed with RSA or ECDSA keys published by the
+// provider, whose keys are found through its discovery document.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256" // Hashes of the signing algorithms
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed or whose signature doesn't check out
+var ErrInvalidToken = errors.New("invalid ID token")
+
+// ErrTokenExpired is returned for tokens past their expiry, or not valid yet
+var ErrTokenExpired = errors.New("ID token expired")
+
+// ErrWrongIssuer is returned for tokens issued by another provider than the configured one
+var ErrWrongIssuer = errors.New("ID token from another issuer")
+
+// ErrWrongAudience is returned for tokens issued to another client than the configured ones
+var ErrWrongAudience = errors.New("ID token for another audience")
+
+// DefaultLeeway is how far the clocks of the server and the provider may drift apart
+const DefaultLeeway = time.Minute
+
+// playerNamespace derives player IDs from issuers and subjects, see Claims.PlayerID
+var playerNamespace = uuid.MustParse("0b7e3f4c-5b0a-4c57-9d0e-6a1f2c3d4e5f")
+
+// Config is the identity provider the server trusts
+type Config struct {
+	Issuer   string   // URL of the provider, as found in the iss claim of its tokens
+	Audience []string // Client IDs the tokens may be issued to, any of them is accepted
+	JWKSURL  string   // Where the provider publishes its keys, found through discovery when empty
+
+	// Claim holding the player ID, for providers that know the players' IDs on this server.
+	// When empty, or missing from a token, the player ID is derived from the issuer and subject.
+	PlayerIDClaim string
+
+	Leeway time.Duration // DefaultLeeway when zero
+}
+
+// Claims is what a verified ID token says about the player
+type Claims struct {
+	Issuer    string
+	Subject   string // The player's ID at the provider
+	PlayerID  string // The player's ID on this server
+	Name      string // May be empty
+	Email     string // May be empty
+	ExpiresAt time.Time
+}
+
+// Verifier checks ID tokens against the keys of the configured provider
+type Verifier struct {
+	config Config
+	keys   *keySet
+	now    func() time.Time
+}
+
+// NewVerifier creates a verifier for the provider, its keys are fetched on first use
+func NewVerifier(config Config) *Verifier {
+	if config.Leeway == 0 {
+		config.Leeway = DefaultLeeway
+	}
+	return &Verifier{
+		config: config,
+		keys:   newKeySet(config.Issuer, config.JWKSURL),
+		now:    time.Now,
+	}
+}
+
+// Config returns the provider the verifier trusts
+func (v *Verifier) Config() Config {
+	return v.config
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type payload struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	Name      string   `json:"name"`
+	Username  string   `json:"preferred_username"`
+	Email     string   `json:"email"`
+}
+
+// audience is the aud claim, a single string or an array of them
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Verify checks the signature, issuer, audience and lifetime of an ID token and returns its claims
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, fmt.Errorf("%w: not a JWT", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Claims{}, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	key, err := v.keys.key(ctx, h.KeyID)
+	if err != nil {
+		return Claims{}, err
+	}
+	if err := verifySignature(h.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return Claims{}, err
+	}
+
+	// Only read once the signature is known to be the provider's
+	var p payload
+	if err := decodeSegment(parts[1], &p); err != nil {
+		return Claims{}, err
+	}
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return Claims{}, err
+	}
+
+	if p.Issuer != v.config.Issuer {
+		return Claims{}, ErrWrongIssuer
+	}
+	if !slices.ContainsFunc(p.Audience, func(aud string) bool { return slices.Contains(v.config.Audience, aud) }) {
+		return Claims{}, ErrWrongAudience
+	}
+	if p.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	now := v.now()
+	expiresAt := time.Unix(p.ExpiresAt, 0)
+	if p.ExpiresAt == 0 || !now.Before(expiresAt.Add(v.config.Leeway)) {
+		return Claims{}, ErrTokenExpired
+	}
+	if p.NotBefore != 0 && now.Add(v.config.Leeway).Before(time.Unix(p.NotBefore, 0)) {
+		return Claims{}, ErrTokenExpired
+	}
+
+	claims := Claims{
+		Issuer:    p.Issuer,
+		Subject:   p.Subject,
+		Name:      p.Name,
+		Email:     p.Email,
+		ExpiresAt: expiresAt,
+	}
+	if claims.Name == "" {
+		claims.Name = p.Username
+	}
+
+	claims.PlayerID, _ = raw[v.config.PlayerIDClaim].(string)
+	if v.config.PlayerIDClaim == "" || claims.PlayerID == "" {
+		claims.PlayerID = uuid.NewSHA1(playerNamespace, []byte(p.Issuer+"\n"+p.Subject)).String()
+	}
+
+	return claims, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	return nil
+}
+
+// algorithms are the signing algorithms accepted, with their hash and, for ECDSA, the size of their curve.
+// Only asymmetric ones: "none" and shared secrets would let anyone forge tokens.
+var algorithms = map[string]struct {
+	hash      crypto.Hash
+	curveBits int
+}{
+	"RS256": {crypto.SHA256, 0},
+	"RS384": {crypto.SHA384, 0},
+	"RS512": {crypto.SHA512, 0},
+	"ES256": {crypto.SHA256, 256},
+	"ES384": {crypto.SHA384, 384},
+	"ES512": {crypto.SHA512, 521},
+}
+
+// verifySignature checks the signature of a token with the algorithm it names
+func verifySignature(algorithm string, key crypto.PublicKey, signed string, signature []byte) error {
+	alg, supported := algorithms[algorithm]
+	if !supported {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, algorithm)
+	}
+
+	digest := alg.hash.New()
+	digest.Write([]byte(signed))
+	sum := digest.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if alg.curveBits != 0 {
+			break
+		}
+		if rsa.VerifyPKCS1v15(key, alg.hash, sum, signature) != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if key.Curve.Params().BitSize != alg.curveBits {
+			break
+		}
+		size := (alg.curveBits + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, sum, r, s) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: algorithm %q doesn't match the key", ErrInvalidToken, algorithm)
+}
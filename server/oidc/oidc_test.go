@@ -0,0 +1,183 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProvider is an identity provider publishing an RSA and an ECDSA key
+type testProvider struct {
+	server   *httptest.Server
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	jwksHits int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p := &testProvider{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": p.server.URL, "jwks_uri": p.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.jwksHits++
+		encode := base64.RawURLEncoding.EncodeToString
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(ecKey.X.FillBytes(make([]byte, 32))), "y": encode(ecKey.Y.FillBytes(make([]byte, 32)))},
+		}})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// sign issues a token with the given claims, signed with the key named by keyID
+func (p *testProvider) sign(t *testing.T, keyID string, claims map[string]any) string {
+	alg := "RS256"
+	if keyID == "ec-1" {
+		alg = "ES256"
+	}
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": alg, "kid": keyID, "typ": "JWT"}) + "." + encode(claims)
+	sum := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	if alg == "RS256" {
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, p.rsaKey, crypto.SHA256, sum[:])
+		require.NoError(t, err)
+	} else {
+		r, s, err := ecdsa.Sign(rand.Reader, p.ecKey, sum[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (p *testProvider) claims(subject string) map[string]any {
+	return map[string]any{
+		"iss":  p.server.URL,
+		"sub":  subject,
+		"aud":  "poker-web",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+		"name": "Alice",
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Accepts tokens signed with the provider's keys", func(t *testing.T) {
+		// Setup
+		provider := newTestProvider(t)
+		verifier := NewVerifier(Config{Issuer: provider.server.URL, Audience: []string{"poker-web"}})
+
+		for _, keyID := range []string{"rsa-1", "ec-1"} {
+			// Act
+			claims, err := verifier.Verify(ctx, provider.sign(t, keyID, provider.claims("alice")))
+
+			// Assert
+			require.NoError(t, err, keyID)
+			assert.Equal(t, "alice", claims.Subject)
+			assert.Equal(t, "Alice", claims.Name)
+		}
+		assert.Equal(t, 1, provider.jwksHits, "keys are cached")
+	})
+
+	t.Run("Maps subjects to stable player IDs", func(t *testing.T) {
+		// Setup
+		provider := newTestProvider(t)
+		verifier := NewVerifier(Config{Issuer: provider.server.URL, Audience: []string{"poker-web"}})
+
+		// Act
+		alice, err := verifier.Verify(ctx, provider.sign(t, "rsa-1", provider.claims("alice")))
+		require.NoError(t, err)
+		again, err := verifier.Verify(ctx, provider.sign(t, "ec-1", provider.claims("alice")))
+		require.NoError(t, err)
+		bob, err := verifier.Verify(ctx, provider.sign(t, "rsa-1", provider.claims("bob")))
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEmpty(t, alice.PlayerID)
+		assert.Equal(t, alice.PlayerID, again.PlayerID)
+		assert.NotEqual(t, alice.PlayerID, bob.PlayerID)
+	})
+
+	t.Run("Takes the player ID from the configured claim", func(t *testing.T) {
+		// Setup
+		provider := newTestProvider(t)
+		verifier := NewVerifier(Config{Issuer: provider.server.URL, Audience: []string{"poker-web"}, PlayerIDClaim: "poker_player"})
+		claims := provider.claims("alice")
+		claims["poker_player"] = "player-42"
+
+		// Act
+		verified, err := verifier.Verify(ctx, provider.sign(t, "rsa-1", claims))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "player-42", verified.PlayerID)
+	})
+
+	t.Run("Rejects tokens that don't check out", func(t *testing.T) {
+		// Setup
+		provider := newTestProvider(t)
+		verifier := NewVerifier(Config{Issuer: provider.server.URL, Audience: []string{"poker-web"}})
+
+		expired := provider.claims("alice")
+		expired["exp"] = time.Now().Add(-time.Hour).Unix()
+		otherAudience := provider.claims("alice")
+		otherAudience["aud"] = []string{"other-app"}
+		otherIssuer := provider.claims("alice")
+		otherIssuer["iss"] = "https://evil.example"
+
+		valid := provider.sign(t, "rsa-1", provider.claims("alice"))
+		tampered := valid[:len(valid)-4] + "AAAA"
+		unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + "."
+
+		tests := []struct {
+			token string
+			err   error
+		}{
+			{provider.sign(t, "rsa-1", expired), ErrTokenExpired},
+			{provider.sign(t, "rsa-1", otherAudience), ErrWrongAudience},
+			{provider.sign(t, "rsa-1", otherIssuer), ErrWrongIssuer},
+			{provider.sign(t, "rsa-2", provider.claims("alice")), ErrUnknownKey},
+			{tampered, ErrInvalidToken},
+			{unsigned, ErrUnknownKey},
+			{"not-a-token", ErrInvalidToken},
+		}
+
+		for _, test := range tests {
+			// Act
+			_, err := verifier.Verify(ctx, test.token)
+
+			// Assert
+			assert.ErrorIs(t, err, test.err)
+		}
+	})
+}
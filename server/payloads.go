@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lazharichir/poker/server/connection"
+	"github.com/lazharichir/poker/server/events"
+)
+
+// PayloadStatsResponse reports the envelope sizes sent since the server started
+type PayloadStatsResponse struct {
+	MaxEnvelopeBytes int                  `json:"maxEnvelopeBytes"` // Zero when envelopes are never chunked
+	Compression      bool                 `json:"compression"`
+	BatchWindowMs    int64                `json:"batchWindowMs"` // Zero when envelopes are never batched
+	Events           []events.PayloadSize `json:"events"`
+}
+
+// maxEnvelopeSizeFromEnv reads POKER_MAX_ENVELOPE_BYTES, the size above which envelopes reach players in chunks.
+// Envelopes are never chunked by default.
+func maxEnvelopeSizeFromEnv() int {
+	value := os.Getenv("POKER_MAX_ENVELOPE_BYTES")
+	if value == "" {
+		return 0
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		log.Fatalf("Invalid POKER_MAX_ENVELOPE_BYTES: %q", value)
+	}
+	return limit
+}
+
+// compressionFromEnv reads POKER_WS_COMPRESSION, which negotiates permessage-deflate with the clients that support it
+func compressionFromEnv() bool {
+	value := os.Getenv("POKER_WS_COMPRESSION")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid POKER_WS_COMPRESSION: %v", err)
+	}
+	return enabled
+}
+
+// batchWindowFromEnv reads POKER_WS_BATCH_WINDOW (Go duration), how long the frames of clients speaking
+// BatchSubprotocol wait for the events that follow them. Zero sends every envelope in its own frame.
+func batchWindowFromEnv() time.Duration {
+	value := os.Getenv("POKER_WS_BATCH_WINDOW")
+	if value == "" {
+		return connection.DefaultBatchWindow
+	}
+
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		log.Fatalf("Invalid POKER_WS_BATCH_WINDOW: %q", value)
+	}
+	return window
+}
+
+// handlePayloadStats returns the size of the envelopes sent, by event name
+func (s *Server) handlePayloadStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(PayloadStatsResponse{
+		MaxEnvelopeBytes: s.dispatcher.MaxEnvelopeSize(),
+		Compression:      upgrader.EnableCompression,
+		BatchWindowMs:    s.batchWindow.Milliseconds(),
+		Events:           s.dispatcher.PayloadStats().Snapshot(),
+	})
+}
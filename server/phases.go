@@ -0,0 +1,30 @@
+package server
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lazharichir/poker/server/metrics"
+)
+
+// phaseSLAsFromEnv reads POKER_PHASE_SLAS, the longest hands should stay in each phase as comma-separated
+// phase=duration pairs, e.g. "antes=90s,continuation=4m". Phases it names replace their default SLA, "0" turns one off.
+func phaseSLAsFromEnv() metrics.PhaseSLAs {
+	slas := metrics.DefaultPhaseSLAs()
+
+	for _, pair := range splitList(os.Getenv("POKER_PHASE_SLAS")) {
+		phase, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			log.Fatalf("Invalid POKER_PHASE_SLAS entry %q, use phase=duration", pair)
+		}
+		sla, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || sla < 0 {
+			log.Fatalf("Invalid POKER_PHASE_SLAS duration for %s: %q", phase, value)
+		}
+		slas[strings.TrimSpace(phase)] = sla
+	}
+
+	return slas
+}
@@ -0,0 +1,4 @@
+// Package pokerpb holds the protobuf messages and gRPC service of the poker API
+package pokerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative poker.proto
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: poker.proto
+
+package pokerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Poker_Play_FullMethodName       = "/poker.v1.Poker/Play"
+	Poker_ListTables_FullMethodName = "/poker.v1.Poker/ListTables"
+	Poker_GetTable_FullMethodName   = "/poker.v1.Poker/GetTable"
+)
+
+// PokerClient is the client API for Poker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Poker is the gRPC counterpart of the WebSocket protocol, for bots and other non-browser clients
+type PokerClient interface {
+	// Play opens a session: commands go up the stream, and the events and responses the
+	// WebSocket would deliver come down it. The session ends when either side closes the stream.
+	Play(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Command, Envelope], error)
+	// ListTables returns the tables of the lobby
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+	// GetTable returns the public state of a table
+	GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*Table, error)
+}
+
+type pokerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPokerClient(cc grpc.ClientConnInterface) PokerClient {
+	return &pokerClient{cc}
+}
+
+func (c *pokerClient) Play(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Command, Envelope], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Poker_ServiceDesc.Streams[0], Poker_Play_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Command, Envelope]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Poker_PlayClient = grpc.BidiStreamingClient[Command, Envelope]
+
+func (c *pokerClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, Poker_ListTables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokerClient) GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*Table, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Table)
+	err := c.cc.Invoke(ctx, Poker_GetTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PokerServer is the server API for Poker service.
+// All implementations must embed UnimplementedPokerServer
+// for forward compatibility.
+//
+// Poker is the gRPC counterpart of the WebSocket protocol, for bots and other non-browser clients
+type PokerServer interface {
+	// Play opens a session: commands go up the stream, and the events and responses the
+	// WebSocket would deliver come down it. The session ends when either side closes the stream.
+	Play(grpc.BidiStreamingServer[Command, Envelope]) error
+	// ListTables returns the tables of the lobby
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	// GetTable returns the public state of a table
+	GetTable(context.Context, *GetTableRequest) (*Table, error)
+	mustEmbedUnimplementedPokerServer()
+}
+
+// UnimplementedPokerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPokerServer struct{}
+
+func (UnimplementedPokerServer) Play(grpc.BidiStreamingServer[Command, Envelope]) error {
+	return status.Errorf(codes.Unimplemented, "method Play not implemented")
+}
+func (UnimplementedPokerServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedPokerServer) GetTable(context.Context, *GetTableRequest) (*Table, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTable not implemented")
+}
+func (UnimplementedPokerServer) mustEmbedUnimplementedPokerServer() {}
+func (UnimplementedPokerServer) testEmbeddedByValue()               {}
+
+// UnsafePokerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PokerServer will
+// result in compilation errors.
+type UnsafePokerServer interface {
+	mustEmbedUnimplementedPokerServer()
+}
+
+func RegisterPokerServer(s grpc.ServiceRegistrar, srv PokerServer) {
+	// If the following call pancis, it indicates UnimplementedPokerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Poker_ServiceDesc, srv)
+}
+
+func _Poker_Play_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PokerServer).Play(&grpc.GenericServerStream[Command, Envelope]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Poker_PlayServer = grpc.BidiStreamingServer[Command, Envelope]
+
+func _Poker_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokerServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Poker_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokerServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Poker_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokerServer).GetTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Poker_GetTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokerServer).GetTable(ctx, req.(*GetTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Poker_ServiceDesc is the grpc.ServiceDesc for Poker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Poker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "poker.v1.Poker",
+	HandlerType: (*PokerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTables",
+			Handler:    _Poker_ListTables_Handler,
+		},
+		{
+			MethodName: "GetTable",
+			Handler:    _Poker_GetTable_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Play",
+			Handler:       _Poker_Play_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "poker.proto",
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:generate go run ../cmd/protocoldoc -out protocol.json
+
+// protocolCatalog lists every command and event with its fields, generated from the code by cmd/protocoldoc
+//
+//go:embed protocol.json
+var protocolCatalog []byte
+
+// handleProtocol returns the protocol catalog, the reference client teams build against
+func (s *Server) handleProtocol(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(protocolCatalog)
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"log"
+	"os"
+
+	"github.com/lazharichir/poker/server/events"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisPublisherFromEnv publishes event envelopes to Redis when POKER_EVENTS_REDIS_URL is set,
+// on channels prefixed with POKER_EVENTS_REDIS_PREFIX ("poker:events" by default)
+func redisPublisherFromEnv() *events.RedisPublisher {
+	redisURL := os.Getenv("POKER_EVENTS_REDIS_URL")
+	if redisURL == "" {
+		return nil
+	}
+
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("Invalid POKER_EVENTS_REDIS_URL: %v", err)
+	}
+
+	prefix := os.Getenv("POKER_EVENTS_REDIS_PREFIX")
+	if prefix == "" {
+		prefix = "poker:events"
+	}
+
+	log.Printf("Publishing events to Redis channels %s:*", prefix)
+
+	return events.NewRedisPublisher(redis.NewClient(options), prefix)
+}
@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// Sink delivers session summaries outside the game, e.g. to a back-office webhook or a mailbox
+type Sink interface {
+	Deliver(summary events.PlayerSessionSummarized) error
+}
+
+// SessionReporter forwards every session summary to the configured sinks
+type SessionReporter struct {
+	sinks []Sink
+}
+
+// NewSessionReporter creates a reporter, with no sinks it does nothing
+func NewSessionReporter(sinks ...Sink) *SessionReporter {
+	return &SessionReporter{sinks: sinks}
+}
+
+// HandleEvent delivers session summaries, it is meant to be registered as an event handler.
+// Delivery runs in the background so a slow sink never holds up the table.
+func (r *SessionReporter) HandleEvent(event events.Event) {
+	summary, ok := event.(events.PlayerSessionSummarized)
+	if !ok || len(r.sinks) == 0 {
+		return
+	}
+
+	go func() {
+		for _, sink := range r.sinks {
+			if err := sink.Deliver(summary); err != nil {
+				log.Printf("Failed to deliver session summary for player %s: %v", summary.PlayerID, err)
+			}
+		}
+	}()
+}
+
+// SinksFromEnv builds the sinks configured by environment variables:
+// POKER_SESSION_WEBHOOK_URL posts summaries as JSON, POKER_SESSION_SMTP_ADDR, POKER_SESSION_EMAIL_FROM
+// and POKER_SESSION_EMAIL_TO mail them, with POKER_SESSION_SMTP_USER and POKER_SESSION_SMTP_PASSWORD if the server needs auth.
+func SinksFromEnv() []Sink {
+	sinks := []Sink{}
+
+	if url := os.Getenv("POKER_SESSION_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, NewWebhookSink(url))
+	}
+
+	if addr := os.Getenv("POKER_SESSION_SMTP_ADDR"); addr != "" {
+		sink := &EmailSink{
+			Addr: addr,
+			From: os.Getenv("POKER_SESSION_EMAIL_FROM"),
+			To:   strings.Split(os.Getenv("POKER_SESSION_EMAIL_TO"), ","),
+		}
+		if user := os.Getenv("POKER_SESSION_SMTP_USER"); user != "" {
+			host, _, _ := net.SplitHostPort(addr)
+			sink.Auth = smtp.PlainAuth("", user, os.Getenv("POKER_SESSION_SMTP_PASSWORD"), host)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks
+}
+
+// WebhookSink posts each summary as JSON to a URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookSink creates a webhook sink with a short request timeout
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Deliver posts the summary
+func (s *WebhookSink) Deliver(summary events.PlayerSessionSummarized) error {
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// EmailSink mails a plain-text report of each summary
+type EmailSink struct {
+	Addr string // SMTP server, host:port
+	From string
+	To   []string
+	Auth smtp.Auth // nil if the server does not need auth
+}
+
+// Deliver mails the summary
+func (s *EmailSink) Deliver(summary events.PlayerSessionSummarized) error {
+	if s.From == "" || len(s.To) == 0 || s.To[0] == "" {
+		return errors.New("email sink needs a sender and at least one recipient")
+	}
+
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, formatEmail(s.From, s.To, summary))
+}
+
+// formatEmail renders the summary as a plain-text message
+func formatEmail(from string, to []string, summary events.PlayerSessionSummarized) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: Session summary for player %s\r\n", summary.PlayerID)
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "Table: %s\r\n", summary.TableID)
+	fmt.Fprintf(&b, "Ended: %s (%s)\r\n", summary.At.Format(time.RFC1123), summary.Reason)
+	fmt.Fprintf(&b, "Duration: %s\r\n", summary.Duration.Round(time.Second))
+	fmt.Fprintf(&b, "Hands played: %d\r\n", summary.HandsPlayed)
+	fmt.Fprintf(&b, "Net result: %+d\r\n", summary.NetResult)
+	fmt.Fprintf(&b, "Final stack: %d\r\n", summary.FinalStack)
+	fmt.Fprintf(&b, "Biggest pot won: %d\r\n", summary.BiggestPotWon)
+	fmt.Fprintf(&b, "Biggest pot lost: %d\r\n", summary.BiggestPotLost)
+	return []byte(b.String())
+}
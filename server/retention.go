@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain"
+)
+
+// pruneInterval is how often the background job applies the retention policy
+const pruneInterval = time.Hour
+
+// RetentionStats reports the volume pruned since the server started
+type RetentionStats struct {
+	Policy            RetentionPolicyResponse `json:"policy"`
+	Runs              int                     `json:"runs"`
+	EventsPrunedTotal int                     `json:"eventsPrunedTotal"`
+	HandsPrunedTotal  int                     `json:"handsPrunedTotal"`
+	LastRunAt         time.Time               `json:"lastRunAt,omitempty"`
+	LastRun           domain.PruneReport      `json:"lastRun"`
+}
+
+// RetentionPolicyResponse is the configured policy, in a readable form
+type RetentionPolicyResponse struct {
+	EventMaxAge string `json:"eventMaxAge"`
+	HandMaxAge  string `json:"handMaxAge"`
+}
+
+// Pruner periodically prunes lobby and table history according to the retention policy
+type Pruner struct {
+	lobby  *domain.Lobby
+	policy domain.RetentionPolicy
+
+	mu    sync.Mutex
+	stats RetentionStats
+}
+
+// NewPruner creates a pruner for the lobby
+func NewPruner(lobby *domain.Lobby, policy domain.RetentionPolicy) *Pruner {
+	return &Pruner{
+		lobby:  lobby,
+		policy: policy,
+		stats: RetentionStats{
+			Policy: RetentionPolicyResponse{
+				EventMaxAge: formatMaxAge(policy.EventMaxAge),
+				HandMaxAge:  formatMaxAge(policy.HandMaxAge),
+			},
+		},
+	}
+}
+
+// retentionPolicyFromEnv reads POKER_RETENTION_EVENTS and POKER_RETENTION_HANDS (Go durations).
+// Raw events are kept 90 days by default, hand summaries forever.
+func retentionPolicyFromEnv() domain.RetentionPolicy {
+	policy := domain.RetentionPolicy{
+		EventMaxAge: 90 * 24 * time.Hour,
+	}
+
+	if value := os.Getenv("POKER_RETENTION_EVENTS"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid POKER_RETENTION_EVENTS: %v", err)
+		}
+		policy.EventMaxAge = d
+	}
+
+	if value := os.Getenv("POKER_RETENTION_HANDS"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid POKER_RETENTION_HANDS: %v", err)
+		}
+		policy.HandMaxAge = d
+	}
+
+	return policy
+}
+
+// Start runs the pruning job until the process exits
+func (p *Pruner) Start() {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.Run(false)
+	}
+}
+
+// Run applies the policy once. A dry run only reports what would be pruned and doesn't count towards the totals.
+func (p *Pruner) Run(dryRun bool) domain.PruneReport {
+	report := p.lobby.Prune(p.policy, time.Now(), dryRun)
+
+	if dryRun {
+		return report
+	}
+
+	p.mu.Lock()
+	p.stats.Runs++
+	p.stats.EventsPrunedTotal += report.EventsPruned
+	p.stats.HandsPrunedTotal += report.HandsPruned
+	p.stats.LastRunAt = time.Now()
+	p.stats.LastRun = report
+	p.mu.Unlock()
+
+	if report.EventsPruned > 0 || report.HandsPruned > 0 {
+		log.Printf("Pruned %d events and %d hands", report.EventsPruned, report.HandsPruned)
+	}
+
+	return report
+}
+
+// Stats returns the pruning metrics
+func (p *Pruner) Stats() RetentionStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+func formatMaxAge(d time.Duration) string {
+	if d <= 0 {
+		return "forever"
+	}
+	return d.String()
+}
+
+// handleRetention returns the pruning metrics on GET, and runs a pruning pass on POST.
+// POST ?dryRun=true reports what would be pruned without removing anything.
+func (s *Server) handleRetention(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(s.pruner.Stats())
+
+	case http.MethodPost:
+		dryRun := r.URL.Query().Get("dryRun") == "true"
+		json.NewEncoder(w).Encode(s.pruner.Run(dryRun))
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Subprotocol is the WebSocket subprotocol the server speaks. Clients may offer it in Sec-WebSocket-Protocol
+// to be sure they reach a poker server, it is echoed back when they do.
+const Subprotocol = "poker.v1"
+
+// BatchSubprotocol is Subprotocol with the events emitted in quick succession coalesced into one frame,
+// which then holds a JSON array of envelopes rather than a single one. It is chosen over Subprotocol
+// when clients offer both.
+const BatchSubprotocol = "poker.v1.batch"
+
+// SecurityConfig decides who may reach the server and how. The zero value accepts every origin over plain HTTP,
+// which is fine on a developer's machine only.
+type SecurityConfig struct {
+	AllowedOrigins []string // Origins of the web clients allowed to connect, "*" for any
+
+	// TLS with a certificate and key on disk...
+	CertFile string
+	KeyFile  string
+
+	// ...or with certificates obtained from Let's Encrypt for these domains, cached in AutocertCacheDir
+	AutocertDomains  []string
+	AutocertCacheDir string
+}
+
+// securityConfigFromEnv reads POKER_ALLOWED_ORIGINS (comma-separated), POKER_TLS_CERT_FILE with POKER_TLS_KEY_FILE,
+// and POKER_TLS_AUTOCERT_DOMAINS (comma-separated) with POKER_TLS_AUTOCERT_CACHE
+func securityConfigFromEnv() (SecurityConfig, error) {
+	config := SecurityConfig{
+		AllowedOrigins:   splitList(os.Getenv("POKER_ALLOWED_ORIGINS")),
+		CertFile:         os.Getenv("POKER_TLS_CERT_FILE"),
+		KeyFile:          os.Getenv("POKER_TLS_KEY_FILE"),
+		AutocertDomains:  splitList(os.Getenv("POKER_TLS_AUTOCERT_DOMAINS")),
+		AutocertCacheDir: os.Getenv("POKER_TLS_AUTOCERT_CACHE"),
+	}
+
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return SecurityConfig{}, errors.New("POKER_TLS_CERT_FILE and POKER_TLS_KEY_FILE must be set together")
+	}
+	if config.CertFile != "" && len(config.AutocertDomains) > 0 {
+		return SecurityConfig{}, errors.New("use either POKER_TLS_CERT_FILE or POKER_TLS_AUTOCERT_DOMAINS, not both")
+	}
+	if len(config.AutocertDomains) > 0 && config.AutocertCacheDir == "" {
+		config.AutocertCacheDir = "autocert"
+	}
+
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if parsed, err := url.Parse(origin); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return SecurityConfig{}, fmt.Errorf("invalid origin in POKER_ALLOWED_ORIGINS: %q", origin)
+		}
+	}
+	if len(config.AllowedOrigins) == 0 {
+		log.Printf("POKER_ALLOWED_ORIGINS is not set, any web page may connect: set it before exposing the server")
+	}
+
+	return config, nil
+}
+
+// requireBearerToken lets requests through only if they carry the token of the environment variable as a bearer
+// token, for the realm of the WWW-Authenticate challenge. Without the variable nothing is served, with 403 and
+// the reason given.
+func requireBearerToken(variable string, realm string, disabled string, next http.HandlerFunc) http.HandlerFunc {
+	token := os.Getenv(variable)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, disabled+", set "+variable, http.StatusForbidden)
+			return
+		}
+
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+realm+`"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// requireAdminToken lets requests through only if they carry the token of POKER_ADMIN_TOKEN as a bearer token.
+// Without the variable the admin API isn't served at all.
+func requireAdminToken(next http.HandlerFunc) http.HandlerFunc {
+	return requireBearerToken("POKER_ADMIN_TOKEN", "admin", "the admin API is disabled", next)
+}
+
+// splitList splits a comma-separated list, leaving out empty items
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// TLSEnabled checks if the server listens over HTTPS
+func (c SecurityConfig) TLSEnabled() bool {
+	return c.CertFile != "" || len(c.AutocertDomains) > 0
+}
+
+// AllowsOrigin checks the Origin header of a request. Requests without one don't come from a browser
+// (bots, gRPC gateways, curl) and are let through: origins only protect browsers from other sites.
+func (c SecurityConfig) AllowsOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(c.AllowedOrigins) == 0 {
+		return true
+	}
+
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// autocertManager returns the manager obtaining certificates from Let's Encrypt, nil without autocert domains
+func (c SecurityConfig) autocertManager() *autocert.Manager {
+	if len(c.AutocertDomains) == 0 {
+		return nil
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(c.AutocertDomains...),
+		Cache:      autocert.DirCache(c.AutocertCacheDir),
+	}
+}
+
+// tlsConfig returns the TLS configuration shared by the HTTP and gRPC servers, nil when TLS is off
+func (c SecurityConfig) tlsConfig(manager *autocert.Manager) (*tls.Config, error) {
+	if manager != nil {
+		config := manager.TLSConfig()
+		config.MinVersion = tls.VersionTLS12
+		return config, nil
+	}
+
+	if c.CertFile == "" {
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// secureHeaders tells browsers to stick to HTTPS once they reached the server over it
+func secureHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=31536000")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fromOrigin is a request sent by a page of the origin, none for an empty origin
+func fromOrigin(origin string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/api/tables", nil)
+	if origin != "" {
+		request.Header.Set("Origin", origin)
+	}
+	return request
+}
+
+func TestAllowsOrigin(t *testing.T) {
+	t.Run("Allowed origins match exactly, whatever their case", func(t *testing.T) {
+		// Setup
+		config := SecurityConfig{AllowedOrigins: []string{"https://poker.example.com"}}
+
+		// Act & Assert
+		assert.True(t, config.AllowsOrigin(fromOrigin("https://poker.example.com")))
+		assert.True(t, config.AllowsOrigin(fromOrigin("https://POKER.example.com")))
+		assert.False(t, config.AllowsOrigin(fromOrigin("https://poker.example.com.evil.com")))
+		assert.False(t, config.AllowsOrigin(fromOrigin("http://poker.example.com")))
+	})
+
+	t.Run("The wildcard allows any origin", func(t *testing.T) {
+		// Setup
+		config := SecurityConfig{AllowedOrigins: []string{"https://poker.example.com", "*"}}
+
+		// Act & Assert
+		assert.True(t, config.AllowsOrigin(fromOrigin("https://anywhere.example.org")))
+	})
+
+	t.Run("Other origins are rejected", func(t *testing.T) {
+		// Setup
+		config := SecurityConfig{AllowedOrigins: []string{"https://poker.example.com"}}
+
+		// Act & Assert
+		assert.False(t, config.AllowsOrigin(fromOrigin("https://evil.example.com")))
+	})
+
+	t.Run("Requests without an origin don't come from a browser and are let through", func(t *testing.T) {
+		// Setup
+		config := SecurityConfig{AllowedOrigins: []string{"https://poker.example.com"}}
+
+		// Act & Assert
+		assert.True(t, config.AllowsOrigin(fromOrigin("")))
+	})
+}
+
+func TestCORS(t *testing.T) {
+	serve := func(t *testing.T, allowed []string, origin string) *httptest.ResponseRecorder {
+		previous := securityConfig
+		securityConfig = SecurityConfig{AllowedOrigins: allowed}
+		t.Cleanup(func() { securityConfig = previous })
+
+		recorder := httptest.NewRecorder()
+		corsMiddleware(func(w http.ResponseWriter, r *http.Request) {})(recorder, fromOrigin(origin))
+		return recorder
+	}
+
+	t.Run("An allowed origin is echoed back", func(t *testing.T) {
+		// Act
+		response := serve(t, []string{"https://poker.example.com"}, "https://poker.example.com")
+
+		// Assert
+		assert.Equal(t, "https://poker.example.com", response.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Origin", response.Header().Get("Vary"))
+	})
+
+	t.Run("Other origins get no CORS origin", func(t *testing.T) {
+		// Act
+		response := serve(t, []string{"https://poker.example.com"}, "https://evil.example.com")
+
+		// Assert
+		assert.Empty(t, response.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Without allowed origins any origin may call", func(t *testing.T) {
+		// Act
+		response := serve(t, nil, "https://anywhere.example.org")
+
+		// Assert
+		assert.Equal(t, "*", response.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestSecurityConfigFromEnv(t *testing.T) {
+	t.Run("Reads the allowed origins and TLS files", func(t *testing.T) {
+		// Setup
+		t.Setenv("POKER_ALLOWED_ORIGINS", "https://poker.example.com, https://admin.example.com,")
+		t.Setenv("POKER_TLS_CERT_FILE", "cert.pem")
+		t.Setenv("POKER_TLS_KEY_FILE", "key.pem")
+
+		// Act
+		config, err := securityConfigFromEnv()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://poker.example.com", "https://admin.example.com"}, config.AllowedOrigins)
+		assert.True(t, config.TLSEnabled())
+	})
+
+	t.Run("A certificate needs its key", func(t *testing.T) {
+		// Setup
+		t.Setenv("POKER_TLS_CERT_FILE", "cert.pem")
+
+		// Act
+		_, err := securityConfigFromEnv()
+
+		// Assert
+		assert.ErrorContains(t, err, "must be set together")
+	})
+
+	t.Run("Certificate files and autocert can't be used together", func(t *testing.T) {
+		// Setup
+		t.Setenv("POKER_TLS_CERT_FILE", "cert.pem")
+		t.Setenv("POKER_TLS_KEY_FILE", "key.pem")
+		t.Setenv("POKER_TLS_AUTOCERT_DOMAINS", "poker.example.com")
+
+		// Act
+		_, err := securityConfigFromEnv()
+
+		// Assert
+		assert.ErrorContains(t, err, "not both")
+	})
+
+	t.Run("Origins must have a scheme and a host", func(t *testing.T) {
+		// Setup
+		t.Setenv("POKER_ALLOWED_ORIGINS", "poker.example.com")
+
+		// Act
+		_, err := securityConfigFromEnv()
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid origin")
+	})
+
+	t.Run("Autocert caches its certificates in a default directory", func(t *testing.T) {
+		// Setup
+		t.Setenv("POKER_TLS_AUTOCERT_DOMAINS", "poker.example.com")
+
+		// Act
+		config, err := securityConfigFromEnv()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "autocert", config.AutocertCacheDir)
+	})
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	serve := func(t *testing.T, token string, authorization string) *httptest.ResponseRecorder {
+		t.Setenv("POKER_TEST_TOKEN", token)
+		handler := requireBearerToken("POKER_TEST_TOKEN", "test", "the test API is disabled", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		return recorder
+	}
+
+	t.Run("The right token gets through", func(t *testing.T) {
+		// Act
+		response := serve(t, "secret-token", "Bearer secret-token")
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+
+	t.Run("Nothing is served without the variable", func(t *testing.T) {
+		// Act
+		response := serve(t, "", "Bearer secret-token")
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Contains(t, response.Body.String(), "POKER_TEST_TOKEN")
+	})
+
+	t.Run("Requests without a token are challenged", func(t *testing.T) {
+		// Act
+		response := serve(t, "secret-token", "")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+		assert.Equal(t, `Bearer realm="test"`, response.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("Wrong tokens are refused, prefixes and longer tokens included", func(t *testing.T) {
+		for _, authorization := range []string{
+			"Bearer wrong-token",
+			"Bearer secret",
+			"Bearer secret-token-and-more",
+			"Basic secret-token",
+			"secret-token",
+		} {
+			// Act
+			response := serve(t, "secret-token", authorization)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, response.Code, authorization)
+		}
+	})
+}
@@ -1,57 +1,155 @@
 package server
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/lazharichir/poker/domain"
+	"github.com/lazharichir/poker/domain/cards"
+	"github.com/lazharichir/poker/domain/escrow"
+	"github.com/lazharichir/poker/domain/handhistory"
+	"github.com/lazharichir/poker/domain/hands"
+	"github.com/lazharichir/poker/domain/logging"
+	"github.com/lazharichir/poker/domain/projections"
+	"github.com/lazharichir/poker/domain/wallet"
+	"github.com/lazharichir/poker/server/broadcast"
+	"github.com/lazharichir/poker/server/cluster"
+	"github.com/lazharichir/poker/server/compliance"
 	"github.com/lazharichir/poker/server/connection"
 	"github.com/lazharichir/poker/server/events"
 	"github.com/lazharichir/poker/server/handlers"
+	"github.com/lazharichir/poker/server/metrics"
+	"github.com/lazharichir/poker/server/oidc"
+	"github.com/lazharichir/poker/server/reports"
+	"github.com/lazharichir/poker/server/store"
+	"github.com/lazharichir/poker/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    []string{BatchSubprotocol, Subprotocol},
 	CheckOrigin: func(r *http.Request) bool {
-		return true // In production, implement proper origin checks
+		return securityConfig.AllowsOrigin(r)
 	},
 }
 
+// securityConfig holds the allowed origins and TLS settings, read from the environment by NewServer
+var securityConfig SecurityConfig
+
 // Server represents the WebSocket server
 type Server struct {
-	lobby      *domain.Lobby
-	connMgr    *connection.Manager
-	cmdRouter  *handlers.CommandRouter
-	dispatcher *events.Dispatcher
+	lobby        *domain.Lobby
+	connMgr      *connection.Manager
+	cmdRouter    *handlers.CommandRouter
+	dispatcher   *events.Dispatcher
+	pruner       *Pruner
+	broadcaster  *broadcast.Hub
+	speeds       *projections.TableSpeeds
+	heatmaps     *projections.SelectionHeatmaps
+	bigPots      *projections.BigPots
+	playerStats  *projections.PlayerStats
+	siteStats    *projections.SiteStats
+	commandStats *handlers.CommandStats
+	metrics      *metrics.Metrics
+	cluster      *cluster.Node          // nil when running as a single instance
+	recorder     *store.Recorder        // nil without an event store
+	publisher    *events.RedisPublisher // nil unless events are published to Redis
+	hands        handhistory.Store
+	handRecorder *handhistory.Recorder
+	projections  projections.Store      // Where projections outlive the process, in memory without an event store
+	batchWindow  time.Duration          // How long frames to batching clients wait for more, zero never batches
+	oidc         *oidc.Verifier         // nil when players don't sign in with an identity provider
+	authenticate handlers.Authenticator // Verifies ID tokens with oidc, nil without it
+	compliance   *compliance.Exporter   // nil unless exports are signed, see compliance.go
+
+	// Set by Start and stopped by Shutdown, see shutdown.go
+	mu             sync.Mutex
+	httpServer     *http.Server
+	grpcServer     *grpc.Server
+	stopBackground context.CancelFunc // Tells the recorders to store what is left
+	background     sync.WaitGroup     // The recorders, done once they stored it
+	shuttingDown   atomic.Bool
 }
 
 // TableResponse represents a table in API responses
 type TableResponse struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	PlayerCount int      `json:"playerCount"`
-	Players     []string `json:"players"`
-	Status      string   `json:"status"`
-	AnteValue   int      `json:"anteValue"`
-	CurrentHand string   `json:"currentHand,omitempty"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	PlayerCount     int      `json:"playerCount"`
+	Players         []string `json:"players"`
+	Status          string   `json:"status"`
+	AnteValue       int      `json:"anteValue"`
+	CurrentHand     string   `json:"currentHand,omitempty"`
+	HandNumber      int      `json:"handNumber"` // Of the current hand, or of the last one between hands
+	HandsPlayed     int      `json:"handsPlayed"`
+	OpenedAt        int64    `json:"openedAt"`  // Unix milliseconds
+	RunningMs       int64    `json:"runningMs"` // Time since the table opened
+	Speed           string   `json:"speed"`
+	AvgActionMs     int64    `json:"avgActionMs"`
+	TimeoutsPerHour float64  `json:"timeoutsPerHour"`
 }
 
 // CreateTableRequest represents the request to create a new table
 type CreateTableRequest struct {
-	Name      string `json:"name"`
-	AnteValue int    `json:"anteValue"`
+	Name        string `json:"name"`
+	AnteValue   int    `json:"anteValue"`
+	Preset      string `json:"preset,omitempty"`      // "standard" (default), "turbo" or "deep"
+	FeedPrivacy string `json:"feedPrivacy,omitempty"` // "anonymous" or "public" to show the table's big pots in the site feed
+	Private     bool   `json:"private,omitempty"`     // Friends' table, whose players may agree on a rematch once it's finished
+
+	// Variants deal and select other community card counts, and give more time to select; zero keeps 8, 3 and 5s
+	CommunityCards         int `json:"communityCards,omitempty"`
+	SelectedCards          int `json:"selectedCards,omitempty"`
+	SelectionWindowSeconds int `json:"selectionWindowSeconds,omitempty"`
+
+	// Bots join a player waiting alone for that long, when the server allows it (POKER_BOT_FILL). Zero never seats bots.
+	BotFillAfterSeconds int `json:"botFillAfterSeconds,omitempty"`
+	BotFillBots         int `json:"botFillBots,omitempty"` // 1 or 2, 1 when zero
+}
+
+// RevealSeedRequest carries both escrow key shares needed to open a hand's shuffle seed
+type RevealSeedRequest struct {
+	HandID string `json:"handId"`
+	ShareA string `json:"shareA"` // base64 encoded
+	ShareB string `json:"shareB"` // base64 encoded
+}
+
+// RevealSeedResponse is the audit result for a hand's shuffle
+type RevealSeedResponse struct {
+	HandID         string   `json:"handId"`
+	Seed           string   `json:"seed"`
+	SeedCommitment string   `json:"seedCommitment"`
+	Deck           []string `json:"deck"`
 }
 
-// corsMiddleware adds CORS headers to all responses
+// corsMiddleware adds CORS headers to all responses, pages from origins that aren't allowed can't read them
 func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if origin := r.Header.Get("Origin"); origin != "" && len(securityConfig.AllowedOrigins) > 0 {
+			w.Header().Add("Vary", "Origin")
+			if securityConfig.AllowsOrigin(r) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
@@ -68,39 +166,294 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 
 // NewServer creates a new poker WebSocket server
 func NewServer() *Server {
-	lobby := &domain.Lobby{}
+	// Bankrolls live in the wallet ledger: buy-ins are debited from it, cash-outs and prizes credited to it
+	lobby := &domain.Lobby{Wallet: wallet.NewLedger(), BotFill: botFillFromEnv()}
 	connMgr := connection.NewManager()
+	connMgr.SetReconnectGrace(reconnectGraceFromEnv())
+
+	// Shuffle seeds are escrowed when an operator public key is configured
+	if encoded := os.Getenv("POKER_SEED_ESCROW_PUBLIC_KEY"); encoded != "" {
+		publicKey, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			log.Fatalf("Invalid seed escrow public key: %v", err)
+		}
+		seedEscrow, err := escrow.NewEscrow(publicKey)
+		if err != nil {
+			log.Fatalf("Invalid seed escrow public key: %v", err)
+		}
+		lobby.SeedEscrow = seedEscrow
+	}
 
-	dispatcher := events.NewDispatcher(connMgr)
-	cmdRouter := handlers.NewCommandRouter(lobby, connMgr)
+	scopes := tracing.NewScopes()
+	broadcaster := broadcast.NewHub()
+	cmdRouter := handlers.NewCommandRouter(lobby, connMgr, scopes)
+
+	// Rate-limited commands are counted too, so metrics come before the configured middleware
+	commandStats := handlers.NewCommandStats()
+	serverMetrics := metrics.New(func() int { return len(lobby.GetTables()) })
+	serverMetrics.SetPhaseSLAs(phaseSLAsFromEnv())
+	cmdRouter.Use(handlers.Metrics(commandStats), handlers.CommandErrors(serverMetrics.CommandFailed))
+	cmdRouter.Use(commandMiddlewareFromEnv()...)
+
+	// Players sign in with the operator's identity provider when one is configured
+	verifier := oidcVerifierFromEnv()
+	var authenticate handlers.Authenticator
+	if verifier != nil {
+		authenticate = authenticateWith(verifier)
+		cmdRouter.SetAuthenticator(authenticate)
+	}
+
+	// Spectators watch over Server-Sent Events or from their websocket
+	localSpectators := events.SpectatorFanOut{broadcaster, connMgr}
+
+	// In a cluster, events also reach the players and spectators connected to the other instances
+	var clients events.Clients = connMgr
+	var spectators events.Spectators = localSpectators
+	node := clusterFromEnv(connMgr, localSpectators)
+	if node != nil {
+		clients = node
+		spectators = node
+		cmdRouter.SetCluster(node)
+	}
+
+	// Other services can follow the live stream on Redis
+	publisher := redisPublisherFromEnv()
+	if publisher != nil {
+		clients = events.FanOut{clients, publisher}
+	}
+
+	dispatcher := events.NewDispatcher(clients, scopes, spectators)
+	dispatcher.SetMaxEnvelopeSize(maxEnvelopeSizeFromEnv())
+	dispatcher.SetHandViews(func(tableID string, handID string) []domain.HandView {
+		table, err := lobby.GetTable(tableID)
+		if err != nil {
+			return nil
+		}
+		hand, err := table.GetHandByID(handID)
+		if err != nil {
+			return nil
+		}
+
+		views := make([]domain.HandView, 0, len(hand.Players))
+		for _, player := range hand.Players {
+			views = append(views, hand.BuildPlayerView(player.ID))
+		}
+		return views
+	})
+
+	// Connections that negotiate permessage-deflate get their frames compressed
+	upgrader.EnableCompression = compressionFromEnv()
+
+	// Web clients may only connect from the allowed origins
+	var err error
+	if securityConfig, err = securityConfigFromEnv(); err != nil {
+		log.Fatalf("Invalid security configuration: %v", err)
+	}
+
+	// Hole cards stay out of the logs unless explicitly let in
+	logging.SetUnsafeCards(unsafeCardLoggingFromEnv())
 
 	// Register dispatcher as event handler for the lobby
-	lobby.AddEventHandler(dispatcher.HandleEvent)
+	lobby.AddEventHandler(serverMetrics.TimeDispatch(dispatcher.HandleEvent))
+	lobby.AddEventHandler(serverMetrics.HandleEvent)
+
+	speeds := projections.NewTableSpeeds()
+	lobby.AddEventHandler(speeds.HandleEvent)
+
+	heatmaps := projections.NewSelectionHeatmaps()
+	lobby.AddEventHandler(heatmaps.HandleEvent)
+
+	bigPots := projections.NewBigPots(bigPotThresholdFromEnv(), hands.FourOfAKind)
+	lobby.AddEventHandler(bigPots.HandleEvent)
+
+	playerStats := projections.NewPlayerStats()
+	lobby.AddEventHandler(playerStats.HandleEvent)
+
+	siteStats := projections.NewSiteStats()
+	lobby.AddEventHandler(siteStats.HandleEvent)
+
+	// Session summaries also go to the back office when a webhook or mail server is configured
+	lobby.AddEventHandler(reports.NewSessionReporter(reports.SinksFromEnv()...).HandleEvent)
+
+	// Table events outlive the process when a durable event store is configured
+	// Finished hands are kept for players to review, in the event store's database when there is one,
+	// along with the other projections
+	var recorder *store.Recorder
+	var handHistory handhistory.Store = handhistory.NewMemoryStore()
+	var projectionStore projections.Store = projections.NewMemoryStore()
+	eventStore := eventStoreFromEnv()
+	if eventStore != nil {
+		recorder = store.NewRecorder(eventStore, recorderConfigFromEnv())
+		if err := recorder.Recover(context.Background()); err != nil {
+			log.Fatalf("Could not recover the events left in the write-ahead log: %v", err)
+		}
+
+		// Tables wait between hands while their events are stored slower than they are emitted
+		recorder.OnBackPressure(func(tableID string, lagging bool) {
+			if table, err := lobby.GetTable(tableID); err == nil {
+				table.SetBackPressure(lagging)
+			}
+		})
+		lobby.AddEventHandler(recorder.HandleEvent)
+		projectionStore = eventStore.Projections()
+		handHistory = handhistory.NewProjectionStore(projectionStore)
+		if err := playerStats.Load(context.Background(), projectionStore); err != nil {
+			log.Fatalf("Could not load the player stats: %v", err)
+		}
+	}
+	handRecorder := handhistory.NewRecorder(handHistory)
+	lobby.AddEventHandler(handRecorder.HandleEvent)
 
 	return &Server{
-		lobby:      lobby,
-		connMgr:    connMgr,
-		cmdRouter:  cmdRouter,
-		dispatcher: dispatcher,
+		lobby:        lobby,
+		connMgr:      connMgr,
+		cmdRouter:    cmdRouter,
+		dispatcher:   dispatcher,
+		pruner:       NewPruner(lobby, retentionPolicyFromEnv()),
+		broadcaster:  broadcaster,
+		speeds:       speeds,
+		heatmaps:     heatmaps,
+		bigPots:      bigPots,
+		playerStats:  playerStats,
+		siteStats:    siteStats,
+		commandStats: commandStats,
+		metrics:      serverMetrics,
+		cluster:      node,
+		recorder:     recorder,
+		publisher:    publisher,
+		hands:        handHistory,
+		handRecorder: handRecorder,
+		projections:  projectionStore,
+		batchWindow:  batchWindowFromEnv(),
+		oidc:         verifier,
+		authenticate: authenticate,
+		compliance:   complianceExporterFromEnv(lobby, eventStore),
 	}
 }
 
-// Start begins the server on the specified port
+// playerStatsSaveInterval is how often the player stats changed since the last save are stored
+const playerStatsSaveInterval = 30 * time.Second
+
+// Start begins the server on the specified port, it returns http.ErrServerClosed once Shutdown is called
 func (s *Server) Start(port string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	s.stopBackground = cancel
+	s.mu.Unlock()
+
 	// Start connection manager in its own goroutine
 	go s.connMgr.Start()
+	go s.pruner.Start()
+	if s.cluster != nil {
+		go s.cluster.Start(ctx)
+		http.HandleFunc(cluster.ForwardPath, s.handleForwardedCommand)
+	}
+	if s.recorder != nil {
+		s.background.Add(1)
+		go func() {
+			defer s.background.Done()
+			s.recorder.Start(ctx)
+		}()
+	}
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		s.handRecorder.Start(ctx)
+	}()
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		s.playerStats.Start(ctx, s.projections, playerStatsSaveInterval)
+	}()
+	go s.metrics.Start(ctx)
+	if s.publisher != nil {
+		go s.publisher.Start(ctx)
+	}
+
+	// Both the HTTP and gRPC servers use TLS when a certificate or autocert domains are configured
+	certManager := securityConfig.autocertManager()
+	tlsConfig, err := securityConfig.tlsConfig(certManager)
+	if err != nil {
+		return err
+	}
+
+	// Bots and other non-browser clients can use the gRPC API instead of the WebSocket
+	if grpcPort := grpcPortFromEnv(); grpcPort != "" {
+		go s.serveGRPC(grpcPort, tlsConfig)
+	}
 
 	// Set up HTTP handlers with CORS middleware
 	http.HandleFunc("/ws", s.handleWebSocket)
 	http.HandleFunc("/api/tables", corsMiddleware(s.handleGetTables))
 	http.HandleFunc("/api/tables/create", corsMiddleware(s.handleCreateTable))
+	http.HandleFunc("/api/time", corsMiddleware(s.handleTime))
+	http.HandleFunc("/api/tables/spectate", corsMiddleware(s.handleSpectate))
+	http.HandleFunc("/api/tables/{id}/hands", corsMiddleware(s.handleTableHands))
+	http.HandleFunc("/api/hands/{id}", corsMiddleware(s.handleHand))
+	http.HandleFunc("/api/admin/seeds/reveal", requireAdminToken(s.handleRevealSeed))
+	http.HandleFunc("/api/admin/retention", requireAdminToken(s.handleRetention))
+	http.HandleFunc("/api/admin/bans", requireAdminToken(s.handleBans))
+	http.HandleFunc("/api/admin/tables/blocklist", requireAdminToken(s.handleTableBlocklist))
+	http.HandleFunc("/api/admin/tables/snapshot", requireAdminToken(s.handleTableSnapshot))
+	http.HandleFunc("/api/admin/tables/statemachine", requireAdminToken(s.handleTableStateMachine))
+	http.HandleFunc("/api/admin/payloads", requireAdminToken(s.handlePayloadStats))
+	http.HandleFunc("/api/admin/commands", requireAdminToken(s.handleCommandStats))
+	http.HandleFunc("/api/admin/wallet", requireAdminToken(s.handleWallet))
+	http.HandleFunc("/api/admin/tables/bots", requireAdminToken(s.handleBots))
+	http.HandleFunc("GET /api/support/hands/{id}/players/{playerID}/actions", requireSupportToken(s.handleSupportActionLog))
+	http.HandleFunc("GET /api/support/hands/{id}/showdown", requireSupportToken(s.handleSupportShowdown))
+	http.HandleFunc("/api/analytics/selections", corsMiddleware(s.handleSelectionHeatmap))
+	http.HandleFunc("/api/feed/big-pots", corsMiddleware(s.handleBigPots))
+	http.HandleFunc("/api/players/{id}/stats", corsMiddleware(s.handlePlayerStats))
+	http.HandleFunc("/api/leaderboard", corsMiddleware(s.handleLeaderboard))
+	http.HandleFunc("GET /api/compliance/players/{id}/actions", requireBearerToken("POKER_COMPLIANCE_TOKEN", "compliance", "compliance exports are disabled", s.handleComplianceExport))
+	http.HandleFunc("/api/dashboard/stats", corsMiddleware(requireStatsToken(s.handleSiteStats)))
+	http.HandleFunc("/api/protocol", corsMiddleware(s.handleProtocol))
+	http.HandleFunc("/api/auth/oidc", corsMiddleware(s.handleOIDCConfig))
+	http.Handle("/metrics", s.metrics.Handler())
+
+	if tlsConfig == nil {
+		httpServer := &http.Server{Addr: "0.0.0.0:" + port}
+		s.mu.Lock()
+		s.httpServer = httpServer
+		s.mu.Unlock()
+
+		log.Printf("Starting server on port %s", port)
+		return httpServer.ListenAndServe()
+	}
+
+	// Let's Encrypt checks the domains over plain HTTP, which otherwise redirects to HTTPS
+	if certManager != nil {
+		go func() {
+			if err := http.ListenAndServe("0.0.0.0:80", certManager.HTTPHandler(nil)); err != nil {
+				log.Printf("ACME challenge server stopped: %v", err)
+			}
+		}()
+	}
+
+	httpsServer := &http.Server{
+		Addr:      "0.0.0.0:" + port,
+		Handler:   secureHeaders(http.DefaultServeMux),
+		TLSConfig: tlsConfig,
+	}
+	s.mu.Lock()
+	s.httpServer = httpsServer
+	s.mu.Unlock()
 
-	log.Printf("Starting server on port %s", port)
-	return http.ListenAndServe("0.0.0.0:"+port, nil)
+	log.Printf("Starting server on port %s over TLS", port)
+	return httpsServer.ListenAndServeTLS("", "")
 }
 
 // handleWebSocket handles incoming WebSocket connections
 func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	// Clients signing in present their ID token on connect, a bad one is turned away before the upgrade
+	identity, err := s.authenticateRequest(r.Context(), idToken(r))
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("Error upgrading to WebSocket: %v", err)
@@ -112,13 +465,15 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	log.Printf("New client connected: %s with ID: %s", r.RemoteAddr, clientID)
 
 	client := &connection.Client{
-		ID:   clientID,
-		Conn: conn,
-		Send: make(chan []byte, 256),
+		ID:       clientID,
+		Conn:     conn,
+		Send:     make(chan connection.Message, 256),
+		Identity: identity,
 	}
 
 	// Register with connection manager
 	s.connMgr.Register <- client
+	s.metrics.ConnectionOpened()
 
 	// Handle reading and writing in separate goroutines
 	go s.readPump(client)
@@ -129,8 +484,9 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 // readPump reads messages from the WebSocket connection
 func (s *Server) readPump(client *connection.Client) {
 	defer func() {
-		s.connMgr.Unregister <- client
+		s.disconnect(client)
 		client.Conn.Close()
+		s.metrics.ConnectionClosed()
 	}()
 
 	for {
@@ -143,28 +499,74 @@ func (s *Server) readPump(client *connection.Client) {
 		}
 
 		// Process the message through the command router
-		if err := s.cmdRouter.HandleCommand(client, message); err != nil {
+		if err := s.cmdRouter.HandleCommand(context.Background(), client, message); err != nil {
 			log.Printf("Error handling command: %v", err)
 			// You could send an error message back to the client here
 		}
 	}
 }
 
+// disconnect unregisters a client whose connection is gone
+func (s *Server) disconnect(client *connection.Client) {
+	// Players who may still reconnect keep their seat as is until the grace period is over
+	if !s.connMgr.HoldSession(client, func() { s.markClientAway(client) }) {
+		s.markClientAway(client)
+	}
+	s.connMgr.Unregister <- client
+}
+
+// markClientAway lets the client's tables act for the player while they are disconnected
+func (s *Server) markClientAway(client *connection.Client) {
+	if client.Player == nil {
+		return
+	}
+
+	for _, tableID := range client.TableIDs {
+		table, err := s.lobby.GetTable(tableID)
+		if err != nil {
+			continue
+		}
+		if err := table.Do("disconnect", func() error { return table.MarkPlayerAway(client.Player.ID) }); err != nil {
+			log.Printf("Error marking player %s away: %v", client.Player.ID, err)
+		}
+	}
+}
+
 // writePump sends messages to the WebSocket connection
 func (s *Server) writePump(client *connection.Client) {
 	defer func() {
 		client.Conn.Close()
 	}()
 
+	// Clients that asked for batches get the frames queued in quick succession together
+	var batcher *connection.Batcher
+	if client.Conn.Subprotocol() == BatchSubprotocol && s.batchWindow > 0 {
+		batcher = &connection.Batcher{Window: s.batchWindow, MaxBytes: s.dispatcher.MaxEnvelopeSize()}
+	}
+
 	for {
-		message, ok := <-client.Send
+		var message connection.Message
+		var ok bool
+		envelopes := 1
+		if batcher != nil {
+			message, envelopes, ok = batcher.Next(client.Send)
+		} else {
+			message, ok = <-client.Send
+		}
 		if !ok {
 			// Channel closed
-			client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			client.Conn.WriteMessage(websocket.CloseMessage, s.closeFrame())
 			return
 		}
 
-		err := client.Conn.WriteMessage(websocket.TextMessage, message)
+		if message.Ctx == nil {
+			message.Ctx = context.Background()
+		}
+		_, span := tracing.Tracer().Start(message.Ctx, "ws.write", writeAttributes(client, message))
+		span.SetAttributes(attribute.Int("poker.envelopes", envelopes))
+
+		err := client.Conn.WriteMessage(websocket.TextMessage, message.Data)
+		span.End()
 		if err != nil {
 			log.Printf("Error writing message: %v", err)
 			return
@@ -172,6 +574,19 @@ func (s *Server) writePump(client *connection.Client) {
 	}
 }
 
+// writeAttributes describe a frame written to a client. poker.queued_ms is how long the frame waited for the
+// client's writer, which grows when the client reads slower than the table plays.
+func writeAttributes(client *connection.Client, message connection.Message) trace.SpanStartEventOption {
+	attributes := []attribute.KeyValue{
+		attribute.String("poker.client_id", client.ID),
+		attribute.Int("poker.bytes", len(message.Data)),
+	}
+	if !message.Queued.IsZero() {
+		attributes = append(attributes, attribute.Int64("poker.queued_ms", time.Since(message.Queued).Milliseconds()))
+	}
+	return trace.WithAttributes(attributes...)
+}
+
 func (s *Server) sendHello(client *connection.Client) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -195,20 +610,35 @@ func (s *Server) handleGetTables(w http.ResponseWriter, r *http.Request) {
 	tableResponses := make([]TableResponse, 0, len(tables))
 
 	for _, table := range tables {
-		players := table.GetPlayers()
-		playerIDs := make([]string, 0, len(players))
-		for _, player := range players {
+		// The ante and status change in the table's game loop, the snapshot is taken there
+		snapshot := table.Snapshot()
+		playerIDs := make([]string, 0, len(snapshot.Players))
+		for _, player := range snapshot.Players {
 			playerIDs = append(playerIDs, player.ID)
 		}
+		currentHand := ""
+		if snapshot.ActiveHand != nil {
+			currentHand = snapshot.ActiveHand.ID
+		}
+
+		speed := s.speeds.Get(table.ID)
+		clock := table.RunClock()
 
 		tableResponses = append(tableResponses, TableResponse{
-			ID:          table.ID,
-			Name:        table.Name,
-			PlayerCount: len(players),
-			Players:     playerIDs,
-			Status:      string(table.Status),
-			AnteValue:   table.Rules.AnteValue,
-			CurrentHand: table.GetCurrentHandID(),
+			ID:              table.ID,
+			Name:            table.Name,
+			PlayerCount:     len(playerIDs),
+			Players:         playerIDs,
+			Status:          string(snapshot.Status),
+			AnteValue:       snapshot.Rules.AnteValue,
+			CurrentHand:     currentHand,
+			HandNumber:      clock.HandNumber,
+			HandsPlayed:     clock.HandsPlayed,
+			OpenedAt:        clock.OpenedAt.UnixMilli(),
+			RunningMs:       clock.Running(time.Now()).Milliseconds(),
+			Speed:           string(speed.Rating),
+			AvgActionMs:     speed.AverageActionTime.Milliseconds(),
+			TimeoutsPerHour: speed.TimeoutsPerHour,
 		})
 	}
 
@@ -216,6 +646,23 @@ func (s *Server) handleGetTables(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(tableResponses)
 }
 
+// handleTime is the HTTP counterpart of the TIME_SYNC command, for clients that sync before connecting.
+// The client sends its own time as ?clientTime=<unix ms>.
+func (s *Server) handleTime(w http.ResponseWriter, r *http.Request) {
+	receivedAt := time.Now()
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clientTime, _ := strconv.ParseInt(r.URL.Query().Get("clientTime"), 10, 64)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(handlers.NewTimeSyncResult(clientTime, receivedAt))
+}
+
 // handleCreateTable creates a new table
 func (s *Server) handleCreateTable(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -235,31 +682,147 @@ func (s *Server) handleCreateTable(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if createReq.AnteValue <= 0 {
+	if createReq.AnteValue == 0 {
 		createReq.AnteValue = 10 // Default ante value
 	}
 
-	// Calculate min buy-in (10x ante)
-	minBuyIn := createReq.AnteValue * 10
+	rules, err := domain.PresetTableRules(createReq.Preset, 6, createReq.AnteValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rules.FeedPrivacy = domain.FeedPrivacy(createReq.FeedPrivacy) // Tables stay out of the big pots feed unless they opt in
+	rules.Private = createReq.Private
+	rules.BotFill = domain.BotFill{
+		After: time.Duration(createReq.BotFillAfterSeconds) * time.Second,
+		Bots:  createReq.BotFillBots,
+	}
+	rules.CommunityCards = createReq.CommunityCards
+	rules.SelectedCards = createReq.SelectedCards
+	if createReq.SelectionWindowSeconds != 0 {
+		rules.SelectionWindow = time.Duration(createReq.SelectionWindowSeconds) * time.Second
+	}
+	if err := rules.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Create the table
-	table, err := s.lobby.CreateTable(createReq.Name, 6, minBuyIn)
+	table, err := s.lobby.NewTable(createReq.Name, rules)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// The table runs here, other instances forward its commands to this one
+	if s.cluster != nil {
+		if err := s.cluster.Claim(r.Context(), table.ID); err != nil {
+			table.Do("claim", func() error {
+				table.Close("could not claim table ownership")
+				return nil
+			})
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
 	// Return the created table
+	snapshot := table.Snapshot()
 	response := TableResponse{
 		ID:          table.ID,
 		Name:        table.Name,
 		PlayerCount: 0,
 		Players:     []string{},
-		Status:      string(table.Status),
-		AnteValue:   table.Rules.AnteValue,
+		Status:      string(snapshot.Status),
+		AnteValue:   snapshot.Rules.AnteValue,
+		Speed:       string(projections.SpeedRatingUnknown),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
+
+// handleRevealSeed opens a hand's escrowed shuffle seed. Both operator key shares
+// must be supplied in the same request, so no single operator can reveal a seed alone.
+func (s *Server) handleRevealSeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req RevealSeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	shareA, errA := base64.StdEncoding.DecodeString(req.ShareA)
+	shareB, errB := base64.StdEncoding.DecodeString(req.ShareB)
+	if errA != nil || errB != nil {
+		http.Error(w, "Key shares must be base64 encoded", http.StatusBadRequest)
+		return
+	}
+
+	var table *domain.Table
+	var hand *domain.Hand
+	for _, candidate := range s.lobby.GetTables() {
+		if h, err := candidate.GetHandByID(req.HandID); err == nil {
+			table, hand = candidate, h
+			break
+		}
+	}
+
+	if hand == nil {
+		http.Error(w, "Hand not found", http.StatusNotFound)
+		return
+	}
+
+	// The hand is still played by its table, read it from the game loop
+	var ended bool
+	var sealedSeed escrow.SealedSeed
+	var seedCommitment string
+	table.Do("seed reveal", func() error {
+		ended = hand.IsInPhase(domain.HandPhase_Ended)
+		sealedSeed, seedCommitment = hand.SealedSeed, hand.SeedCommitment
+		return nil
+	})
+
+	// Revealing the seed of a hand in progress would show everyone's cards
+	if !ended {
+		http.Error(w, "Seeds are revealed once the hand has ended", http.StatusConflict)
+		return
+	}
+
+	seed, err := escrow.Open(sealedSeed, shareA, shareB)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	commitment := sha256.Sum256(seed)
+	if hex.EncodeToString(commitment[:]) != seedCommitment {
+		http.Error(w, "Seed does not match the hand's commitment", http.StatusConflict)
+		return
+	}
+
+	// Replay the shuffle so the full deal can be checked
+	var fixedSeed [32]byte
+	copy(fixedSeed[:], seed)
+	deck := cards.ShuffleCardsWithSeed(cards.NewDeck52(), fixedSeed)
+
+	response := RevealSeedResponse{
+		HandID:         req.HandID,
+		Seed:           hex.EncodeToString(seed),
+		SeedCommitment: seedCommitment,
+		Deck:           make([]string, 0, len(deck)),
+	}
+	for _, card := range deck {
+		response.Deck = append(response.Deck, card.String())
+	}
+
+	log.Printf("Shuffle seed revealed for hand %s", req.HandID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
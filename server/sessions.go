@@ -0,0 +1,24 @@
+package server
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/lazharichir/poker/server/connection"
+)
+
+// reconnectGraceFromEnv reads POKER_RECONNECT_GRACE (Go duration), how long dropped players may take to resume
+// their session before their tables act for them. Zero disables session resume.
+func reconnectGraceFromEnv() time.Duration {
+	value := os.Getenv("POKER_RECONNECT_GRACE")
+	if value == "" {
+		return connection.DefaultReconnectGrace
+	}
+
+	grace, err := time.ParseDuration(value)
+	if err != nil || grace < 0 {
+		log.Fatalf("Invalid POKER_RECONNECT_GRACE: %q", value)
+	}
+	return grace
+}
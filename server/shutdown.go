@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/gorilla/websocket"
+)
+
+// Shutdown stops the server gracefully. It stops accepting connections, lets the hands in progress end
+// (those still running when ctx is done are voided and their bets refunded), closes the clients' connections
+// with a close frame, then stores the events and hand histories still waiting for the store.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shuttingDown.Store(true)
+
+	s.mu.Lock()
+	httpServer, grpcServer, stopBackground := s.httpServer, s.grpcServer, s.stopBackground
+	s.mu.Unlock()
+
+	// Connected clients keep playing: websockets are hijacked from the HTTP server, and gRPC streams
+	// already open are waited for
+	var errs []error
+	if httpServer != nil {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	grpcStopped := make(chan struct{})
+	if grpcServer != nil {
+		go func() {
+			defer close(grpcStopped)
+			grpcServer.GracefulStop()
+		}()
+	}
+
+	log.Printf("Shutting down, waiting for the hands in progress to end")
+	if err := s.lobby.Drain(ctx); err != nil {
+		log.Printf("Hands still in progress were voided: %v", err)
+	}
+
+	s.connMgr.CloseAll()
+	if grpcServer != nil {
+		select {
+		case <-grpcStopped:
+		case <-ctx.Done():
+			// Streams whose clients don't hang up are cut
+			grpcServer.Stop()
+			<-grpcStopped
+		}
+	}
+
+	// The recorders store what is left even once ctx is done, losing events is worse than a late exit
+	if stopBackground != nil {
+		stopBackground()
+	}
+	s.background.Wait()
+
+	return errors.Join(errs...)
+}
+
+// closeFrame is the close frame sent to a websocket client whose connection the server closes
+func (s *Server) closeFrame() []byte {
+	if s.shuttingDown.Load() {
+		return websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server shutting down")
+	}
+	return []byte{}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// requireStatsToken lets requests through only if they carry the token of POKER_STATS_TOKEN as a bearer token.
+// Without the variable the statistics aren't served at all.
+func requireStatsToken(next http.HandlerFunc) http.HandlerFunc {
+	return requireBearerToken("POKER_STATS_TOKEN", "stats", "site statistics are disabled", next)
+}
+
+// handleSiteStats returns the activity of the whole site: players, pots by hour, busiest tables and the day's
+// biggest hand. Each instance of a cluster reports the tables it runs.
+func (s *Server) handleSiteStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(s.siteStats.Snapshot(time.Now()))
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// spectateKeepAlive is how often an idle spectator stream gets a comment line, so proxies keep it open
+const spectateKeepAlive = 15 * time.Second
+
+// handleSpectate streams a table's public events as Server-Sent Events (?tableId=).
+// Meant for large audiences such as tournament final tables: frames are serialized once per event.
+func (s *Server) handleSpectate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// In a cluster the table may run on another instance, its events are relayed here
+	tableID := r.URL.Query().Get("tableId")
+	if !s.tableExists(r, tableID) {
+		http.Error(w, "table not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	viewer := s.broadcaster.Subscribe(tableID)
+	defer s.broadcaster.Unsubscribe(tableID, viewer)
+
+	keepAlive := time.NewTicker(spectateKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case frame, ok := <-viewer.Frames:
+			if !ok {
+				// Table closed or the viewer fell behind
+				return
+			}
+			if _, err := w.Write(frame); err != nil {
+				log.Printf("Error writing spectator frame: %v", err)
+				return
+			}
+			flusher.Flush()
+
+		case <-keepAlive.C:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lazharichir/poker/server/store"
+)
+
+// eventStoreFromEnv stores table events in PostgreSQL when POKER_EVENTS_POSTGRES_URL is set,
+// migrating the schema on startup
+func eventStoreFromEnv() *store.PostgresStore {
+	databaseURL := os.Getenv("POKER_EVENTS_POSTGRES_URL")
+	if databaseURL == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("Invalid POKER_EVENTS_POSTGRES_URL: %v", err)
+	}
+
+	eventStore := store.NewPostgresStore(pool)
+	if err := eventStore.Migrate(ctx); err != nil {
+		log.Fatalf("Could not migrate the event store: %v", err)
+	}
+
+	log.Printf("Storing table events in PostgreSQL")
+
+	return eventStore
+}
+
+// recorderConfigFromEnv reads how table events are batched on their way to the store: POKER_EVENTS_BATCH_SIZE,
+// POKER_EVENTS_FLUSH_INTERVAL (Go duration), POKER_EVENTS_HIGH_WATER, and POKER_EVENTS_WAL_DIR for the
+// write-ahead log that keeps events waiting for the store safe from a crash
+func recorderConfigFromEnv() store.RecorderConfig {
+	config := store.DefaultRecorderConfig()
+
+	if value := os.Getenv("POKER_EVENTS_BATCH_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Fatalf("Invalid POKER_EVENTS_BATCH_SIZE: %q", value)
+		}
+		config.BatchSize = size
+	}
+
+	if value := os.Getenv("POKER_EVENTS_FLUSH_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			log.Fatalf("Invalid POKER_EVENTS_FLUSH_INTERVAL: %q", value)
+		}
+		config.FlushInterval = interval
+	}
+
+	if value := os.Getenv("POKER_EVENTS_HIGH_WATER"); value != "" {
+		highWater, err := strconv.Atoi(value)
+		if err != nil || highWater <= 0 {
+			log.Fatalf("Invalid POKER_EVENTS_HIGH_WATER: %q", value)
+		}
+		config.HighWater = highWater
+	}
+
+	if dir := os.Getenv("POKER_EVENTS_WAL_DIR"); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			log.Fatalf("Invalid POKER_EVENTS_WAL_DIR: %v", err)
+		}
+		config.WALDir = dir
+	}
+
+	return config
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrations are applied in file name order, each one once
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrator brings the schema of the event store's database up to date: the events, and the projections
+// sharing its database. Applied migrations are recorded in the schema_migrations table.
+type Migrator struct {
+	pool *pgxpool.Pool
+}
+
+// NewMigrator creates a migrator on the given connection pool
+func NewMigrator(pool *pgxpool.Pool) *Migrator {
+	return &Migrator{pool: pool}
+}
+
+// Migrations lists every migration, in the order they are applied
+func (m *Migrator) Migrations() ([]string, error) {
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Pending lists the migrations not applied yet, in the order they will be
+func (m *Migrator) Pending(ctx context.Context) ([]string, error) {
+	if err := m.createVersionTable(ctx); err != nil {
+		return nil, err
+	}
+
+	files, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []string{}
+	for _, file := range files {
+		var applied bool
+		if err := m.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, file).Scan(&applied); err != nil {
+			return nil, err
+		}
+		if !applied {
+			pending = append(pending, file)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations
+func (m *Migrator) Migrate(ctx context.Context) error {
+	if err := m.createVersionTable(ctx); err != nil {
+		return err
+	}
+
+	files, err := m.Migrations()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := m.apply(ctx, file); err != nil {
+			return fmt.Errorf("migration %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) createVersionTable(ctx context.Context) error {
+	_, err := m.pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT        PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	return err
+}
+
+func (m *Migrator) apply(ctx context.Context, file string) error {
+	script, err := migrations.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Instances starting together must not apply the same migration twice
+	if _, err := tx.Exec(ctx, `LOCK TABLE schema_migrations IN EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	var applied bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, file).Scan(&applied); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, string(script)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, file); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS events (
+    table_id    TEXT        NOT NULL,
+    sequence    BIGINT      NOT NULL,
+    event_id    TEXT        NOT NULL,
+    name        TEXT        NOT NULL,
+    payload     JSONB       NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    stored_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (table_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS events_occurred_at_idx ON events (occurred_at);
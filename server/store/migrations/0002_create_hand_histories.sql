@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS hand_histories (
+    hand_id    TEXT        PRIMARY KEY,
+    table_id   TEXT        NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at   TIMESTAMPTZ NOT NULL,
+    record     JSONB       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS hand_histories_table_idx ON hand_histories (table_id, ended_at DESC);
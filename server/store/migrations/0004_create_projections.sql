@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS projections (
+    key        TEXT        COLLATE "C" PRIMARY KEY,
+    value      BYTEA       NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Hand histories are kept in the projections table from now on, hand_histories is left for rollbacks.
+-- Keys follow handhistory.ProjectionStore, the index is keyed by end time in its key layout.
+-- Index entries hold the whole record, which decodes into a summary as well.
+INSERT INTO projections (key, value)
+SELECT 'hands/' || hand_id, convert_to(record::text, 'UTF8')
+FROM hand_histories
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO projections (key, value)
+SELECT 'table-hands/' || table_id || '/' || to_char(ended_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"') || '/' || hand_id,
+       convert_to(record::text, 'UTF8')
+FROM hand_histories
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// appendedChannel is the NOTIFY channel announcing appends, its payload is the table ID
+const appendedChannel = "events_appended"
+
+// subscriptionRecheck is how long a subscriber waits for a notification before reading the stream anyway,
+// in case one was missed
+var subscriptionRecheck = 5 * time.Second
+
+// PostgresStore is a durable EventStore, one stream per table in the events table
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore creates a store on the given connection pool, call Migrate before using it
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+// Migrate brings the database schema up to date
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	return NewMigrator(s.pool).Migrate(ctx)
+}
+
+func (s *PostgresStore) Append(ctx context.Context, tableID string, batch ...events.Event) (int64, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Appends to the same table are serialized, so sequences have no gaps
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, tableID); err != nil {
+		return 0, err
+	}
+
+	var sequence int64
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(sequence), 0) FROM events WHERE table_id = $1`, tableID).Scan(&sequence); err != nil {
+		return 0, err
+	}
+
+	rows := make([][]any, 0, len(batch))
+	for _, event := range batch {
+		encoded, err := events.Encode(event)
+		if err != nil {
+			return 0, err
+		}
+		sequence++
+		rows = append(rows, []any{tableID, sequence, events.ExtractEventID(event), encoded.Name, encoded.Version, []byte(encoded.Payload), event.Timestamp()})
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"events"},
+		[]string{"table_id", "sequence", "event_id", "name", "version", "payload", "occurred_at"},
+		pgx.CopyFromRows(rows),
+	); err != nil {
+		return 0, err
+	}
+
+	// Delivered to the subscribers once the transaction commits
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, appendedChannel, tableID); err != nil {
+		return 0, err
+	}
+
+	return sequence, tx.Commit(ctx)
+}
+
+func (s *PostgresStore) LoadEvents(ctx context.Context, tableID string, fromSequence int64) ([]events.StoredEvent, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT sequence, name, version, payload, stored_at
+		FROM events
+		WHERE table_id = $1 AND sequence >= $2
+		ORDER BY sequence`, tableID, fromSequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loaded := []events.StoredEvent{}
+	for rows.Next() {
+		var (
+			sequence int64
+			name     string
+			version  int
+			payload  []byte
+			storedAt time.Time
+		)
+		if err := rows.Scan(&sequence, &name, &version, &payload, &storedAt); err != nil {
+			return nil, err
+		}
+
+		event, err := events.Decode(events.Encoded{Name: name, Version: version, Payload: payload})
+		if err != nil {
+			return nil, fmt.Errorf("table %s event %d: %w", tableID, sequence, err)
+		}
+
+		loaded = append(loaded, events.StoredEvent{
+			TableID:  tableID,
+			Sequence: sequence,
+			Event:    event,
+			StoredAt: storedAt,
+		})
+	}
+
+	return loaded, rows.Err()
+}
+
+func (s *PostgresStore) Tables(ctx context.Context) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `SELECT DISTINCT table_id FROM events ORDER BY table_id`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// Subscribe holds a connection of the pool for as long as the subscription lasts, to LISTEN for appends
+func (s *PostgresStore) Subscribe(ctx context.Context, tableID string, fromSequence int64) (<-chan events.StoredEvent, error) {
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, `LISTEN `+appendedChannel); err != nil {
+		conn.Release()
+		return nil, err
+	}
+
+	subscription := make(chan events.StoredEvent)
+
+	go func() {
+		defer close(subscription)
+		defer func() {
+			// The connection goes back to the pool, without the subscription
+			conn.Exec(context.Background(), `UNLISTEN `+appendedChannel)
+			conn.Release()
+		}()
+
+		next := max(fromSequence, 1)
+		for {
+			loaded, err := s.LoadEvents(ctx, tableID, next)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Subscription to table %s stopped at event %d: %v", tableID, next, err)
+				}
+				return
+			}
+
+			for _, stored := range loaded {
+				select {
+				case subscription <- stored:
+					next = stored.Sequence + 1
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if err := waitForAppend(ctx, conn.Conn(), tableID); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Subscription to table %s lost its connection: %v", tableID, err)
+				}
+				return
+			}
+		}
+	}()
+
+	return subscription, nil
+}
+
+// waitForAppend returns once events were appended to the table's stream, or after subscriptionRecheck.
+// Errors other than the wait running out are returned.
+func waitForAppend(ctx context.Context, conn *pgx.Conn, tableID string) error {
+	waitCtx, cancel := context.WithTimeout(ctx, subscriptionRecheck)
+	defer cancel()
+
+	for {
+		notification, err := conn.WaitForNotification(waitCtx)
+		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if notification.Payload == tableID {
+			return nil
+		}
+	}
+}
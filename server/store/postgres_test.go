@@ -0,0 +1,122 @@
+//go:build integration
+
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresStore connects to the database of POKER_EVENTS_POSTGRES_URL and migrates it, the test is skipped
+// without one. Each test writes to tables of its own, the database may be shared.
+func postgresStore(t *testing.T) *PostgresStore {
+	url := os.Getenv("POKER_EVENTS_POSTGRES_URL")
+	if url == "" {
+		t.Skip("POKER_EVENTS_POSTGRES_URL is not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	store := NewPostgresStore(pool)
+	require.NoError(t, store.Migrate(t.Context()))
+	return store
+}
+
+// testTableID is a table ID no other run of the tests uses
+func testTableID(t *testing.T) string {
+	return "test-" + t.Name() + "-" + time.Now().Format("20060102150405.000000000")
+}
+
+func TestPostgresStore(t *testing.T) {
+	t.Run("Migrating again has nothing left to apply", func(t *testing.T) {
+		// Setup
+		store := postgresStore(t)
+
+		// Act
+		pending, err := NewMigrator(store.pool).Pending(t.Context())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("Appended events are loaded back in order, numbered from 1", func(t *testing.T) {
+		// Setup
+		store := postgresStore(t)
+		tableID := testTableID(t)
+
+		// Act
+		first, err := store.Append(t.Context(), tableID, events.PotChanged{ID: "event-1", TableID: tableID}, events.PotChanged{ID: "event-2", TableID: tableID})
+		require.NoError(t, err)
+		last, err := store.Append(t.Context(), tableID, events.TableClosed{ID: "event-3", TableID: tableID, Reason: "test"})
+		require.NoError(t, err)
+		loaded, err := store.LoadEvents(t.Context(), tableID, 0)
+		require.NoError(t, err)
+		later, err := store.LoadEvents(t.Context(), tableID, 3)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, int64(2), first)
+		assert.Equal(t, int64(3), last)
+		require.Len(t, loaded, 3)
+		for i, stored := range loaded {
+			assert.Equal(t, int64(i+1), stored.Sequence)
+			assert.Equal(t, tableID, stored.TableID)
+		}
+		assert.Equal(t, "event-2", events.ExtractEventID(loaded[1].Event))
+		assert.Equal(t, "test", loaded[2].Event.(events.TableClosed).Reason)
+		require.Len(t, later, 1)
+		assert.Equal(t, "event-3", events.ExtractEventID(later[0].Event))
+	})
+
+	t.Run("Tables lists the tables with a stream", func(t *testing.T) {
+		// Setup
+		store := postgresStore(t)
+		tableID := testTableID(t)
+		_, err := store.Append(t.Context(), tableID, events.PotChanged{ID: "event-1", TableID: tableID})
+		require.NoError(t, err)
+
+		// Act
+		tables, err := store.Tables(t.Context())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, tables, tableID)
+	})
+
+	t.Run("Subscribers get the stored events then those appended later", func(t *testing.T) {
+		// Setup
+		store := postgresStore(t)
+		tableID := testTableID(t)
+		_, err := store.Append(t.Context(), tableID, events.PotChanged{ID: "event-1", TableID: tableID})
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		// Act
+		subscription, err := store.Subscribe(ctx, tableID, 0)
+		require.NoError(t, err)
+		stored := <-subscription
+		_, err = store.Append(t.Context(), tableID, events.PotChanged{ID: "event-2", TableID: tableID})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, "event-1", events.ExtractEventID(stored.Event))
+		select {
+		case appended := <-subscription:
+			assert.Equal(t, "event-2", events.ExtractEventID(appended.Event))
+			assert.Equal(t, int64(2), appended.Sequence)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the appended event was not streamed")
+		}
+	})
+}
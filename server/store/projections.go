@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lazharichir/poker/domain/projections"
+)
+
+// PostgresProjections is a durable projections.Store, one row per key in the projections table
+type PostgresProjections struct {
+	pool *pgxpool.Pool
+}
+
+// Projections returns a projections store in the same database, whose schema Migrate also brings up to date
+func (s *PostgresStore) Projections() *PostgresProjections {
+	return &PostgresProjections{pool: s.pool}
+}
+
+func (s *PostgresProjections) Upsert(ctx context.Context, key string, value []byte) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO projections (key, value)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = now()`, key, value)
+	return err
+}
+
+func (s *PostgresProjections) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := s.pool.QueryRow(ctx, `SELECT value FROM projections WHERE key = $1`, key).Scan(&value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, projections.ErrNotFound
+	}
+	return value, err
+}
+
+func (s *PostgresProjections) Scan(ctx context.Context, prefix string) ([]projections.Record, error) {
+	// Keys use the C collation, so they sort and match prefixes byte by byte, as they do in memory
+	rows, err := s.pool.Query(ctx, `
+		SELECT key, value
+		FROM projections
+		WHERE key LIKE $1 ESCAPE '\'
+		ORDER BY key`, likePrefix(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []projections.Record{}
+	for rows.Next() {
+		var record projections.Record
+		if err := rows.Scan(&record.Key, &record.Value); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// likePrefix is the LIKE pattern matching the keys starting with prefix
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
@@ -0,0 +1,455 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+)
+
+// recorderBuffer is how many events may wait for the store before the emitting table is held up
+const recorderBuffer = 1024
+
+// RecorderConfig tunes how a recorder batches its appends
+type RecorderConfig struct {
+	BatchSize     int           // Most events appended at once, a full batch is appended right away
+	FlushInterval time.Duration // Longest an event waits for its batch to fill
+	HighWater     int           // Events waiting for one table above which it is told to slow down, see OnBackPressure
+
+	// WALDir keeps the events waiting for the store on disk, one file per table, until they are stored.
+	// Events left over by a crash are stored by Recover. Empty keeps them in memory only.
+	// Events are written to the log but not synced one by one, so the log survives the process crashing,
+	// not the host: events the operating system had yet to write to disk are lost with it.
+	WALDir string
+}
+
+// DefaultRecorderConfig appends up to 64 events at once, at least every 50ms, without a write-ahead log
+func DefaultRecorderConfig() RecorderConfig {
+	return RecorderConfig{
+		BatchSize:     64,
+		FlushInterval: 50 * time.Millisecond,
+		HighWater:     256,
+	}
+}
+
+// Recorder appends every table event to an event store. Each table has its own partition, whose writer
+// appends its events in batches and in the order they were emitted, so a slow table doesn't hold the others up.
+// A partition that is full holds its emitting table back.
+type Recorder struct {
+	store  events.EventStore
+	config RecorderConfig
+
+	mu         sync.Mutex
+	partitions map[string]*partition // By table ID
+	stop       chan struct{}
+
+	onBackPressure func(tableID string, lagging bool)
+}
+
+// NewRecorder creates a recorder, Start runs its writers
+func NewRecorder(store events.EventStore, config RecorderConfig) *Recorder {
+	defaults := DefaultRecorderConfig()
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	if config.HighWater <= 0 {
+		config.HighWater = defaults.HighWater
+	}
+
+	return &Recorder{
+		store:      store,
+		config:     config,
+		partitions: make(map[string]*partition),
+		stop:       make(chan struct{}),
+	}
+}
+
+// OnBackPressure registers the function told when a table emits events faster than they are stored (lagging),
+// and when the store has caught up again. It is called from the recorder's writers, never from HandleEvent.
+func (r *Recorder) OnBackPressure(handler func(tableID string, lagging bool)) {
+	r.onBackPressure = handler
+}
+
+// HandleEvent queues table events for the store, it is meant to be registered as an event handler.
+// The event is in the write-ahead log when it returns.
+func (r *Recorder) HandleEvent(event events.Event) {
+	tableID := events.ExtractTableID(event)
+	if tableID == "" {
+		return
+	}
+
+	r.partition(tableID).add(event)
+
+	// The table is gone, store what it left and stop its writer
+	if _, closed := event.(events.TableClosed); closed {
+		r.mu.Lock()
+		p := r.partitions[tableID]
+		delete(r.partitions, tableID)
+		r.mu.Unlock()
+		close(p.closing)
+	}
+}
+
+// Start flushes the partitions every FlushInterval until ctx is done, then stores what is left and returns
+// once it is stored
+func (r *Recorder) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			close(r.stop)
+			r.mu.Lock()
+			writers := make([]*partition, 0, len(r.partitions))
+			for _, p := range r.partitions {
+				writers = append(writers, p)
+			}
+			r.mu.Unlock()
+			for _, p := range writers {
+				<-p.done
+			}
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			for _, p := range r.partitions {
+				p.requestFlush()
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Recover stores the events a crash left in the write-ahead log, skipping those the store already has.
+// It must run before the tables emit their first events.
+func (r *Recorder) Recover(ctx context.Context) error {
+	if r.config.WALDir == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(r.config.WALDir, "*.wal"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		tableID, err := url.PathUnescape(strings.TrimSuffix(filepath.Base(file), ".wal"))
+		if err != nil {
+			return err
+		}
+
+		pending, err := readWAL(file)
+		if err != nil {
+			return err
+		}
+
+		stored, err := r.store.LoadEvents(ctx, tableID, 0)
+		if err != nil {
+			return err
+		}
+		storedIDs := make(map[string]bool, len(stored))
+		for _, s := range stored {
+			storedIDs[events.ExtractEventID(s.Event)] = true
+		}
+
+		missing := []events.Event{}
+		for _, event := range pending {
+			if !storedIDs[events.ExtractEventID(event)] {
+				missing = append(missing, event)
+			}
+		}
+
+		if len(missing) > 0 {
+			if _, err := r.store.Append(ctx, tableID, missing...); err != nil {
+				return err
+			}
+			log.Printf("Recovered %d events of table %s from the write-ahead log", len(missing), tableID)
+		}
+
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partition returns the table's partition, creating it and starting its writer the first time
+func (r *Recorder) partition(tableID string) *partition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, exists := r.partitions[tableID]
+	if !exists {
+		p = &partition{
+			recorder: r,
+			tableID:  tableID,
+			flush:    make(chan struct{}, 1),
+			closing:  make(chan struct{}),
+			done:     make(chan struct{}),
+		}
+		p.drained = sync.NewCond(&p.mu)
+		if r.config.WALDir != "" {
+			p.walPath = filepath.Join(r.config.WALDir, url.PathEscape(tableID)+".wal")
+		}
+		r.partitions[tableID] = p
+		go p.run()
+	}
+	return p
+}
+
+// partition holds the events of one table waiting for the store
+type partition struct {
+	recorder *Recorder
+	tableID  string
+
+	mu      sync.Mutex
+	pending []events.Event
+	drained *sync.Cond // Signalled when events leave pending
+	wal     *os.File   // Holds pending, in order
+	walPath string
+	lagging bool // As last reported to OnBackPressure
+
+	flush   chan struct{}
+	closing chan struct{}
+	done    chan struct{} // Closed once the writer is gone
+}
+
+// add queues an event, waiting for room if the partition is full
+func (p *partition) add(event events.Event) {
+	p.mu.Lock()
+	for len(p.pending) >= recorderBuffer {
+		p.drained.Wait()
+	}
+
+	if err := p.writeWAL(event); err != nil {
+		log.Printf("Could not log %s event of table %s ahead: %v", event.Name(), p.tableID, err)
+	}
+	p.pending = append(p.pending, event)
+	size := len(p.pending)
+	p.mu.Unlock()
+
+	if size >= p.recorder.config.BatchSize {
+		p.requestFlush()
+	}
+}
+
+// requestFlush wakes the partition's writer up, unless it is already due to flush
+func (p *partition) requestFlush() {
+	select {
+	case p.flush <- struct{}{}:
+	default:
+	}
+}
+
+// run is the partition's writer, appending batches until the table closes or the recorder stops
+func (p *partition) run() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.flush:
+			p.flushPending()
+		case <-p.closing:
+			p.flushPending()
+			p.closeWAL()
+			return
+		case <-p.recorder.stop:
+			p.flushPending()
+			p.closeWAL()
+			return
+		}
+	}
+}
+
+// flushPending appends the waiting events in batches. Events that could not be stored stay first in line.
+func (p *partition) flushPending() {
+	config := p.recorder.config
+
+	for {
+		p.mu.Lock()
+		batch := append([]events.Event{}, p.pending[:min(len(p.pending), config.BatchSize)]...)
+		p.mu.Unlock()
+
+		p.reportPressure()
+		if len(batch) == 0 {
+			return
+		}
+
+		if _, err := p.recorder.store.Append(context.Background(), p.tableID, batch...); err != nil {
+			log.Printf("Could not store %d events of table %s, retrying: %v", len(batch), p.tableID, err)
+			return
+		}
+
+		p.mu.Lock()
+		p.pending = p.pending[len(batch):]
+		if err := p.rewriteWAL(); err != nil {
+			log.Printf("Could not trim the write-ahead log of table %s: %v", p.tableID, err)
+		}
+		p.drained.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// reportPressure tells OnBackPressure when the table starts or stops lagging. It is released at half the high water mark,
+// so the table doesn't flap around it.
+func (p *partition) reportPressure() {
+	handler := p.recorder.onBackPressure
+	if handler == nil {
+		return
+	}
+
+	p.mu.Lock()
+	size := len(p.pending)
+	highWater := p.recorder.config.HighWater
+	changed := false
+	if !p.lagging && size >= highWater {
+		p.lagging, changed = true, true
+	} else if p.lagging && size <= highWater/2 {
+		p.lagging, changed = false, true
+	}
+	lagging := p.lagging
+	p.mu.Unlock()
+
+	if changed {
+		handler(p.tableID, lagging)
+	}
+}
+
+// walEntry is an event as written to the write-ahead log, one JSON object per line
+type walEntry struct {
+	Name    string          `json:"name"`
+	Version int             `json:"version,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// writeWAL appends an event to the table's write-ahead log, p.mu must be held. The file isn't synced,
+// syncing on every event would hold the table up on the disk, see RecorderConfig.WALDir.
+func (p *partition) writeWAL(event events.Event) error {
+	if p.walPath == "" {
+		return nil
+	}
+
+	if p.wal == nil {
+		file, err := os.OpenFile(p.walPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		p.wal = file
+	}
+
+	line, err := encodeWALEntry(event)
+	if err != nil {
+		return err
+	}
+	_, err = p.wal.Write(line)
+	return err
+}
+
+// rewriteWAL replaces the write-ahead log with the events still pending, p.mu must be held
+func (p *partition) rewriteWAL() error {
+	if p.walPath == "" {
+		return nil
+	}
+
+	if p.wal != nil {
+		p.wal.Close()
+		p.wal = nil
+	}
+
+	if len(p.pending) == 0 {
+		if err := os.Remove(p.walPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	// Write aside then rename, a crash halfway leaves the previous log whole
+	temp := p.walPath + ".tmp"
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	for _, event := range p.pending {
+		line, err := encodeWALEntry(event)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.Write(line); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := os.Rename(temp, p.walPath); err != nil {
+		file.Close()
+		return err
+	}
+
+	p.wal = file
+	return nil
+}
+
+func (p *partition) closeWAL() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.wal != nil {
+		p.wal.Close()
+		p.wal = nil
+	}
+}
+
+func encodeWALEntry(event events.Event) ([]byte, error) {
+	encoded, err := events.Encode(event)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := json.Marshal(walEntry{Name: encoded.Name, Version: encoded.Version, Payload: encoded.Payload})
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+// readWAL decodes the events of a write-ahead log, in order. A line cut short by a crash ends the log.
+func readWAL(path string) ([]events.Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	logged := []events.Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry walEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break
+		}
+		event, err := events.Decode(events.Encoded{Name: entry.Name, Version: entry.Version, Payload: entry.Payload})
+		if err != nil {
+			return nil, err
+		}
+		logged = append(logged, event)
+	}
+
+	return logged, scanner.Err()
+}
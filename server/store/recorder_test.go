@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lazharichir/poker/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedIDs lists the IDs of a table's events in the order they were stored
+func storedIDs(t *testing.T, store events.EventStore, tableID string) []string {
+	stored, err := store.LoadEvents(context.Background(), tableID, 0)
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, event := range stored {
+		ids = append(ids, events.ExtractEventID(event.Event))
+	}
+	return ids
+}
+
+func TestRecorderShutdown(t *testing.T) {
+	t.Run("Stores what a closed table left and stops once the others are stored", func(t *testing.T) {
+		// Setup
+		store := events.NewMemoryStore()
+		recorder := NewRecorder(store, RecorderConfig{BatchSize: 64, FlushInterval: time.Hour})
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			recorder.Start(ctx)
+			close(stopped)
+		}()
+
+		// Act
+		recorder.HandleEvent(events.PotChanged{ID: "closed-1", TableID: "table-closed"})
+		recorder.HandleEvent(events.TableClosed{ID: "closed-2", TableID: "table-closed"})
+		recorder.HandleEvent(events.PotChanged{ID: "open-1", TableID: "table-open"})
+		cancel()
+
+		// Assert
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("Start did not return once its context was done")
+		}
+		assert.Equal(t, []string{"open-1"}, storedIDs(t, store, "table-open"))
+		assert.Eventually(t, func() bool {
+			stored, err := store.LoadEvents(context.Background(), "table-closed", 0)
+			return err == nil && len(stored) == 2
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"closed-1", "closed-2"}, storedIDs(t, store, "table-closed"))
+	})
+}
+
+func TestRecorderBatches(t *testing.T) {
+	t.Run("Stores each table's events in the order they were emitted", func(t *testing.T) {
+		// Setup
+		store := events.NewMemoryStore()
+		recorder := NewRecorder(store, RecorderConfig{BatchSize: 2, FlushInterval: time.Hour})
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		go func() {
+			recorder.Start(ctx)
+			close(stopped)
+		}()
+
+		// Act
+		for _, id := range []string{"a-1", "a-2", "a-3", "a-4", "a-5"} {
+			recorder.HandleEvent(events.PotChanged{ID: id, TableID: "table-a"})
+		}
+		recorder.HandleEvent(events.PotChanged{ID: "b-1", TableID: "table-b"})
+		cancel()
+		<-stopped
+
+		// Assert
+		assert.Equal(t, []string{"a-1", "a-2", "a-3", "a-4", "a-5"}, storedIDs(t, store, "table-a"))
+		assert.Equal(t, []string{"b-1"}, storedIDs(t, store, "table-b"))
+	})
+
+	t.Run("Tells a lagging table to slow down until half the high water mark is stored", func(t *testing.T) {
+		// Setup
+		recorder := NewRecorder(events.NewMemoryStore(), RecorderConfig{BatchSize: 100, FlushInterval: time.Hour, HighWater: 4})
+		var mu sync.Mutex
+		reports := []bool{}
+		recorder.OnBackPressure(func(tableID string, lagging bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, lagging)
+		})
+		p := recorder.partition("table-1")
+		pendingSizes := func(sizes ...int) {
+			for _, size := range sizes {
+				p.mu.Lock()
+				p.pending = make([]events.Event, size)
+				p.mu.Unlock()
+				p.reportPressure()
+			}
+		}
+
+		// Act
+		pendingSizes(3, 4, 5, 3)
+		mu.Lock()
+		whileLagging := append([]bool{}, reports...)
+		mu.Unlock()
+		pendingSizes(2, 3, 4)
+
+		// Assert
+		assert.Equal(t, []bool{true}, whileLagging, "above half the high water mark the table still lags")
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []bool{true, false, true}, reports)
+	})
+}
+
+func TestRecorderRecover(t *testing.T) {
+	writeWAL := func(t *testing.T, dir string, tableID string, logged []events.Event, tail string) {
+		data := []byte{}
+		for _, event := range logged {
+			line, err := encodeWALEntry(event)
+			require.NoError(t, err)
+			data = append(data, line...)
+		}
+		data = append(data, tail...)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, tableID+".wal"), data, 0o600))
+	}
+
+	t.Run("Stores the logged events the store doesn't have yet", func(t *testing.T) {
+		// Setup
+		dir := t.TempDir()
+		store := events.NewMemoryStore()
+		_, err := store.Append(context.Background(), "table-1", events.PotChanged{ID: "event-1", TableID: "table-1"})
+		require.NoError(t, err)
+		writeWAL(t, dir, "table-1", []events.Event{
+			events.PotChanged{ID: "event-1", TableID: "table-1"},
+			events.PotChanged{ID: "event-2", TableID: "table-1"},
+			events.PotChanged{ID: "event-3", TableID: "table-1"},
+		}, "")
+
+		// Act
+		err = NewRecorder(store, RecorderConfig{WALDir: dir}).Recover(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"event-1", "event-2", "event-3"}, storedIDs(t, store, "table-1"))
+		assert.NoFileExists(t, filepath.Join(dir, "table-1.wal"))
+	})
+
+	t.Run("A last line cut short by the crash ends the log", func(t *testing.T) {
+		// Setup
+		dir := t.TempDir()
+		store := events.NewMemoryStore()
+		writeWAL(t, dir, "table-1", []events.Event{
+			events.PotChanged{ID: "event-1", TableID: "table-1"},
+			events.PotChanged{ID: "event-2", TableID: "table-1"},
+		}, `{"name":"POT_CHANGED","payload":{"ID":"eve`)
+
+		// Act
+		err := NewRecorder(store, RecorderConfig{WALDir: dir}).Recover(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"event-1", "event-2"}, storedIDs(t, store, "table-1"))
+	})
+}
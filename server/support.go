@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/lazharichir/poker/domain/handhistory"
+)
+
+// requireSupportToken lets requests through only if they carry the token of POKER_SUPPORT_TOKEN as a bearer token.
+// Without the variable the support API isn't served at all.
+func requireSupportToken(next http.HandlerFunc) http.HandlerFunc {
+	return requireBearerToken("POKER_SUPPORT_TOKEN", "support", "the support API is disabled", next)
+}
+
+// handleSupportActionLog returns a player's prompts, actions and timeouts in a hand, with latencies.
+// Served at GET /api/support/hands/{id}/players/{playerID}/actions, it is redacted and never shows cards.
+func (s *Server) handleSupportActionLog(w http.ResponseWriter, r *http.Request) {
+	handID := r.PathValue("id")
+	playerID := r.PathValue("playerID")
+
+	for _, table := range s.lobby.GetTables() {
+		if _, err := table.GetHandByID(handID); err != nil {
+			continue
+		}
+
+		entries, err := table.PlayerActionLog(handID, playerID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	http.Error(w, "Hand not found", http.StatusNotFound)
+}
+
+// handleSupportShowdown explains how a finished hand's showdown was decided: the 5 cards chosen for each player,
+// the steps comparing the top two hands and the rule that decided between them.
+// Served at GET /api/support/hands/{id}/showdown, it only shows the cards of the hands that were evaluated.
+func (s *Server) handleSupportShowdown(w http.ResponseWriter, r *http.Request) {
+	handID := r.PathValue("id")
+
+	hand, err := s.hands.Hand(r.Context(), handID)
+	if errors.Is(err, handhistory.ErrHandNotFound) {
+		http.Error(w, "Hand not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Could not load hand %s: %v", handID, err)
+		http.Error(w, "Could not load the hand history", http.StatusInternalServerError)
+		return
+	}
+
+	explanation, err := hand.Explain()
+	if errors.Is(err, handhistory.ErrNoShowdown) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("Could not explain the showdown of hand %s: %v", handID, err)
+		http.Error(w, "Could not explain the showdown", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(explanation)
+}
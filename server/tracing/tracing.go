@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/lazharichir/poker/server"
+
+// Tracer returns the tracer used across the server
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global tracer provider and returns a function that flushes it on shutdown.
+// Spans are printed to stdout when POKER_TRACING=stdout, otherwise the no-op provider stays in place.
+func Setup() (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if os.Getenv("POKER_TRACING") != "stdout" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Scopes remembers the trace context of the command currently being applied to a table or player,
+// so that the events it causes (which are emitted without a context) can be attributed to it
+type Scopes struct {
+	mu   sync.RWMutex
+	ctxs map[string]context.Context
+}
+
+// NewScopes creates an empty scope registry
+func NewScopes() *Scopes {
+	return &Scopes{
+		ctxs: make(map[string]context.Context),
+	}
+}
+
+// Bind associates ctx with the given keys until the returned function is called
+func (s *Scopes) Bind(ctx context.Context, keys ...string) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bound := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		s.ctxs[key] = ctx
+		bound = append(bound, key)
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, key := range bound {
+			// Only unbind if a later command hasn't taken over the key
+			if s.ctxs[key] == ctx {
+				delete(s.ctxs, key)
+			}
+		}
+	}
+}
+
+// Lookup returns the context bound to the first matching key, or a background context
+func (s *Scopes) Lookup(keys ...string) context.Context {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range keys {
+		if ctx, ok := s.ctxs[key]; ok {
+			return ctx
+		}
+	}
+
+	return context.Background()
+}
+
+// TableKey returns the scope key for a table
+func TableKey(tableID string) string {
+	if tableID == "" {
+		return ""
+	}
+	return "table:" + tableID
+}
+
+// PlayerKey returns the scope key for a player
+func PlayerKey(playerID string) string {
+	if playerID == "" {
+		return ""
+	}
+	return "player:" + playerID
+}
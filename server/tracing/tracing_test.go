@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a recorded span, as a command does
+func startSpan(t *testing.T, name string) (context.Context, trace.Span) {
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return provider.Tracer("test").Start(context.Background(), name)
+}
+
+func TestScopes(t *testing.T) {
+	t.Run("Events of the table and the player are attributed to the bound span", func(t *testing.T) {
+		// Setup
+		scopes := NewScopes()
+		ctx, span := startSpan(t, "command")
+
+		// Act
+		unbind := scopes.Bind(ctx, TableKey("table-1"), PlayerKey("player-1"))
+		defer unbind()
+
+		// Assert
+		assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(scopes.Lookup(TableKey("table-1"))))
+		assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(scopes.Lookup(PlayerKey("player-1"))))
+		assert.False(t, trace.SpanContextFromContext(scopes.Lookup(TableKey("table-2"))).IsValid())
+	})
+
+	t.Run("Unbinding releases the keys", func(t *testing.T) {
+		// Setup
+		scopes := NewScopes()
+		ctx, _ := startSpan(t, "command")
+		unbind := scopes.Bind(ctx, TableKey("table-1"), PlayerKey("player-1"))
+
+		// Act
+		unbind()
+
+		// Assert
+		assert.False(t, trace.SpanContextFromContext(scopes.Lookup(TableKey("table-1"))).IsValid())
+		assert.False(t, trace.SpanContextFromContext(scopes.Lookup(PlayerKey("player-1"))).IsValid())
+	})
+
+	t.Run("Unbinding leaves the keys a later command took over", func(t *testing.T) {
+		// Setup
+		scopes := NewScopes()
+		first, _ := startSpan(t, "first command")
+		second, span := startSpan(t, "second command")
+		unbindFirst := scopes.Bind(first, TableKey("table-1"))
+		unbindSecond := scopes.Bind(second, TableKey("table-1"))
+		defer unbindSecond()
+
+		// Act
+		unbindFirst()
+
+		// Assert
+		assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(scopes.Lookup(TableKey("table-1"))))
+	})
+
+	t.Run("Empty IDs bind nothing", func(t *testing.T) {
+		// Setup
+		scopes := NewScopes()
+		ctx, span := startSpan(t, "command")
+
+		// Act
+		scopes.Bind(ctx, TableKey(""), PlayerKey("player-1"))
+
+		// Assert
+		assert.Empty(t, TableKey(""))
+		assert.False(t, trace.SpanContextFromContext(scopes.Lookup(TableKey(""))).IsValid())
+		assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(scopes.Lookup(TableKey(""), PlayerKey("player-1"))))
+	})
+}
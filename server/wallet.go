@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lazharichir/poker/domain/wallet"
+)
+
+// WalletResponse is a player's balance with the transactions that led to it
+type WalletResponse struct {
+	PlayerID     string               `json:"playerId"`
+	Balance      int                  `json:"balance"`
+	Transactions []wallet.Transaction `json:"transactions"`
+}
+
+// handleWallet returns a player's wallet balance and ledger (?playerId=)
+func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	playerID := r.URL.Query().Get("playerId")
+	if playerID == "" {
+		http.Error(w, "playerId is required", http.StatusBadRequest)
+		return
+	}
+	if s.lobby.Wallet == nil {
+		http.Error(w, "no wallet configured", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(WalletResponse{
+		PlayerID:     playerID,
+		Balance:      s.lobby.Wallet.Balance(playerID),
+		Transactions: s.lobby.Wallet.Transactions(playerID),
+	})
+}